@@ -1,15 +1,40 @@
 package main
 
 import (
+	"context"
 	"fmt"
+	"goods/internal/broker/kafka"
 	config "goods/internal/cfg"
+	myLog "goods/internal/logger"
 	"goods/internal/services"
 	"goods/internal/transport"
+	grpctransport "goods/internal/transport/grpc"
+	"os"
 )
 
 func main() {
 	cfg := config.LoadConfig()
+	// Схема меняется отдельным запуском: main migrate up|down|status
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		os.Exit(runMigrate(cfg, os.Args[2:]))
+	}
 	fmt.Printf("%v", cfg)
 	s := services.NewSrv(cfg)
+	go s.RunReservationSweeper(context.Background(), cfg.ReservationSweepInterval)
+	go s.RunPriceScheduler(context.Background(), cfg.PriceScheduleInterval)
+	go s.RunCardPurge(context.Background(), cfg.CardPurgeInterval, cfg.CardRetention)
+	go s.RunIdempotencyPurge(context.Background(), cfg.IdempotencyPurgeInterval)
+	dispatcher := s.NewWebhookDispatcher(cfg.WebhookTimeout, cfg.WebhookBatchSize, cfg.WebhookMaxAttempts, cfg.WebhookBackoff)
+	go dispatcher.Run(context.Background(), cfg.WebhookPollInterval)
+	if len(cfg.KafkaBrokers) > 0 {
+		producer := kafka.NewProducer(cfg.KafkaBrokers, cfg.KafkaProductTopic)
+		defer producer.Close()
+		go s.NewOutboxRelay(producer, cfg.OutboxBatchSize).Run(context.Background(), cfg.OutboxPollInterval)
+	} else {
+		myLog.Log.Warnf("KAFKA_BOOTSTRAP_SERVERS is not set, outbox events are not published")
+	}
+	go func() {
+		myLog.Log.Fatalf("gRPC server stopped: %v", grpctransport.Serve(cfg.GRPCAddr, s, cfg.ServiceToken))
+	}()
 	transport.HandleCreate(cfg, s)
 }
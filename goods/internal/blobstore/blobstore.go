@@ -0,0 +1,60 @@
+// Package blobstore хранит файлы медиа товаров (изображения и миниатюры)
+package blobstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	config "goods/internal/cfg"
+	"strings"
+)
+
+// BlobStore - хранилище объектов по ключу. Ключ - относительный путь вида "cards/<uuid>/<file>".
+type BlobStore interface {
+	// Put сохраняет объект, перезаписывая существующий
+	Put(ctx context.Context, key string, contentType string, data []byte) error
+	// Delete удаляет объект; отсутствие объекта ошибкой не считается
+	Delete(ctx context.Context, key string) error
+	// URL возвращает постоянный публичный адрес объекта
+	URL(key string) string
+}
+
+// Реализации хранилища
+const (
+	StoreLocal = "local"
+	StoreS3    = "s3"
+)
+
+var errInvalidKey = errors.New("blobstore: invalid key")
+
+// New создаёт хранилище, выбранное в конфигурации
+func New(cfg config.Config) (BlobStore, error) {
+	switch cfg.MediaStore {
+	case StoreLocal:
+		return NewLocalStore(cfg.MediaLocalDir, cfg.MediaPublicURL), nil
+	case StoreS3:
+		return NewS3Store(S3Options{
+			Endpoint:  cfg.S3Endpoint,
+			Region:    cfg.S3Region,
+			Bucket:    cfg.S3Bucket,
+			AccessKey: cfg.S3AccessKey,
+			SecretKey: cfg.S3SecretKey,
+			PublicURL: cfg.MediaPublicURL,
+		})
+	default:
+		return nil, fmt.Errorf("blobstore: unknown store %q", cfg.MediaStore)
+	}
+}
+
+// validKey отклоняет пустые и абсолютные ключи, а также выход за пределы хранилища
+func validKey(key string) bool {
+	if key == "" || strings.HasPrefix(key, "/") || strings.Contains(key, `\`) {
+		return false
+	}
+	for _, part := range strings.Split(key, "/") {
+		if part == "" || part == "." || part == ".." {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,69 @@
+package blobstore
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// LocalStore хранит объекты в каталоге файловой системы; файлы раздаёт сам сервис
+type LocalStore struct {
+	dir       string
+	publicURL string
+}
+
+// NewLocalStore создаёт хранилище в каталоге dir; URL объектов строятся от publicURL
+func NewLocalStore(dir string, publicURL string) *LocalStore {
+	return &LocalStore{dir: dir, publicURL: strings.TrimSuffix(publicURL, "/")}
+}
+
+// Dir возвращает корневой каталог хранилища
+func (s *LocalStore) Dir() string {
+	return s.dir
+}
+
+func (s *LocalStore) Put(_ context.Context, key string, _ string, data []byte) error {
+	if !validKey(key) {
+		return errInvalidKey
+	}
+	path := filepath.Join(s.dir, filepath.FromSlash(key))
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	// Запись через временный файл: читатель не увидит объект записанным наполовину
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+func (s *LocalStore) Delete(_ context.Context, key string) error {
+	if !validKey(key) {
+		return errInvalidKey
+	}
+	err := os.Remove(filepath.Join(s.dir, filepath.FromSlash(key)))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+func (s *LocalStore) URL(key string) string {
+	return s.publicURL + "/" + key
+}
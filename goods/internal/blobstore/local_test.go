@@ -0,0 +1,45 @@
+package blobstore
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLocalStorePutAndDelete(t *testing.T) {
+	store := NewLocalStore(t.TempDir(), "http://cdn.example/media/")
+	ctx := context.Background()
+	key := "cards/1/image.png"
+
+	if err := store.Put(ctx, key, "image/png", []byte("data")); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(filepath.Join(store.Dir(), "cards", "1", "image.png"))
+	if err != nil || string(data) != "data" {
+		t.Fatalf("stored %q, %v, want the uploaded data", data, err)
+	}
+	if url := store.URL(key); url != "http://cdn.example/media/cards/1/image.png" {
+		t.Fatalf("URL = %s", url)
+	}
+
+	if err := store.Delete(ctx, key); err != nil {
+		t.Fatal(err)
+	}
+	// Повторное удаление не ошибка
+	if err := store.Delete(ctx, key); err != nil {
+		t.Fatalf("deleting a missing object: %v", err)
+	}
+}
+
+func TestLocalStoreRejectsKeysOutsideDir(t *testing.T) {
+	store := NewLocalStore(t.TempDir(), "")
+	for _, key := range []string{"", "/etc/passwd", "../escape", "cards/../../escape", "cards//image", `cards\image`, "cards/./image"} {
+		if err := store.Put(context.Background(), key, "image/png", nil); err != errInvalidKey {
+			t.Errorf("Put(%q) = %v, want errInvalidKey", key, err)
+		}
+		if err := store.Delete(context.Background(), key); err != errInvalidKey {
+			t.Errorf("Delete(%q) = %v, want errInvalidKey", key, err)
+		}
+	}
+}
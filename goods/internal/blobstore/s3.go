@@ -0,0 +1,184 @@
+package blobstore
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+// S3Options - параметры S3-совместимого хранилища (AWS S3, MinIO и т.п.)
+type S3Options struct {
+	Endpoint  string // Например, http://localhost:9000
+	Region    string
+	Bucket    string
+	AccessKey string
+	SecretKey string
+	PublicURL string // Базовый адрес для URL объектов; пусто - Endpoint/Bucket
+}
+
+// S3Store работает с бакетом через REST API с подписью AWS Signature V4.
+// Используется адресация по пути (endpoint/bucket/key), которую поддерживают и локальные заменители S3.
+type S3Store struct {
+	opts     S3Options
+	endpoint *url.URL
+	client   *http.Client
+}
+
+func NewS3Store(opts S3Options) (*S3Store, error) {
+	if opts.Endpoint == "" || opts.Bucket == "" || opts.AccessKey == "" || opts.SecretKey == "" {
+		return nil, errors.New("blobstore: S3 endpoint, bucket and credentials are required")
+	}
+	endpoint, err := url.Parse(strings.TrimSuffix(opts.Endpoint, "/"))
+	if err != nil || endpoint.Host == "" {
+		return nil, fmt.Errorf("blobstore: invalid S3 endpoint %q", opts.Endpoint)
+	}
+	if opts.Region == "" {
+		opts.Region = "us-east-1"
+	}
+	if opts.PublicURL == "" {
+		opts.PublicURL = endpoint.String() + "/" + opts.Bucket
+	}
+	opts.PublicURL = strings.TrimSuffix(opts.PublicURL, "/")
+	return &S3Store{
+		opts:     opts,
+		endpoint: endpoint,
+		client:   &http.Client{Timeout: 30 * time.Second},
+	}, nil
+}
+
+func (s *S3Store) Put(ctx context.Context, key string, contentType string, data []byte) error {
+	if !validKey(key) {
+		return errInvalidKey
+	}
+	return s.do(ctx, http.MethodPut, key, contentType, data)
+}
+
+func (s *S3Store) Delete(ctx context.Context, key string) error {
+	if !validKey(key) {
+		return errInvalidKey
+	}
+	// S3 отвечает 204 и на удаление отсутствующего объекта
+	return s.do(ctx, http.MethodDelete, key, "", nil)
+}
+
+func (s *S3Store) URL(key string) string {
+	return s.opts.PublicURL + "/" + escapePath(key)
+}
+
+func (s *S3Store) do(ctx context.Context, method string, key string, contentType string, data []byte) error {
+	path := "/" + escapePath(s.opts.Bucket) + "/" + escapePath(key)
+	target := *s.endpoint
+	target.Path = strings.TrimSuffix(target.Path, "/") + path
+	target.RawPath = strings.TrimSuffix(s.endpoint.EscapedPath(), "/") + path
+
+	req, err := http.NewRequestWithContext(ctx, method, target.String(), bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.ContentLength = int64(len(data))
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	s.sign(req, data, time.Now().UTC())
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("blobstore: S3 %s %s: %s: %s", method, key, resp.Status, strings.TrimSpace(string(body)))
+	}
+	return nil
+}
+
+// sign добавляет к запросу подпись AWS Signature Version 4
+func (s *S3Store) sign(req *http.Request, payload []byte, now time.Time) {
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+	payloadHash := sha256Hex(payload)
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	// Подписываются host, content-type (если есть) и заголовки x-amz-*
+	values := map[string]string{
+		"host":                 req.URL.Host,
+		"x-amz-content-sha256": payloadHash,
+		"x-amz-date":           amzDate,
+	}
+	if ct := req.Header.Get("Content-Type"); ct != "" {
+		values["content-type"] = ct
+	}
+	headers := make([]string, 0, len(values))
+	for h := range values {
+		headers = append(headers, h)
+	}
+	sort.Strings(headers)
+
+	var canonicalHeaders strings.Builder
+	for _, h := range headers {
+		canonicalHeaders.WriteString(h + ":" + strings.TrimSpace(values[h]) + "\n")
+	}
+	signedHeaders := strings.Join(headers, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		req.URL.RawQuery,
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := date + "/" + s.opts.Region + "/s3/aws4_request"
+	stringToSign := strings.Join([]string{"AWS4-HMAC-SHA256", amzDate, scope, sha256Hex([]byte(canonicalRequest))}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+s.opts.SecretKey), date)
+	key = hmacSHA256(key, s.opts.Region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s.opts.AccessKey, scope, signedHeaders, signature))
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+// escapePath кодирует сегменты пути по правилам SigV4: не кодируются только A-Z, a-z, 0-9, '-', '.', '_', '~'
+func escapePath(path string) string {
+	parts := strings.Split(path, "/")
+	for i, part := range parts {
+		var b strings.Builder
+		for _, c := range []byte(part) {
+			if ('A' <= c && c <= 'Z') || ('a' <= c && c <= 'z') || ('0' <= c && c <= '9') || c == '-' || c == '.' || c == '_' || c == '~' {
+				b.WriteByte(c)
+			} else {
+				fmt.Fprintf(&b, "%%%02X", c)
+			}
+		}
+		parts[i] = b.String()
+	}
+	return strings.Join(parts, "/")
+}
@@ -0,0 +1,26 @@
+package contracts
+
+// ProductKafkaDTO - сообщение о товаре для сервиса поиска.
+// Формат должен совпадать с contracts.ProductKafkaDTO в search.
+type ProductKafkaDTO struct {
+	ID          string `json:"id"`          // уникальный идентификатор
+	Name        string `json:"name"`        // название продукта
+	Description string `json:"description"` // описание продукта
+	Price       Money  `json:"price"`       // цена
+	PriceMin    Money  `json:"priceMin"`    // минимальная цена среди вариантов
+	PriceMax    Money  `json:"priceMax"`    // максимальная цена среди вариантов
+	Stock       int    `json:"stock"`       // количество на складе (используется как "популярность")
+	Category    string `json:"category"`    // категория продукта
+	Brand       string `json:"brand"`       // бренд продукта
+	// значения атрибутов по схеме категории: строки, числа и логические значения
+	Attributes map[string]interface{} `json:"attributes,omitempty"`
+	// товар удалён или снят с публикации: такое сообщение (tombstone) содержит только ID,
+	// и поиск убирает товар из индекса
+	Deleted bool `json:"deleted,omitempty"`
+}
+
+// Money - сумма в минимальных единицах валюты (копейках) и код валюты ISO 4217
+type Money struct {
+	Amount   int64  `json:"amount"`
+	Currency string `json:"currency"`
+}
@@ -0,0 +1,66 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"goods/internal/models"
+	"strconv"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+)
+
+// Producer публикует события outbox в топик Kafka.
+// Ключ сообщения - идентификатор карточки, поэтому все события одной карточки
+// попадают в одну партицию и читаются в порядке публикации.
+type Producer struct {
+	writer *kafka.Writer
+}
+
+func NewProducer(brokers []string, topic string) *Producer {
+	return &Producer{
+		writer: &kafka.Writer{
+			Addr:         kafka.TCP(brokers...),
+			Topic:        topic,
+			Balancer:     &kafka.Hash{},
+			RequiredAcks: kafka.RequireAll,
+			BatchTimeout: 10 * time.Millisecond,
+		},
+	}
+}
+
+// Publish синхронно отправляет события и возвращает число событий в начале списка,
+// которые гарантированно записаны в Kafka. Остальные нужно отправить повторно.
+func (p *Producer) Publish(ctx context.Context, events []models.OutboxEvent) (int, error) {
+	messages := make([]kafka.Message, 0, len(events))
+	for _, event := range events {
+		messages = append(messages, kafka.Message{
+			Key:   []byte(event.AggregateID.String()),
+			Value: event.Payload,
+			Headers: []kafka.Header{
+				{Key: "event-type", Value: []byte(event.EventType)},
+				{Key: "event-id", Value: []byte(strconv.FormatInt(event.ID, 10))},
+			},
+		})
+	}
+
+	err := p.writer.WriteMessages(ctx, messages...)
+	if err == nil {
+		return len(events), nil
+	}
+
+	// При частичной ошибке считаем успешным только непрерывный префикс
+	var writeErrs kafka.WriteErrors
+	if errors.As(err, &writeErrs) {
+		for i, e := range writeErrs {
+			if e != nil {
+				return i, err
+			}
+		}
+	}
+	return 0, err
+}
+
+func (p *Producer) Close() error {
+	return p.writer.Close()
+}
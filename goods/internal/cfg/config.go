@@ -3,6 +3,9 @@ package config
 import (
 	"log"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 )
@@ -14,6 +17,45 @@ type Config struct {
 	DBHost     string
 	DBPort     string
 	SslMode    string
+
+	GRPCAddr     string // Адрес gRPC API для внутренних сервисов
+	ServiceToken string // Секрет внутренних сервисов (X-Service-Token, x-service-token в gRPC); пусто - резервы по HTTP и все вызовы gRPC отклоняются
+
+	RedisAddr     string // Пусто - кэш чтения карточек отключён
+	RedisPassword string
+	RedisDB       int
+	RedisTimeout  time.Duration // Таймаут одной команды Redis; при недоступности Redis чтение идёт в базу
+	CacheTTL      time.Duration // Сколько живёт карточка в кэше
+
+	ReservationTTL           time.Duration // Срок резерва по умолчанию
+	ReservationMaxTTL        time.Duration // Максимальный срок резерва
+	ReservationSweepInterval time.Duration // Период возврата истёкших резервов в остаток
+	PriceScheduleInterval    time.Duration // Период применения расписаний цен
+	CardRetention            time.Duration // Сколько хранится удалённая карточка до окончательного удаления
+	CardPurgeInterval        time.Duration // Период окончательного удаления карточек
+	IdempotencyTTL           time.Duration // Сколько хранится ответ на запрос с Idempotency-Key
+	IdempotencyPurgeInterval time.Duration // Период удаления истёкших ключей идемпотентности
+
+	KafkaBrokers       []string      // Пусто - публикация событий в Kafka отключена
+	KafkaProductTopic  string        // Топик событий о товарах для сервиса поиска
+	OutboxPollInterval time.Duration // Период опроса outbox
+	OutboxBatchSize    int           // Сколько событий публикуется за один проход
+
+	WebhookPollInterval time.Duration // Период отправки оповещений продавцам
+	WebhookTimeout      time.Duration // Таймаут одной попытки доставки
+	WebhookBatchSize    int           // Сколько доставок отправляется за один проход
+	WebhookMaxAttempts  int           // После стольких неудачных попыток доставка считается проваленной
+	WebhookBackoff      time.Duration // Задержка перед первым повтором, дальше удваивается
+
+	MediaStore     string // Хранилище изображений: local или s3
+	MediaLocalDir  string // Каталог локального хранилища
+	MediaPublicURL string // Базовый адрес, от которого строятся URL изображений
+	MediaMaxSize   int    // Максимальный размер загружаемого изображения, байт
+	S3Endpoint     string
+	S3Region       string
+	S3Bucket       string
+	S3AccessKey    string
+	S3SecretKey    string
 }
 
 func LoadConfig() Config {
@@ -30,5 +72,87 @@ func LoadConfig() Config {
 		DBHost:     os.Getenv("DB_HOST"),
 		DBPort:     os.Getenv("DB_PORT"),
 		SslMode:    os.Getenv("DB_SSLMODE"),
+
+		GRPCAddr:     getString("GRPC_ADDR", ":9090"),
+		ServiceToken: os.Getenv("SERVICE_TOKEN"),
+
+		RedisAddr:     os.Getenv("REDIS_ADDR"),
+		RedisPassword: os.Getenv("REDIS_PASSWORD"),
+		RedisDB:       getInt("REDIS_DB", 0),
+		RedisTimeout:  getDuration("REDIS_TIMEOUT", 200*time.Millisecond),
+		CacheTTL:      getDuration("CACHE_TTL", 5*time.Minute),
+
+		ReservationTTL:           getDuration("RESERVATION_TTL", 15*time.Minute),
+		ReservationMaxTTL:        getDuration("RESERVATION_MAX_TTL", 24*time.Hour),
+		ReservationSweepInterval: getDuration("RESERVATION_SWEEP_INTERVAL", 30*time.Second),
+		PriceScheduleInterval:    getDuration("PRICE_SCHEDULE_INTERVAL", 30*time.Second),
+		CardRetention:            getDuration("CARD_RETENTION", 30*24*time.Hour),
+		CardPurgeInterval:        getDuration("CARD_PURGE_INTERVAL", time.Hour),
+		IdempotencyTTL:           getDuration("IDEMPOTENCY_TTL", 24*time.Hour),
+		IdempotencyPurgeInterval: getDuration("IDEMPOTENCY_PURGE_INTERVAL", time.Hour),
+
+		KafkaBrokers:       getList("KAFKA_BOOTSTRAP_SERVERS"),
+		KafkaProductTopic:  getString("KAFKA_PRODUCT_TOPIC", "products"),
+		OutboxPollInterval: getDuration("OUTBOX_POLL_INTERVAL", time.Second),
+		OutboxBatchSize:    getInt("OUTBOX_BATCH_SIZE", 100),
+
+		WebhookPollInterval: getDuration("WEBHOOK_POLL_INTERVAL", 5*time.Second),
+		WebhookTimeout:      getDuration("WEBHOOK_TIMEOUT", 10*time.Second),
+		WebhookBatchSize:    getInt("WEBHOOK_BATCH_SIZE", 50),
+		WebhookMaxAttempts:  getInt("WEBHOOK_MAX_ATTEMPTS", 8),
+		WebhookBackoff:      getDuration("WEBHOOK_BACKOFF", 30*time.Second),
+
+		MediaStore:     getString("MEDIA_STORE", "local"),
+		MediaLocalDir:  getString("MEDIA_LOCAL_DIR", "media"),
+		MediaPublicURL: getString("MEDIA_PUBLIC_URL", "/media"),
+		MediaMaxSize:   getInt("MEDIA_MAX_SIZE", 10<<20),
+		S3Endpoint:     os.Getenv("S3_ENDPOINT"),
+		S3Region:       os.Getenv("S3_REGION"),
+		S3Bucket:       os.Getenv("S3_BUCKET"),
+		S3AccessKey:    os.Getenv("S3_ACCESS_KEY"),
+		S3SecretKey:    os.Getenv("S3_SECRET_KEY"),
+	}
+}
+
+// getString читает строку из переменной окружения, возвращая def, если она не задана
+func getString(key string, def string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return def
+}
+
+// getInt читает целое число из переменной окружения, возвращая def, если она не задана
+func getInt(key string, def int) int {
+	raw := os.Getenv(key)
+	if raw == "" {
+		return def
+	}
+	v, err := strconv.Atoi(raw)
+	if err != nil {
+		log.Fatalf("Invalid int for %s: %v", key, err)
+	}
+	return v
+}
+
+// getList читает список через запятую из переменной окружения
+func getList(key string) []string {
+	raw := os.Getenv(key)
+	if raw == "" {
+		return nil
+	}
+	return strings.Split(raw, ",")
+}
+
+// getDuration читает длительность из переменной окружения, возвращая def, если она не задана
+func getDuration(key string, def time.Duration) time.Duration {
+	raw := os.Getenv(key)
+	if raw == "" {
+		return def
+	}
+	v, err := time.ParseDuration(raw)
+	if err != nil {
+		log.Fatalf("Invalid duration for %s: %v", key, err)
 	}
+	return v
 }
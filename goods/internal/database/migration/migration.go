@@ -0,0 +1,312 @@
+// Package migration применяет к базе пронумерованные SQL-миграции, встроенные в бинарник.
+// Каждая миграция - пара файлов migrations/NNNN_name.up.sql и NNNN_name.down.sql.
+package migration
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// service - имя сервиса в schema_migrations. Сервисы могут работать с общей базой
+// (в docker.env goods и sellers указана одна база market), поэтому версии учитываются по сервису.
+const service = "goods"
+
+// lockKey - ключ advisory-блокировки, общий для всех сервисов: параллельно запущенные
+// migrate up не применят одну миграцию дважды и не создадут schema_migrations одновременно
+const lockKey int64 = 0x6d696772617465
+
+// legacyTables создавались одноразовым init.sql, пока он дописывался вместе с изменениями схемы.
+// 0001 принимает только базу с исходной схемой (good_cards и goods), а миграции 0002+ создают
+// эти таблицы без IF NOT EXISTS, поэтому базу, где они уже есть, Up не трогает и сообщает об этом.
+var legacyTables = []string{
+	"reservations", "outbox", "inventory_movements", "good_card_revisions", "good_skus", "categories", "brands",
+	"good_card_media", "price_schedules", "warehouses", "webhooks", "webhook_deliveries",
+}
+
+//go:embed migrations/*.sql
+var files embed.FS
+
+// Migration - одна версия схемы
+type Migration struct {
+	Version int
+	Name    string
+	Up      string
+	Down    string
+}
+
+// Status - состояние миграции в базе; AppliedAt == nil, если миграция не применена
+type Status struct {
+	Version   int
+	Name      string
+	AppliedAt *time.Time
+}
+
+// Load возвращает встроенные миграции по возрастанию версии
+func Load() ([]Migration, error) {
+	names, err := fs.Glob(files, "migrations/*.sql")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := make(map[int]*Migration)
+	for _, name := range names {
+		base := path.Base(name)
+		var direction string
+		switch {
+		case strings.HasSuffix(base, ".up.sql"):
+			direction, base = "up", strings.TrimSuffix(base, ".up.sql")
+		case strings.HasSuffix(base, ".down.sql"):
+			direction, base = "down", strings.TrimSuffix(base, ".down.sql")
+		default:
+			return nil, fmt.Errorf("migration %s: expected .up.sql or .down.sql suffix", name)
+		}
+		number, title, ok := strings.Cut(base, "_")
+		version, err := strconv.Atoi(number)
+		if !ok || err != nil || version <= 0 {
+			return nil, fmt.Errorf("migration %s: expected NNNN_name prefix", name)
+		}
+
+		body, err := files.ReadFile(name)
+		if err != nil {
+			return nil, err
+		}
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version, Name: title}
+			byVersion[version] = m
+		}
+		if m.Name != title {
+			return nil, fmt.Errorf("migration %d: up and down names differ (%s, %s)", version, m.Name, title)
+		}
+		if direction == "up" {
+			m.Up = string(body)
+		} else {
+			m.Down = string(body)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.Up == "" || m.Down == "" {
+			return nil, fmt.Errorf("migration %d_%s: both up and down files are required", m.Version, m.Name)
+		}
+		migrations = append(migrations, *m)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	return migrations, nil
+}
+
+// Up применяет все неприменённые миграции и возвращает их
+func Up(db *sql.DB) ([]Migration, error) {
+	migrations, err := Load()
+	if err != nil {
+		return nil, err
+	}
+
+	var done []Migration
+	err = withLock(db, func(conn *sql.Conn, applied map[int]time.Time) error {
+		if len(applied) == 0 {
+			if err := checkAdoptable(conn); err != nil {
+				return err
+			}
+		}
+		for _, m := range migrations {
+			if _, ok := applied[m.Version]; ok {
+				continue
+			}
+			err := inTx(conn, func(tx *sql.Tx) error {
+				if _, err := tx.Exec(m.Up); err != nil {
+					return err
+				}
+				_, err := tx.Exec("INSERT INTO schema_migrations (service, version, name) VALUES ($1, $2, $3)", service, m.Version, m.Name)
+				return err
+			})
+			if err != nil {
+				return fmt.Errorf("migration %d_%s up: %w", m.Version, m.Name, err)
+			}
+			done = append(done, m)
+		}
+		return nil
+	})
+	return done, err
+}
+
+// Down откатывает steps последних применённых миграций и возвращает их в порядке отката
+func Down(db *sql.DB, steps int) ([]Migration, error) {
+	migrations, err := Load()
+	if err != nil {
+		return nil, err
+	}
+
+	var done []Migration
+	err = withLock(db, func(conn *sql.Conn, applied map[int]time.Time) error {
+		for i := len(migrations) - 1; i >= 0 && len(done) < steps; i-- {
+			m := migrations[i]
+			if _, ok := applied[m.Version]; !ok {
+				continue
+			}
+			err := inTx(conn, func(tx *sql.Tx) error {
+				if _, err := tx.Exec(m.Down); err != nil {
+					return err
+				}
+				_, err := tx.Exec("DELETE FROM schema_migrations WHERE service = $1 AND version = $2", service, m.Version)
+				return err
+			})
+			if err != nil {
+				return fmt.Errorf("migration %d_%s down: %w", m.Version, m.Name, err)
+			}
+			done = append(done, m)
+		}
+		return nil
+	})
+	return done, err
+}
+
+// List возвращает состояние каждой встроенной миграции
+func List(db *sql.DB) ([]Status, error) {
+	migrations, err := Load()
+	if err != nil {
+		return nil, err
+	}
+	applied, err := appliedVersions(db)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]Status, 0, len(migrations))
+	for _, m := range migrations {
+		s := Status{Version: m.Version, Name: m.Name}
+		if at, ok := applied[m.Version]; ok {
+			s.AppliedAt = &at
+		}
+		statuses = append(statuses, s)
+	}
+	return statuses, nil
+}
+
+// Check возвращает ошибку, если в базе применены не все встроенные миграции
+func Check(db *sql.DB) error {
+	statuses, err := List(db)
+	if err != nil {
+		return err
+	}
+	var pending []string
+	for _, s := range statuses {
+		if s.AppliedAt == nil {
+			pending = append(pending, fmt.Sprintf("%04d_%s", s.Version, s.Name))
+		}
+	}
+	if len(pending) > 0 {
+		return fmt.Errorf("database schema is behind, pending migrations: %s; run \"migrate up\"", strings.Join(pending, ", "))
+	}
+	return nil
+}
+
+// withLock выполняет fn на отдельном соединении под advisory-блокировкой:
+// блокировка сессионная, поэтому захват, миграции и освобождение идут через одно соединение
+func withLock(db *sql.DB, fn func(conn *sql.Conn, applied map[int]time.Time) error) error {
+	ctx := context.Background()
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, "SELECT pg_advisory_lock($1)", lockKey); err != nil {
+		return err
+	}
+	defer conn.ExecContext(ctx, "SELECT pg_advisory_unlock($1)", lockKey)
+
+	_, err = conn.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS schema_migrations (
+			service VARCHAR(64) NOT NULL,
+			version INT NOT NULL,
+			name VARCHAR(255) NOT NULL,
+			applied_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+			PRIMARY KEY (service, version)
+		)`)
+	if err != nil {
+		return err
+	}
+	// Список применённых читается под блокировкой, чтобы не устареть до начала миграций
+	applied, err := appliedVersions(conn)
+	if err != nil {
+		return err
+	}
+	return fn(conn, applied)
+}
+
+// checkAdoptable возвращает ошибку, если в базе без учёта версий уже есть таблицы из legacyTables
+func checkAdoptable(conn *sql.Conn) error {
+	var found []string
+	for _, table := range legacyTables {
+		var exists bool
+		if err := conn.QueryRowContext(context.Background(), "SELECT to_regclass($1) IS NOT NULL", table).Scan(&exists); err != nil {
+			return err
+		}
+		if exists {
+			found = append(found, table)
+		}
+	}
+	if len(found) > 0 {
+		return fmt.Errorf("database has no recorded %s migrations but already contains %s: it was created by a newer init.sql; "+
+			"only databases with the baseline schema can be adopted, recreate it or load its data into a migrated database",
+			service, strings.Join(found, ", "))
+	}
+	return nil
+}
+
+func inTx(conn *sql.Conn, fn func(tx *sql.Tx) error) error {
+	tx, err := conn.BeginTx(context.Background(), nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// queryer - общее у *sql.DB и *sql.Conn для чтения списка применённых миграций
+type queryer interface {
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// appliedVersions возвращает применённые версии сервиса; до первой миграции таблицы нет, и список пуст
+func appliedVersions(q queryer) (map[int]time.Time, error) {
+	ctx := context.Background()
+	var exists bool
+	if err := q.QueryRowContext(ctx, "SELECT to_regclass('schema_migrations') IS NOT NULL").Scan(&exists); err != nil {
+		return nil, err
+	}
+
+	applied := make(map[int]time.Time)
+	if !exists {
+		return applied, nil
+	}
+	rows, err := q.QueryContext(ctx, "SELECT version, applied_at FROM schema_migrations WHERE service = $1", service)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var version int
+		var at time.Time
+		if err := rows.Scan(&version, &at); err != nil {
+			return nil, err
+		}
+		applied[version] = at
+	}
+	return applied, rows.Err()
+}
@@ -0,0 +1,44 @@
+package migration
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLoadEmbeddedMigrations(t *testing.T) {
+	migrations, err := Load()
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if len(migrations) == 0 {
+		t.Fatal("no embedded migrations")
+	}
+	// Версии идут подряд с 1: пропуск обычно означает потерянный при слиянии файл
+	for i, m := range migrations {
+		if m.Version != i+1 {
+			t.Fatalf("migration #%d has version %d, want %d", i, m.Version, i+1)
+		}
+		if m.Name == "" {
+			t.Errorf("migration %d has empty name", m.Version)
+		}
+	}
+}
+
+func TestLegacyTablesAreCreatedByLaterMigrations(t *testing.T) {
+	migrations, err := Load()
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	// Таблица, которую создаёт 0001, не мешает принятию базы и не должна считаться признаком init.sql
+	for _, table := range legacyTables {
+		createdBy := 0
+		for _, m := range migrations {
+			if strings.Contains(m.Up, "CREATE TABLE "+table+" (") {
+				createdBy = m.Version
+			}
+		}
+		if createdBy < 2 {
+			t.Errorf("legacy table %s is not created by a migration after 0001", table)
+		}
+	}
+}
@@ -0,0 +1,2 @@
+DROP TABLE goods;
+DROP TABLE good_cards;
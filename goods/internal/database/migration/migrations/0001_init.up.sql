@@ -0,0 +1,16 @@
+-- IF NOT EXISTS: базы с исходной схемой одноразового init.sql принимают версионирование с этой миграции;
+-- базы, где init.sql уже создал таблицы поздних миграций, Up отклоняет (см. legacyTables)
+CREATE TABLE IF NOT EXISTS good_cards (
+	uuid UUID PRIMARY KEY DEFAULT gen_random_uuid(), -- Уникальный идентификатор карточки товара
+	price NUMERIC(10, 2) NOT NULL,                   -- Цена товара
+	name VARCHAR(255) NOT NULL,                      -- Название товара
+	description TEXT NOT NULL,                       -- Описание товара
+	weight NUMERIC(10, 2) NOT NULL,                  -- Вес товара
+	seller_id UUID NOT NULL,                         -- Уникальный идентификатор продавца
+	is_active BOOLEAN NOT NULL DEFAULT TRUE          -- Статус активации товара
+);
+
+CREATE TABLE IF NOT EXISTS goods (
+	card_id UUID REFERENCES good_cards(uuid) ON DELETE CASCADE, -- Внешний ключ на карточку товара
+	quantity INT DEFAULT 0                                      -- Количество товара
+);
@@ -0,0 +1,2 @@
+DROP TABLE reservations;
+ALTER TABLE goods DROP COLUMN reserved;
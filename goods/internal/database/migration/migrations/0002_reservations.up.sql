@@ -0,0 +1,12 @@
+ALTER TABLE goods ADD COLUMN reserved INT NOT NULL DEFAULT 0; -- Количество товара в активных резервах
+
+CREATE TABLE reservations (
+	uuid UUID PRIMARY KEY DEFAULT gen_random_uuid(),                     -- Идентификатор резерва
+	card_id UUID NOT NULL REFERENCES good_cards(uuid) ON DELETE CASCADE, -- Зарезервированный товар
+	quantity INT NOT NULL CHECK (quantity > 0),                          -- Количество в резерве
+	status VARCHAR(16) NOT NULL DEFAULT 'active',                        -- active, committed, released, expired
+	expires_at TIMESTAMPTZ NOT NULL,                                     -- Момент истечения резерва
+	created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX reservations_active_expires_idx ON reservations (expires_at) WHERE status = 'active';
@@ -0,0 +1,10 @@
+CREATE TABLE outbox (
+	id BIGSERIAL PRIMARY KEY,                      -- Порядок публикации событий
+	aggregate_id UUID NOT NULL,                    -- Карточка товара, к которой относится событие (ключ сообщения Kafka)
+	event_type VARCHAR(32) NOT NULL,               -- Тип события
+	payload JSONB NOT NULL,                        -- Сообщение в формате contracts.ProductKafkaDTO
+	created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+	published_at TIMESTAMPTZ                       -- NULL, пока событие не опубликовано
+);
+
+CREATE INDEX outbox_unpublished_idx ON outbox (id) WHERE published_at IS NULL;
@@ -0,0 +1,16 @@
+CREATE TABLE inventory_movements (
+	id BIGSERIAL PRIMARY KEY,
+	card_id UUID NOT NULL,                           -- Товар (без внешнего ключа: история переживает удаление карточки)
+	delta INT NOT NULL,                              -- Изменение доступного количества
+	reason VARCHAR(32) NOT NULL,                     -- Код причины движения
+	actor VARCHAR(255) NOT NULL DEFAULT '',          -- Кто выполнил операцию
+	correlation_id VARCHAR(255) NOT NULL DEFAULT '', -- Идентификатор запроса или резерва
+	quantity_after INT NOT NULL,                     -- Доступное количество после движения
+	created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX inventory_movements_card_idx ON inventory_movements (card_id, id);
+
+-- Остатки, существовавшие до появления журнала, фиксируются как входящий остаток
+INSERT INTO inventory_movements (card_id, delta, reason, quantity_after)
+SELECT card_id, COALESCE(quantity, 0), 'opening_balance', COALESCE(quantity, 0) FROM goods WHERE card_id IS NOT NULL;
@@ -0,0 +1,2 @@
+DROP TABLE good_card_revisions;
+ALTER TABLE good_cards DROP COLUMN version;
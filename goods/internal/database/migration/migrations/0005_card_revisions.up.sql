@@ -0,0 +1,16 @@
+ALTER TABLE good_cards ADD COLUMN version INT NOT NULL DEFAULT 1; -- Версия для оптимистичной блокировки
+
+CREATE TABLE good_card_revisions (
+	card_id UUID NOT NULL REFERENCES good_cards(uuid) ON DELETE CASCADE,
+	version INT NOT NULL,                          -- Версия карточки, которую описывает ревизия
+	price NUMERIC(10, 2) NOT NULL,
+	name VARCHAR(255) NOT NULL,
+	description TEXT NOT NULL,
+	weight NUMERIC(10, 2) NOT NULL,
+	is_active BOOLEAN NOT NULL,
+	created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+	PRIMARY KEY (card_id, version)
+);
+
+INSERT INTO good_card_revisions (card_id, version, price, name, description, weight, is_active)
+SELECT uuid, version, price, name, description, weight, is_active FROM good_cards;
@@ -0,0 +1,13 @@
+-- Резервы и журнал снова ссылаются на карточку варианта
+ALTER INDEX inventory_movements_sku_idx RENAME TO inventory_movements_card_idx;
+ALTER TABLE inventory_movements RENAME COLUMN sku_id TO card_id;
+UPDATE inventory_movements m SET card_id = s.card_id FROM good_skus s WHERE s.uuid = m.card_id;
+ALTER TABLE reservations DROP CONSTRAINT reservations_sku_id_fkey;
+ALTER TABLE reservations RENAME COLUMN sku_id TO card_id;
+UPDATE reservations r SET card_id = s.card_id FROM good_skus s WHERE s.uuid = r.card_id;
+ALTER TABLE reservations ADD FOREIGN KEY (card_id) REFERENCES good_cards(uuid) ON DELETE CASCADE;
+
+DROP INDEX goods_sku_idx;
+ALTER TABLE goods DROP COLUMN sku_id;
+DROP TABLE good_skus;
+ALTER TABLE good_cards DROP COLUMN variant_axes;
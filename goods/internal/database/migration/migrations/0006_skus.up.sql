@@ -0,0 +1,28 @@
+ALTER TABLE good_cards ADD COLUMN variant_axes TEXT[] NOT NULL DEFAULT '{}'; -- Оси вариантов (например, size и color)
+
+CREATE TABLE good_skus (
+	uuid UUID PRIMARY KEY DEFAULT gen_random_uuid(),                     -- Идентификатор варианта (SKU)
+	card_id UUID NOT NULL REFERENCES good_cards(uuid) ON DELETE CASCADE, -- Карточка, к которой относится вариант
+	attributes JSONB NOT NULL DEFAULT '{}',                              -- Значения осей вариантов
+	price NUMERIC(10, 2),                                                -- Цена варианта; NULL - цена карточки
+	weight NUMERIC(10, 2),                                               -- Вес варианта; NULL - вес карточки
+	created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+	UNIQUE (card_id, attributes)
+);
+
+-- Существующие товары становятся вариантом по умолчанию с идентификатором карточки,
+-- поэтому прежние идентификаторы товаров остаются действительными
+INSERT INTO good_skus (uuid, card_id) SELECT DISTINCT card_id, card_id FROM goods WHERE card_id IS NOT NULL;
+
+ALTER TABLE goods ADD COLUMN sku_id UUID REFERENCES good_skus(uuid) ON DELETE CASCADE; -- Вариант, остаток которого хранит строка
+DELETE FROM goods WHERE card_id IS NULL;
+UPDATE goods SET sku_id = card_id;
+ALTER TABLE goods ALTER COLUMN sku_id SET NOT NULL;
+CREATE UNIQUE INDEX goods_sku_idx ON goods (sku_id);
+
+-- Резервы и журнал движения относятся к варианту
+ALTER TABLE reservations DROP CONSTRAINT reservations_card_id_fkey;
+ALTER TABLE reservations RENAME COLUMN card_id TO sku_id;
+ALTER TABLE reservations ADD FOREIGN KEY (sku_id) REFERENCES good_skus(uuid) ON DELETE CASCADE;
+ALTER TABLE inventory_movements RENAME COLUMN card_id TO sku_id;
+ALTER INDEX inventory_movements_card_idx RENAME TO inventory_movements_sku_idx;
@@ -0,0 +1,4 @@
+ALTER TABLE good_cards DROP COLUMN brand_id;
+ALTER TABLE good_cards DROP COLUMN category_id;
+DROP TABLE brands;
+DROP TABLE categories;
@@ -0,0 +1,21 @@
+CREATE TABLE categories (
+	uuid UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+	parent_id UUID REFERENCES categories(uuid) ON DELETE RESTRICT, -- NULL у корневых категорий
+	name VARCHAR(255) NOT NULL,
+	created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+-- Имена соседних категорий уникальны, в том числе среди корневых
+CREATE UNIQUE INDEX categories_sibling_name_idx ON categories (COALESCE(parent_id, '00000000-0000-0000-0000-000000000000'), name);
+
+CREATE TABLE brands (
+	uuid UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+	name VARCHAR(255) UNIQUE NOT NULL,
+	created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+ALTER TABLE good_cards ADD COLUMN category_id UUID REFERENCES categories(uuid) ON DELETE RESTRICT; -- Категория товара
+ALTER TABLE good_cards ADD COLUMN brand_id UUID REFERENCES brands(uuid) ON DELETE RESTRICT;        -- Бренд товара
+
+CREATE INDEX good_cards_category_idx ON good_cards (category_id);
+CREATE INDEX good_cards_brand_idx ON good_cards (brand_id);
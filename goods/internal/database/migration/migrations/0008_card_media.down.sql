@@ -0,0 +1,2 @@
+-- Файлы изображений в хранилище не удаляются
+DROP TABLE good_card_media;
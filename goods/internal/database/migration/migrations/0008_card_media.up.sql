@@ -0,0 +1,14 @@
+CREATE TABLE good_card_media (
+	uuid UUID PRIMARY KEY,
+	card_id UUID NOT NULL REFERENCES good_cards(uuid) ON DELETE CASCADE,
+	position INT NOT NULL,                         -- Порядок показа изображений карточки
+	content_type VARCHAR(64) NOT NULL,
+	size BIGINT NOT NULL,                          -- Размер оригинала, байт
+	width INT NOT NULL,
+	height INT NOT NULL,
+	blob_key TEXT NOT NULL,                        -- Ключ оригинала в хранилище
+	thumbnail_key TEXT NOT NULL,                   -- Ключ миниатюры в хранилище
+	created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX good_card_media_card_idx ON good_card_media (card_id, position);
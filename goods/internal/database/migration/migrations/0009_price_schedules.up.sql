@@ -0,0 +1,16 @@
+CREATE TABLE price_schedules (
+	uuid UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+	card_id UUID NOT NULL REFERENCES good_cards(uuid) ON DELETE CASCADE,
+	starts_at TIMESTAMPTZ NOT NULL,
+	ends_at TIMESTAMPTZ NOT NULL,
+	price NUMERIC(10, 2),                          -- Абсолютная цена на время действия
+	percent NUMERIC(5, 2),                         -- Или скидка в процентах от базовой цены
+	status VARCHAR(16) NOT NULL DEFAULT 'pending', -- pending, active, finished, cancelled
+	created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+	CHECK (ends_at > starts_at),
+	CHECK ((price IS NULL) <> (percent IS NULL))
+);
+
+-- У карточки не больше одного действующего расписания
+CREATE UNIQUE INDEX price_schedules_active_idx ON price_schedules (card_id) WHERE status = 'active';
+CREATE INDEX price_schedules_due_idx ON price_schedules (starts_at, ends_at) WHERE status IN ('pending', 'active');
@@ -0,0 +1,16 @@
+ALTER TABLE inventory_movements DROP COLUMN warehouse_id;
+ALTER TABLE reservations DROP COLUMN warehouse_id;
+
+DROP INDEX goods_warehouse_idx;
+DROP INDEX goods_sku_warehouse_idx;
+ALTER TABLE goods DROP COLUMN warehouse_id;
+
+-- Остаток всех складов варианта сводится в одну строку
+WITH merged AS (
+	DELETE FROM goods RETURNING card_id, sku_id, quantity, reserved
+)
+INSERT INTO goods (card_id, sku_id, quantity, reserved)
+SELECT card_id, sku_id, SUM(quantity), SUM(reserved) FROM merged GROUP BY card_id, sku_id;
+
+CREATE UNIQUE INDEX goods_sku_idx ON goods (sku_id);
+DROP TABLE warehouses;
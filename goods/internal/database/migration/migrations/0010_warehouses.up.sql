@@ -0,0 +1,26 @@
+CREATE TABLE warehouses (
+	uuid UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+	seller_id UUID NOT NULL,                       -- Продавец, которому принадлежит склад
+	name VARCHAR(255) NOT NULL,
+	priority INT NOT NULL DEFAULT 0,               -- Порядок выбора склада для списания: меньше - раньше
+	created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+	UNIQUE (seller_id, name)
+);
+
+-- Существующий остаток переносится на склад по умолчанию продавца карточки
+INSERT INTO warehouses (seller_id, name) SELECT DISTINCT seller_id, 'default' FROM good_cards;
+
+ALTER TABLE goods ADD COLUMN warehouse_id UUID REFERENCES warehouses(uuid) ON DELETE RESTRICT; -- Склад, на котором лежит остаток
+UPDATE goods g SET warehouse_id = w.uuid
+FROM good_cards gc JOIN warehouses w ON w.seller_id = gc.seller_id AND w.name = 'default'
+WHERE gc.uuid = g.card_id;
+ALTER TABLE goods ALTER COLUMN warehouse_id SET NOT NULL;
+DROP INDEX goods_sku_idx;
+CREATE UNIQUE INDEX goods_sku_warehouse_idx ON goods (sku_id, warehouse_id);
+CREATE INDEX goods_warehouse_idx ON goods (warehouse_id);
+
+-- У закрытых резервов и журнала склад может быть удалён, история при этом сохраняется
+ALTER TABLE reservations ADD COLUMN warehouse_id UUID REFERENCES warehouses(uuid) ON DELETE SET NULL; -- Склад, с которого зарезервирован товар
+UPDATE reservations r SET warehouse_id = g.warehouse_id FROM goods g WHERE g.sku_id = r.sku_id;
+ALTER TABLE inventory_movements ADD COLUMN warehouse_id UUID REFERENCES warehouses(uuid) ON DELETE SET NULL; -- Склад движения
+UPDATE inventory_movements m SET warehouse_id = g.warehouse_id FROM goods g WHERE g.sku_id = m.sku_id;
@@ -0,0 +1,27 @@
+ALTER TABLE good_cards ADD COLUMN low_stock_threshold INT CHECK (low_stock_threshold >= 0); -- Порог остатка для оповещения; NULL - не задан
+
+CREATE TABLE webhooks (
+	seller_id UUID PRIMARY KEY,                    -- У продавца один адрес оповещений
+	url TEXT NOT NULL,
+	secret TEXT NOT NULL,                          -- Ключ HMAC-подписи доставок
+	created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE TABLE webhook_deliveries (
+	id BIGSERIAL PRIMARY KEY,
+	seller_id UUID NOT NULL,
+	card_id UUID NOT NULL,                         -- Без внешнего ключа: журнал переживает удаление карточки
+	event VARCHAR(32) NOT NULL,                    -- low_stock, out_of_stock
+	url TEXT NOT NULL,                             -- Адрес на момент оповещения
+	payload JSONB NOT NULL,
+	status VARCHAR(16) NOT NULL DEFAULT 'pending', -- pending, delivered, failed
+	attempts INT NOT NULL DEFAULT 0,
+	next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+	response_code INT,                             -- Код ответа последней попытки
+	last_error TEXT NOT NULL DEFAULT '',
+	created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+	delivered_at TIMESTAMPTZ
+);
+
+CREATE INDEX webhook_deliveries_due_idx ON webhook_deliveries (next_attempt_at) WHERE status = 'pending';
+CREATE INDEX webhook_deliveries_seller_idx ON webhook_deliveries (seller_id, id);
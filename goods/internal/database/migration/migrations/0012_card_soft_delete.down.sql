@@ -0,0 +1,3 @@
+-- Мягко удалённые карточки при откате удаляются окончательно
+DELETE FROM good_cards WHERE deleted_at IS NOT NULL;
+ALTER TABLE good_cards DROP COLUMN deleted_at;
@@ -0,0 +1,3 @@
+ALTER TABLE good_cards ADD COLUMN deleted_at TIMESTAMPTZ; -- Момент мягкого удаления; NULL - карточка не удалена
+
+CREATE INDEX good_cards_deleted_idx ON good_cards (deleted_at) WHERE deleted_at IS NOT NULL;
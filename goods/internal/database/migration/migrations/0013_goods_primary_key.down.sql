@@ -0,0 +1,4 @@
+ALTER TABLE goods DROP CONSTRAINT goods_pkey;
+CREATE UNIQUE INDEX goods_sku_warehouse_idx ON goods (sku_id, warehouse_id);
+ALTER TABLE goods ALTER COLUMN quantity DROP NOT NULL;
+ALTER TABLE goods ALTER COLUMN card_id DROP NOT NULL;
@@ -0,0 +1,5 @@
+-- Строка остатка однозначно определяется вариантом и складом
+UPDATE goods SET quantity = 0 WHERE quantity IS NULL;
+ALTER TABLE goods ALTER COLUMN card_id SET NOT NULL;
+ALTER TABLE goods ALTER COLUMN quantity SET NOT NULL;
+ALTER TABLE goods ADD CONSTRAINT goods_pkey PRIMARY KEY USING INDEX goods_sku_warehouse_idx;
@@ -0,0 +1 @@
+DROP TABLE goods_idempotency_keys;
@@ -0,0 +1,14 @@
+-- Ключи сервиса goods; у sellers своя таблица в той же базе market, поэтому ключи и их очистка не пересекаются
+CREATE TABLE goods_idempotency_keys (
+	scope UUID NOT NULL,                          -- Продавец запроса; нулевой UUID - запрос без продавца
+	key VARCHAR(255) NOT NULL,                    -- Значение заголовка Idempotency-Key
+	fingerprint CHAR(64) NOT NULL,                -- SHA-256 метода, пути и тела первого запроса
+	status_code INT,                              -- NULL, пока первый запрос выполняется
+	content_type VARCHAR(255) NOT NULL DEFAULT '',
+	body BYTEA,
+	created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+	expires_at TIMESTAMPTZ NOT NULL,              -- После этого момента ключ можно использовать заново
+	PRIMARY KEY (scope, key)
+);
+
+CREATE INDEX goods_idempotency_keys_expires_idx ON goods_idempotency_keys (expires_at);
@@ -0,0 +1,4 @@
+DROP TABLE card_moderation_log;
+ALTER TABLE good_cards DROP COLUMN moderation_changed_at;
+ALTER TABLE good_cards DROP COLUMN moderation_reason;
+ALTER TABLE good_cards DROP COLUMN moderation_status;
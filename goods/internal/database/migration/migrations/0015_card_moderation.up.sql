@@ -0,0 +1,21 @@
+-- Карточки, опубликованные до модерации, считаются одобренными; новые создаются черновиками
+ALTER TABLE good_cards ADD COLUMN moderation_status VARCHAR(16) NOT NULL DEFAULT 'approved'
+	CHECK (moderation_status IN ('draft', 'submitted', 'approved', 'rejected'));
+ALTER TABLE good_cards ALTER COLUMN moderation_status SET DEFAULT 'draft';
+ALTER TABLE good_cards ADD COLUMN moderation_reason TEXT;                                 -- Причина последнего отклонения
+ALTER TABLE good_cards ADD COLUMN moderation_changed_at TIMESTAMPTZ NOT NULL DEFAULT now(); -- Момент смены статуса; порядок очереди
+
+CREATE INDEX good_cards_moderation_queue_idx ON good_cards (moderation_changed_at, uuid) WHERE moderation_status = 'submitted';
+
+CREATE TABLE card_moderation_log (
+	id BIGSERIAL PRIMARY KEY,
+	card_id UUID NOT NULL,                         -- Без внешнего ключа: журнал переживает удаление карточки
+	card_version INT NOT NULL,                     -- Версия карточки, к которой относится решение
+	from_status VARCHAR(16) NOT NULL,
+	to_status VARCHAR(16) NOT NULL,
+	reason TEXT NOT NULL DEFAULT '',
+	actor VARCHAR(255) NOT NULL DEFAULT '',        -- Модератор, продавец или system для автоматического возврата на проверку
+	created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX card_moderation_log_card_idx ON card_moderation_log (card_id, id);
@@ -0,0 +1,2 @@
+ALTER TABLE good_cards DROP COLUMN attributes;
+DROP TABLE category_attributes;
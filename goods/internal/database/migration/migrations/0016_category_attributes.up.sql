@@ -0,0 +1,12 @@
+CREATE TABLE category_attributes (
+	category_id UUID NOT NULL REFERENCES categories(uuid) ON DELETE CASCADE,
+	name VARCHAR(64) NOT NULL,
+	type VARCHAR(16) NOT NULL CHECK (type IN ('string', 'number', 'integer', 'boolean', 'enum')),
+	unit VARCHAR(32) NOT NULL DEFAULT '',            -- Единица измерения, например GB
+	allowed_values TEXT[] NOT NULL DEFAULT '{}',     -- Допустимые значения enum
+	required BOOLEAN NOT NULL DEFAULT FALSE,
+	position INT NOT NULL,                           -- Порядок атрибута в схеме и при чтении
+	PRIMARY KEY (category_id, name)
+);
+
+ALTER TABLE good_cards ADD COLUMN attributes JSONB NOT NULL DEFAULT '{}'; -- Значения атрибутов по схеме категории
@@ -0,0 +1,9 @@
+DROP TABLE exchange_rates;
+DROP TABLE card_prices;
+-- Цены в других валютах в рублях не выразить: откат допустим, только пока все карточки в рублях
+ALTER TABLE good_card_revisions ALTER COLUMN price TYPE NUMERIC(10, 2) USING price / 100.0;
+ALTER TABLE good_card_revisions DROP COLUMN currency;
+ALTER TABLE price_schedules ALTER COLUMN price TYPE NUMERIC(10, 2) USING price / 100.0;
+ALTER TABLE good_skus ALTER COLUMN price TYPE NUMERIC(10, 2) USING price / 100.0;
+ALTER TABLE good_cards ALTER COLUMN price TYPE NUMERIC(10, 2) USING price / 100.0;
+ALTER TABLE good_cards DROP COLUMN currency;
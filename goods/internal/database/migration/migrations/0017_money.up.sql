@@ -0,0 +1,28 @@
+-- Цены хранятся целым числом минимальных единиц валюты (копеек), у карточки своя валюта.
+-- Цены вариантов и расписаний задаются в валюте карточки. Существующие цены - в рублях.
+ALTER TABLE good_cards ADD COLUMN currency CHAR(3) NOT NULL DEFAULT 'RUB';
+ALTER TABLE good_cards ALTER COLUMN currency DROP DEFAULT;
+ALTER TABLE good_cards ALTER COLUMN price TYPE BIGINT USING ROUND(price * 100);
+ALTER TABLE good_skus ALTER COLUMN price TYPE BIGINT USING ROUND(price * 100);
+ALTER TABLE price_schedules ALTER COLUMN price TYPE BIGINT USING ROUND(price * 100);
+ALTER TABLE good_card_revisions ADD COLUMN currency CHAR(3) NOT NULL DEFAULT 'RUB';
+ALTER TABLE good_card_revisions ALTER COLUMN currency DROP DEFAULT;
+ALTER TABLE good_card_revisions ALTER COLUMN price TYPE BIGINT USING ROUND(price * 100);
+
+-- Необязательные цены карточки в других валютах: показываются вместо пересчёта по курсу
+CREATE TABLE card_prices (
+	card_id UUID NOT NULL REFERENCES good_cards(uuid) ON DELETE CASCADE,
+	currency CHAR(3) NOT NULL,
+	amount BIGINT NOT NULL CHECK (amount >= 0),   -- В минимальных единицах currency
+	PRIMARY KEY (card_id, currency)
+);
+
+-- Курсы для показа цен в другой валюте: 1 base = rate quote
+CREATE TABLE exchange_rates (
+	base CHAR(3) NOT NULL,
+	quote CHAR(3) NOT NULL,
+	rate NUMERIC(20, 10) NOT NULL CHECK (rate > 0),
+	updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+	PRIMARY KEY (base, quote),
+	CHECK (base <> quote)
+);
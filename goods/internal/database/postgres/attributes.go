@@ -0,0 +1,238 @@
+package postgresdb
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	myErrors "goods/internal/errors"
+	"goods/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// ReadAttributeSchema возвращает схему атрибутов категории в порядке атрибутов
+func (db *Postgres) ReadAttributeSchema(categoryID uuid.UUID) ([]models.AttributeDefinition, error) {
+	var exists bool
+	if err := db.Connection.QueryRow("SELECT EXISTS(SELECT 1 FROM categories WHERE uuid = $1)", categoryID).Scan(&exists); err != nil {
+		return nil, myErrors.ErrAttributeInternal
+	}
+	if !exists {
+		return nil, myErrors.ErrCategoryNotFound
+	}
+	schemas, err := loadSchemas(db.Connection, []string{categoryID.String()})
+	if err != nil {
+		return nil, myErrors.ErrAttributeInternal
+	}
+	return schemas[categoryID], nil
+}
+
+// SetAttributeSchema заменяет схему атрибутов категории. Значения удалённых из схемы атрибутов
+// удаляются из карточек, остальные значения проверяются по новой схеме: если хотя бы одна карточка
+// ей не соответствует, схема не меняется.
+func (db *Postgres) SetAttributeSchema(categoryID uuid.UUID, schema []models.AttributeDefinition) error {
+	tx, err := db.Connection.Begin()
+	if err != nil {
+		return myErrors.ErrAttributeInternal
+	}
+	defer tx.Rollback()
+
+	// Карточки блокируются раньше категории: изменение карточки блокирует сначала её, потом категорию.
+	// После блокировки категории список перечитывается - в него попадают карточки, перенесённые в категорию за это время.
+	if _, err := lockCategoryCards(tx, categoryID); err != nil {
+		return myErrors.ErrAttributeInternal
+	}
+	var lockedID uuid.UUID
+	err = tx.QueryRow("SELECT uuid FROM categories WHERE uuid = $1 FOR UPDATE", categoryID).Scan(&lockedID)
+	if err == sql.ErrNoRows {
+		return myErrors.ErrCategoryNotFound
+	}
+	if err != nil {
+		return myErrors.ErrAttributeInternal
+	}
+	cards, err := lockCategoryCards(tx, categoryID)
+	if err != nil {
+		return myErrors.ErrAttributeInternal
+	}
+
+	if _, err := tx.Exec("DELETE FROM category_attributes WHERE category_id = $1", categoryID); err != nil {
+		return myErrors.ErrAttributeInternal
+	}
+	defined := make(map[string]bool, len(schema))
+	for i, def := range schema {
+		_, err := tx.Exec(`
+			INSERT INTO category_attributes (category_id, name, type, unit, allowed_values, required, position)
+			VALUES ($1, $2, $3, $4, $5, $6, $7)`,
+			categoryID, def.Name, def.Type, def.Unit, pq.Array(allowedValues(def.AllowedValues)), def.Required, i)
+		if err != nil {
+			return myErrors.ErrAttributeInternal
+		}
+		defined[def.Name] = true
+	}
+
+	for _, card := range cards {
+		stripped := false
+		for name := range card.values {
+			if !defined[name] {
+				delete(card.values, name)
+				stripped = true
+			}
+		}
+		if err := models.CheckAttributes(schema, card.values); err != nil {
+			var myErr myErrors.Error
+			if errors.As(err, &myErr) {
+				return myErrors.AttributeSchemaConflict(card.id.String(), myErr.GetCause())
+			}
+			return myErrors.ErrAttributeInternal
+		}
+		if !stripped {
+			continue
+		}
+		raw, err := json.Marshal(card.values)
+		if err != nil {
+			return myErrors.ErrAttributeInternal
+		}
+		if _, err := tx.Exec("UPDATE good_cards SET attributes = $2 WHERE uuid = $1", card.id, raw); err != nil {
+			return myErrors.ErrAttributeInternal
+		}
+		if err := writeCardEvent(tx, card.id, models.EventCardUpdated); err != nil {
+			return myErrors.ErrAttributeInternal
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return myErrors.ErrAttributeInternal
+	}
+	return nil
+}
+
+// cardAttributes - значения атрибутов карточки
+type cardAttributes struct {
+	id     uuid.UUID
+	values map[string]interface{}
+}
+
+// lockCategoryCards блокирует карточки категории, включая удалённые, в порядке uuid
+func lockCategoryCards(tx *sql.Tx, categoryID uuid.UUID) ([]cardAttributes, error) {
+	rows, err := tx.Query("SELECT uuid, attributes FROM good_cards WHERE category_id = $1 ORDER BY uuid FOR UPDATE", categoryID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var cards []cardAttributes
+	for rows.Next() {
+		var card cardAttributes
+		var raw []byte
+		if err := rows.Scan(&card.id, &raw); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(raw, &card.values); err != nil {
+			return nil, err
+		}
+		cards = append(cards, card)
+	}
+	return cards, rows.Err()
+}
+
+// checkCardAttributes проверяет сохранённые значения атрибутов карточки по схеме её категории.
+// Вызывается после записи карточки; категория блокируется от смены схемы до конца транзакции.
+func checkCardAttributes(tx *sql.Tx, cardID uuid.UUID) error {
+	var categoryID *uuid.UUID
+	var raw []byte
+	if err := tx.QueryRow("SELECT category_id, attributes FROM good_cards WHERE uuid = $1", cardID).Scan(&categoryID, &raw); err != nil {
+		return err
+	}
+	var values map[string]interface{}
+	if err := json.Unmarshal(raw, &values); err != nil {
+		return err
+	}
+
+	var schema []models.AttributeDefinition
+	if categoryID != nil {
+		// FOR KEY SHARE не мешает переименованию категории, но ждёт смены схемы
+		if _, err := tx.Exec("SELECT uuid FROM categories WHERE uuid = $1 FOR KEY SHARE", *categoryID); err != nil {
+			return err
+		}
+		schemas, err := loadSchemas(tx, []string{categoryID.String()})
+		if err != nil {
+			return err
+		}
+		schema = schemas[*categoryID]
+	}
+	return models.CheckAttributes(schema, values)
+}
+
+// attributeMap превращает значения из запроса в объект для колонки attributes
+func attributeMap(list []models.AttributeValue) ([]byte, error) {
+	values := make(map[string]interface{}, len(list))
+	for _, attr := range list {
+		values[attr.Name] = attr.Value
+	}
+	return json.Marshal(values)
+}
+
+// loadSchemas загружает схемы атрибутов категорий categoryIDs
+func loadSchemas(q queryer, categoryIDs []string) (map[uuid.UUID][]models.AttributeDefinition, error) {
+	schemas := make(map[uuid.UUID][]models.AttributeDefinition, len(categoryIDs))
+	if len(categoryIDs) == 0 {
+		return schemas, nil
+	}
+	rows, err := q.Query(`
+		SELECT category_id, name, type, unit, allowed_values, required
+		FROM category_attributes
+		WHERE category_id = ANY($1::uuid[])
+		ORDER BY category_id, position`, pq.Array(categoryIDs))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var categoryID uuid.UUID
+		var def models.AttributeDefinition
+		if err := rows.Scan(&categoryID, &def.Name, &def.Type, &def.Unit, pq.Array(&def.AllowedValues), &def.Required); err != nil {
+			return nil, err
+		}
+		schemas[categoryID] = append(schemas[categoryID], def)
+	}
+	return schemas, rows.Err()
+}
+
+// fillAttributes раскладывает сохранённые значения атрибутов карточек по схемам их категорий.
+// raw - содержимое колонки attributes по UUID карточки.
+func fillAttributes(q queryer, goods []models.Good, raw map[uuid.UUID][]byte) error {
+	var categoryIDs []string
+	seen := make(map[uuid.UUID]bool)
+	for _, good := range goods {
+		if id := good.Card.CategoryID; id != nil && !seen[*id] {
+			seen[*id] = true
+			categoryIDs = append(categoryIDs, id.String())
+		}
+	}
+	schemas, err := loadSchemas(q, categoryIDs)
+	if err != nil {
+		return err
+	}
+
+	for i := range goods {
+		var values map[string]interface{}
+		if err := json.Unmarshal(raw[goods[i].Card.UUID], &values); err != nil {
+			return err
+		}
+		var schema []models.AttributeDefinition
+		if id := goods[i].Card.CategoryID; id != nil {
+			schema = schemas[*id]
+		}
+		goods[i].Card.Attributes = models.AttributeList(schema, values)
+	}
+	return nil
+}
+
+// allowedValues заменяет nil пустым срезом: колонка allowed_values не допускает NULL
+func allowedValues(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
@@ -0,0 +1,294 @@
+package postgresdb
+
+import (
+	"database/sql"
+	myErrors "goods/internal/errors"
+	"goods/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// Ключ advisory-блокировки перемещений в дереве категорий: два параллельных
+// перемещения могли бы по отдельности пройти проверку на цикл и вместе его создать
+const categoryTreeLockKey = 7346150002
+
+// descendantsQuery - категория $1 и все вложенные в неё категории
+const descendantsQuery = `
+	WITH RECURSIVE tree AS (
+		SELECT uuid FROM categories WHERE uuid = $1
+		UNION ALL
+		SELECT c.uuid FROM categories c JOIN tree t ON c.parent_id = t.uuid
+	)
+	SELECT uuid FROM tree`
+
+func (db *Postgres) CreateCategory(parentID *uuid.UUID, name string) (uuid.UUID, error) {
+	var id uuid.UUID
+	err := db.Connection.QueryRow("INSERT INTO categories (parent_id, name) VALUES ($1, $2) RETURNING uuid", parentID, name).Scan(&id)
+	if pgError(err, foreignKeyViolation) != nil {
+		return uuid.Nil, myErrors.ErrCategoryNotFound
+	}
+	if pgError(err, uniqueViolation) != nil {
+		return uuid.Nil, myErrors.ErrCategoryAlreadyExists
+	}
+	if err != nil {
+		return uuid.Nil, myErrors.ErrCategoryInternal
+	}
+	return id, nil
+}
+
+func (db *Postgres) ReadCategory(id uuid.UUID) (models.Category, error) {
+	category := models.Category{UUID: id}
+	err := db.Connection.QueryRow("SELECT parent_id, name FROM categories WHERE uuid = $1", id).Scan(&category.ParentID, &category.Name)
+	if err == sql.ErrNoRows {
+		return models.Category{}, myErrors.ErrCategoryNotFound
+	}
+	if err != nil {
+		return models.Category{}, myErrors.ErrCategoryInternal
+	}
+	return category, nil
+}
+
+// ListCategories возвращает дочерние категории parentID, а при nil - корневые
+func (db *Postgres) ListCategories(parentID *uuid.UUID) ([]models.Category, error) {
+	rows, err := db.Connection.Query(`
+		SELECT uuid, parent_id, name FROM categories
+		WHERE parent_id IS NOT DISTINCT FROM $1::uuid
+		ORDER BY name`, parentID)
+	if err != nil {
+		return nil, myErrors.ErrCategoryInternal
+	}
+	defer rows.Close()
+
+	categories := make([]models.Category, 0)
+	for rows.Next() {
+		var category models.Category
+		if err := rows.Scan(&category.UUID, &category.ParentID, &category.Name); err != nil {
+			return nil, myErrors.ErrCategoryInternal
+		}
+		categories = append(categories, category)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, myErrors.ErrCategoryInternal
+	}
+	return categories, nil
+}
+
+// CategoryPath возвращает цепочку категорий от корня до id
+func (db *Postgres) CategoryPath(id uuid.UUID) ([]models.Category, error) {
+	rows, err := db.Connection.Query(`
+		WITH RECURSIVE path AS (
+			SELECT uuid, parent_id, name, 0 AS depth FROM categories WHERE uuid = $1
+			UNION ALL
+			SELECT c.uuid, c.parent_id, c.name, p.depth + 1 FROM categories c JOIN path p ON c.uuid = p.parent_id
+		)
+		SELECT uuid, parent_id, name FROM path ORDER BY depth DESC`, id)
+	if err != nil {
+		return nil, myErrors.ErrCategoryInternal
+	}
+	defer rows.Close()
+
+	path := make([]models.Category, 0)
+	for rows.Next() {
+		var category models.Category
+		if err := rows.Scan(&category.UUID, &category.ParentID, &category.Name); err != nil {
+			return nil, myErrors.ErrCategoryInternal
+		}
+		path = append(path, category)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, myErrors.ErrCategoryInternal
+	}
+	if len(path) == 0 {
+		return nil, myErrors.ErrCategoryNotFound
+	}
+	return path, nil
+}
+
+// RenameCategory меняет имя категории и публикует новые снимки её карточек
+func (db *Postgres) RenameCategory(id uuid.UUID, name string) error {
+	tx, err := db.Connection.Begin()
+	if err != nil {
+		return myErrors.ErrCategoryInternal
+	}
+	defer tx.Rollback()
+
+	res, err := tx.Exec("UPDATE categories SET name = $1 WHERE uuid = $2", name, id)
+	if pgError(err, uniqueViolation) != nil {
+		return myErrors.ErrCategoryAlreadyExists
+	}
+	if err != nil {
+		return myErrors.ErrCategoryInternal
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return myErrors.ErrCategoryNotFound
+	}
+	if err := writeReferencingCardEvents(tx, "category_id", id); err != nil {
+		return myErrors.ErrCategoryInternal
+	}
+	if err := tx.Commit(); err != nil {
+		return myErrors.ErrCategoryInternal
+	}
+	return nil
+}
+
+// MoveCategory переносит категорию вместе с поддеревом под parentID (nil - в корень)
+func (db *Postgres) MoveCategory(id uuid.UUID, parentID *uuid.UUID) error {
+	tx, err := db.Connection.Begin()
+	if err != nil {
+		return myErrors.ErrCategoryInternal
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec("SELECT pg_advisory_xact_lock($1)", categoryTreeLockKey); err != nil {
+		return myErrors.ErrCategoryInternal
+	}
+
+	if parentID != nil {
+		// Новый родитель не может лежать внутри перемещаемого поддерева
+		var inSubtree bool
+		err := tx.QueryRow("SELECT $2 IN ("+descendantsQuery+")", id, *parentID).Scan(&inSubtree)
+		if err != nil {
+			return myErrors.ErrCategoryInternal
+		}
+		if inSubtree {
+			return myErrors.ErrCategoryCycle
+		}
+	}
+
+	res, err := tx.Exec("UPDATE categories SET parent_id = $1 WHERE uuid = $2", parentID, id)
+	if pgError(err, foreignKeyViolation) != nil {
+		return myErrors.ErrCategoryNotFound
+	}
+	if pgError(err, uniqueViolation) != nil {
+		return myErrors.ErrCategoryAlreadyExists
+	}
+	if err != nil {
+		return myErrors.ErrCategoryInternal
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return myErrors.ErrCategoryNotFound
+	}
+	if err := tx.Commit(); err != nil {
+		return myErrors.ErrCategoryInternal
+	}
+	return nil
+}
+
+// DeleteCategory удаляет пустую категорию: без подкатегорий и карточек
+func (db *Postgres) DeleteCategory(id uuid.UUID) error {
+	res, err := db.Connection.Exec("DELETE FROM categories WHERE uuid = $1", id)
+	if pgError(err, foreignKeyViolation) != nil {
+		return myErrors.ErrCategoryNotEmpty
+	}
+	if err != nil {
+		return myErrors.ErrCategoryInternal
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return myErrors.ErrCategoryNotFound
+	}
+	return nil
+}
+
+func (db *Postgres) CreateBrand(name string) (uuid.UUID, error) {
+	var id uuid.UUID
+	err := db.Connection.QueryRow("INSERT INTO brands (name) VALUES ($1) RETURNING uuid", name).Scan(&id)
+	if pgError(err, uniqueViolation) != nil {
+		return uuid.Nil, myErrors.ErrBrandAlreadyExists
+	}
+	if err != nil {
+		return uuid.Nil, myErrors.ErrBrandInternal
+	}
+	return id, nil
+}
+
+func (db *Postgres) ListBrands() ([]models.Brand, error) {
+	rows, err := db.Connection.Query("SELECT uuid, name FROM brands ORDER BY name")
+	if err != nil {
+		return nil, myErrors.ErrBrandInternal
+	}
+	defer rows.Close()
+
+	brands := make([]models.Brand, 0)
+	for rows.Next() {
+		var brand models.Brand
+		if err := rows.Scan(&brand.UUID, &brand.Name); err != nil {
+			return nil, myErrors.ErrBrandInternal
+		}
+		brands = append(brands, brand)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, myErrors.ErrBrandInternal
+	}
+	return brands, nil
+}
+
+// RenameBrand меняет имя бренда и публикует новые снимки его карточек
+func (db *Postgres) RenameBrand(id uuid.UUID, name string) error {
+	tx, err := db.Connection.Begin()
+	if err != nil {
+		return myErrors.ErrBrandInternal
+	}
+	defer tx.Rollback()
+
+	res, err := tx.Exec("UPDATE brands SET name = $1 WHERE uuid = $2", name, id)
+	if pgError(err, uniqueViolation) != nil {
+		return myErrors.ErrBrandAlreadyExists
+	}
+	if err != nil {
+		return myErrors.ErrBrandInternal
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return myErrors.ErrBrandNotFound
+	}
+	if err := writeReferencingCardEvents(tx, "brand_id", id); err != nil {
+		return myErrors.ErrBrandInternal
+	}
+	if err := tx.Commit(); err != nil {
+		return myErrors.ErrBrandInternal
+	}
+	return nil
+}
+
+func (db *Postgres) DeleteBrand(id uuid.UUID) error {
+	res, err := db.Connection.Exec("DELETE FROM brands WHERE uuid = $1", id)
+	if pgError(err, foreignKeyViolation) != nil {
+		return myErrors.ErrBrandInUse
+	}
+	if err != nil {
+		return myErrors.ErrBrandInternal
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return myErrors.ErrBrandNotFound
+	}
+	return nil
+}
+
+// writeReferencingCardEvents блокирует карточки, ссылающиеся на id через column,
+// и записывает для каждой событие об изменении. Карточки блокируются в порядке uuid,
+// чтобы параллельные переименования не взаимоблокировались.
+func writeReferencingCardEvents(tx *sql.Tx, column string, id uuid.UUID) error {
+	rows, err := tx.Query("SELECT uuid FROM good_cards WHERE "+column+" = $1 AND deleted_at IS NULL ORDER BY uuid FOR UPDATE", id)
+	if err != nil {
+		return err
+	}
+	var cardIDs []uuid.UUID
+	for rows.Next() {
+		var cardID uuid.UUID
+		if err := rows.Scan(&cardID); err != nil {
+			rows.Close()
+			return err
+		}
+		cardIDs = append(cardIDs, cardID)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for _, cardID := range cardIDs {
+		if err := writeCardEvent(tx, cardID, models.EventCardUpdated); err != nil {
+			return err
+		}
+	}
+	return nil
+}
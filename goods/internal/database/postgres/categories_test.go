@@ -0,0 +1,76 @@
+package postgresdb
+
+import (
+	"errors"
+	myErrors "goods/internal/errors"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+func TestMoveCategoryIntoOwnSubtree(t *testing.T) {
+	db, mock := newMockPostgres(t)
+	id, child := uuid.New(), uuid.New()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("SELECT pg_advisory_xact_lock")).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT $2 IN (")).
+		WithArgs(id, child).WillReturnRows(sqlmock.NewRows([]string{"in"}).AddRow(true))
+	mock.ExpectRollback()
+
+	if err := db.MoveCategory(id, &child); err != myErrors.ErrCategoryCycle {
+		t.Fatalf("err = %v, want ErrCategoryCycle", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDeleteCategoryErrors(t *testing.T) {
+	db, mock := newMockPostgres(t)
+	id := uuid.New()
+
+	// Подкатегории и карточки ссылаются на категорию внешним ключом
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM categories")).
+		WithArgs(id).WillReturnError(&pq.Error{Code: foreignKeyViolation})
+	if err := db.DeleteCategory(id); err != myErrors.ErrCategoryNotEmpty {
+		t.Fatalf("err = %v, want ErrCategoryNotEmpty", err)
+	}
+
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM categories")).
+		WithArgs(id).WillReturnResult(sqlmock.NewResult(0, 0))
+	if err := db.DeleteCategory(id); err != myErrors.ErrCategoryNotFound {
+		t.Fatalf("err = %v, want ErrCategoryNotFound", err)
+	}
+}
+
+func TestCreateBrandDuplicate(t *testing.T) {
+	db, mock := newMockPostgres(t)
+
+	mock.ExpectQuery(regexp.QuoteMeta("INSERT INTO brands")).
+		WithArgs("Acme").WillReturnError(&pq.Error{Code: uniqueViolation})
+	if _, err := db.CreateBrand("Acme"); err != myErrors.ErrBrandAlreadyExists {
+		t.Fatalf("err = %v, want ErrBrandAlreadyExists", err)
+	}
+}
+
+func TestCardReferenceError(t *testing.T) {
+	internal := errors.New("internal")
+	cases := []struct {
+		err  error
+		want error
+	}{
+		{&pq.Error{Code: foreignKeyViolation, Constraint: "good_cards_category_id_fkey"}, myErrors.ErrCategoryNotFound},
+		{&pq.Error{Code: foreignKeyViolation, Constraint: "good_cards_brand_id_fkey"}, myErrors.ErrBrandNotFound},
+		{&pq.Error{Code: uniqueViolation}, internal},
+		{errors.New("connection reset"), internal},
+	}
+	for _, c := range cases {
+		if got := cardReferenceError(c.err, internal); got != c.want {
+			t.Errorf("cardReferenceError(%v) = %v, want %v", c.err, got, c.want)
+		}
+	}
+}
@@ -2,16 +2,20 @@ package postgresdb
 
 import (
 	"database/sql"
+	"errors"
 	"fmt"
 	config "goods/internal/cfg"
+	"goods/internal/database/migration"
 	myErrors "goods/internal/errors"
 	myLog "goods/internal/logger"
 	"goods/internal/models"
+	"goods/pkg/money"
+	"slices"
 	"strings"
 	"time"
 
 	"github.com/google/uuid"
-	_ "github.com/lib/pq"
+	"github.com/lib/pq"
 )
 
 type Postgres struct {
@@ -19,8 +23,7 @@ type Postgres struct {
 }
 
 func NewPostgres(cfg config.Config) *Postgres {
-	connStr := fmt.Sprintf("user=%s password=%s dbname=%s host=%s port=%s sslmode=%s", cfg.DBUser, cfg.DBPassword, cfg.DBName, cfg.DBHost, cfg.DBPort, cfg.SslMode)
-	db, err := sql.Open("postgres", connStr)
+	db, err := Connect(cfg)
 	if err != nil {
 		myLog.Log.Fatalf("Failed to connect to PostgreSQL: %v", err)
 		return nil //, myErrors.ErrCreatePostgresConnection
@@ -35,19 +38,51 @@ func NewPostgres(cfg config.Config) *Postgres {
 	}
 	time.Sleep(time.Minute)
 
+	// Со схемой старее кода запросы падали бы уже во время работы
+	if err := migration.Check(db); err != nil {
+		myLog.Log.Fatalf("Refusing to serve: %v", err)
+		return nil
+	}
+
 	return &Postgres{
 		Connection: db,
 	}
 }
 
+// Connect открывает пул соединений с базой без проверки схемы (для команды migrate)
+func Connect(cfg config.Config) (*sql.DB, error) {
+	connStr := fmt.Sprintf("user=%s password=%s dbname=%s host=%s port=%s sslmode=%s", cfg.DBUser, cfg.DBPassword, cfg.DBName, cfg.DBHost, cfg.DBPort, cfg.SslMode)
+	return sql.Open("postgres", connStr)
+}
+
 func (db *Postgres) CreateGoodCard(goodCard models.GoodCard) (uuid.UUID, error) {
+	tx, err := db.Connection.Begin()
+	if err != nil {
+		return uuid.Nil, myErrors.ErrCreateGoodCardInternal
+	}
+	defer tx.Rollback()
+
+	id, err := insertGoodCard(tx, goodCard)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return uuid.Nil, myErrors.ErrCreateGoodCardInternal
+	}
+	return id, nil
+}
+
+// insertGoodCard добавляет карточку товара в транзакции tx вместе с событием для outbox
+func insertGoodCard(tx *sql.Tx, goodCard models.GoodCard) (uuid.UUID, error) {
 	// Проверяем, существует ли карточка товара с полным совпадением
 	var existingID uuid.UUID
 	checkQuery := `
 		SELECT uuid FROM good_cards 
-		WHERE name = \$1 AND description = \$2 AND price = \$3 AND weight = \$4 AND seller_id = \$5 AND is_active = \$6`
+		WHERE name = $1 AND description = $2 AND price = $3 AND currency = $4 AND weight = $5 AND seller_id = $6 AND is_active = $7
+		  AND deleted_at IS NULL`
 
-	err := db.Connection.QueryRow(checkQuery, goodCard.Name, goodCard.Description, goodCard.Price, goodCard.Weight, goodCard.SellerID, goodCard.IsActive).Scan(&existingID)
+	err := tx.QueryRow(checkQuery, goodCard.Name, goodCard.Description, goodCard.Price.Amount, goodCard.Price.Currency,
+		goodCard.Weight, goodCard.SellerID, goodCard.IsActive).Scan(&existingID)
 	if err == nil {
 		// Если карточка найдена, возвращаем ошибку
 		return uuid.Nil, myErrors.ErrGoodCardAlreadyExists
@@ -57,44 +92,180 @@ func (db *Postgres) CreateGoodCard(goodCard models.GoodCard) (uuid.UUID, error)
 	}
 
 	// Если карточка не найдена, добавляем новую
+	attributes, err := attributeMap(goodCard.Attributes)
+	if err != nil {
+		return uuid.Nil, myErrors.ErrCreateGoodCardInternal
+	}
 	query := `
-		INSERT INTO good_cards (price, name, description, weight, seller_id, is_active)
-		VALUES (\$1, \$2, \$3, \$4, \$5, \$6)
+		INSERT INTO good_cards (price, currency, name, description, weight, seller_id, is_active, variant_axes, category_id, brand_id, attributes)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
 		RETURNING uuid`
 
 	var id uuid.UUID
-	err = db.Connection.QueryRow(query, goodCard.Price, goodCard.Name, goodCard.Description, goodCard.Weight, goodCard.SellerID, goodCard.IsActive).Scan(&id)
+	err = tx.QueryRow(query, goodCard.Price.Amount, goodCard.Price.Currency, goodCard.Name, goodCard.Description, goodCard.Weight, goodCard.SellerID, goodCard.IsActive,
+		pq.Array(variantAxes(goodCard.VariantAxes)), goodCard.CategoryID, goodCard.BrandID, attributes).Scan(&id)
 	if err != nil {
+		return uuid.Nil, cardReferenceError(err, myErrors.ErrCreateGoodCardInternal)
+	}
+	if err := cardAttributesError(checkCardAttributes(tx, id), myErrors.ErrCreateGoodCardInternal); err != nil {
+		return uuid.Nil, err
+	}
+
+	if err := recordRevision(tx, id); err != nil {
+		return uuid.Nil, myErrors.ErrCreateGoodCardInternal
+	}
+	// Событие для сервиса поиска фиксируется вместе с карточкой
+	if err := writeCardEvent(tx, id, models.EventCardCreated); err != nil {
 		return uuid.Nil, myErrors.ErrCreateGoodCardInternal
 	}
 	return id, nil
 }
 
+// DeleteGoodCard помечает карточку удалённой. Остаток, резервы и история сохраняются:
+// карточку можно восстановить, а окончательно её удаляет PurgeDeletedCards.
 func (db *Postgres) DeleteGoodCard(cardID uuid.UUID) error {
-	var exists bool
-	err := db.Connection.QueryRow("SELECT EXISTS(SELECT 1 FROM good_cards WHERE uuid = $1)", cardID).Scan(&exists)
+	tx, err := db.Connection.Begin()
+	if err != nil {
+		return myErrors.ErrDeleteGoodCardInternal
+	}
+	defer tx.Rollback()
 
+	// Блокируем карточку, чтобы событие удаления было последним для неё
+	res, err := tx.Exec("UPDATE good_cards SET deleted_at = now() WHERE uuid = $1 AND deleted_at IS NULL", cardID)
 	if err != nil {
 		return myErrors.ErrDeleteGoodCardInternal // Если произошла ошибка при выполнении запроса
 	}
-	if !exists {
+	if n, _ := res.RowsAffected(); n == 0 {
 		return myErrors.ErrGoodCardNotFound // Если карточка товара не найдена
 	}
 
-	_, err = db.Connection.Exec(`DELETE FROM good_cards WHERE uuid = \$1`, cardID)
-	return err // Возвращаем ошибку, если она возникла, или nil, если удаление прошло успешно
+	if err := writeCardEvent(tx, cardID, models.EventCardDeleted); err != nil {
+		return myErrors.ErrDeleteGoodCardInternal
+	}
+	if err := tx.Commit(); err != nil {
+		return myErrors.ErrDeleteGoodCardInternal
+	}
+	return nil
 }
 
-func (db *Postgres) UpdateGoodCard(id uuid.UUID, goodCard models.GoodCard) error {
-	// Проверяем, существует ли карточка товара с таким ID
-	var exists bool
-	err := db.Connection.QueryRow("SELECT EXISTS(SELECT 1 FROM good_cards WHERE uuid = $1)", id).Scan(&exists)
+// UpdateGoodCard обновляет карточку, если её версия равна expectedVersion
+// (0 - без проверки), и возвращает новую версию
+func (db *Postgres) UpdateGoodCard(id uuid.UUID, goodCard models.GoodCard, expectedVersion int) (int, error) {
+	tx, err := db.Connection.Begin()
+	if err != nil {
+		return 0, myErrors.ErrUpdateGoodCardInternal
+	}
+	defer tx.Rollback()
 
+	version, err := updateGoodCard(tx, id, goodCard, expectedVersion)
 	if err != nil {
-		return myErrors.ErrUpdateGoodCardInternal // Если произошла ошибка при выполнении запроса
+		return 0, err
 	}
-	if !exists {
-		return myErrors.ErrGoodCardNotFound
+	if err := tx.Commit(); err != nil {
+		return 0, myErrors.ErrUpdateGoodCardInternal
+	}
+	return version, nil
+}
+
+// updateGoodCard обновляет карточку товара в транзакции tx по правилам PUT:
+// пустые строки, отрицательные числа и незаданная цена означают "не менять", is_active записывается всегда
+func updateGoodCard(tx *sql.Tx, id uuid.UUID, goodCard models.GoodCard, expectedVersion int) (int, error) {
+	var patch models.GoodCardPatch
+	if goodCard.Price != (money.Money{}) && goodCard.Price.Amount >= 0 {
+		patch.Price = &goodCard.Price
+	}
+	if goodCard.Name != "" {
+		patch.Name = &goodCard.Name
+	}
+	if goodCard.Description != "" {
+		patch.Description = &goodCard.Description
+	}
+	if goodCard.Weight >= 0 {
+		patch.Weight = &goodCard.Weight
+	}
+	patch.IsActive = &goodCard.IsActive
+	if goodCard.VariantAxes != nil {
+		patch.VariantAxes = &goodCard.VariantAxes
+	}
+	patch.CategoryID = goodCard.CategoryID
+	patch.BrandID = goodCard.BrandID
+	return patchGoodCard(tx, id, patch, expectedVersion)
+}
+
+// cardReferenceError сообщает о несуществующей категории или бренде карточки,
+// остальные ошибки заменяет на internal
+func cardReferenceError(err error, internal error) error {
+	if pqErr := pgError(err, foreignKeyViolation); pqErr != nil {
+		switch pqErr.Constraint {
+		case "good_cards_category_id_fkey":
+			return myErrors.ErrCategoryNotFound
+		case "good_cards_brand_id_fkey":
+			return myErrors.ErrBrandNotFound
+		}
+	}
+	return internal
+}
+
+// cardAttributesError пропускает ошибку проверки атрибутов, остальные ошибки заменяет на internal
+func cardAttributesError(err error, internal error) error {
+	var myErr myErrors.Error
+	if err == nil || errors.As(err, &myErr) {
+		return err
+	}
+	return internal
+}
+
+// nullableID превращает uuid.Nil в NULL
+func nullableID(id uuid.UUID) *uuid.UUID {
+	if id == uuid.Nil {
+		return nil
+	}
+	return &id
+}
+
+// variantAxes заменяет nil пустым срезом: колонка variant_axes не допускает NULL
+func variantAxes(axes []string) []string {
+	if axes == nil {
+		return []string{}
+	}
+	return axes
+}
+
+func (db *Postgres) PatchGoodCard(id uuid.UUID, patch models.GoodCardPatch, expectedVersion int) (int, error) {
+	tx, err := db.Connection.Begin()
+	if err != nil {
+		return 0, myErrors.ErrUpdateGoodCardInternal
+	}
+	defer tx.Rollback()
+
+	version, err := patchGoodCard(tx, id, patch, expectedVersion)
+	if err != nil {
+		return 0, err
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, myErrors.ErrUpdateGoodCardInternal
+	}
+	return version, nil
+}
+
+// patchGoodCard меняет только заданные в patch поля карточки вместе с ревизией и событием для outbox
+func patchGoodCard(tx *sql.Tx, id uuid.UUID, patch models.GoodCardPatch, expectedVersion int) (int, error) {
+	// Блокируем карточку: между проверкой версии и обновлением её никто не изменит
+	var currentVersion int
+	var currentAxes []string
+	var moderation string
+	var active bool
+	var currency string
+	err := tx.QueryRow("SELECT version, variant_axes, moderation_status, is_active, currency FROM good_cards WHERE uuid = $1 AND deleted_at IS NULL FOR UPDATE", id).
+		Scan(&currentVersion, pq.Array(&currentAxes), &moderation, &active, &currency)
+	if err == sql.ErrNoRows {
+		return 0, myErrors.ErrGoodCardNotFound
+	}
+	if err != nil {
+		return 0, myErrors.ErrUpdateGoodCardInternal // Если произошла ошибка при выполнении запроса
+	}
+	if expectedVersion != 0 && expectedVersion != currentVersion {
+		return 0, myErrors.ErrVersionMismatch
 	}
 
 	query := "UPDATE good_cards SET"
@@ -102,162 +273,306 @@ func (db *Postgres) UpdateGoodCard(id uuid.UUID, goodCard models.GoodCard) error
 	var setClauses []string
 	paramIndex := 1 // Индекс параметра для использования в запросе
 
-	if goodCard.Price >= 0 {
+	if patch.Price != nil {
+		// Валюта карточки задаётся при создании: от неё зависят цены вариантов и расписаний
+		if patch.Price.Currency != currency {
+			return 0, myErrors.ErrCurrencyMismatch
+		}
 		setClauses = append(setClauses, fmt.Sprintf(" price = $%d", paramIndex))
-		params = append(params, goodCard.Price)
+		params = append(params, patch.Price.Amount)
 		paramIndex++
 	}
-	if goodCard.Name != "" {
+	if patch.Name != nil {
 		setClauses = append(setClauses, fmt.Sprintf(" name = $%d", paramIndex))
-		params = append(params, goodCard.Name)
+		params = append(params, *patch.Name)
 		paramIndex++
 	}
-	if goodCard.Description != "" {
+	if patch.Description != nil {
 		setClauses = append(setClauses, fmt.Sprintf(" description = $%d", paramIndex))
-		params = append(params, goodCard.Description)
+		params = append(params, *patch.Description)
 		paramIndex++
 	}
-	if goodCard.Weight >= 0 {
+	if patch.Weight != nil {
 		setClauses = append(setClauses, fmt.Sprintf(" weight = $%d", paramIndex))
-		params = append(params, goodCard.Weight)
+		params = append(params, *patch.Weight)
 		paramIndex++
 	}
-	if goodCard.SellerID != uuid.Nil {
-		setClauses = append(setClauses, fmt.Sprintf(" seller_id = $%d", paramIndex))
-		params = append(params, goodCard.SellerID)
+	// seller_id не обновляется: карточка не может перейти к другому продавцу
+	if patch.IsActive != nil {
+		setClauses = append(setClauses, fmt.Sprintf(" is_active = $%d", paramIndex))
+		params = append(params, *patch.IsActive)
+		paramIndex++
+	}
+	if patch.VariantAxes != nil && !slices.Equal(*patch.VariantAxes, currentAxes) {
+		// Смена осей сделала бы существующие варианты некорректными
+		var hasSKUs bool
+		if err := tx.QueryRow("SELECT EXISTS(SELECT 1 FROM good_skus WHERE card_id = $1)", id).Scan(&hasSKUs); err != nil {
+			return 0, myErrors.ErrUpdateGoodCardInternal
+		}
+		if hasSKUs {
+			return 0, myErrors.ErrVariantAxesLocked
+		}
+		setClauses = append(setClauses, fmt.Sprintf(" variant_axes = $%d", paramIndex))
+		params = append(params, pq.Array(variantAxes(*patch.VariantAxes)))
+		paramIndex++
+	}
+	if patch.CategoryID != nil {
+		setClauses = append(setClauses, fmt.Sprintf(" category_id = $%d", paramIndex))
+		params = append(params, nullableID(*patch.CategoryID))
+		paramIndex++
+	}
+	if patch.BrandID != nil {
+		setClauses = append(setClauses, fmt.Sprintf(" brand_id = $%d", paramIndex))
+		params = append(params, nullableID(*patch.BrandID))
+		paramIndex++
+	}
+	if patch.Attributes != nil {
+		attributes, err := attributeMap(*patch.Attributes)
+		if err != nil {
+			return 0, myErrors.ErrUpdateGoodCardInternal
+		}
+		setClauses = append(setClauses, fmt.Sprintf(" attributes = $%d", paramIndex))
+		params = append(params, attributes)
 		paramIndex++
 	}
-	setClauses = append(setClauses, fmt.Sprintf(" is_active = $%d", paramIndex))
-	params = append(params, goodCard.IsActive)
-	paramIndex++
 
 	// Проверяем, есть ли поля для обновления
 	if len(setClauses) == 0 {
-		return myErrors.ErrUpdateGoodCardNotFields
+		return 0, myErrors.ErrUpdateGoodCardNotFields
 	}
 
+	// Активация и деактивация не меняют содержимое карточки и не требуют повторной проверки
+	contentChanged := !(len(setClauses) == 1 && patch.IsActive != nil)
+
 	// Соединяем части запроса
 	query += strings.Join(setClauses, ", ")
-	query += fmt.Sprintf(" WHERE uuid = $%d", paramIndex)
+	query += fmt.Sprintf(", version = version + 1 WHERE uuid = $%d RETURNING version", paramIndex)
 	params = append(params, id)
 
 	// Выполняем запрос
-	_, err_ := db.Connection.Exec(query, params...)
-	return err_
+	var version int
+	if err := tx.QueryRow(query, params...).Scan(&version); err != nil {
+		return 0, cardReferenceError(err, myErrors.ErrUpdateGoodCardInternal)
+	}
+	// Значения проверяются и при смене категории: у новой категории другая схема
+	if patch.Attributes != nil || patch.CategoryID != nil {
+		if err := cardAttributesError(checkCardAttributes(tx, id), myErrors.ErrUpdateGoodCardInternal); err != nil {
+			return 0, err
+		}
+	}
+	if err := recordRevision(tx, id); err != nil {
+		return 0, myErrors.ErrUpdateGoodCardInternal
+	}
+	if contentChanged {
+		if err := resubmitForReview(tx, id); err != nil {
+			return 0, myErrors.ErrUpdateGoodCardInternal
+		}
+	}
+
+	// Деактивированная опубликованная карточка снимается с публикации
+	eventType := models.EventCardUpdated
+	if patch.IsActive != nil && !*patch.IsActive && moderation == models.ModerationApproved && active {
+		eventType = models.EventCardUnpublished
+	}
+	if err := writeCardEvent(tx, id, eventType); err != nil {
+		return 0, myErrors.ErrUpdateGoodCardInternal
+	}
+	return version, nil
 }
 
 ///////////////////////////////////////////////////good/////////////////////////////////////////////////////////////////////////////////////////////////
 
-func (db *Postgres) CreateGood(cardID uuid.UUID, quantity int) error {
-	// Проверяем, существует ли карточка товара с таким ID
-	var exists bool
-	err := db.Connection.QueryRow("SELECT EXISTS(SELECT 1 FROM good_cards WHERE uuid = $1)", cardID).Scan(&exists)
-
+// CreateGood создаёт вариант по умолчанию для карточки без осей вариантов.
+// Его UUID совпадает с UUID карточки.
+func (db *Postgres) CreateGood(cardID uuid.UUID, quantity int, meta models.MovementMeta) error {
+	tx, err := db.Connection.Begin()
 	if err != nil {
-		return myErrors.ErrCreateGoodInternal // Ошибка при выполнении запроса
-	}
-	if !exists {
-		return myErrors.ErrGoodCardNotFound
+		return myErrors.ErrCreateGoodInternal
 	}
+	defer tx.Rollback()
 
-	// Создаем запрос на добавление товара
-	query := "INSERT INTO goods (card_id, quantity) VALUES ($1, $2)"
-	_, err = db.Connection.Exec(query, cardID, quantity)
-	return err
+	if _, err := insertSKU(tx, cardID, models.SKU{UUID: cardID}, quantity, models.MovementInitial, meta); err != nil {
+		if err == myErrors.ErrSKUInternal {
+			return myErrors.ErrCreateGoodInternal
+		}
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return myErrors.ErrCreateGoodInternal
+	}
+	return nil
 }
 
-func (db *Postgres) DeleteGood(goodID uuid.UUID) error {
-	// Проверяем, существует ли товар с таким ID
-	var exists bool
-	err := db.Connection.QueryRow("SELECT EXISTS(SELECT 1 FROM goods WHERE card_id = $1)", goodID).Scan(&exists)
+// DeleteGood удаляет вариант вместе с его остатком и резервами; журнал движения сохраняется
+func (db *Postgres) DeleteGood(skuID uuid.UUID) error {
+	tx, err := db.Connection.Begin()
+	if err != nil {
+		return myErrors.ErrDeleteGoodInternal
+	}
+	defer tx.Rollback()
 
+	cardID, err := lockSKU(tx, skuID)
+	if err == myErrors.ErrGoodNotFound {
+		return err
+	}
 	if err != nil {
 		return myErrors.ErrDeleteGoodInternal // Ошибка при выполнении запроса
 	}
-	if !exists {
-		return myErrors.ErrGoodNotFound
-	}
 
-	// Удаляем товар (это также удалит связанную карточку товара из good_cards)
-	query := "DELETE FROM goods WHERE card_id = $1"
-	_, err = db.Connection.Exec(query, goodID)
-	return err
+	if _, err := tx.Exec("DELETE FROM good_skus WHERE uuid = $1", skuID); err != nil {
+		return myErrors.ErrDeleteGoodInternal
+	}
+	if err := writeCardEvent(tx, cardID, models.EventStockChanged); err != nil {
+		return myErrors.ErrDeleteGoodInternal
+	}
+	if err := tx.Commit(); err != nil {
+		return myErrors.ErrDeleteGoodInternal
+	}
+	return nil
 }
 
-func (db *Postgres) AddCountGood(goodID uuid.UUID, number int) (int, error) {
-	// Проверяем, существует ли товар с таким ID
-	var exists bool
-	err := db.Connection.QueryRow("SELECT EXISTS(SELECT 1 FROM goods WHERE card_id = $1)", goodID).Scan(&exists)
+// AddCountGood пополняет остаток варианта на складе warehouseID (uuid.Nil - склад по умолчанию).
+// Возвращает доступное количество варианта по всем складам.
+func (db *Postgres) AddCountGood(skuID uuid.UUID, warehouseID uuid.UUID, number int, meta models.MovementMeta) (int, error) {
+	tx, err := db.Connection.Begin()
+	if err != nil {
+		return 0, myErrors.ErrAddCountGoodInternal
+	}
+	defer tx.Rollback()
 
+	cardID, err := lockSKU(tx, skuID)
+	if err == myErrors.ErrGoodNotFound {
+		return 0, err
+	}
 	if err != nil {
-		return 0, myErrors.ErrAddCountGoodInternal // Ошибка при выполнении запроса
+		return 0, myErrors.ErrAddCountGoodInternal
 	}
-	if !exists {
-		return 0, myErrors.ErrGoodNotFound
+	warehouseID, err = resolveWarehouse(tx, cardID, warehouseID)
+	if err == myErrors.ErrWarehouseNotFound {
+		return 0, err
+	}
+	if err != nil {
+		return 0, myErrors.ErrAddCountGoodInternal
 	}
 
-	// Обновляем количество товара
-	query := "UPDATE goods SET quantity = quantity + $1 WHERE card_id = $2 RETURNING quantity"
-	var newQuantity int
-	err = db.Connection.QueryRow(query, number, goodID).Scan(&newQuantity)
+	// Строка остатка на складе появляется при первом пополнении
+	_, err = tx.Exec(`
+		INSERT INTO goods (card_id, sku_id, warehouse_id, quantity) VALUES ($1, $2, $3, $4)
+		ON CONFLICT (sku_id, warehouse_id) DO UPDATE SET quantity = goods.quantity + EXCLUDED.quantity`,
+		cardID, skuID, warehouseID, number)
 	if err != nil {
-		return 0, err // Возвращаем ошибку, если что-то пошло не так
+		return 0, myErrors.ErrAddCountGoodInternal // Ошибка при выполнении запроса
 	}
 
+	if err := recordMovement(tx, skuID, warehouseID, number, models.MovementRestock, meta); err != nil {
+		return 0, myErrors.ErrAddCountGoodInternal
+	}
+	if err := writeCardEvent(tx, cardID, models.EventStockChanged); err != nil {
+		return 0, myErrors.ErrAddCountGoodInternal
+	}
+	newQuantity, err := skuQuantity(tx, skuID)
+	if err != nil {
+		return 0, myErrors.ErrAddCountGoodInternal
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, myErrors.ErrAddCountGoodInternal
+	}
 	return newQuantity, nil // Возвращаем новое количество товара
 }
 
-func (db *Postgres) DeleteCountGood(goodID uuid.UUID, number int) (int, error) {
-	// Проверяем, существует ли товар с таким ID
-	var exists bool
-	err := db.Connection.QueryRow("SELECT EXISTS(SELECT 1 FROM goods WHERE card_id = $1)", goodID).Scan(&exists)
+// DeleteCountGood списывает остаток варианта со склада warehouseID, а при uuid.Nil -
+// с первого по приоритету склада, на котором хватает остатка.
+// Возвращает доступное количество варианта по всем складам.
+func (db *Postgres) DeleteCountGood(skuID uuid.UUID, warehouseID uuid.UUID, number int, meta models.MovementMeta) (int, error) {
+	tx, err := db.Connection.Begin()
+	if err != nil {
+		return 0, myErrors.ErrDeleteCountGoodInternal
+	}
+	defer tx.Rollback()
 
+	cardID, err := lockSKU(tx, skuID)
+	if err == myErrors.ErrGoodNotFound {
+		return 0, err
+	}
 	if err != nil {
-		return 0, myErrors.ErrDeleteCountGoodInternal // Ошибка при выполнении запроса
+		return 0, myErrors.ErrDeleteCountGoodInternal
 	}
-	if !exists {
-		return 0, myErrors.ErrGoodNotFound
+	if warehouseID != uuid.Nil {
+		warehouseID, err = resolveWarehouse(tx, cardID, warehouseID)
+	} else {
+		warehouseID, err = allocateWarehouse(tx, skuID, uuid.Nil, number)
+	}
+	if err == myErrors.ErrNotEnoughQuantity {
+		return notEnoughQuantity(tx, skuID, myErrors.ErrDeleteCountGoodInternal)
+	}
+	if err == myErrors.ErrWarehouseNotFound {
+		return 0, err
 	}
-
-	// Получаем текущее количество товара
-	var currentQuantity int
-	err = db.Connection.QueryRow("SELECT quantity FROM goods WHERE card_id = $1", goodID).Scan(&currentQuantity)
 	if err != nil {
-		return 0, err // Возвращаем ошибку, если не удалось получить текущее количество
+		return 0, myErrors.ErrDeleteCountGoodInternal
 	}
 
-	// Проверяем, достаточно ли товара для удаления
-	if currentQuantity < number {
-		return currentQuantity, myErrors.ErrNotEnoughQuantity // Недостаточно товара для удаления
+	// Проверка остатка и списание выполняются одним условным UPDATE,
+	// иначе два параллельных списания могут оба пройти проверку и уйти в минус
+	query := "UPDATE goods SET quantity = quantity - $1 WHERE sku_id = $2 AND warehouse_id = $3 AND quantity >= $1"
+	res, err := tx.Exec(query, number, skuID, warehouseID)
+	if err != nil {
+		return 0, myErrors.ErrDeleteCountGoodInternal // Ошибка при выполнении запроса
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		// На складе не хватает варианта для списания
+		return notEnoughQuantity(tx, skuID, myErrors.ErrDeleteCountGoodInternal)
 	}
 
-	// Обновляем количество товара
-	query := "UPDATE goods SET quantity = quantity - $1 WHERE card_id = $2 RETURNING quantity"
-	var newQuantity int
-	err = db.Connection.QueryRow(query, number, goodID).Scan(&newQuantity)
+	if err := recordMovement(tx, skuID, warehouseID, -number, models.MovementWriteOff, meta); err != nil {
+		return 0, myErrors.ErrDeleteCountGoodInternal
+	}
+	if err := enqueueStockAlert(tx, cardID, number); err != nil {
+		return 0, myErrors.ErrDeleteCountGoodInternal
+	}
+	if err := writeCardEvent(tx, cardID, models.EventStockChanged); err != nil {
+		return 0, myErrors.ErrDeleteCountGoodInternal
+	}
+	newQuantity, err := skuQuantity(tx, skuID)
 	if err != nil {
-		return 0, err // Возвращаем ошибку, если что-то пошло не так
+		return 0, myErrors.ErrDeleteCountGoodInternal
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, myErrors.ErrDeleteCountGoodInternal
 	}
-
 	return newQuantity, nil // Возвращаем новое количество товара
 }
 
-func (db *Postgres) ReadGood(goodID uuid.UUID) (models.Good, error) {
-	// Проверяем, существует ли товар с таким ID
-	var good models.Good
-	query := `
-		SELECT g.uuid, g.quantity, gc.uuid, gc.name, gc.description 
-		FROM goods g
-		JOIN good_cards gc ON g.card_id = gc.uuid 
-		WHERE g.uuid = \$1`
+// notEnoughQuantity возвращает текущее количество варианта вместе с ErrNotEnoughQuantity
+func notEnoughQuantity(tx *sql.Tx, skuID uuid.UUID, internal error) (int, error) {
+	currentQuantity, err := skuQuantity(tx, skuID)
+	if err != nil {
+		return 0, internal
+	}
+	return currentQuantity, myErrors.ErrNotEnoughQuantity // Недостаточно товара для удаления
+}
 
-	err := db.Connection.QueryRow(query, goodID).Scan(&good.UUID, &good.Quantity, &good.Card.UUID, &good.Card.Name, &good.Card.Description)
+func (db *Postgres) ReadGood(goodID uuid.UUID) (models.Good, error) {
+	// Товар читается так же, как в списке и пакетном чтении: с ценами, вариантами, остатком по складам и атрибутами
+	goods, err := db.ReadGoodCards([]uuid.UUID{goodID})
 	if err != nil {
-		if err == sql.ErrNoRows {
-			return models.Good{}, myErrors.ErrGoodNotFound // Если товар не найден
-		}
-		return models.Good{}, myErrors.ErrReadCardInternal // Ошибка при выполнении запроса
+		return models.Good{}, err
+	}
+	if len(goods) == 0 {
+		return models.Good{}, myErrors.ErrGoodNotFound
 	}
+	return goods[0], nil
+}
 
-	return good, nil // Возвращаем структуру Good с заполненной карточкой товара
+// CardOwner возвращает продавца карточки товара
+func (db *Postgres) CardOwner(cardID uuid.UUID) (uuid.UUID, error) {
+	var sellerID uuid.UUID
+	err := db.Connection.QueryRow("SELECT seller_id FROM good_cards WHERE uuid = $1 AND deleted_at IS NULL", cardID).Scan(&sellerID)
+	if err == sql.ErrNoRows {
+		return uuid.Nil, myErrors.ErrGoodCardNotFound
+	}
+	if err != nil {
+		return uuid.Nil, myErrors.ErrReadCardInternal
+	}
+	return sellerID, nil
 }
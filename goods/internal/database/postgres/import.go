@@ -0,0 +1,130 @@
+package postgresdb
+
+import (
+	"database/sql"
+	"errors"
+	myErrors "goods/internal/errors"
+	"goods/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// ImportGoodCards записывает пачку проверенных строк импорта в одной транзакции.
+// Каждая строка выполняется под своей точкой сохранения: ошибка строки
+// откатывает только её и попадает в отчёт, остальные строки пачки применяются.
+func (db *Postgres) ImportGoodCards(sellerID uuid.UUID, rows []models.ImportRow, meta models.MovementMeta) ([]models.ImportRowResult, error) {
+	tx, err := db.Connection.Begin()
+	if err != nil {
+		return nil, myErrors.ErrImportInternal
+	}
+	defer tx.Rollback()
+
+	results := make([]models.ImportRowResult, 0, len(rows))
+	for _, row := range rows {
+		if _, err := tx.Exec("SAVEPOINT import_row"); err != nil {
+			return nil, myErrors.ErrImportInternal
+		}
+
+		result, err := importRow(tx, sellerID, row, meta)
+		if err != nil {
+			if _, rbErr := tx.Exec("ROLLBACK TO SAVEPOINT import_row"); rbErr != nil {
+				return nil, myErrors.ErrImportInternal
+			}
+			result = models.ImportRowResult{Line: row.Line, Status: models.ImportRejected, Reason: rejectReason(err)}
+		} else if _, err := tx.Exec("RELEASE SAVEPOINT import_row"); err != nil {
+			return nil, myErrors.ErrImportInternal
+		}
+		results = append(results, result)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, myErrors.ErrImportInternal
+	}
+	return results, nil
+}
+
+func importRow(tx *sql.Tx, sellerID uuid.UUID, row models.ImportRow, meta models.MovementMeta) (models.ImportRowResult, error) {
+	card := row.Card
+	card.SellerID = sellerID
+
+	if card.UUID == uuid.Nil {
+		id, err := insertGoodCard(tx, card)
+		if err != nil {
+			return models.ImportRowResult{}, err
+		}
+		// Остаток карточки с вариантами задаётся через её варианты, а не импортом
+		if len(card.VariantAxes) == 0 {
+			quantity := 0
+			if row.Quantity != nil {
+				quantity = *row.Quantity
+			}
+			if _, err := insertSKU(tx, id, models.SKU{UUID: id}, quantity, models.MovementImport, meta); err != nil {
+				return models.ImportRowResult{}, err
+			}
+		} else if row.Quantity != nil && *row.Quantity != 0 {
+			return models.ImportRowResult{}, myErrors.ErrSKUAttributesMismatch
+		}
+		return models.ImportRowResult{Line: row.Line, Status: models.ImportCreated, ID: id.String()}, nil
+	}
+
+	// Обновлять можно только свою карточку; чужая неотличима от несуществующей
+	var owner uuid.UUID
+	err := tx.QueryRow("SELECT seller_id FROM good_cards WHERE uuid = $1 AND deleted_at IS NULL FOR UPDATE", card.UUID).Scan(&owner)
+	if err == sql.ErrNoRows || (err == nil && owner != sellerID) {
+		return models.ImportRowResult{}, myErrors.ErrGoodCardNotFound
+	}
+	if err != nil {
+		return models.ImportRowResult{}, err
+	}
+	// Импорт перезаписывает карточку целиком, версия не сверяется
+	if _, err := updateGoodCard(tx, card.UUID, card, 0); err != nil {
+		return models.ImportRowResult{}, err
+	}
+	if row.Quantity != nil {
+		if err := setImportedQuantity(tx, card.UUID, *row.Quantity, meta); err != nil {
+			return models.ImportRowResult{}, err
+		}
+	}
+	return models.ImportRowResult{Line: row.Line, Status: models.ImportUpdated, ID: card.UUID.String()}, nil
+}
+
+// setImportedQuantity доводит остаток карточки без вариантов по всем складам до quantity.
+// Недостача списывается целиком с одного склада, как при пакетном списании; если ни на одном
+// складе столько нет, строка отклоняется с ErrNotEnoughQuantity. Карточка уже заблокирована.
+func setImportedQuantity(tx *sql.Tx, cardID uuid.UUID, quantity int, meta models.MovementMeta) error {
+	var axes int
+	if err := tx.QueryRow("SELECT COALESCE(array_length(variant_axes, 1), 0) FROM good_cards WHERE uuid = $1", cardID).Scan(&axes); err != nil {
+		return err
+	}
+	if axes > 0 {
+		return myErrors.ErrSKUAttributesMismatch
+	}
+
+	// Вариант карточки без вариантов совпадает с ней по uuid
+	current, err := skuQuantity(tx, cardID)
+	if err != nil {
+		return err
+	}
+	item := models.StockAdjustment{GoodID: cardID, Delta: quantity - current}
+	switch {
+	case item.Delta > 0:
+		err = restockItem(tx, cardID, item, models.MovementImport, meta)
+	case item.Delta < 0:
+		err = writeOffItem(tx, cardID, item, models.MovementImport, meta)
+	default:
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return writeCardEvent(tx, cardID, models.EventStockChanged)
+}
+
+// rejectReason возвращает причину отказа для отчёта, не раскрывая внутренние ошибки
+func rejectReason(err error) string {
+	var myErr myErrors.Error
+	if errors.As(err, &myErr) {
+		return myErr.GetCause()
+	}
+	return myErrors.ErrImportInternal.GetCause()
+}
@@ -2,6 +2,8 @@ package postgresdb
 
 import (
 	"goods/internal/models"
+	"goods/pkg/money"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -9,10 +11,85 @@ import (
 type InterfacePostgresDB interface {
 	CreateGoodCard(goodCard models.GoodCard) (uuid.UUID, error)
 	DeleteGoodCard(cardID uuid.UUID) error
-	UpdateGoodCard(id uuid.UUID, goodCard models.GoodCard) error
-	CreateGood(cardID uuid.UUID, quantity int) error
-	DeleteGood(goodID uuid.UUID) error
-	AddCountGood(goodID uuid.UUID, number int) (int, error)
-	DeleteCountGood(goodID uuid.UUID, number int) (int, error)
+	UpdateGoodCard(id uuid.UUID, goodCard models.GoodCard, expectedVersion int) (int, error)
+	PatchGoodCard(id uuid.UUID, patch models.GoodCardPatch, expectedVersion int) (int, error)
+	ListRevisions(cardID uuid.UUID) ([]models.GoodCardRevision, error)
+	RestoreRevision(cardID uuid.UUID, version int, expectedVersion int) (int, error)
+	CreateGood(cardID uuid.UUID, quantity int, meta models.MovementMeta) error
+	CreateSKU(cardID uuid.UUID, sku models.SKU, quantity int, meta models.MovementMeta) (uuid.UUID, error)
+	UpdateSKU(skuID uuid.UUID, price *money.Money, weight *float64) error
+	DeleteGood(skuID uuid.UUID) error
+	AddCountGood(skuID uuid.UUID, warehouseID uuid.UUID, number int, meta models.MovementMeta) (int, error)
+	DeleteCountGood(skuID uuid.UUID, warehouseID uuid.UUID, number int, meta models.MovementMeta) (int, error)
+	AdjustStock(sellerID uuid.UUID, items []models.StockAdjustment, meta models.MovementMeta) ([]models.StockAdjustmentResult, error)
 	ReadGood(goodID uuid.UUID) (models.Good, error)
+	ReadGoodCard(cardID uuid.UUID) (models.Good, error)
+	ReadGoodCards(ids []uuid.UUID) ([]models.Good, error)
+	CardOwner(cardID uuid.UUID) (uuid.UUID, error)
+	SKUOwner(skuID uuid.UUID) (uuid.UUID, error)
+	SKUCards(skuIDs []uuid.UUID) ([]uuid.UUID, error)
+	ReservationCard(reservationID uuid.UUID) (uuid.UUID, error)
+	ListGoods(filter models.GoodFilter) (models.GoodPage, error)
+	ReserveGood(skuID uuid.UUID, warehouseID uuid.UUID, number int, ttl time.Duration, meta models.MovementMeta) (models.Reservation, error)
+	CommitReservation(reservationID uuid.UUID, meta models.MovementMeta) error
+	ReleaseReservation(reservationID uuid.UUID, meta models.MovementMeta) error
+	ExpireReservations(limit int) (int, error)
+	ListMovements(skuID uuid.UUID, limit int, cursor string) (models.MovementPage, error)
+	QuantityAt(skuID uuid.UUID, at time.Time) (int, error)
+	CheckLedger() ([]models.LedgerMismatch, error)
+	ImportGoodCards(sellerID uuid.UUID, rows []models.ImportRow, meta models.MovementMeta) ([]models.ImportRowResult, error)
+	CreateCategory(parentID *uuid.UUID, name string) (uuid.UUID, error)
+	ReadCategory(id uuid.UUID) (models.Category, error)
+	ListCategories(parentID *uuid.UUID) ([]models.Category, error)
+	CategoryPath(id uuid.UUID) ([]models.Category, error)
+	RenameCategory(id uuid.UUID, name string) error
+	MoveCategory(id uuid.UUID, parentID *uuid.UUID) error
+	DeleteCategory(id uuid.UUID) error
+	ReadAttributeSchema(categoryID uuid.UUID) ([]models.AttributeDefinition, error)
+	SetAttributeSchema(categoryID uuid.UUID, schema []models.AttributeDefinition) error
+	CreateBrand(name string) (uuid.UUID, error)
+	ListBrands() ([]models.Brand, error)
+	RenameBrand(id uuid.UUID, name string) error
+	DeleteBrand(id uuid.UUID) error
+
+	CreatePriceSchedule(schedule models.PriceSchedule) (uuid.UUID, error)
+	ListPriceSchedules(cardID uuid.UUID) ([]models.PriceSchedule, error)
+	CancelPriceSchedule(cardID uuid.UUID, scheduleID uuid.UUID) error
+	ApplyPriceSchedules(limit int) (int, error)
+	ReadCardPrices(cardID uuid.UUID) ([]money.Money, error)
+	SetCardPrices(cardID uuid.UUID, prices []money.Money) error
+	CardPricesIn(cardIDs []uuid.UUID, currency string) (map[uuid.UUID]money.Money, error)
+	ListExchangeRates() ([]models.ExchangeRate, error)
+	SetExchangeRates(rates []models.ExchangeRate) error
+
+	CreateWarehouse(warehouse models.Warehouse) (uuid.UUID, error)
+	ListWarehouses(sellerID uuid.UUID) ([]models.Warehouse, error)
+	UpdateWarehouse(warehouse models.Warehouse) error
+	DeleteWarehouse(sellerID uuid.UUID, id uuid.UUID) error
+
+	RestoreGoodCard(sellerID uuid.UUID, cardID uuid.UUID) error
+	PurgeDeletedCards(retention time.Duration, limit int) (int, []models.Media, error)
+
+	SetLowStockThreshold(cardID uuid.UUID, threshold *int) error
+	SetWebhook(webhook models.Webhook) (models.Webhook, error)
+	ReadWebhook(sellerID uuid.UUID) (models.Webhook, error)
+	DeleteWebhook(sellerID uuid.UUID) error
+	ListWebhookDeliveries(sellerID uuid.UUID, status string, limit int, cursor string) (models.WebhookDeliveryPage, error)
+	ClaimWebhookDeliveries(limit int, lease time.Duration) ([]models.WebhookDelivery, error)
+	RecordWebhookAttempt(id int64, attempt models.WebhookAttempt) error
+	AddMedia(media models.Media) (models.Media, error)
+	ListMedia(cardID uuid.UUID) ([]models.Media, error)
+	ReorderMedia(cardID uuid.UUID, ids []uuid.UUID) error
+	DeleteMedia(cardID uuid.UUID, mediaID uuid.UUID) (models.Media, error)
+	ProcessOutbox(limit int, handle func(events []models.OutboxEvent) int) (int, error)
+
+	ClaimIdempotencyKey(scope uuid.UUID, key string, fingerprint string, ttl time.Duration, lease time.Duration) (models.IdempotentResponse, bool, error)
+	SaveIdempotentResponse(scope uuid.UUID, key string, response models.IdempotentResponse) error
+	ReleaseIdempotencyKey(scope uuid.UUID, key string) error
+	PurgeIdempotencyKeys(limit int) (int, error)
+
+	SubmitGoodCard(cardID uuid.UUID, actor string) error
+	ApproveGoodCard(cardID uuid.UUID, expectedVersion int, actor string) error
+	RejectGoodCard(cardID uuid.UUID, expectedVersion int, reason string, actor string) error
+	ListModerationLog(cardID uuid.UUID) ([]models.ModerationDecision, error)
 }
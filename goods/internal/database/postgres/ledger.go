@@ -0,0 +1,122 @@
+package postgresdb
+
+import (
+	"database/sql"
+	myErrors "goods/internal/errors"
+	"goods/internal/models"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// recordMovement дописывает движение в журнал. Вызывается в транзакции
+// после изменения goods, поэтому quantity_after берётся из уже обновлённой строки склада.
+func recordMovement(tx *sql.Tx, skuID uuid.UUID, warehouseID uuid.UUID, delta int, reason string, meta models.MovementMeta) error {
+	_, err := tx.Exec(`
+		INSERT INTO inventory_movements (sku_id, warehouse_id, delta, reason, actor, correlation_id, quantity_after)
+		SELECT $1, $2, $3, $4, $5, $6, quantity FROM goods WHERE sku_id = $1 AND warehouse_id = $2`,
+		skuID, warehouseID, delta, reason, meta.Actor, meta.CorrelationID)
+	return err
+}
+
+func (db *Postgres) ListMovements(skuID uuid.UUID, limit int, cursor string) (models.MovementPage, error) {
+	if limit <= 0 {
+		limit = models.DefaultPageLimit
+	}
+	if limit > models.MaxPageLimit {
+		limit = models.MaxPageLimit
+	}
+
+	// Курсор - id последней отданной записи; история идёт от новых к старым
+	var beforeID int64
+	if cursor != "" {
+		id, err := strconv.ParseInt(cursor, 10, 64)
+		if err != nil || id <= 0 {
+			return models.MovementPage{}, myErrors.ErrInvalidCursor
+		}
+		beforeID = id
+	}
+
+	query := `
+		SELECT id, sku_id, warehouse_id, delta, reason, actor, correlation_id, quantity_after, created_at
+		FROM inventory_movements
+		WHERE sku_id = $1 AND ($2 = 0 OR id < $2)
+		ORDER BY id DESC
+		LIMIT $3`
+	rows, err := db.Connection.Query(query, skuID, beforeID, limit+1)
+	if err != nil {
+		return models.MovementPage{}, myErrors.ErrLedgerInternal
+	}
+	defer rows.Close()
+
+	page := models.MovementPage{Items: make([]models.InventoryMovement, 0, limit)}
+	for rows.Next() {
+		var m models.InventoryMovement
+		if err := rows.Scan(&m.ID, &m.SKUID, &m.WarehouseID, &m.Delta, &m.Reason, &m.Actor, &m.CorrelationID, &m.QuantityAfter, &m.CreatedAt); err != nil {
+			return models.MovementPage{}, myErrors.ErrLedgerInternal
+		}
+		if len(page.Items) == limit {
+			page.NextCursor = strconv.FormatInt(page.Items[len(page.Items)-1].ID, 10)
+			break
+		}
+		page.Items = append(page.Items, m)
+	}
+	if err := rows.Err(); err != nil {
+		return models.MovementPage{}, myErrors.ErrLedgerInternal
+	}
+	return page, nil
+}
+
+// QuantityAt восстанавливает доступное количество товара по всем складам на момент at по журналу
+func (db *Postgres) QuantityAt(skuID uuid.UUID, at time.Time) (int, error) {
+	var exists bool
+	err := db.Connection.QueryRow("SELECT EXISTS(SELECT 1 FROM inventory_movements WHERE sku_id = $1)", skuID).Scan(&exists)
+	if err != nil {
+		return 0, myErrors.ErrLedgerInternal
+	}
+	if !exists {
+		return 0, myErrors.ErrGoodNotFound
+	}
+
+	var quantity int
+	err = db.Connection.QueryRow(`
+		SELECT COALESCE(SUM(delta), 0) FROM inventory_movements
+		WHERE sku_id = $1 AND created_at <= $2`, skuID, at).Scan(&quantity)
+	if err != nil {
+		return 0, myErrors.ErrLedgerInternal
+	}
+	return quantity, nil
+}
+
+// CheckLedger пересчитывает журнал и возвращает остатки на складах, у которых сумма движений
+// не совпадает с goods.quantity. Записи удалённых складов не проверяются.
+func (db *Postgres) CheckLedger() ([]models.LedgerMismatch, error) {
+	rows, err := db.Connection.Query(`
+		SELECT COALESCE(g.sku_id, l.sku_id), COALESCE(g.warehouse_id, l.warehouse_id), COALESCE(l.total, 0), COALESCE(g.quantity, 0)
+		FROM goods g
+		FULL OUTER JOIN (
+			SELECT sku_id, warehouse_id, SUM(delta) AS total FROM inventory_movements
+			WHERE warehouse_id IS NOT NULL
+			GROUP BY sku_id, warehouse_id
+		) l ON l.sku_id = g.sku_id AND l.warehouse_id = g.warehouse_id
+		WHERE COALESCE(l.total, 0) <> COALESCE(g.quantity, 0)
+		  AND (g.sku_id IS NOT NULL OR EXISTS(SELECT 1 FROM good_skus WHERE uuid = l.sku_id))`)
+	if err != nil {
+		return nil, myErrors.ErrLedgerInternal
+	}
+	defer rows.Close()
+
+	mismatches := make([]models.LedgerMismatch, 0)
+	for rows.Next() {
+		var m models.LedgerMismatch
+		if err := rows.Scan(&m.SKUID, &m.WarehouseID, &m.LedgerQuantity, &m.ActualQuantity); err != nil {
+			return nil, myErrors.ErrLedgerInternal
+		}
+		mismatches = append(mismatches, m)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, myErrors.ErrLedgerInternal
+	}
+	return mismatches, nil
+}
@@ -0,0 +1,91 @@
+package postgresdb
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	myErrors "goods/internal/errors"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+)
+
+func newMockPostgres(t *testing.T) (*Postgres, sqlmock.Sqlmock) {
+	t.Helper()
+	conn, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return &Postgres{Connection: conn}, mock
+}
+
+func TestQuantityAtSumsMovementsUpToMoment(t *testing.T) {
+	db, mock := newMockPostgres(t)
+	skuID := uuid.New()
+	at := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT EXISTS(SELECT 1 FROM inventory_movements WHERE sku_id = $1)")).
+		WithArgs(skuID).WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT COALESCE(SUM(delta), 0) FROM inventory_movements")).
+		WithArgs(skuID, at).WillReturnRows(sqlmock.NewRows([]string{"sum"}).AddRow(7))
+
+	quantity, err := db.QuantityAt(skuID, at)
+	if err != nil || quantity != 7 {
+		t.Fatalf("QuantityAt = %d, %v, want 7", quantity, err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestQuantityAtUnknownSKU(t *testing.T) {
+	db, mock := newMockPostgres(t)
+	skuID := uuid.New()
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT EXISTS")).
+		WithArgs(skuID).WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+
+	// Без записей в журнале товар неизвестен, а не пуст: ноль означал бы, что он когда-то был
+	if _, err := db.QuantityAt(skuID, time.Now()); err != myErrors.ErrGoodNotFound {
+		t.Fatalf("err = %v, want ErrGoodNotFound", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCheckLedgerReportsMismatches(t *testing.T) {
+	db, mock := newMockPostgres(t)
+	skuID, warehouseID := uuid.New(), uuid.New()
+
+	mock.ExpectQuery(regexp.QuoteMeta("FROM goods g")).
+		WillReturnRows(sqlmock.NewRows([]string{"sku_id", "warehouse_id", "ledger", "actual"}).
+			AddRow(skuID.String(), warehouseID.String(), 5, 3))
+
+	mismatches, err := db.CheckLedger()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(mismatches) != 1 {
+		t.Fatalf("mismatches = %+v, want one", mismatches)
+	}
+	m := mismatches[0]
+	if m.SKUID != skuID || m.WarehouseID != warehouseID || m.LedgerQuantity != 5 || m.ActualQuantity != 3 {
+		t.Fatalf("mismatch = %+v, want sku %v warehouse %v ledger 5 actual 3", m, skuID, warehouseID)
+	}
+}
+
+func TestCheckLedgerConsistent(t *testing.T) {
+	db, mock := newMockPostgres(t)
+
+	mock.ExpectQuery(regexp.QuoteMeta("FROM goods g")).
+		WillReturnRows(sqlmock.NewRows([]string{"sku_id", "warehouse_id", "ledger", "actual"}))
+
+	// Пустой результат отдаётся пустым списком, а не nil: в JSON это [] вместо null
+	mismatches, err := db.CheckLedger()
+	if err != nil || mismatches == nil || len(mismatches) != 0 {
+		t.Fatalf("CheckLedger = %#v, %v, want an empty list", mismatches, err)
+	}
+}
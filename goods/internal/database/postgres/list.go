@@ -0,0 +1,225 @@
+package postgresdb
+
+import (
+	"database/sql"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	myErrors "goods/internal/errors"
+	"goods/internal/models"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// sortColumns сопоставляет порядок сортировки с колонкой и направлением
+var sortColumns = map[string]struct {
+	column string
+	desc   bool
+}{
+	models.SortDefault:           {column: "gc.uuid"},
+	models.SortPriceAsc:          {column: "gc.price"},
+	models.SortPriceDesc:         {column: "gc.price", desc: true},
+	models.SortNameAsc:           {column: "gc.name"},
+	models.SortNameDesc:          {column: "gc.name", desc: true},
+	models.SortModerationChanged: {column: "gc.moderation_changed_at"},
+}
+
+// listCursor - позиция последней отданной строки. Пагинация по ключу (keyset),
+// поэтому вставка новых строк не сдвигает уже выданные страницы.
+type listCursor struct {
+	Sort  string    `json:"s"`
+	Value string    `json:"v"`
+	ID    uuid.UUID `json:"id"`
+}
+
+func encodeCursor(c listCursor) string {
+	raw, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+func decodeCursor(s string) (listCursor, error) {
+	var c listCursor
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return c, myErrors.ErrInvalidCursor
+	}
+	if err := json.Unmarshal(raw, &c); err != nil || c.ID == uuid.Nil {
+		return c, myErrors.ErrInvalidCursor
+	}
+	return c, nil
+}
+
+func (db *Postgres) ListGoods(filter models.GoodFilter) (models.GoodPage, error) {
+	order, ok := sortColumns[filter.Sort]
+	if !ok {
+		return models.GoodPage{}, myErrors.ErrInvalidSort
+	}
+
+	// Суммы в разных валютах несравнимы
+	if (filter.MinPrice != nil || filter.MaxPrice != nil) && filter.Currency == "" {
+		return models.GoodPage{}, myErrors.ErrInvalidFilter
+	}
+
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = models.DefaultPageLimit
+	}
+	if limit > models.MaxPageLimit {
+		limit = models.MaxPageLimit
+	}
+
+	var params []interface{}
+	whereClauses := []string{"gc.deleted_at IS NULL"} // Удалённые карточки не показываются
+	paramIndex := 1                                   // Индекс параметра для использования в запросе
+
+	addClause := func(clause string, value interface{}) {
+		whereClauses = append(whereClauses, fmt.Sprintf(clause, paramIndex))
+		params = append(params, value)
+		paramIndex++
+	}
+
+	if filter.SellerID != nil {
+		addClause("gc.seller_id = $%d", *filter.SellerID)
+	}
+	if filter.IsActive != nil {
+		addClause("gc.is_active = $%d", *filter.IsActive)
+	}
+	if filter.Currency != "" {
+		addClause("gc.currency = $%d", filter.Currency)
+	}
+	if filter.MinPrice != nil {
+		addClause("gc.price >= $%d", *filter.MinPrice)
+	}
+	if filter.MaxPrice != nil {
+		addClause("gc.price <= $%d", *filter.MaxPrice)
+	}
+	if filter.MinWeight != nil {
+		addClause("gc.weight >= $%d", *filter.MinWeight)
+	}
+	if filter.MaxWeight != nil {
+		addClause("gc.weight <= $%d", *filter.MaxWeight)
+	}
+	if filter.NamePrefix != "" {
+		// Экранируем спецсимволы LIKE, чтобы префикс искался буквально
+		prefix := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(filter.NamePrefix)
+		addClause("gc.name LIKE $%d", prefix+"%")
+	}
+	if filter.CategoryID != nil {
+		addClause("gc.category_id IN ("+strings.ReplaceAll(descendantsQuery, "$1", "$%[1]d")+")", *filter.CategoryID)
+	}
+	if filter.BrandID != nil {
+		addClause("gc.brand_id = $%d", *filter.BrandID)
+	}
+	if filter.Moderation != "" {
+		addClause("gc.moderation_status = $%d", filter.Moderation)
+	}
+
+	if filter.Cursor != "" {
+		cursor, err := decodeCursor(filter.Cursor)
+		if err != nil {
+			return models.GoodPage{}, err
+		}
+		if cursor.Sort != filter.Sort {
+			return models.GoodPage{}, myErrors.ErrInvalidCursor
+		}
+		op := ">"
+		if order.desc {
+			op = "<"
+		}
+		// Сравнение кортежей (ключ, uuid) даёт однозначный порядок даже при равных ключах
+		whereClauses = append(whereClauses, fmt.Sprintf("(%s, gc.uuid) %s ($%d::%s, $%d)",
+			order.column, op, paramIndex, castType(order.column), paramIndex+1))
+		params = append(params, cursor.Value, cursor.ID)
+		paramIndex += 2
+	}
+
+	query := `
+		SELECT gc.uuid, COALESCE(g.quantity, 0), COALESCE(g.reserved, 0), gc.uuid, gc.price, gc.currency, gc.name, gc.description,
+		       gc.weight, gc.seller_id, gc.is_active, gc.version, gc.variant_axes,
+		       gc.category_id, gc.brand_id, gc.low_stock_threshold, gc.moderation_status, COALESCE(gc.moderation_reason, ''),
+		       gc.attributes, ` + effectivePriceExpr + `, ` + order.column + `::text
+		FROM good_cards gc
+		` + effectivePriceJoin + `
+		LEFT JOIN (
+			SELECT card_id, SUM(quantity) AS quantity, SUM(reserved) AS reserved FROM goods GROUP BY card_id
+		) g ON g.card_id = gc.uuid`
+	if len(whereClauses) > 0 {
+		query += " WHERE " + strings.Join(whereClauses, " AND ")
+	}
+	direction := "ASC"
+	if order.desc {
+		direction = "DESC"
+	}
+	query += fmt.Sprintf(" ORDER BY %s %s, gc.uuid %s LIMIT $%d", order.column, direction, direction, paramIndex)
+	// Запрашиваем на одну строку больше, чтобы понять, есть ли следующая страница
+	params = append(params, limit+1)
+
+	rows, err := db.Connection.Query(query, params...)
+	if err != nil {
+		return models.GoodPage{}, myErrors.ErrListGoodsInternal
+	}
+	defer rows.Close()
+
+	page := models.GoodPage{Items: make([]models.Good, 0, limit)}
+	rawAttributes := make(map[uuid.UUID][]byte, limit)
+	var lastKey string
+	for rows.Next() {
+		var good models.Good
+		var sortKey sql.NullString
+		var raw []byte
+		err := rows.Scan(&good.UUID, &good.Quantity, &good.Reserved, &good.Card.UUID, &good.Card.Price.Amount, &good.Card.Price.Currency, &good.Card.Name,
+			&good.Card.Description, &good.Card.Weight, &good.Card.SellerID, &good.Card.IsActive, &good.Card.Version, pq.Array(&good.Card.VariantAxes),
+			&good.Card.CategoryID, &good.Card.BrandID, &good.Card.LowStockThreshold, &good.Card.ModerationStatus, &good.Card.ModerationReason,
+			&raw, &good.Card.EffectivePrice.Amount, &sortKey)
+		if err != nil {
+			return models.GoodPage{}, myErrors.ErrListGoodsInternal
+		}
+		good.Card.EffectivePrice.Currency = good.Card.Price.Currency
+		if len(page.Items) == limit {
+			// Лишняя строка существует - значит, есть следующая страница
+			last := page.Items[len(page.Items)-1]
+			page.NextCursor = encodeCursor(listCursor{Sort: filter.Sort, Value: lastKey, ID: last.Card.UUID})
+			break
+		}
+		page.Items = append(page.Items, good)
+		rawAttributes[good.Card.UUID] = raw
+		lastKey = sortKey.String
+	}
+	if err := rows.Err(); err != nil {
+		return models.GoodPage{}, myErrors.ErrListGoodsInternal
+	}
+
+	// Варианты всех карточек страницы загружаются одним запросом
+	cardIDs := make([]uuid.UUID, 0, len(page.Items))
+	for _, good := range page.Items {
+		cardIDs = append(cardIDs, good.Card.UUID)
+	}
+	skus, err := loadSKUs(db.Connection, cardIDs)
+	if err != nil {
+		return models.GoodPage{}, myErrors.ErrListGoodsInternal
+	}
+	for i := range page.Items {
+		page.Items[i].SKUs = skus[page.Items[i].Card.UUID]
+	}
+	if err := fillAttributes(db.Connection, page.Items, rawAttributes); err != nil {
+		return models.GoodPage{}, myErrors.ErrListGoodsInternal
+	}
+
+	return page, nil
+}
+
+// castType возвращает тип, к которому приводится значение курсора для колонки сортировки
+func castType(column string) string {
+	switch column {
+	case "gc.price":
+		return "bigint"
+	case "gc.uuid":
+		return "uuid"
+	case "gc.moderation_changed_at":
+		return "timestamptz"
+	default:
+		return "text"
+	}
+}
@@ -0,0 +1,49 @@
+package postgresdb
+
+import (
+	myErrors "goods/internal/errors"
+	"goods/internal/models"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestListCursorRoundTrip(t *testing.T) {
+	want := listCursor{Sort: models.SortPriceAsc, Value: "1050", ID: uuid.New()}
+	got, err := decodeCursor(encodeCursor(want))
+	if err != nil || got != want {
+		t.Fatalf("decodeCursor = %+v, %v, want %+v", got, err, want)
+	}
+
+	for _, raw := range []string{"!!!", encodeCursor(listCursor{Sort: models.SortPriceAsc})} {
+		if _, err := decodeCursor(raw); err != myErrors.ErrInvalidCursor {
+			t.Errorf("%q: err = %v, want ErrInvalidCursor", raw, err)
+		}
+	}
+}
+
+func TestListGoodsRejectsInvalidFilter(t *testing.T) {
+	// Все проверки выполняются до обращения к базе, поэтому ожидания запросов не задаются
+	db, mock := newMockPostgres(t)
+	price := int64(100)
+	cursor := encodeCursor(listCursor{Sort: models.SortNameAsc, Value: "a", ID: uuid.New()})
+
+	cases := []struct {
+		name   string
+		filter models.GoodFilter
+		want   error
+	}{
+		{"unknown sort", models.GoodFilter{Sort: "weight_asc"}, myErrors.ErrInvalidSort},
+		{"price without currency", models.GoodFilter{MinPrice: &price}, myErrors.ErrInvalidFilter},
+		{"cursor of another sort", models.GoodFilter{Sort: models.SortPriceAsc, Cursor: cursor}, myErrors.ErrInvalidCursor},
+		{"malformed cursor", models.GoodFilter{Cursor: "???"}, myErrors.ErrInvalidCursor},
+	}
+	for _, c := range cases {
+		if _, err := db.ListGoods(c.filter); err != c.want {
+			t.Errorf("%s: err = %v, want %v", c.name, err, c.want)
+		}
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,132 @@
+package postgresdb
+
+import (
+	"database/sql"
+	myErrors "goods/internal/errors"
+	"goods/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// AddMedia добавляет изображение в конец списка изображений карточки
+func (db *Postgres) AddMedia(media models.Media) (models.Media, error) {
+	tx, err := db.Connection.Begin()
+	if err != nil {
+		return models.Media{}, myErrors.ErrMediaInternal
+	}
+	defer tx.Rollback()
+
+	// Блокировка карточки упорядочивает параллельные загрузки при выборе позиции
+	var lockedID uuid.UUID
+	err = tx.QueryRow("SELECT uuid FROM good_cards WHERE uuid = $1 AND deleted_at IS NULL FOR UPDATE", media.CardID).Scan(&lockedID)
+	if err == sql.ErrNoRows {
+		return models.Media{}, myErrors.ErrGoodCardNotFound
+	}
+	if err != nil {
+		return models.Media{}, myErrors.ErrMediaInternal
+	}
+
+	err = tx.QueryRow(`
+		INSERT INTO good_card_media (uuid, card_id, position, content_type, size, width, height, blob_key, thumbnail_key)
+		SELECT $1, $2, COALESCE(MAX(position), 0) + 1, $3, $4, $5, $6, $7, $8 FROM good_card_media WHERE card_id = $2
+		RETURNING position`,
+		media.UUID, media.CardID, media.ContentType, media.Size, media.Width, media.Height, media.Key, media.ThumbnailKey).Scan(&media.Position)
+	if err != nil {
+		return models.Media{}, myErrors.ErrMediaInternal
+	}
+	// Новое изображение одобренной карточки тоже проверяется модератором
+	if err := resubmitForReview(tx, media.CardID); err != nil {
+		return models.Media{}, myErrors.ErrMediaInternal
+	}
+	if err := tx.Commit(); err != nil {
+		return models.Media{}, myErrors.ErrMediaInternal
+	}
+	return media, nil
+}
+
+// ListMedia возвращает изображения карточки в порядке показа
+func (db *Postgres) ListMedia(cardID uuid.UUID) ([]models.Media, error) {
+	rows, err := db.Connection.Query(`
+		SELECT uuid, card_id, position, content_type, size, width, height, blob_key, thumbnail_key
+		FROM good_card_media
+		WHERE card_id = $1
+		ORDER BY position`, cardID)
+	if err != nil {
+		return nil, myErrors.ErrMediaInternal
+	}
+	defer rows.Close()
+
+	media := make([]models.Media, 0)
+	for rows.Next() {
+		var m models.Media
+		if err := rows.Scan(&m.UUID, &m.CardID, &m.Position, &m.ContentType, &m.Size, &m.Width, &m.Height, &m.Key, &m.ThumbnailKey); err != nil {
+			return nil, myErrors.ErrMediaInternal
+		}
+		media = append(media, m)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, myErrors.ErrMediaInternal
+	}
+	return media, nil
+}
+
+// ReorderMedia задаёт порядок изображений: ids должен перечислять все изображения карточки
+func (db *Postgres) ReorderMedia(cardID uuid.UUID, ids []uuid.UUID) error {
+	tx, err := db.Connection.Begin()
+	if err != nil {
+		return myErrors.ErrMediaInternal
+	}
+	defer tx.Rollback()
+
+	var lockedID uuid.UUID
+	err = tx.QueryRow("SELECT uuid FROM good_cards WHERE uuid = $1 AND deleted_at IS NULL FOR UPDATE", cardID).Scan(&lockedID)
+	if err == sql.ErrNoRows {
+		return myErrors.ErrGoodCardNotFound
+	}
+	if err != nil {
+		return myErrors.ErrMediaInternal
+	}
+
+	var count int
+	if err := tx.QueryRow("SELECT COUNT(*) FROM good_card_media WHERE card_id = $1", cardID).Scan(&count); err != nil {
+		return myErrors.ErrMediaInternal
+	}
+	if count != len(ids) {
+		return myErrors.ErrMediaOrderMismatch
+	}
+
+	seen := make(map[uuid.UUID]bool, len(ids))
+	for i, id := range ids {
+		if seen[id] {
+			return myErrors.ErrMediaOrderMismatch
+		}
+		seen[id] = true
+		res, err := tx.Exec("UPDATE good_card_media SET position = $1 WHERE uuid = $2 AND card_id = $3", i+1, id, cardID)
+		if err != nil {
+			return myErrors.ErrMediaInternal
+		}
+		if n, _ := res.RowsAffected(); n == 0 {
+			return myErrors.ErrMediaOrderMismatch
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return myErrors.ErrMediaInternal
+	}
+	return nil
+}
+
+// DeleteMedia удаляет запись об изображении и возвращает её, чтобы удалить файлы из хранилища
+func (db *Postgres) DeleteMedia(cardID uuid.UUID, mediaID uuid.UUID) (models.Media, error) {
+	m := models.Media{UUID: mediaID, CardID: cardID}
+	err := db.Connection.QueryRow(`
+		DELETE FROM good_card_media WHERE uuid = $1 AND card_id = $2
+		RETURNING blob_key, thumbnail_key`, mediaID, cardID).Scan(&m.Key, &m.ThumbnailKey)
+	if err == sql.ErrNoRows {
+		return models.Media{}, myErrors.ErrMediaNotFound
+	}
+	if err != nil {
+		return models.Media{}, myErrors.ErrMediaInternal
+	}
+	return m, nil
+}
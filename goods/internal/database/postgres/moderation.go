@@ -0,0 +1,136 @@
+package postgresdb
+
+import (
+	"database/sql"
+	myErrors "goods/internal/errors"
+	"goods/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// recordModeration дополняет журнал модерации переходом карточки; версия берётся из текущей строки карточки
+func recordModeration(tx *sql.Tx, cardID uuid.UUID, from string, to string, reason string, actor string) error {
+	_, err := tx.Exec(`
+		INSERT INTO card_moderation_log (card_id, card_version, from_status, to_status, reason, actor)
+		SELECT uuid, version, $2, $3, $4, $5 FROM good_cards WHERE uuid = $1`,
+		cardID, from, to, reason, actor)
+	return err
+}
+
+// resubmitForReview возвращает одобренную карточку на проверку после изменения её содержимого
+// и снимает её с публикации. Карточки в других статусах не меняются.
+func resubmitForReview(tx *sql.Tx, cardID uuid.UUID) error {
+	var active bool
+	err := tx.QueryRow(`
+		UPDATE good_cards SET moderation_status = $2, moderation_reason = NULL, moderation_changed_at = now()
+		WHERE uuid = $1 AND moderation_status = $3
+		RETURNING is_active`, cardID, models.ModerationSubmitted, models.ModerationApproved).Scan(&active)
+	if err == sql.ErrNoRows {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if err := recordModeration(tx, cardID, models.ModerationApproved, models.ModerationSubmitted, "", models.ModerationActorSystem); err != nil {
+		return err
+	}
+	if active {
+		return writeCardEvent(tx, cardID, models.EventCardUnpublished)
+	}
+	return nil
+}
+
+// SubmitGoodCard отправляет черновик или отклонённую карточку на проверку
+func (db *Postgres) SubmitGoodCard(cardID uuid.UUID, actor string) error {
+	return db.moderate(cardID, 0, []string{models.ModerationDraft, models.ModerationRejected}, models.ModerationSubmitted, "", actor)
+}
+
+// ApproveGoodCard одобряет карточку на проверке, если её версия равна expectedVersion (0 - без проверки).
+// Активная карточка сразу становится видна поиску.
+func (db *Postgres) ApproveGoodCard(cardID uuid.UUID, expectedVersion int, actor string) error {
+	return db.moderate(cardID, expectedVersion, []string{models.ModerationSubmitted}, models.ModerationApproved, "", actor)
+}
+
+// RejectGoodCard отклоняет карточку на проверке с причиной, которую увидит продавец
+func (db *Postgres) RejectGoodCard(cardID uuid.UUID, expectedVersion int, reason string, actor string) error {
+	return db.moderate(cardID, expectedVersion, []string{models.ModerationSubmitted}, models.ModerationRejected, reason, actor)
+}
+
+// moderate переводит карточку из одного из статусов from в статус to и записывает переход в журнал
+func (db *Postgres) moderate(cardID uuid.UUID, expectedVersion int, from []string, to string, reason string, actor string) error {
+	tx, err := db.Connection.Begin()
+	if err != nil {
+		return myErrors.ErrModerationInternal
+	}
+	defer tx.Rollback()
+
+	var status string
+	var version int
+	err = tx.QueryRow("SELECT moderation_status, version FROM good_cards WHERE uuid = $1 AND deleted_at IS NULL FOR UPDATE", cardID).
+		Scan(&status, &version)
+	if err == sql.ErrNoRows {
+		return myErrors.ErrGoodCardNotFound
+	}
+	if err != nil {
+		return myErrors.ErrModerationInternal
+	}
+	if expectedVersion != 0 && expectedVersion != version {
+		return myErrors.ErrVersionMismatch
+	}
+	allowed := false
+	for _, s := range from {
+		allowed = allowed || s == status
+	}
+	if !allowed {
+		return myErrors.ErrModerationInvalidState
+	}
+
+	// Причина хранится только у отклонённой карточки
+	var storedReason *string
+	if to == models.ModerationRejected {
+		storedReason = &reason
+	}
+	_, err = tx.Exec("UPDATE good_cards SET moderation_status = $2, moderation_reason = $3, moderation_changed_at = now() WHERE uuid = $1",
+		cardID, to, storedReason)
+	if err != nil {
+		return myErrors.ErrModerationInternal
+	}
+	if err := recordModeration(tx, cardID, status, to, reason, actor); err != nil {
+		return myErrors.ErrModerationInternal
+	}
+	if to == models.ModerationApproved {
+		if err := writeCardEvent(tx, cardID, models.EventCardApproved); err != nil {
+			return myErrors.ErrModerationInternal
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return myErrors.ErrModerationInternal
+	}
+	return nil
+}
+
+// ListModerationLog возвращает журнал модерации карточки от старых решений к новым
+func (db *Postgres) ListModerationLog(cardID uuid.UUID) ([]models.ModerationDecision, error) {
+	rows, err := db.Connection.Query(`
+		SELECT id, card_id, card_version, from_status, to_status, reason, actor, created_at
+		FROM card_moderation_log
+		WHERE card_id = $1
+		ORDER BY id`, cardID)
+	if err != nil {
+		return nil, myErrors.ErrModerationInternal
+	}
+	defer rows.Close()
+
+	decisions := make([]models.ModerationDecision, 0)
+	for rows.Next() {
+		var d models.ModerationDecision
+		if err := rows.Scan(&d.ID, &d.CardID, &d.CardVersion, &d.FromStatus, &d.ToStatus, &d.Reason, &d.Actor, &d.CreatedAt); err != nil {
+			return nil, myErrors.ErrModerationInternal
+		}
+		decisions = append(decisions, d)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, myErrors.ErrModerationInternal
+	}
+	return decisions, nil
+}
@@ -0,0 +1,174 @@
+package postgresdb
+
+import (
+	"database/sql"
+	myErrors "goods/internal/errors"
+	"goods/internal/models"
+	"goods/pkg/money"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// priceAmount возвращает сумму цены, заданной в валюте карточки currency; nil остаётся nil
+func priceAmount(price *money.Money, currency string) (*int64, error) {
+	if price == nil {
+		return nil, nil
+	}
+	if price.Currency != currency {
+		return nil, myErrors.ErrCurrencyMismatch
+	}
+	return &price.Amount, nil
+}
+
+// ReadCardPrices возвращает цены карточки в других валютах, упорядоченные по коду валюты
+func (db *Postgres) ReadCardPrices(cardID uuid.UUID) ([]money.Money, error) {
+	var exists bool
+	err := db.Connection.QueryRow("SELECT EXISTS(SELECT 1 FROM good_cards WHERE uuid = $1 AND deleted_at IS NULL)", cardID).Scan(&exists)
+	if err != nil {
+		return nil, myErrors.ErrPriceListInternal
+	}
+	if !exists {
+		return nil, myErrors.ErrGoodCardNotFound
+	}
+
+	prices, err := loadCardPrices(db.Connection, []uuid.UUID{cardID}, "")
+	if err != nil {
+		return nil, myErrors.ErrPriceListInternal
+	}
+	return prices[cardID], nil
+}
+
+// SetCardPrices заменяет цены карточки в других валютах. Цена в валюте карточки задаётся самой карточкой.
+func (db *Postgres) SetCardPrices(cardID uuid.UUID, prices []money.Money) error {
+	tx, err := db.Connection.Begin()
+	if err != nil {
+		return myErrors.ErrPriceListInternal
+	}
+	defer tx.Rollback()
+
+	var currency string
+	err = tx.QueryRow("SELECT currency FROM good_cards WHERE uuid = $1 AND deleted_at IS NULL FOR UPDATE", cardID).Scan(&currency)
+	if err == sql.ErrNoRows {
+		return myErrors.ErrGoodCardNotFound
+	}
+	if err != nil {
+		return myErrors.ErrPriceListInternal
+	}
+
+	if _, err := tx.Exec("DELETE FROM card_prices WHERE card_id = $1", cardID); err != nil {
+		return myErrors.ErrPriceListInternal
+	}
+	for _, price := range prices {
+		if price.Currency == currency {
+			return myErrors.ErrPriceListCardCurrency
+		}
+		_, err := tx.Exec("INSERT INTO card_prices (card_id, currency, amount) VALUES ($1, $2, $3)", cardID, price.Currency, price.Amount)
+		if pgError(err, uniqueViolation) != nil {
+			return myErrors.ValidationError("prices", "currencies must be unique")
+		}
+		if err != nil {
+			return myErrors.ErrPriceListInternal
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return myErrors.ErrPriceListInternal
+	}
+	return nil
+}
+
+// CardPricesIn возвращает цены карточек в валюте currency; карточки без такой цены пропускаются
+func (db *Postgres) CardPricesIn(cardIDs []uuid.UUID, currency string) (map[uuid.UUID]money.Money, error) {
+	prices, err := loadCardPrices(db.Connection, cardIDs, currency)
+	if err != nil {
+		return nil, myErrors.ErrPriceListInternal
+	}
+	result := make(map[uuid.UUID]money.Money, len(prices))
+	for id, list := range prices {
+		if len(list) > 0 {
+			result[id] = list[0]
+		}
+	}
+	return result, nil
+}
+
+// loadCardPrices загружает цены карточек в других валютах, сгруппированные по карточке;
+// непустой currency оставляет только цены в этой валюте
+func loadCardPrices(q queryer, cardIDs []uuid.UUID, currency string) (map[uuid.UUID][]money.Money, error) {
+	result := make(map[uuid.UUID][]money.Money, len(cardIDs))
+	for _, id := range cardIDs {
+		result[id] = []money.Money{}
+	}
+	if len(cardIDs) == 0 {
+		return result, nil
+	}
+
+	ids := make([]string, 0, len(cardIDs))
+	for _, id := range cardIDs {
+		ids = append(ids, id.String())
+	}
+	rows, err := q.Query(`
+		SELECT card_id, currency, amount FROM card_prices
+		WHERE card_id = ANY($1::uuid[]) AND ($2 = '' OR currency = $2)
+		ORDER BY card_id, currency`, pq.Array(ids), currency)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var cardID uuid.UUID
+		var price money.Money
+		if err := rows.Scan(&cardID, &price.Currency, &price.Amount); err != nil {
+			return nil, err
+		}
+		result[cardID] = append(result[cardID], price)
+	}
+	return result, rows.Err()
+}
+
+// ListExchangeRates возвращает все курсы, упорядоченные по паре валют
+func (db *Postgres) ListExchangeRates() ([]models.ExchangeRate, error) {
+	rows, err := db.Connection.Query("SELECT base, quote, rate::text, updated_at FROM exchange_rates ORDER BY base, quote")
+	if err != nil {
+		return nil, myErrors.ErrPriceListInternal
+	}
+	defer rows.Close()
+
+	rates := make([]models.ExchangeRate, 0)
+	for rows.Next() {
+		var r models.ExchangeRate
+		if err := rows.Scan(&r.Base, &r.Quote, &r.Rate, &r.UpdatedAt); err != nil {
+			return nil, myErrors.ErrPriceListInternal
+		}
+		rates = append(rates, r)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, myErrors.ErrPriceListInternal
+	}
+	return rates, nil
+}
+
+// SetExchangeRates добавляет курсы или обновляет существующие курсы тех же пар валют
+func (db *Postgres) SetExchangeRates(rates []models.ExchangeRate) error {
+	tx, err := db.Connection.Begin()
+	if err != nil {
+		return myErrors.ErrPriceListInternal
+	}
+	defer tx.Rollback()
+
+	for _, r := range rates {
+		_, err := tx.Exec(`
+			INSERT INTO exchange_rates (base, quote, rate) VALUES ($1, $2, $3::numeric)
+			ON CONFLICT (base, quote) DO UPDATE SET rate = EXCLUDED.rate, updated_at = now()`, r.Base, r.Quote, r.Rate)
+		if err != nil {
+			return myErrors.ErrPriceListInternal
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return myErrors.ErrPriceListInternal
+	}
+	return nil
+}
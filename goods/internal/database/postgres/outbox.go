@@ -0,0 +1,131 @@
+package postgresdb
+
+import (
+	"database/sql"
+	"encoding/json"
+	"goods/internal/broker/contracts"
+	myErrors "goods/internal/errors"
+	"goods/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// Ключ advisory-блокировки, под которой работает ретранслятор outbox.
+// Одновременно события публикует только один экземпляр сервиса, иначе нарушится порядок.
+const outboxLockKey = 7346150001
+
+// writeCardEvent записывает в outbox снимок карточки товара в формате сервиса поиска:
+// остаток суммируется по вариантам, цена дополняется диапазоном цен вариантов,
+// категория и бренд передаются названиями.
+// Вызывается в транзакции изменения после того, как строка карточки заблокирована,
+// поэтому события одной карточки получают id в порядке фиксации изменений.
+// Поиск получает только одобренные активные карточки. Для любой другой карточки передаётся
+// tombstone (Deleted = true) с одним идентификатором: поиск удаляет документ, а непроверенное
+// содержимое не раскрывается.
+func writeCardEvent(tx *sql.Tx, cardID uuid.UUID, eventType string) error {
+	var dto contracts.ProductKafkaDTO
+	var deleted, published bool
+	var attributes []byte
+	err := tx.QueryRow(`
+		SELECT gc.deleted_at IS NOT NULL, gc.moderation_status = 'approved' AND gc.is_active, gc.uuid, gc.name, gc.description, gc.currency, `+effectivePriceExpr+`,
+		       COALESCE(MIN(COALESCE(s.price, `+effectivePriceExpr+`)), `+effectivePriceExpr+`),
+		       COALESCE(MAX(COALESCE(s.price, `+effectivePriceExpr+`)), `+effectivePriceExpr+`),
+		       COALESCE(SUM(g.quantity), 0), COALESCE(c.name, ''), COALESCE(b.name, ''), gc.attributes
+		FROM good_cards gc
+		`+effectivePriceJoin+`
+		LEFT JOIN good_skus s ON s.card_id = gc.uuid
+		LEFT JOIN goods g ON g.sku_id = s.uuid
+		LEFT JOIN categories c ON c.uuid = gc.category_id
+		LEFT JOIN brands b ON b.uuid = gc.brand_id
+		WHERE gc.uuid = $1
+		GROUP BY gc.uuid, ps.price, ps.percent, c.name, b.name`, cardID).Scan(&deleted, &published, &dto.ID, &dto.Name, &dto.Description, &dto.Price.Currency, &dto.Price.Amount, &dto.PriceMin.Amount, &dto.PriceMax.Amount,
+		&dto.Stock, &dto.Category, &dto.Brand, &attributes)
+	if err != nil {
+		return err
+	}
+	// Цены вариантов задаются в валюте карточки
+	dto.PriceMin.Currency = dto.Price.Currency
+	dto.PriceMax.Currency = dto.Price.Currency
+	if err := json.Unmarshal(attributes, &dto.Attributes); err != nil {
+		return err
+	}
+	if deleted || !published || eventType == models.EventCardUnpublished {
+		if eventType == models.EventCardCreated {
+			// Новая неопубликованная карточка в индекс ещё не попадала
+			return nil
+		}
+		// Удалённая или снятая с публикации карточка (деактивация, повторная проверка)
+		// убирается из индекса; повторное удаление для поиска не ошибка
+		dto = contracts.ProductKafkaDTO{ID: dto.ID, Deleted: true}
+	}
+
+	payload, err := json.Marshal(dto)
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec(`INSERT INTO outbox (aggregate_id, event_type, payload) VALUES ($1, $2, $3)`, cardID, eventType, payload)
+	return err
+}
+
+// ProcessOutbox передаёт handle до limit неопубликованных событий в порядке записи
+// и помечает опубликованными первые n из них, где n - результат handle.
+// Если outbox уже обрабатывает другой экземпляр, возвращает 0 без вызова handle.
+func (db *Postgres) ProcessOutbox(limit int, handle func(events []models.OutboxEvent) int) (int, error) {
+	tx, err := db.Connection.Begin()
+	if err != nil {
+		return 0, myErrors.ErrOutboxInternal
+	}
+	defer tx.Rollback()
+
+	var locked bool
+	if err := tx.QueryRow("SELECT pg_try_advisory_xact_lock($1)", outboxLockKey).Scan(&locked); err != nil {
+		return 0, myErrors.ErrOutboxInternal
+	}
+	if !locked {
+		return 0, nil
+	}
+
+	rows, err := tx.Query(`
+		SELECT id, aggregate_id, event_type, payload, created_at FROM outbox
+		WHERE published_at IS NULL
+		ORDER BY id
+		LIMIT $1`, limit)
+	if err != nil {
+		return 0, myErrors.ErrOutboxInternal
+	}
+	var events []models.OutboxEvent
+	for rows.Next() {
+		var event models.OutboxEvent
+		if err := rows.Scan(&event.ID, &event.AggregateID, &event.EventType, &event.Payload, &event.CreatedAt); err != nil {
+			rows.Close()
+			return 0, myErrors.ErrOutboxInternal
+		}
+		events = append(events, event)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, myErrors.ErrOutboxInternal
+	}
+	if len(events) == 0 {
+		return 0, nil
+	}
+
+	published := handle(events)
+	if published <= 0 {
+		return 0, nil
+	}
+
+	ids := make([]int64, 0, published)
+	for _, event := range events[:published] {
+		ids = append(ids, event.ID)
+	}
+	if _, err := tx.Exec("UPDATE outbox SET published_at = now() WHERE id = ANY($1)", pq.Array(ids)); err != nil {
+		return 0, myErrors.ErrOutboxInternal
+	}
+	// Если фиксация не удастся, события будут опубликованы повторно (at-least-once)
+	if err := tx.Commit(); err != nil {
+		return 0, myErrors.ErrOutboxInternal
+	}
+	return published, nil
+}
@@ -0,0 +1,256 @@
+package postgresdb
+
+import (
+	"database/sql"
+	myErrors "goods/internal/errors"
+	"goods/internal/models"
+	"goods/pkg/money"
+
+	"github.com/google/uuid"
+)
+
+// Действующее расписание цены карточки gc (не больше одного) и цена с его учётом.
+// Переопределённые цены вариантов расписание не меняет.
+const (
+	effectivePriceJoin = `LEFT JOIN price_schedules ps ON ps.card_id = gc.uuid AND ps.status = 'active'`
+	effectivePriceExpr = `COALESCE(ps.price, ROUND(gc.price * (100 - ps.percent) / 100)::BIGINT, gc.price)`
+)
+
+// CreatePriceSchedule добавляет расписание, если оно не пересекается с ожидающими и действующими
+// расписаниями карточки. Расписание, начало которого уже наступило, применяется сразу.
+func (db *Postgres) CreatePriceSchedule(schedule models.PriceSchedule) (uuid.UUID, error) {
+	tx, err := db.Connection.Begin()
+	if err != nil {
+		return uuid.Nil, myErrors.ErrScheduleInternal
+	}
+	defer tx.Rollback()
+
+	// Под блокировкой карточки проверка пересечения и вставка не разойдутся с параллельным запросом
+	var currency string
+	err = tx.QueryRow("SELECT currency FROM good_cards WHERE uuid = $1 AND deleted_at IS NULL FOR UPDATE", schedule.CardID).Scan(&currency)
+	if err == sql.ErrNoRows {
+		return uuid.Nil, myErrors.ErrGoodCardNotFound
+	}
+	if err != nil {
+		return uuid.Nil, myErrors.ErrScheduleInternal
+	}
+	price, err := priceAmount(schedule.Price, currency)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	var overlaps bool
+	err = tx.QueryRow(`
+		SELECT EXISTS(
+			SELECT 1 FROM price_schedules
+			WHERE card_id = $1 AND status IN ('pending', 'active')
+			  AND tstzrange(starts_at, ends_at) && tstzrange($2, $3)
+		)`, schedule.CardID, schedule.StartsAt, schedule.EndsAt).Scan(&overlaps)
+	if err != nil {
+		return uuid.Nil, myErrors.ErrScheduleInternal
+	}
+	if overlaps {
+		return uuid.Nil, myErrors.ErrScheduleOverlap
+	}
+
+	var id uuid.UUID
+	err = tx.QueryRow(`
+		INSERT INTO price_schedules (card_id, starts_at, ends_at, price, percent, status)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING uuid`,
+		schedule.CardID, schedule.StartsAt, schedule.EndsAt, price, schedule.Percent, models.ScheduleStatusPending).Scan(&id)
+	if err != nil {
+		return uuid.Nil, myErrors.ErrScheduleInternal
+	}
+	if _, err := advanceSchedule(tx, schedule.CardID, id); err != nil {
+		return uuid.Nil, myErrors.ErrScheduleInternal
+	}
+
+	if err := tx.Commit(); err != nil {
+		return uuid.Nil, myErrors.ErrScheduleInternal
+	}
+	return id, nil
+}
+
+func (db *Postgres) ListPriceSchedules(cardID uuid.UUID) ([]models.PriceSchedule, error) {
+	rows, err := db.Connection.Query(`
+		SELECT ps.uuid, ps.card_id, ps.starts_at, ps.ends_at, ps.price, gc.currency, ps.percent, ps.status, ps.created_at
+		FROM price_schedules ps
+		JOIN good_cards gc ON gc.uuid = ps.card_id
+		WHERE ps.card_id = $1
+		ORDER BY ps.starts_at`, cardID)
+	if err != nil {
+		return nil, myErrors.ErrScheduleInternal
+	}
+	defer rows.Close()
+
+	schedules := make([]models.PriceSchedule, 0)
+	for rows.Next() {
+		var s models.PriceSchedule
+		var price sql.NullInt64
+		var currency string
+		if err := rows.Scan(&s.UUID, &s.CardID, &s.StartsAt, &s.EndsAt, &price, &currency, &s.Percent, &s.Status, &s.CreatedAt); err != nil {
+			return nil, myErrors.ErrScheduleInternal
+		}
+		if price.Valid {
+			s.Price = &money.Money{Amount: price.Int64, Currency: currency}
+		}
+		schedules = append(schedules, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, myErrors.ErrScheduleInternal
+	}
+	return schedules, nil
+}
+
+// CancelPriceSchedule отменяет ожидающее или действующее расписание; действующее при этом снимается
+func (db *Postgres) CancelPriceSchedule(cardID uuid.UUID, scheduleID uuid.UUID) error {
+	tx, err := db.Connection.Begin()
+	if err != nil {
+		return myErrors.ErrScheduleInternal
+	}
+	defer tx.Rollback()
+
+	var lockedID uuid.UUID
+	err = tx.QueryRow("SELECT uuid FROM good_cards WHERE uuid = $1 AND deleted_at IS NULL FOR UPDATE", cardID).Scan(&lockedID)
+	if err == sql.ErrNoRows {
+		return myErrors.ErrGoodCardNotFound
+	}
+	if err != nil {
+		return myErrors.ErrScheduleInternal
+	}
+
+	var status string
+	err = tx.QueryRow("SELECT status FROM price_schedules WHERE uuid = $1 AND card_id = $2", scheduleID, cardID).Scan(&status)
+	if err == sql.ErrNoRows {
+		return myErrors.ErrScheduleNotFound
+	}
+	if err != nil {
+		return myErrors.ErrScheduleInternal
+	}
+	if status != models.ScheduleStatusPending && status != models.ScheduleStatusActive {
+		return myErrors.ErrScheduleFinished
+	}
+
+	if _, err := tx.Exec("UPDATE price_schedules SET status = $1 WHERE uuid = $2", models.ScheduleStatusCancelled, scheduleID); err != nil {
+		return myErrors.ErrScheduleInternal
+	}
+	if status == models.ScheduleStatusActive {
+		if err := writeCardEvent(tx, cardID, models.EventPriceChanged); err != nil {
+			return myErrors.ErrScheduleInternal
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return myErrors.ErrScheduleInternal
+	}
+	return nil
+}
+
+// ApplyPriceSchedules начинает и завершает наступившие расписания, обрабатывая не более limit за вызов.
+// Каждое расписание переводится в своей транзакции под блокировкой карточки.
+// Возвращает количество расписаний, сменивших статус.
+func (db *Postgres) ApplyPriceSchedules(limit int) (int, error) {
+	// Завершения идут первыми: следующее расписание карточки может начаться в момент окончания предыдущего
+	rows, err := db.Connection.Query(`
+		SELECT uuid, card_id FROM price_schedules
+		WHERE status IN ('pending', 'active')
+		  AND (ends_at <= now() OR (status = 'pending' AND starts_at <= now()))
+		ORDER BY ends_at <= now() DESC, starts_at
+		LIMIT $1`, limit)
+	if err != nil {
+		return 0, myErrors.ErrScheduleInternal
+	}
+	type due struct{ id, cardID uuid.UUID }
+	var schedules []due
+	for rows.Next() {
+		var d due
+		if err := rows.Scan(&d.id, &d.cardID); err != nil {
+			rows.Close()
+			return 0, myErrors.ErrScheduleInternal
+		}
+		schedules = append(schedules, d)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, myErrors.ErrScheduleInternal
+	}
+
+	advanced := 0
+	for _, d := range schedules {
+		changed, err := db.advanceScheduleTx(d.cardID, d.id)
+		if err != nil {
+			return advanced, err
+		}
+		if changed {
+			advanced++
+		}
+	}
+	return advanced, nil
+}
+
+func (db *Postgres) advanceScheduleTx(cardID uuid.UUID, scheduleID uuid.UUID) (bool, error) {
+	tx, err := db.Connection.Begin()
+	if err != nil {
+		return false, myErrors.ErrScheduleInternal
+	}
+	defer tx.Rollback()
+
+	// Расписания карточки в корзине тоже продвигаются: восстановленная карточка получит актуальную цену,
+	// а событие об удалённой карточке снимает её с витрины
+	var lockedID uuid.UUID
+	err = tx.QueryRow("SELECT uuid FROM good_cards WHERE uuid = $1 FOR UPDATE", cardID).Scan(&lockedID)
+	if err == sql.ErrNoRows {
+		return false, nil // Карточку удалили вместе с расписанием
+	}
+	if err != nil {
+		return false, myErrors.ErrScheduleInternal
+	}
+
+	changed, err := advanceSchedule(tx, cardID, scheduleID)
+	if err != nil {
+		return false, myErrors.ErrScheduleInternal
+	}
+	if err := tx.Commit(); err != nil {
+		return false, myErrors.ErrScheduleInternal
+	}
+	return changed, nil
+}
+
+// advanceSchedule переводит расписание в статус, соответствующий текущему времени,
+// и записывает событие, если изменилась действующая цена. Карточка должна быть заблокирована.
+func advanceSchedule(tx *sql.Tx, cardID uuid.UUID, scheduleID uuid.UUID) (bool, error) {
+	var status string
+	var started, ended bool
+	err := tx.QueryRow(`
+		SELECT status, starts_at <= now(), ends_at <= now() FROM price_schedules
+		WHERE uuid = $1`, scheduleID).Scan(&status, &started, &ended)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	next := status
+	switch {
+	case (status == models.ScheduleStatusPending || status == models.ScheduleStatusActive) && ended:
+		next = models.ScheduleStatusFinished
+	case status == models.ScheduleStatusPending && started:
+		next = models.ScheduleStatusActive
+	}
+	if next == status {
+		return false, nil
+	}
+
+	if _, err := tx.Exec("UPDATE price_schedules SET status = $1 WHERE uuid = $2", next, scheduleID); err != nil {
+		return false, err
+	}
+	// Расписание, пропущенное целиком (например, пока сервис не работал), цену не меняло
+	if status == models.ScheduleStatusActive || next == models.ScheduleStatusActive {
+		if err := writeCardEvent(tx, cardID, models.EventPriceChanged); err != nil {
+			return false, err
+		}
+	}
+	return true, nil
+}
@@ -0,0 +1,55 @@
+package postgresdb
+
+import (
+	myErrors "goods/internal/errors"
+	"goods/internal/models"
+	"goods/pkg/money"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+)
+
+func TestCreatePriceScheduleRejectsOverlap(t *testing.T) {
+	db, mock := newMockPostgres(t)
+	schedule := models.PriceSchedule{
+		CardID:   uuid.New(),
+		StartsAt: time.Now().Add(time.Hour),
+		EndsAt:   time.Now().Add(2 * time.Hour),
+		Price:    &money.Money{Amount: 900, Currency: "RUB"},
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT currency FROM good_cards")).
+		WithArgs(schedule.CardID).WillReturnRows(sqlmock.NewRows([]string{"currency"}).AddRow("RUB"))
+	mock.ExpectQuery(regexp.QuoteMeta("tstzrange(starts_at, ends_at) && tstzrange($2, $3)")).
+		WithArgs(schedule.CardID, schedule.StartsAt, schedule.EndsAt).
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+	mock.ExpectRollback()
+
+	if _, err := db.CreatePriceSchedule(schedule); err != myErrors.ErrScheduleOverlap {
+		t.Fatalf("err = %v, want ErrScheduleOverlap", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCreatePriceScheduleRejectsForeignCurrency(t *testing.T) {
+	db, mock := newMockPostgres(t)
+	schedule := models.PriceSchedule{CardID: uuid.New(), Price: &money.Money{Amount: 900, Currency: "USD"}}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT currency FROM good_cards")).
+		WithArgs(schedule.CardID).WillReturnRows(sqlmock.NewRows([]string{"currency"}).AddRow("RUB"))
+	mock.ExpectRollback()
+
+	if _, err := db.CreatePriceSchedule(schedule); err != myErrors.ErrCurrencyMismatch {
+		t.Fatalf("err = %v, want ErrCurrencyMismatch", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,247 @@
+package postgresdb
+
+import (
+	"database/sql"
+	myErrors "goods/internal/errors"
+	"goods/internal/models"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Исполнитель фоновых операций в журнале движения
+const systemActor = "system"
+
+// reservationMeta подставляет идентификатор резерва, если запрос не передал свой
+func reservationMeta(meta models.MovementMeta, reservationID uuid.UUID) models.MovementMeta {
+	if meta.CorrelationID == "" {
+		meta.CorrelationID = reservationID.String()
+	}
+	return meta
+}
+
+// ReserveGood резервирует number единиц варианта на одном складе: на warehouseID,
+// если на нём хватает остатка, иначе на первом по приоритету складе с достаточным остатком
+func (db *Postgres) ReserveGood(skuID uuid.UUID, warehouseID uuid.UUID, number int, ttl time.Duration, meta models.MovementMeta) (models.Reservation, error) {
+	tx, err := db.Connection.Begin()
+	if err != nil {
+		return models.Reservation{}, myErrors.ErrReserveGoodInternal
+	}
+	defer tx.Rollback()
+
+	cardID, err := lockSKU(tx, skuID)
+	if err == myErrors.ErrGoodNotFound {
+		return models.Reservation{}, err
+	}
+	if err != nil {
+		return models.Reservation{}, myErrors.ErrReserveGoodInternal
+	}
+	if warehouseID != uuid.Nil {
+		if warehouseID, err = resolveWarehouse(tx, cardID, warehouseID); err == myErrors.ErrWarehouseNotFound {
+			return models.Reservation{}, err
+		}
+		if err != nil {
+			return models.Reservation{}, myErrors.ErrReserveGoodInternal
+		}
+	}
+	warehouseID, err = allocateWarehouse(tx, skuID, warehouseID, number)
+	if err == myErrors.ErrNotEnoughQuantity {
+		return models.Reservation{}, err
+	}
+	if err != nil {
+		return models.Reservation{}, myErrors.ErrReserveGoodInternal
+	}
+
+	// Списание с доступного остатка и перенос в резерв одним условным UPDATE:
+	// строка блокируется, и два параллельных резерва не могут пройти проверку одновременно
+	res, err := tx.Exec(`
+		UPDATE goods SET quantity = quantity - $1, reserved = reserved + $1
+		WHERE sku_id = $2 AND warehouse_id = $3 AND quantity >= $1`, number, skuID, warehouseID)
+	if err != nil {
+		return models.Reservation{}, myErrors.ErrReserveGoodInternal
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return models.Reservation{}, myErrors.ErrNotEnoughQuantity
+	}
+	if err := writeCardEvent(tx, cardID, models.EventStockChanged); err != nil {
+		return models.Reservation{}, myErrors.ErrReserveGoodInternal
+	}
+
+	reservation := models.Reservation{SKUID: skuID, WarehouseID: warehouseID, Quantity: number, Status: models.ReservationActive}
+	err = tx.QueryRow(`
+		INSERT INTO reservations (sku_id, warehouse_id, quantity, status, expires_at)
+		VALUES ($1, $2, $3, $4, now() + $5 * interval '1 millisecond')
+		RETURNING uuid, expires_at, created_at`,
+		skuID, warehouseID, number, models.ReservationActive, ttl.Milliseconds()).Scan(&reservation.UUID, &reservation.ExpiresAt, &reservation.CreatedAt)
+	if err != nil {
+		return models.Reservation{}, myErrors.ErrReserveGoodInternal
+	}
+	if err := recordMovement(tx, skuID, warehouseID, -number, models.MovementReservationHold, reservationMeta(meta, reservation.UUID)); err != nil {
+		return models.Reservation{}, myErrors.ErrReserveGoodInternal
+	}
+
+	if err := tx.Commit(); err != nil {
+		return models.Reservation{}, myErrors.ErrReserveGoodInternal
+	}
+	return reservation, nil
+}
+
+// CommitReservation окончательно списывает зарезервированное количество
+func (db *Postgres) CommitReservation(reservationID uuid.UUID, meta models.MovementMeta) error {
+	return db.closeReservation(reservationID, models.ReservationCommitted, meta)
+}
+
+// ReservationCard возвращает карточку зарезервированного варианта
+func (db *Postgres) ReservationCard(reservationID uuid.UUID) (uuid.UUID, error) {
+	var cardID uuid.UUID
+	err := db.Connection.QueryRow(`
+		SELECT s.card_id FROM reservations r
+		JOIN good_skus s ON s.uuid = r.sku_id
+		WHERE r.uuid = $1`, reservationID).Scan(&cardID)
+	if err == sql.ErrNoRows {
+		return uuid.Nil, myErrors.ErrReservationNotFound
+	}
+	if err != nil {
+		return uuid.Nil, myErrors.ErrReservationInternal
+	}
+	return cardID, nil
+}
+
+// ReleaseReservation возвращает зарезервированное количество в доступный остаток
+func (db *Postgres) ReleaseReservation(reservationID uuid.UUID, meta models.MovementMeta) error {
+	return db.closeReservation(reservationID, models.ReservationReleased, meta)
+}
+
+func (db *Postgres) closeReservation(reservationID uuid.UUID, status string, meta models.MovementMeta) error {
+	tx, err := db.Connection.Begin()
+	if err != nil {
+		return myErrors.ErrReservationInternal
+	}
+	defer tx.Rollback()
+
+	var reservation models.Reservation
+	var warehouseID uuid.NullUUID // У закрытого резерва склад может быть удалён
+	err = tx.QueryRow(`
+		SELECT sku_id, warehouse_id, quantity, status, expires_at FROM reservations
+		WHERE uuid = $1 FOR UPDATE`, reservationID).Scan(&reservation.SKUID, &warehouseID, &reservation.Quantity, &reservation.Status, &reservation.ExpiresAt)
+	if err == sql.ErrNoRows {
+		return myErrors.ErrReservationNotFound
+	}
+	if err != nil {
+		return myErrors.ErrReservationInternal
+	}
+	if reservation.Status != models.ReservationActive {
+		return myErrors.ErrReservationNotActive
+	}
+	// Истёкший резерв нельзя подтвердить, даже если уборщик ещё до него не добрался
+	if status == models.ReservationCommitted && !reservation.ExpiresAt.After(time.Now()) {
+		return myErrors.ErrReservationExpired
+	}
+
+	reservation.UUID = reservationID
+	reservation.WarehouseID = warehouseID.UUID
+	if err := releaseStock(tx, reservation, status != models.ReservationCommitted); err != nil {
+		return err
+	}
+	// Подтверждение не меняет доступный остаток и в журнал не попадает
+	if status == models.ReservationReleased {
+		err := recordMovement(tx, reservation.SKUID, reservation.WarehouseID, reservation.Quantity, models.MovementReservationRelease, reservationMeta(meta, reservationID))
+		if err != nil {
+			return myErrors.ErrReservationInternal
+		}
+	}
+	if _, err := tx.Exec(`UPDATE reservations SET status = $1 WHERE uuid = $2`, status, reservationID); err != nil {
+		return myErrors.ErrReservationInternal
+	}
+
+	if err := tx.Commit(); err != nil {
+		return myErrors.ErrReservationInternal
+	}
+	return nil
+}
+
+// ExpireReservations возвращает в остаток истёкшие резервы, обрабатывая не более limit за вызов.
+// Возвращает количество обработанных резервов.
+func (db *Postgres) ExpireReservations(limit int) (int, error) {
+	tx, err := db.Connection.Begin()
+	if err != nil {
+		return 0, myErrors.ErrReservationInternal
+	}
+	defer tx.Rollback()
+
+	// SKIP LOCKED позволяет нескольким экземплярам сервиса убирать резервы параллельно
+	rows, err := tx.Query(`
+		SELECT uuid, sku_id, warehouse_id, quantity FROM reservations
+		WHERE status = $1 AND expires_at <= now()
+		ORDER BY expires_at
+		LIMIT $2
+		FOR UPDATE SKIP LOCKED`, models.ReservationActive, limit)
+	if err != nil {
+		return 0, myErrors.ErrReservationInternal
+	}
+	var expired []models.Reservation
+	for rows.Next() {
+		var reservation models.Reservation
+		var warehouseID uuid.NullUUID // Как в closeReservation: склад резерва может быть удалён
+		if err := rows.Scan(&reservation.UUID, &reservation.SKUID, &warehouseID, &reservation.Quantity); err != nil {
+			rows.Close()
+			return 0, myErrors.ErrReservationInternal
+		}
+		reservation.WarehouseID = warehouseID.UUID
+		expired = append(expired, reservation)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, myErrors.ErrReservationInternal
+	}
+
+	for _, reservation := range expired {
+		if err := releaseStock(tx, reservation, true); err != nil {
+			return 0, err
+		}
+		meta := models.MovementMeta{Actor: systemActor, CorrelationID: reservation.UUID.String()}
+		if err := recordMovement(tx, reservation.SKUID, reservation.WarehouseID, reservation.Quantity, models.MovementReservationExpire, meta); err != nil {
+			return 0, myErrors.ErrReservationInternal
+		}
+		if _, err := tx.Exec(`UPDATE reservations SET status = $1 WHERE uuid = $2`, models.ReservationExpired, reservation.UUID); err != nil {
+			return 0, myErrors.ErrReservationInternal
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, myErrors.ErrReservationInternal
+	}
+	return len(expired), nil
+}
+
+// releaseStock снимает количество резерва с goods.reserved и,
+// если toAvailable, возвращает его в доступный остаток
+func releaseStock(tx *sql.Tx, reservation models.Reservation, toAvailable bool) error {
+	if !toAvailable {
+		// Доступный остаток не меняется, событие для поиска не нужно
+		query := "UPDATE goods SET reserved = reserved - $1 WHERE sku_id = $2 AND warehouse_id = $3"
+		if _, err := tx.Exec(query, reservation.Quantity, reservation.SKUID, reservation.WarehouseID); err != nil {
+			return myErrors.ErrReservationInternal
+		}
+		return nil
+	}
+
+	// Резерв возвращается в остаток и у удалённой карточки, поэтому lockSKU не подходит
+	var cardID uuid.UUID
+	err := tx.QueryRow(`
+		SELECT gc.uuid FROM good_skus s
+		JOIN good_cards gc ON gc.uuid = s.card_id
+		WHERE s.uuid = $1
+		FOR UPDATE OF gc`, reservation.SKUID).Scan(&cardID)
+	if err != nil {
+		return myErrors.ErrReservationInternal
+	}
+	query := "UPDATE goods SET reserved = reserved - $1, quantity = quantity + $1 WHERE sku_id = $2 AND warehouse_id = $3"
+	if _, err := tx.Exec(query, reservation.Quantity, reservation.SKUID, reservation.WarehouseID); err != nil {
+		return myErrors.ErrReservationInternal
+	}
+	if err := writeCardEvent(tx, cardID, models.EventStockChanged); err != nil {
+		return myErrors.ErrReservationInternal
+	}
+	return nil
+}
@@ -0,0 +1,34 @@
+package postgresdb
+
+import (
+	"errors"
+	myErrors "goods/internal/errors"
+	"goods/internal/models"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+)
+
+func TestExpireReservationsAcceptsMissingWarehouse(t *testing.T) {
+	db, mock := newMockPostgres(t)
+	reservationID, skuID := uuid.New(), uuid.New()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT uuid, sku_id, warehouse_id, quantity FROM reservations")).
+		WithArgs(models.ReservationActive, 10).
+		WillReturnRows(sqlmock.NewRows([]string{"uuid", "sku_id", "warehouse_id", "quantity"}).AddRow(reservationID, skuID, nil, 2))
+	// Строка с пустым складом прочитана, уборщик перешёл к возврату остатка
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT gc.uuid FROM good_skus s")).
+		WithArgs(skuID).
+		WillReturnError(errors.New("stop"))
+	mock.ExpectRollback()
+
+	if _, err := db.ExpireReservations(10); err != myErrors.ErrReservationInternal {
+		t.Fatalf("err = %v, want ErrReservationInternal", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,115 @@
+package postgresdb
+
+import (
+	"database/sql"
+	myErrors "goods/internal/errors"
+	"goods/internal/models"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// RestoreGoodCard снимает с карточки продавца пометку об удалении
+func (db *Postgres) RestoreGoodCard(sellerID uuid.UUID, cardID uuid.UUID) error {
+	tx, err := db.Connection.Begin()
+	if err != nil {
+		return myErrors.ErrUpdateGoodCardInternal
+	}
+	defer tx.Rollback()
+
+	// Чужая карточка неотличима от несуществующей
+	var deleted bool
+	err = tx.QueryRow("SELECT deleted_at IS NOT NULL FROM good_cards WHERE uuid = $1 AND seller_id = $2 FOR UPDATE", cardID, sellerID).Scan(&deleted)
+	if err == sql.ErrNoRows {
+		return myErrors.ErrGoodCardNotFound
+	}
+	if err != nil {
+		return myErrors.ErrUpdateGoodCardInternal
+	}
+	if !deleted {
+		return myErrors.ErrGoodCardNotDeleted
+	}
+
+	if _, err := tx.Exec("UPDATE good_cards SET deleted_at = NULL WHERE uuid = $1", cardID); err != nil {
+		return myErrors.ErrUpdateGoodCardInternal
+	}
+	if err := writeCardEvent(tx, cardID, models.EventCardRestored); err != nil {
+		return myErrors.ErrUpdateGoodCardInternal
+	}
+	if err := tx.Commit(); err != nil {
+		return myErrors.ErrUpdateGoodCardInternal
+	}
+	return nil
+}
+
+// PurgeDeletedCards окончательно удаляет до limit карточек, удалённых раньше чем retention назад.
+// Карточка, на которую ссылаются заказы - активные или подтверждённые резервы её вариантов, -
+// не удаляется. Возвращает число удалённых карточек и их изображения, которые нужно убрать из хранилища.
+func (db *Postgres) PurgeDeletedCards(retention time.Duration, limit int) (int, []models.Media, error) {
+	tx, err := db.Connection.Begin()
+	if err != nil {
+		return 0, nil, myErrors.ErrPurgeInternal
+	}
+	defer tx.Rollback()
+
+	// SKIP LOCKED: карточку, которую в этот момент восстанавливают, пропускаем до следующего прохода
+	rows, err := tx.Query(`
+		SELECT gc.uuid FROM good_cards gc
+		WHERE gc.deleted_at <= now() - $1 * interval '1 millisecond'
+		  AND NOT EXISTS (
+			SELECT 1 FROM reservations r
+			JOIN good_skus s ON s.uuid = r.sku_id
+			WHERE s.card_id = gc.uuid AND r.status IN ($2, $3)
+		  )
+		ORDER BY gc.deleted_at
+		LIMIT $4
+		FOR UPDATE SKIP LOCKED`,
+		retention.Milliseconds(), models.ReservationActive, models.ReservationCommitted, limit)
+	if err != nil {
+		return 0, nil, myErrors.ErrPurgeInternal
+	}
+	var ids []string
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return 0, nil, myErrors.ErrPurgeInternal
+		}
+		ids = append(ids, id.String())
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, nil, myErrors.ErrPurgeInternal
+	}
+	if len(ids) == 0 {
+		return 0, nil, nil
+	}
+
+	var media []models.Media
+	mediaRows, err := tx.Query("SELECT blob_key, thumbnail_key FROM good_card_media WHERE card_id = ANY($1::uuid[])", pq.Array(ids))
+	if err != nil {
+		return 0, nil, myErrors.ErrPurgeInternal
+	}
+	for mediaRows.Next() {
+		var m models.Media
+		if err := mediaRows.Scan(&m.Key, &m.ThumbnailKey); err != nil {
+			mediaRows.Close()
+			return 0, nil, myErrors.ErrPurgeInternal
+		}
+		media = append(media, m)
+	}
+	mediaRows.Close()
+	if err := mediaRows.Err(); err != nil {
+		return 0, nil, myErrors.ErrPurgeInternal
+	}
+
+	// Варианты, остаток, резервы, изображения и ревизии удаляются каскадно; журнал движения остаётся
+	if _, err := tx.Exec("DELETE FROM good_cards WHERE uuid = ANY($1::uuid[])", pq.Array(ids)); err != nil {
+		return 0, nil, myErrors.ErrPurgeInternal
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, nil, myErrors.ErrPurgeInternal
+	}
+	return len(ids), media, nil
+}
@@ -0,0 +1,73 @@
+package postgresdb
+
+import (
+	myErrors "goods/internal/errors"
+	"goods/internal/models"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+)
+
+func TestRestoreGoodCardErrors(t *testing.T) {
+	db, mock := newMockPostgres(t)
+	sellerID, cardID := uuid.New(), uuid.New()
+	lock := regexp.QuoteMeta("SELECT deleted_at IS NOT NULL FROM good_cards")
+
+	// Чужая или несуществующая карточка
+	mock.ExpectBegin()
+	mock.ExpectQuery(lock).WithArgs(cardID, sellerID).WillReturnRows(sqlmock.NewRows([]string{"deleted"}))
+	mock.ExpectRollback()
+	if err := db.RestoreGoodCard(sellerID, cardID); err != myErrors.ErrGoodCardNotFound {
+		t.Fatalf("err = %v, want ErrGoodCardNotFound", err)
+	}
+
+	// Карточка не удалена
+	mock.ExpectBegin()
+	mock.ExpectQuery(lock).WithArgs(cardID, sellerID).WillReturnRows(sqlmock.NewRows([]string{"deleted"}).AddRow(false))
+	mock.ExpectRollback()
+	if err := db.RestoreGoodCard(sellerID, cardID); err != myErrors.ErrGoodCardNotDeleted {
+		t.Fatalf("err = %v, want ErrGoodCardNotDeleted", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestPurgeWithNothingExpired(t *testing.T) {
+	db, mock := newMockPostgres(t)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("FOR UPDATE SKIP LOCKED")).
+		WithArgs(int64(3600000), models.ReservationActive, models.ReservationCommitted, 100).
+		WillReturnRows(sqlmock.NewRows([]string{"uuid"}))
+	mock.ExpectRollback()
+
+	n, media, err := db.PurgeDeletedCards(time.Hour, 100)
+	if err != nil || n != 0 || media != nil {
+		t.Fatalf("PurgeDeletedCards = %d, %v, %v, want nothing purged", n, media, err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestAddMediaToDeletedCard(t *testing.T) {
+	db, mock := newMockPostgres(t)
+	cardID := uuid.New()
+
+	// Карточка в корзине не блокируется и неотличима от несуществующей
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT uuid FROM good_cards WHERE uuid = $1 AND deleted_at IS NULL FOR UPDATE")).
+		WithArgs(cardID).WillReturnRows(sqlmock.NewRows([]string{"uuid"}))
+	mock.ExpectRollback()
+
+	if _, err := db.AddMedia(models.Media{CardID: cardID}); err != myErrors.ErrGoodCardNotFound {
+		t.Fatalf("err = %v, want ErrGoodCardNotFound", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
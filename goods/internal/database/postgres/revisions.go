@@ -0,0 +1,71 @@
+package postgresdb
+
+import (
+	"database/sql"
+	myErrors "goods/internal/errors"
+	"goods/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// recordRevision сохраняет текущее состояние карточки как ревизию её текущей версии
+func recordRevision(tx *sql.Tx, cardID uuid.UUID) error {
+	_, err := tx.Exec(`
+		INSERT INTO good_card_revisions (card_id, version, price, currency, name, description, weight, is_active)
+		SELECT uuid, version, price, currency, name, description, weight, is_active FROM good_cards WHERE uuid = $1`, cardID)
+	return err
+}
+
+func (db *Postgres) ListRevisions(cardID uuid.UUID) ([]models.GoodCardRevision, error) {
+	rows, err := db.Connection.Query(`
+		SELECT card_id, version, price, currency, name, description, weight, is_active, created_at
+		FROM good_card_revisions
+		WHERE card_id = $1
+		ORDER BY version DESC`, cardID)
+	if err != nil {
+		return nil, myErrors.ErrRevisionInternal
+	}
+	defer rows.Close()
+
+	revisions := make([]models.GoodCardRevision, 0)
+	for rows.Next() {
+		var r models.GoodCardRevision
+		if err := rows.Scan(&r.CardID, &r.Version, &r.Price.Amount, &r.Price.Currency, &r.Name, &r.Description, &r.Weight, &r.IsActive, &r.CreatedAt); err != nil {
+			return nil, myErrors.ErrRevisionInternal
+		}
+		revisions = append(revisions, r)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, myErrors.ErrRevisionInternal
+	}
+	return revisions, nil
+}
+
+// RestoreRevision записывает состояние из ревизии version как новую версию карточки
+func (db *Postgres) RestoreRevision(cardID uuid.UUID, version int, expectedVersion int) (int, error) {
+	tx, err := db.Connection.Begin()
+	if err != nil {
+		return 0, myErrors.ErrUpdateGoodCardInternal
+	}
+	defer tx.Rollback()
+
+	var card models.GoodCard
+	err = tx.QueryRow(`
+		SELECT price, currency, name, description, weight, is_active FROM good_card_revisions
+		WHERE card_id = $1 AND version = $2`, cardID, version).Scan(&card.Price.Amount, &card.Price.Currency, &card.Name, &card.Description, &card.Weight, &card.IsActive)
+	if err == sql.ErrNoRows {
+		return 0, myErrors.ErrRevisionNotFound
+	}
+	if err != nil {
+		return 0, myErrors.ErrRevisionInternal
+	}
+
+	newVersion, err := updateGoodCard(tx, cardID, card, expectedVersion)
+	if err != nil {
+		return 0, err
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, myErrors.ErrUpdateGoodCardInternal
+	}
+	return newVersion, nil
+}
@@ -0,0 +1,75 @@
+package postgresdb
+
+import (
+	"errors"
+	myErrors "goods/internal/errors"
+	"goods/internal/models"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+)
+
+func TestPatchGoodCardRejectsStaleVersion(t *testing.T) {
+	db, mock := newMockPostgres(t)
+	cardID := uuid.New()
+	name := "Чайник"
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT version, variant_axes, moderation_status, is_active, currency FROM good_cards")).
+		WithArgs(cardID).
+		WillReturnRows(sqlmock.NewRows([]string{"version", "variant_axes", "moderation_status", "is_active", "currency"}).
+			AddRow(4, "{}", models.ModerationApproved, true, "RUB"))
+	// Устаревшая версия: ни обновления, ни ревизии, транзакция откатывается
+	mock.ExpectRollback()
+
+	if _, err := db.PatchGoodCard(cardID, models.GoodCardPatch{Name: &name}, 3); err != myErrors.ErrVersionMismatch {
+		t.Fatalf("err = %v, want ErrVersionMismatch", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestUpdateGoodCardWithoutPriceKeepsPrice(t *testing.T) {
+	db, mock := newMockPostgres(t)
+	cardID := uuid.New()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT version, variant_axes, moderation_status, is_active, currency FROM good_cards")).
+		WithArgs(cardID).
+		WillReturnRows(sqlmock.NewRows([]string{"version", "variant_axes", "moderation_status", "is_active", "currency"}).
+			AddRow(4, "{}", models.ModerationApproved, true, "RUB"))
+	// Цена не передана: валюта не сверяется, price не попадает в UPDATE
+	mock.ExpectQuery(regexp.QuoteMeta("UPDATE good_cards SET name = $1, weight = $2, is_active = $3, version = version + 1 WHERE uuid = $4")).
+		WithArgs("Чайник", 0.0, true, cardID).
+		WillReturnError(errors.New("stop"))
+	mock.ExpectRollback()
+
+	_, err := db.UpdateGoodCard(cardID, models.GoodCard{Name: "Чайник", IsActive: true}, 0)
+	if err != myErrors.ErrUpdateGoodCardInternal {
+		t.Fatalf("err = %v, want ErrUpdateGoodCardInternal", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRestoreMissingRevision(t *testing.T) {
+	db, mock := newMockPostgres(t)
+	cardID := uuid.New()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("FROM good_card_revisions")).
+		WithArgs(cardID, 2).
+		WillReturnRows(sqlmock.NewRows([]string{"price", "currency", "name", "description", "weight", "is_active"}))
+	mock.ExpectRollback()
+
+	if _, err := db.RestoreRevision(cardID, 2, 0); err != myErrors.ErrRevisionNotFound {
+		t.Fatalf("err = %v, want ErrRevisionNotFound", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,396 @@
+package postgresdb
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	myErrors "goods/internal/errors"
+	"goods/internal/models"
+	"goods/pkg/money"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// Коды ошибок PostgreSQL при нарушении ограничений
+const (
+	uniqueViolation     = "23505"
+	foreignKeyViolation = "23503"
+)
+
+// pgError возвращает ошибку PostgreSQL с кодом code или nil
+func pgError(err error, code string) *pq.Error {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && string(pqErr.Code) == code {
+		return pqErr
+	}
+	return nil
+}
+
+// queryer - общее для *sql.DB и *sql.Tx чтение строк
+type queryer interface {
+	Query(query string, args ...interface{}) (*sql.Rows, error)
+}
+
+// lockSKU блокирует карточку варианта и возвращает её UUID.
+// Все изменения остатка сначала блокируют карточку, затем строку goods:
+// единый порядок блокировок исключает взаимоблокировки с операциями над карточкой
+// и упорядочивает события outbox по карточке.
+func lockSKU(tx *sql.Tx, skuID uuid.UUID) (uuid.UUID, error) {
+	var cardID uuid.UUID
+	err := tx.QueryRow(`
+		SELECT gc.uuid FROM good_skus s
+		JOIN good_cards gc ON gc.uuid = s.card_id
+		WHERE s.uuid = $1 AND gc.deleted_at IS NULL
+		FOR UPDATE OF gc`, skuID).Scan(&cardID)
+	if err == sql.ErrNoRows {
+		return uuid.Nil, myErrors.ErrGoodNotFound
+	}
+	if err != nil {
+		return uuid.Nil, err
+	}
+	return cardID, nil
+}
+
+// insertSKU создаёт вариант карточки с начальным остатком quantity на складе продавца по умолчанию.
+// Если sku.UUID не задан, вариант карточки без осей получает UUID карточки,
+// а вариант карточки с осями - новый UUID.
+func insertSKU(tx *sql.Tx, cardID uuid.UUID, sku models.SKU, quantity int, reason string, meta models.MovementMeta) (uuid.UUID, error) {
+	var axes []string
+	var currency string
+	err := tx.QueryRow("SELECT variant_axes, currency FROM good_cards WHERE uuid = $1 AND deleted_at IS NULL FOR UPDATE", cardID).
+		Scan(pq.Array(&axes), &currency)
+	if err == sql.ErrNoRows {
+		return uuid.Nil, myErrors.ErrGoodCardNotFound
+	}
+	if err != nil {
+		return uuid.Nil, myErrors.ErrSKUInternal
+	}
+	if !matchesAxes(sku.Attributes, axes) {
+		return uuid.Nil, myErrors.ErrSKUAttributesMismatch
+	}
+	price, err := priceAmount(sku.Price, currency)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	id := sku.UUID
+	if id == uuid.Nil && len(axes) == 0 {
+		id = cardID
+	} else if id == uuid.Nil {
+		id = uuid.New()
+	}
+	attributes := sku.Attributes
+	if attributes == nil {
+		attributes = map[string]string{}
+	}
+	rawAttributes, err := json.Marshal(attributes)
+	if err != nil {
+		return uuid.Nil, myErrors.ErrSKUInternal
+	}
+
+	_, err = tx.Exec(`INSERT INTO good_skus (uuid, card_id, attributes, price, weight) VALUES ($1, $2, $3, $4, $5)`,
+		id, cardID, rawAttributes, price, sku.Weight)
+	if pqErr := pgError(err, uniqueViolation); pqErr != nil {
+		// Повторное создание варианта по умолчанию - это повторное создание товара
+		if pqErr.Constraint == "good_skus_pkey" {
+			return uuid.Nil, myErrors.ErrGoodAlreadyExists
+		}
+		return uuid.Nil, myErrors.ErrSKUAlreadyExists
+	}
+	if err != nil {
+		return uuid.Nil, myErrors.ErrSKUInternal
+	}
+
+	warehouseID, err := resolveWarehouse(tx, cardID, uuid.Nil)
+	if err != nil {
+		return uuid.Nil, myErrors.ErrSKUInternal
+	}
+	_, err = tx.Exec("INSERT INTO goods (card_id, sku_id, warehouse_id, quantity) VALUES ($1, $2, $3, $4)", cardID, id, warehouseID, quantity)
+	if err != nil {
+		return uuid.Nil, myErrors.ErrSKUInternal
+	}
+	if err := recordMovement(tx, id, warehouseID, quantity, reason, meta); err != nil {
+		return uuid.Nil, myErrors.ErrSKUInternal
+	}
+	if err := writeCardEvent(tx, cardID, models.EventStockChanged); err != nil {
+		return uuid.Nil, myErrors.ErrSKUInternal
+	}
+	return id, nil
+}
+
+// matchesAxes проверяет, что атрибуты задают значение ровно для каждой оси карточки
+func matchesAxes(attributes map[string]string, axes []string) bool {
+	if len(attributes) != len(axes) {
+		return false
+	}
+	for _, axis := range axes {
+		if attributes[axis] == "" {
+			return false
+		}
+	}
+	return true
+}
+
+func (db *Postgres) CreateSKU(cardID uuid.UUID, sku models.SKU, quantity int, meta models.MovementMeta) (uuid.UUID, error) {
+	tx, err := db.Connection.Begin()
+	if err != nil {
+		return uuid.Nil, myErrors.ErrSKUInternal
+	}
+	defer tx.Rollback()
+
+	id, err := insertSKU(tx, cardID, sku, quantity, models.MovementInitial, meta)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return uuid.Nil, myErrors.ErrSKUInternal
+	}
+	return id, nil
+}
+
+// UpdateSKU задаёт цену и вес варианта; nil возвращает значение карточки
+func (db *Postgres) UpdateSKU(skuID uuid.UUID, price *money.Money, weight *float64) error {
+	tx, err := db.Connection.Begin()
+	if err != nil {
+		return myErrors.ErrSKUInternal
+	}
+	defer tx.Rollback()
+
+	cardID, err := lockSKU(tx, skuID)
+	if err != nil {
+		if err == myErrors.ErrGoodNotFound {
+			return err
+		}
+		return myErrors.ErrSKUInternal
+	}
+	var currency string
+	if err := tx.QueryRow("SELECT currency FROM good_cards WHERE uuid = $1", cardID).Scan(&currency); err != nil {
+		return myErrors.ErrSKUInternal
+	}
+	amount, err := priceAmount(price, currency)
+	if err != nil {
+		return err
+	}
+	if _, err := tx.Exec("UPDATE good_skus SET price = $1, weight = $2 WHERE uuid = $3", amount, weight, skuID); err != nil {
+		return myErrors.ErrSKUInternal
+	}
+	// Диапазон цен карточки мог измениться
+	if err := writeCardEvent(tx, cardID, models.EventCardUpdated); err != nil {
+		return myErrors.ErrSKUInternal
+	}
+	if err := tx.Commit(); err != nil {
+		return myErrors.ErrSKUInternal
+	}
+	return nil
+}
+
+// SKUOwner возвращает продавца карточки, к которой относится вариант
+func (db *Postgres) SKUOwner(skuID uuid.UUID) (uuid.UUID, error) {
+	var sellerID uuid.UUID
+	err := db.Connection.QueryRow(`
+		SELECT gc.seller_id FROM good_skus s
+		JOIN good_cards gc ON gc.uuid = s.card_id
+		WHERE s.uuid = $1 AND gc.deleted_at IS NULL`, skuID).Scan(&sellerID)
+	if err == sql.ErrNoRows {
+		return uuid.Nil, myErrors.ErrGoodNotFound
+	}
+	if err != nil {
+		return uuid.Nil, myErrors.ErrReadCardInternal
+	}
+	return sellerID, nil
+}
+
+// SKUCards возвращает карточки вариантов skuIDs без повторов, включая удалённые карточки
+func (db *Postgres) SKUCards(skuIDs []uuid.UUID) ([]uuid.UUID, error) {
+	params := make([]string, 0, len(skuIDs))
+	for _, id := range skuIDs {
+		params = append(params, id.String())
+	}
+	rows, err := db.Connection.Query("SELECT DISTINCT card_id FROM good_skus WHERE uuid = ANY($1::uuid[])", pq.Array(params))
+	if err != nil {
+		return nil, myErrors.ErrReadCardInternal
+	}
+	defer rows.Close()
+
+	var cardIDs []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, myErrors.ErrReadCardInternal
+		}
+		cardIDs = append(cardIDs, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, myErrors.ErrReadCardInternal
+	}
+	return cardIDs, nil
+}
+
+// ReadGoodCard возвращает карточку со всеми вариантами и суммарным остатком
+func (db *Postgres) ReadGoodCard(cardID uuid.UUID) (models.Good, error) {
+	goods, err := db.ReadGoodCards([]uuid.UUID{cardID})
+	if err != nil {
+		return models.Good{}, err
+	}
+	if len(goods) == 0 {
+		return models.Good{}, myErrors.ErrGoodCardNotFound
+	}
+	return goods[0], nil
+}
+
+// ReadGoodCards возвращает карточки с вариантами в порядке ids; не найденные и удалённые пропускаются
+func (db *Postgres) ReadGoodCards(ids []uuid.UUID) ([]models.Good, error) {
+	params := make([]string, 0, len(ids))
+	for _, id := range ids {
+		params = append(params, id.String())
+	}
+	rows, err := db.Connection.Query(`
+		SELECT gc.uuid, gc.price, gc.currency, gc.name, gc.description, gc.weight, gc.seller_id, gc.is_active, gc.version, gc.variant_axes,
+		       gc.category_id, gc.brand_id, gc.low_stock_threshold, gc.moderation_status, COALESCE(gc.moderation_reason, ''), gc.attributes,
+		       `+effectivePriceExpr+`
+		FROM good_cards gc
+		`+effectivePriceJoin+`
+		WHERE gc.uuid = ANY($1::uuid[]) AND gc.deleted_at IS NULL`, pq.Array(params))
+	if err != nil {
+		return nil, myErrors.ErrReadCardInternal
+	}
+	defer rows.Close()
+
+	found := make(map[uuid.UUID]models.Good, len(ids))
+	rawAttributes := make(map[uuid.UUID][]byte, len(ids))
+	for rows.Next() {
+		var good models.Good
+		var raw []byte
+		err := rows.Scan(&good.Card.UUID, &good.Card.Price.Amount, &good.Card.Price.Currency, &good.Card.Name, &good.Card.Description,
+			&good.Card.Weight, &good.Card.SellerID, &good.Card.IsActive, &good.Card.Version, pq.Array(&good.Card.VariantAxes),
+			&good.Card.CategoryID, &good.Card.BrandID, &good.Card.LowStockThreshold, &good.Card.ModerationStatus, &good.Card.ModerationReason,
+			&raw, &good.Card.EffectivePrice.Amount)
+		if err != nil {
+			return nil, myErrors.ErrReadCardInternal
+		}
+		good.Card.EffectivePrice.Currency = good.Card.Price.Currency
+		good.UUID = good.Card.UUID
+		found[good.UUID] = good
+		rawAttributes[good.UUID] = raw
+	}
+	if err := rows.Err(); err != nil {
+		return nil, myErrors.ErrReadCardInternal
+	}
+
+	cardIDs := make([]uuid.UUID, 0, len(found))
+	for id := range found {
+		cardIDs = append(cardIDs, id)
+	}
+	skus, err := loadSKUs(db.Connection, cardIDs)
+	if err != nil {
+		return nil, myErrors.ErrReadCardInternal
+	}
+
+	goods := make([]models.Good, 0, len(found))
+	for _, id := range ids {
+		good, ok := found[id]
+		if !ok {
+			continue
+		}
+		// Повторный идентификатор в запросе не дублирует карточку в ответе
+		delete(found, id)
+		good.SKUs = skus[id]
+		for _, sku := range good.SKUs {
+			good.Quantity += sku.Quantity
+			good.Reserved += sku.Reserved
+		}
+		goods = append(goods, good)
+	}
+	if err := fillAttributes(db.Connection, goods, rawAttributes); err != nil {
+		return nil, myErrors.ErrReadCardInternal
+	}
+	return goods, nil
+}
+
+// loadSKUs загружает варианты карточек с остатком по складам, сгруппированные по карточке.
+// Для карточки без вариантов в результате пустой срез, а не nil.
+func loadSKUs(q queryer, cardIDs []uuid.UUID) (map[uuid.UUID][]models.SKU, error) {
+	result := make(map[uuid.UUID][]models.SKU, len(cardIDs))
+	for _, id := range cardIDs {
+		result[id] = []models.SKU{}
+	}
+	if len(cardIDs) == 0 {
+		return result, nil
+	}
+
+	ids := make([]string, 0, len(cardIDs))
+	for _, id := range cardIDs {
+		ids = append(ids, id.String())
+	}
+	stock, err := loadStock(q, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := q.Query(`
+		SELECT s.uuid, s.card_id, s.attributes, s.price, gc.currency, s.weight
+		FROM good_skus s
+		JOIN good_cards gc ON gc.uuid = s.card_id
+		WHERE s.card_id = ANY($1::uuid[])
+		ORDER BY s.card_id, s.created_at, s.uuid`, pq.Array(ids))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var sku models.SKU
+		var rawAttributes []byte
+		var price sql.NullInt64
+		var currency string
+		var weight sql.NullFloat64
+		if err := rows.Scan(&sku.UUID, &sku.CardID, &rawAttributes, &price, &currency, &weight); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(rawAttributes, &sku.Attributes); err != nil {
+			return nil, err
+		}
+		if price.Valid {
+			sku.Price = &money.Money{Amount: price.Int64, Currency: currency}
+		}
+		if weight.Valid {
+			sku.Weight = &weight.Float64
+		}
+		sku.Stock = stock[sku.UUID]
+		if sku.Stock == nil {
+			sku.Stock = []models.WarehouseStock{}
+		}
+		for _, ws := range sku.Stock {
+			sku.Quantity += ws.Quantity
+			sku.Reserved += ws.Reserved
+		}
+		result[sku.CardID] = append(result[sku.CardID], sku)
+	}
+	return result, rows.Err()
+}
+
+// loadStock загружает остаток вариантов карточек cardIDs по складам в порядке выбора складов
+func loadStock(q queryer, cardIDs []string) (map[uuid.UUID][]models.WarehouseStock, error) {
+	rows, err := q.Query(`
+		SELECT g.sku_id, g.warehouse_id, g.quantity, g.reserved
+		FROM goods g
+		JOIN warehouses w ON w.uuid = g.warehouse_id
+		WHERE g.card_id = ANY($1::uuid[])
+		ORDER BY w.priority, w.created_at, w.uuid`, pq.Array(cardIDs))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	stock := make(map[uuid.UUID][]models.WarehouseStock)
+	for rows.Next() {
+		var skuID uuid.UUID
+		var ws models.WarehouseStock
+		if err := rows.Scan(&skuID, &ws.WarehouseID, &ws.Quantity, &ws.Reserved); err != nil {
+			return nil, err
+		}
+		stock[skuID] = append(stock[skuID], ws)
+	}
+	return stock, rows.Err()
+}
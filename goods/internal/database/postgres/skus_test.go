@@ -0,0 +1,75 @@
+package postgresdb
+
+import (
+	myErrors "goods/internal/errors"
+	"goods/internal/models"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+)
+
+func TestMatchesAxes(t *testing.T) {
+	axes := []string{"size", "colour"}
+	cases := []struct {
+		attributes map[string]string
+		want       bool
+	}{
+		{map[string]string{"size": "M", "colour": "red"}, true},
+		{map[string]string{"size": "M"}, false},
+		{map[string]string{"size": "M", "colour": ""}, false},
+		{map[string]string{"size": "M", "material": "cotton"}, false},
+		{map[string]string{"size": "M", "colour": "red", "material": "cotton"}, false},
+	}
+	for _, c := range cases {
+		if got := matchesAxes(c.attributes, axes); got != c.want {
+			t.Errorf("matchesAxes(%v) = %v, want %v", c.attributes, got, c.want)
+		}
+	}
+	if !matchesAxes(nil, nil) {
+		t.Error("card without axes must match a SKU without attributes")
+	}
+}
+
+func TestReadGoodMatchesListingShape(t *testing.T) {
+	db, mock := newMockPostgres(t)
+	cardID, warehouseID := uuid.New(), uuid.New()
+
+	mock.ExpectQuery(regexp.QuoteMeta("FROM good_cards gc")).
+		WillReturnRows(sqlmock.NewRows([]string{"uuid", "price", "currency", "name", "description", "weight", "seller_id", "is_active", "version",
+			"variant_axes", "category_id", "brand_id", "low_stock_threshold", "moderation_status", "moderation_reason", "attributes", "effective_price"}).
+			AddRow(cardID, 1000, "RUB", "Чайник", "", 1.5, uuid.New(), true, 2, "{}", nil, nil, nil, models.ModerationApproved, "", []byte("{}"), 900))
+	mock.ExpectQuery(regexp.QuoteMeta("FROM goods g")).
+		WillReturnRows(sqlmock.NewRows([]string{"sku_id", "warehouse_id", "quantity", "reserved"}).AddRow(cardID, warehouseID, 7, 2))
+	mock.ExpectQuery(regexp.QuoteMeta("FROM good_skus s")).
+		WillReturnRows(sqlmock.NewRows([]string{"uuid", "card_id", "attributes", "price", "currency", "weight"}).
+			AddRow(cardID, cardID, []byte("{}"), nil, "RUB", nil))
+
+	good, err := db.ReadGood(cardID)
+	if err != nil {
+		t.Fatalf("ReadGood: %v", err)
+	}
+	if good.Card.Price.Amount != 1000 || good.Card.Price.Currency != "RUB" || good.Card.EffectivePrice.Amount != 900 {
+		t.Errorf("price = %+v, effective = %+v", good.Card.Price, good.Card.EffectivePrice)
+	}
+	if good.Quantity != 7 || good.Reserved != 2 || len(good.SKUs) != 1 || len(good.SKUs[0].Stock) != 1 || good.SKUs[0].Stock[0].WarehouseID != warehouseID {
+		t.Errorf("good = %+v", good)
+	}
+	if good.Card.Attributes == nil {
+		t.Error("attributes are not filled")
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestReadGoodNotFound(t *testing.T) {
+	db, mock := newMockPostgres(t)
+	mock.ExpectQuery(regexp.QuoteMeta("FROM good_cards gc")).
+		WillReturnRows(sqlmock.NewRows([]string{"uuid"}))
+
+	if _, err := db.ReadGood(uuid.New()); err != myErrors.ErrGoodNotFound {
+		t.Fatalf("err = %v, want ErrGoodNotFound", err)
+	}
+}
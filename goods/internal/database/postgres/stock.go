@@ -0,0 +1,189 @@
+package postgresdb
+
+import (
+	"database/sql"
+	"errors"
+	myErrors "goods/internal/errors"
+	"goods/internal/models"
+	"sort"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// AdjustStock применяет операции пакета в одной транзакции: либо все, либо ни одной.
+// Ошибка конкретной операции возвращается как myErrors.ItemError с её номером.
+// Карточки блокируются заранее в порядке uuid, поэтому пакеты с пересекающимися
+// товарами в разном порядке не взаимоблокируются.
+func (db *Postgres) AdjustStock(sellerID uuid.UUID, items []models.StockAdjustment, meta models.MovementMeta) ([]models.StockAdjustmentResult, error) {
+	tx, err := db.Connection.Begin()
+	if err != nil {
+		return nil, myErrors.ErrStockBatchInternal
+	}
+	defer tx.Rollback()
+
+	cards, err := lockStockCards(tx, sellerID, items)
+	if err != nil {
+		var itemErr myErrors.ItemError
+		if errors.As(err, &itemErr) {
+			return nil, err
+		}
+		return nil, myErrors.ErrStockBatchInternal
+	}
+
+	// Сколько списано с каждой карточки за пакет - для оповещений о низком остатке
+	removed := make(map[uuid.UUID]int)
+	for i, item := range items {
+		cardID := cards[item.GoodID]
+		if item.Delta > 0 {
+			err = restockItem(tx, cardID, item, models.MovementRestock, meta)
+		} else {
+			err = writeOffItem(tx, cardID, item, models.MovementWriteOff, meta)
+		}
+		if err == myErrors.ErrWarehouseNotFound || err == myErrors.ErrNotEnoughQuantity {
+			return nil, myErrors.ItemError{Index: i, Err: err}
+		}
+		if err != nil {
+			return nil, myErrors.ErrStockBatchInternal
+		}
+		removed[cardID] -= item.Delta
+	}
+
+	// События и оповещения пишутся в порядке uuid карточек, чтобы результат не зависел от обхода map
+	cardIDs := make([]uuid.UUID, 0, len(removed))
+	for cardID := range removed {
+		cardIDs = append(cardIDs, cardID)
+	}
+	sort.Slice(cardIDs, func(i, j int) bool { return cardIDs[i].String() < cardIDs[j].String() })
+	for _, cardID := range cardIDs {
+		if removed[cardID] > 0 {
+			if err := enqueueStockAlert(tx, cardID, removed[cardID]); err != nil {
+				return nil, myErrors.ErrStockBatchInternal
+			}
+		}
+		if err := writeCardEvent(tx, cardID, models.EventStockChanged); err != nil {
+			return nil, myErrors.ErrStockBatchInternal
+		}
+	}
+
+	// Количество читается после всех операций, чтобы повторы одного товара в пакете видели итог
+	results := make([]models.StockAdjustmentResult, 0, len(items))
+	for _, item := range items {
+		quantity, err := skuQuantity(tx, item.GoodID)
+		if err != nil {
+			return nil, myErrors.ErrStockBatchInternal
+		}
+		results = append(results, models.StockAdjustmentResult{GoodID: item.GoodID, NewCount: quantity})
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, myErrors.ErrStockBatchInternal
+	}
+	return results, nil
+}
+
+// lockStockCards блокирует карточки вариантов пакета в порядке uuid и возвращает карточку каждого варианта.
+// Чужой, удалённый или несуществующий вариант - ItemError с ErrGoodNotFound для первой такой операции.
+func lockStockCards(tx *sql.Tx, sellerID uuid.UUID, items []models.StockAdjustment) (map[uuid.UUID]uuid.UUID, error) {
+	skuIDs := make([]string, 0, len(items))
+	for _, item := range items {
+		skuIDs = append(skuIDs, item.GoodID.String())
+	}
+
+	// Карточка варианта не меняется, поэтому её можно узнать до блокировки
+	rows, err := tx.Query("SELECT uuid, card_id FROM good_skus WHERE uuid = ANY($1::uuid[])", pq.Array(skuIDs))
+	if err != nil {
+		return nil, err
+	}
+	cards := make(map[uuid.UUID]uuid.UUID)
+	for rows.Next() {
+		var skuID, cardID uuid.UUID
+		if err := rows.Scan(&skuID, &cardID); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		cards[skuID] = cardID
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	cardIDs := make([]string, 0, len(cards))
+	seen := make(map[uuid.UUID]bool)
+	for _, cardID := range cards {
+		if !seen[cardID] {
+			seen[cardID] = true
+			cardIDs = append(cardIDs, cardID.String())
+		}
+	}
+	rows, err = tx.Query(`
+		SELECT uuid FROM good_cards
+		WHERE uuid = ANY($1::uuid[]) AND seller_id = $2 AND deleted_at IS NULL
+		ORDER BY uuid FOR UPDATE`, pq.Array(cardIDs), sellerID)
+	if err != nil {
+		return nil, err
+	}
+	locked := make(map[uuid.UUID]bool)
+	for rows.Next() {
+		var cardID uuid.UUID
+		if err := rows.Scan(&cardID); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		locked[cardID] = true
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for i, item := range items {
+		cardID, ok := cards[item.GoodID]
+		if !ok || !locked[cardID] {
+			return nil, myErrors.ItemError{Index: i, Err: myErrors.ErrGoodNotFound}
+		}
+	}
+	return cards, nil
+}
+
+// restockItem пополняет остаток так же, как AddCountGood, без собственной транзакции.
+// reason - код причины движения для журнала.
+func restockItem(tx *sql.Tx, cardID uuid.UUID, item models.StockAdjustment, reason string, meta models.MovementMeta) error {
+	warehouseID, err := resolveWarehouse(tx, cardID, item.WarehouseID)
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec(`
+		INSERT INTO goods (card_id, sku_id, warehouse_id, quantity) VALUES ($1, $2, $3, $4)
+		ON CONFLICT (sku_id, warehouse_id) DO UPDATE SET quantity = goods.quantity + EXCLUDED.quantity`,
+		cardID, item.GoodID, warehouseID, item.Delta)
+	if err != nil {
+		return err
+	}
+	return recordMovement(tx, item.GoodID, warehouseID, item.Delta, reason, meta)
+}
+
+// writeOffItem списывает остаток так же, как DeleteCountGood, без собственной транзакции
+func writeOffItem(tx *sql.Tx, cardID uuid.UUID, item models.StockAdjustment, reason string, meta models.MovementMeta) error {
+	number := -item.Delta
+	warehouseID := item.WarehouseID
+	var err error
+	if warehouseID != uuid.Nil {
+		warehouseID, err = resolveWarehouse(tx, cardID, warehouseID)
+	} else {
+		warehouseID, err = allocateWarehouse(tx, item.GoodID, uuid.Nil, number)
+	}
+	if err != nil {
+		return err
+	}
+
+	res, err := tx.Exec("UPDATE goods SET quantity = quantity - $1 WHERE sku_id = $2 AND warehouse_id = $3 AND quantity >= $1",
+		number, item.GoodID, warehouseID)
+	if err != nil {
+		return err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return myErrors.ErrNotEnoughQuantity
+	}
+	return recordMovement(tx, item.GoodID, warehouseID, item.Delta, reason, meta)
+}
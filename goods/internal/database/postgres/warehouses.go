@@ -0,0 +1,166 @@
+package postgresdb
+
+import (
+	"database/sql"
+	myErrors "goods/internal/errors"
+	"goods/internal/models"
+
+	"github.com/google/uuid"
+)
+
+func (db *Postgres) CreateWarehouse(warehouse models.Warehouse) (uuid.UUID, error) {
+	var id uuid.UUID
+	err := db.Connection.QueryRow("INSERT INTO warehouses (seller_id, name, priority) VALUES ($1, $2, $3) RETURNING uuid",
+		warehouse.SellerID, warehouse.Name, warehouse.Priority).Scan(&id)
+	if pgError(err, uniqueViolation) != nil {
+		return uuid.Nil, myErrors.ErrWarehouseAlreadyExists
+	}
+	if err != nil {
+		return uuid.Nil, myErrors.ErrWarehouseInternal
+	}
+	return id, nil
+}
+
+// ListWarehouses возвращает склады продавца в порядке выбора для списания
+func (db *Postgres) ListWarehouses(sellerID uuid.UUID) ([]models.Warehouse, error) {
+	rows, err := db.Connection.Query(`
+		SELECT uuid, seller_id, name, priority, created_at FROM warehouses
+		WHERE seller_id = $1
+		ORDER BY priority, created_at, uuid`, sellerID)
+	if err != nil {
+		return nil, myErrors.ErrWarehouseInternal
+	}
+	defer rows.Close()
+
+	warehouses := make([]models.Warehouse, 0)
+	for rows.Next() {
+		var w models.Warehouse
+		if err := rows.Scan(&w.UUID, &w.SellerID, &w.Name, &w.Priority, &w.CreatedAt); err != nil {
+			return nil, myErrors.ErrWarehouseInternal
+		}
+		warehouses = append(warehouses, w)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, myErrors.ErrWarehouseInternal
+	}
+	return warehouses, nil
+}
+
+// UpdateWarehouse меняет имя и приоритет склада продавца
+func (db *Postgres) UpdateWarehouse(warehouse models.Warehouse) error {
+	res, err := db.Connection.Exec("UPDATE warehouses SET name = $1, priority = $2 WHERE uuid = $3 AND seller_id = $4",
+		warehouse.Name, warehouse.Priority, warehouse.UUID, warehouse.SellerID)
+	if pgError(err, uniqueViolation) != nil {
+		return myErrors.ErrWarehouseAlreadyExists
+	}
+	if err != nil {
+		return myErrors.ErrWarehouseInternal
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return myErrors.ErrWarehouseNotFound
+	}
+	return nil
+}
+
+// DeleteWarehouse удаляет склад продавца, на котором не осталось ни остатка, ни резервов.
+// Пустые строки остатка удаляются вместе со складом, журнал движения сохраняется.
+func (db *Postgres) DeleteWarehouse(sellerID uuid.UUID, id uuid.UUID) error {
+	tx, err := db.Connection.Begin()
+	if err != nil {
+		return myErrors.ErrWarehouseInternal
+	}
+	defer tx.Rollback()
+
+	// Блокировка склада не даёт параллельному пополнению завести на нём новую строку остатка
+	var lockedID uuid.UUID
+	err = tx.QueryRow("SELECT uuid FROM warehouses WHERE uuid = $1 AND seller_id = $2 FOR UPDATE", id, sellerID).Scan(&lockedID)
+	if err == sql.ErrNoRows {
+		return myErrors.ErrWarehouseNotFound
+	}
+	if err != nil {
+		return myErrors.ErrWarehouseInternal
+	}
+
+	if _, err := tx.Exec("DELETE FROM goods WHERE warehouse_id = $1 AND quantity = 0 AND reserved = 0", id); err != nil {
+		return myErrors.ErrWarehouseInternal
+	}
+	_, err = tx.Exec("DELETE FROM warehouses WHERE uuid = $1", id)
+	if pgError(err, foreignKeyViolation) != nil {
+		return myErrors.ErrWarehouseNotEmpty
+	}
+	if err != nil {
+		return myErrors.ErrWarehouseInternal
+	}
+
+	if err := tx.Commit(); err != nil {
+		return myErrors.ErrWarehouseInternal
+	}
+	return nil
+}
+
+// resolveWarehouse проверяет, что склад принадлежит продавцу карточки.
+// uuid.Nil означает склад продавца по умолчанию.
+func resolveWarehouse(tx *sql.Tx, cardID uuid.UUID, warehouseID uuid.UUID) (uuid.UUID, error) {
+	var sellerID uuid.UUID
+	if err := tx.QueryRow("SELECT seller_id FROM good_cards WHERE uuid = $1", cardID).Scan(&sellerID); err != nil {
+		return uuid.Nil, err
+	}
+	if warehouseID == uuid.Nil {
+		return defaultWarehouse(tx, sellerID)
+	}
+
+	var owner uuid.UUID
+	err := tx.QueryRow("SELECT seller_id FROM warehouses WHERE uuid = $1", warehouseID).Scan(&owner)
+	if err == sql.ErrNoRows || (err == nil && owner != sellerID) {
+		return uuid.Nil, myErrors.ErrWarehouseNotFound
+	}
+	if err != nil {
+		return uuid.Nil, err
+	}
+	return warehouseID, nil
+}
+
+// defaultWarehouse возвращает первый по приоритету склад продавца,
+// а продавцу без складов создаёт склад по умолчанию
+func defaultWarehouse(tx *sql.Tx, sellerID uuid.UUID) (uuid.UUID, error) {
+	var id uuid.UUID
+	err := tx.QueryRow(`
+		SELECT uuid FROM warehouses WHERE seller_id = $1
+		ORDER BY priority, created_at, uuid
+		LIMIT 1`, sellerID).Scan(&id)
+	if err != sql.ErrNoRows {
+		return id, err
+	}
+
+	// DO UPDATE вместо DO NOTHING, чтобы RETURNING вернул склад, созданный параллельным запросом
+	err = tx.QueryRow(`
+		INSERT INTO warehouses (seller_id, name) VALUES ($1, $2)
+		ON CONFLICT (seller_id, name) DO UPDATE SET name = EXCLUDED.name
+		RETURNING uuid`, sellerID, models.DefaultWarehouseName).Scan(&id)
+	return id, err
+}
+
+// allocateWarehouse выбирает склад для списания number единиц варианта: preferred,
+// если на нём хватает остатка, иначе первый по приоритету склад с достаточным остатком.
+// Количество не делится между складами. Карточка варианта должна быть заблокирована,
+// тогда выбранный склад не опустеет до списания.
+func allocateWarehouse(tx *sql.Tx, skuID uuid.UUID, preferred uuid.UUID, number int) (uuid.UUID, error) {
+	var id uuid.UUID
+	err := tx.QueryRow(`
+		SELECT g.warehouse_id FROM goods g
+		JOIN warehouses w ON w.uuid = g.warehouse_id
+		WHERE g.sku_id = $1 AND g.quantity >= $2
+		ORDER BY g.warehouse_id = $3 DESC, w.priority, w.created_at, w.uuid
+		LIMIT 1`, skuID, number, preferred).Scan(&id)
+	if err == sql.ErrNoRows {
+		return uuid.Nil, myErrors.ErrNotEnoughQuantity
+	}
+	return id, err
+}
+
+// skuQuantity возвращает доступное количество варианта по всем складам
+func skuQuantity(tx *sql.Tx, skuID uuid.UUID) (int, error) {
+	var quantity int
+	err := tx.QueryRow("SELECT COALESCE(SUM(quantity), 0) FROM goods WHERE sku_id = $1", skuID).Scan(&quantity)
+	return quantity, err
+}
@@ -0,0 +1,85 @@
+package postgresdb
+
+import (
+	myErrors "goods/internal/errors"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+func TestDeleteWarehouseWithStock(t *testing.T) {
+	db, mock := newMockPostgres(t)
+	sellerID, id := uuid.New(), uuid.New()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT uuid FROM warehouses")).
+		WithArgs(id, sellerID).WillReturnRows(sqlmock.NewRows([]string{"uuid"}).AddRow(id.String()))
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM goods WHERE warehouse_id = $1 AND quantity = 0 AND reserved = 0")).
+		WithArgs(id).WillReturnResult(sqlmock.NewResult(0, 2))
+	// Оставшиеся непустые строки остатка не дают удалить склад
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM warehouses")).
+		WithArgs(id).WillReturnError(&pq.Error{Code: foreignKeyViolation})
+	mock.ExpectRollback()
+
+	if err := db.DeleteWarehouse(sellerID, id); err != myErrors.ErrWarehouseNotEmpty {
+		t.Fatalf("err = %v, want ErrWarehouseNotEmpty", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDeleteForeignWarehouse(t *testing.T) {
+	db, mock := newMockPostgres(t)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT uuid FROM warehouses")).
+		WillReturnRows(sqlmock.NewRows([]string{"uuid"}))
+	mock.ExpectRollback()
+
+	if err := db.DeleteWarehouse(uuid.New(), uuid.New()); err != myErrors.ErrWarehouseNotFound {
+		t.Fatalf("err = %v, want ErrWarehouseNotFound", err)
+	}
+}
+
+func TestResolveWarehouseOfAnotherSeller(t *testing.T) {
+	db, mock := newMockPostgres(t)
+	cardID, warehouseID := uuid.New(), uuid.New()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT seller_id FROM good_cards")).
+		WithArgs(cardID).WillReturnRows(sqlmock.NewRows([]string{"seller_id"}).AddRow(uuid.New().String()))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT seller_id FROM warehouses")).
+		WithArgs(warehouseID).WillReturnRows(sqlmock.NewRows([]string{"seller_id"}).AddRow(uuid.New().String()))
+
+	tx, err := db.Connection.Begin()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tx.Rollback()
+	if _, err := resolveWarehouse(tx, cardID, warehouseID); err != myErrors.ErrWarehouseNotFound {
+		t.Fatalf("err = %v, want ErrWarehouseNotFound", err)
+	}
+}
+
+func TestAllocateWarehouseWithoutEnoughStock(t *testing.T) {
+	db, mock := newMockPostgres(t)
+	skuID := uuid.New()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT g.warehouse_id FROM goods g")).
+		WithArgs(skuID, 5, uuid.Nil).WillReturnRows(sqlmock.NewRows([]string{"warehouse_id"}))
+
+	tx, err := db.Connection.Begin()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tx.Rollback()
+	// Количество не делится между складами: пять единиц должны найтись на одном
+	if _, err := allocateWarehouse(tx, skuID, uuid.Nil, 5); err != myErrors.ErrNotEnoughQuantity {
+		t.Fatalf("err = %v, want ErrNotEnoughQuantity", err)
+	}
+}
@@ -0,0 +1,241 @@
+package postgresdb
+
+import (
+	"database/sql"
+	"encoding/json"
+	myErrors "goods/internal/errors"
+	"goods/internal/models"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// SetLowStockThreshold задаёт порог остатка карточки; nil отключает оповещение о пороге
+func (db *Postgres) SetLowStockThreshold(cardID uuid.UUID, threshold *int) error {
+	res, err := db.Connection.Exec("UPDATE good_cards SET low_stock_threshold = $1 WHERE uuid = $2", threshold, cardID)
+	if err != nil {
+		return myErrors.ErrWebhookInternal
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return myErrors.ErrGoodCardNotFound
+	}
+	return nil
+}
+
+// SetWebhook регистрирует адрес оповещений продавца, заменяя прежний
+func (db *Postgres) SetWebhook(webhook models.Webhook) (models.Webhook, error) {
+	err := db.Connection.QueryRow(`
+		INSERT INTO webhooks (seller_id, url, secret) VALUES ($1, $2, $3)
+		ON CONFLICT (seller_id) DO UPDATE SET url = EXCLUDED.url, secret = EXCLUDED.secret, created_at = now()
+		RETURNING created_at`, webhook.SellerID, webhook.URL, webhook.Secret).Scan(&webhook.CreatedAt)
+	if err != nil {
+		return models.Webhook{}, myErrors.ErrWebhookInternal
+	}
+	return webhook, nil
+}
+
+// ReadWebhook возвращает адрес оповещений продавца без ключа подписи
+func (db *Postgres) ReadWebhook(sellerID uuid.UUID) (models.Webhook, error) {
+	webhook := models.Webhook{SellerID: sellerID}
+	err := db.Connection.QueryRow("SELECT url, created_at FROM webhooks WHERE seller_id = $1", sellerID).Scan(&webhook.URL, &webhook.CreatedAt)
+	if err == sql.ErrNoRows {
+		return models.Webhook{}, myErrors.ErrWebhookNotFound
+	}
+	if err != nil {
+		return models.Webhook{}, myErrors.ErrWebhookInternal
+	}
+	return webhook, nil
+}
+
+// DeleteWebhook удаляет адрес оповещений; недоставленные оповещения больше не отправляются
+func (db *Postgres) DeleteWebhook(sellerID uuid.UUID) error {
+	res, err := db.Connection.Exec("DELETE FROM webhooks WHERE seller_id = $1", sellerID)
+	if err != nil {
+		return myErrors.ErrWebhookInternal
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return myErrors.ErrWebhookNotFound
+	}
+	return nil
+}
+
+// ListWebhookDeliveries возвращает журнал доставок продавца; status == "" - все статусы
+func (db *Postgres) ListWebhookDeliveries(sellerID uuid.UUID, status string, limit int, cursor string) (models.WebhookDeliveryPage, error) {
+	if limit <= 0 {
+		limit = models.DefaultPageLimit
+	}
+	if limit > models.MaxPageLimit {
+		limit = models.MaxPageLimit
+	}
+
+	// Курсор - id последней отданной записи, как в журнале движения
+	var beforeID int64
+	if cursor != "" {
+		id, err := strconv.ParseInt(cursor, 10, 64)
+		if err != nil || id <= 0 {
+			return models.WebhookDeliveryPage{}, myErrors.ErrInvalidCursor
+		}
+		beforeID = id
+	}
+
+	rows, err := db.Connection.Query(`
+		SELECT id, seller_id, card_id, event, url, payload, status, attempts, next_attempt_at,
+		       response_code, last_error, created_at, delivered_at
+		FROM webhook_deliveries
+		WHERE seller_id = $1 AND ($2 = '' OR status = $2) AND ($3 = 0 OR id < $3)
+		ORDER BY id DESC
+		LIMIT $4`, sellerID, status, beforeID, limit+1)
+	if err != nil {
+		return models.WebhookDeliveryPage{}, myErrors.ErrWebhookInternal
+	}
+	defer rows.Close()
+
+	page := models.WebhookDeliveryPage{Items: make([]models.WebhookDelivery, 0, limit)}
+	for rows.Next() {
+		var d models.WebhookDelivery
+		var code sql.NullInt64
+		var deliveredAt sql.NullTime
+		err := rows.Scan(&d.ID, &d.SellerID, &d.CardID, &d.Event, &d.URL, &d.Payload, &d.Status, &d.Attempts,
+			&d.NextAttemptAt, &code, &d.LastError, &d.CreatedAt, &deliveredAt)
+		if err != nil {
+			return models.WebhookDeliveryPage{}, myErrors.ErrWebhookInternal
+		}
+		if code.Valid {
+			c := int(code.Int64)
+			d.ResponseCode = &c
+		}
+		if deliveredAt.Valid {
+			d.DeliveredAt = &deliveredAt.Time
+		}
+		if len(page.Items) == limit {
+			page.NextCursor = strconv.FormatInt(page.Items[len(page.Items)-1].ID, 10)
+			break
+		}
+		page.Items = append(page.Items, d)
+	}
+	if err := rows.Err(); err != nil {
+		return models.WebhookDeliveryPage{}, myErrors.ErrWebhookInternal
+	}
+	return page, nil
+}
+
+// ClaimWebhookDeliveries забирает до limit наступивших доставок и откладывает их на lease,
+// чтобы другой экземпляр сервиса не отправил их одновременно. Доставка берёт текущие адрес
+// и ключ продавца; доставки продавца, удалившего адрес, завершаются с ошибкой.
+func (db *Postgres) ClaimWebhookDeliveries(limit int, lease time.Duration) ([]models.WebhookDelivery, error) {
+	tx, err := db.Connection.Begin()
+	if err != nil {
+		return nil, myErrors.ErrWebhookInternal
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.Query(`
+		SELECT d.id, d.seller_id, d.card_id, d.event, w.url, w.secret, d.payload, d.attempts
+		FROM webhook_deliveries d
+		LEFT JOIN webhooks w ON w.seller_id = d.seller_id
+		WHERE d.status = $1 AND d.next_attempt_at <= now()
+		ORDER BY d.next_attempt_at, d.id
+		LIMIT $2
+		FOR UPDATE OF d SKIP LOCKED`, models.DeliveryPending, limit)
+	if err != nil {
+		return nil, myErrors.ErrWebhookInternal
+	}
+	var claimed []models.WebhookDelivery
+	var orphaned []int64
+	for rows.Next() {
+		var d models.WebhookDelivery
+		var url, secret sql.NullString
+		if err := rows.Scan(&d.ID, &d.SellerID, &d.CardID, &d.Event, &url, &secret, &d.Payload, &d.Attempts); err != nil {
+			rows.Close()
+			return nil, myErrors.ErrWebhookInternal
+		}
+		if !url.Valid {
+			orphaned = append(orphaned, d.ID)
+			continue
+		}
+		d.URL, d.Secret = url.String, secret.String
+		claimed = append(claimed, d)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, myErrors.ErrWebhookInternal
+	}
+
+	if len(orphaned) > 0 {
+		_, err := tx.Exec("UPDATE webhook_deliveries SET status = $1, last_error = 'webhook removed' WHERE id = ANY($2)",
+			models.DeliveryFailed, pq.Array(orphaned))
+		if err != nil {
+			return nil, myErrors.ErrWebhookInternal
+		}
+	}
+	for _, d := range claimed {
+		_, err := tx.Exec(`
+			UPDATE webhook_deliveries SET url = $1, next_attempt_at = now() + $2 * interval '1 millisecond'
+			WHERE id = $3`, d.URL, lease.Milliseconds(), d.ID)
+		if err != nil {
+			return nil, myErrors.ErrWebhookInternal
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, myErrors.ErrWebhookInternal
+	}
+	return claimed, nil
+}
+
+// RecordWebhookAttempt записывает результат попытки доставки
+func (db *Postgres) RecordWebhookAttempt(id int64, attempt models.WebhookAttempt) error {
+	_, err := db.Connection.Exec(`
+		UPDATE webhook_deliveries
+		SET attempts = attempts + 1, status = $1, response_code = $2, last_error = $3, next_attempt_at = $4,
+		    delivered_at = CASE WHEN $1 = 'delivered' THEN now() END
+		WHERE id = $5`, attempt.Status, attempt.ResponseCode, attempt.Error, attempt.NextAttemptAt, id)
+	if err != nil {
+		return myErrors.ErrWebhookInternal
+	}
+	return nil
+}
+
+// enqueueStockAlert ставит в очередь оповещение, если списание number единиц опустило
+// остаток карточки до порога или до нуля. Вызывается после списания под блокировкой карточки.
+func enqueueStockAlert(tx *sql.Tx, cardID uuid.UUID, number int) error {
+	var alert models.StockAlert
+	var threshold sql.NullInt64
+	err := tx.QueryRow(`
+		SELECT gc.seller_id, gc.low_stock_threshold, COALESCE(SUM(g.quantity), 0), now()
+		FROM good_cards gc
+		LEFT JOIN goods g ON g.card_id = gc.uuid
+		WHERE gc.uuid = $1
+		GROUP BY gc.uuid`, cardID).Scan(&alert.SellerID, &threshold, &alert.Quantity, &alert.OccurredAt)
+	if err != nil {
+		return err
+	}
+	before := alert.Quantity + number
+
+	switch {
+	case alert.Quantity == 0 && before > 0:
+		alert.Event = models.AlertOutOfStock
+	case threshold.Valid && before > int(threshold.Int64) && alert.Quantity <= int(threshold.Int64):
+		alert.Event = models.AlertLowStock
+	default:
+		return nil
+	}
+	alert.CardID = cardID
+	if threshold.Valid {
+		t := int(threshold.Int64)
+		alert.Threshold = &t
+	}
+	payload, err := json.Marshal(alert)
+	if err != nil {
+		return err
+	}
+
+	// Без зарегистрированного адреса оповещение никуда не записывается
+	_, err = tx.Exec(`
+		INSERT INTO webhook_deliveries (seller_id, card_id, event, url, payload)
+		SELECT seller_id, $2, $3, url, $4 FROM webhooks WHERE seller_id = $1`,
+		alert.SellerID, cardID, alert.Event, payload)
+	return err
+}
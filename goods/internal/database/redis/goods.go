@@ -0,0 +1,307 @@
+package rediscashe
+
+import (
+	postgresdb "goods/internal/database/postgres"
+	"goods/internal/models"
+	"goods/pkg/money"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+// Виды значений карточки в кэше
+const (
+	kindCard = "card" // ReadGoodCard: карточка с вариантами
+	kindGood = "good" // ReadGood: карточка с суммарным остатком
+)
+
+var cardKinds = []string{kindCard, kindGood}
+
+// CachedDB кэширует чтение карточек поверх базы. Изменения карточки, её вариантов, остатка,
+// изображений, цен и модерации сбрасывают её значения после записи в базу.
+// Остальные методы передаются базе без изменений.
+type CachedDB struct {
+	postgresdb.InterfacePostgresDB
+	cache *Cache
+}
+
+func NewCachedDB(db postgresdb.InterfacePostgresDB, client *redis.Client, ttl time.Duration) *CachedDB {
+	return &CachedDB{InterfacePostgresDB: db, cache: NewCache(client, "goods", ttl)}
+}
+
+func (db *CachedDB) ReadGoodCard(cardID uuid.UUID) (models.Good, error) {
+	return load(db.cache, kindCard, cardID.String(), func() (models.Good, error) {
+		return db.InterfacePostgresDB.ReadGoodCard(cardID)
+	})
+}
+
+func (db *CachedDB) ReadGood(goodID uuid.UUID) (models.Good, error) {
+	return load(db.cache, kindGood, goodID.String(), func() (models.Good, error) {
+		return db.InterfacePostgresDB.ReadGood(goodID)
+	})
+}
+
+// ReadGoodCards берёт из кэша найденные там карточки и одним запросом загружает из базы остальные.
+// Не найденные и удалённые карточки пропускаются, как в базе.
+func (db *CachedDB) ReadGoodCards(ids []uuid.UUID) ([]models.Good, error) {
+	keys := make([]string, 0, len(ids))
+	for _, id := range ids {
+		keys = append(keys, id.String())
+	}
+	found, err := loadMany(db.cache, kindCard, keys, func(missing []string) (map[string]models.Good, error) {
+		missingIDs := make([]uuid.UUID, 0, len(missing))
+		for _, id := range missing {
+			missingIDs = append(missingIDs, uuid.MustParse(id))
+		}
+		goods, err := db.InterfacePostgresDB.ReadGoodCards(missingIDs)
+		if err != nil {
+			return nil, err
+		}
+		byID := make(map[string]models.Good, len(goods))
+		for _, good := range goods {
+			byID[good.Card.UUID.String()] = good
+		}
+		return byID, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	goods := make([]models.Good, 0, len(ids))
+	for _, key := range keys {
+		if good, ok := found[key]; ok {
+			goods = append(goods, good)
+		}
+	}
+	return goods, nil
+}
+
+func (db *CachedDB) invalidate(cardIDs ...uuid.UUID) {
+	ids := make([]string, 0, len(cardIDs))
+	for _, id := range cardIDs {
+		ids = append(ids, id.String())
+	}
+	db.cache.Invalidate(cardKinds, ids...)
+}
+
+// invalidateSKUs сбрасывает карточки вариантов, найденные до изменения: удалённый вариант потом уже не найти.
+// Если карточки найти не удалось, а изменение прошло, сбрасывается весь кэш.
+func (db *CachedDB) invalidateSKUs(skuIDs []uuid.UUID, change func() error) error {
+	cardIDs, lookupErr := db.SKUCards(skuIDs)
+	err := change()
+	if lookupErr != nil {
+		if err == nil {
+			db.cache.Flush()
+		}
+		return err
+	}
+	db.invalidate(cardIDs...)
+	return err
+}
+
+func (db *CachedDB) invalidateReservation(reservationID uuid.UUID, change func() error) error {
+	cardID, lookupErr := db.ReservationCard(reservationID)
+	err := change()
+	if lookupErr != nil {
+		if err == nil {
+			db.cache.Flush()
+		}
+		return err
+	}
+	db.invalidate(cardID)
+	return err
+}
+
+func (db *CachedDB) DeleteGoodCard(cardID uuid.UUID) error {
+	defer db.invalidate(cardID)
+	return db.InterfacePostgresDB.DeleteGoodCard(cardID)
+}
+
+func (db *CachedDB) UpdateGoodCard(id uuid.UUID, goodCard models.GoodCard, expectedVersion int) (int, error) {
+	defer db.invalidate(id)
+	return db.InterfacePostgresDB.UpdateGoodCard(id, goodCard, expectedVersion)
+}
+
+func (db *CachedDB) PatchGoodCard(id uuid.UUID, patch models.GoodCardPatch, expectedVersion int) (int, error) {
+	defer db.invalidate(id)
+	return db.InterfacePostgresDB.PatchGoodCard(id, patch, expectedVersion)
+}
+
+func (db *CachedDB) RestoreRevision(cardID uuid.UUID, version int, expectedVersion int) (int, error) {
+	defer db.invalidate(cardID)
+	return db.InterfacePostgresDB.RestoreRevision(cardID, version, expectedVersion)
+}
+
+func (db *CachedDB) RestoreGoodCard(sellerID uuid.UUID, cardID uuid.UUID) error {
+	defer db.invalidate(cardID)
+	return db.InterfacePostgresDB.RestoreGoodCard(sellerID, cardID)
+}
+
+func (db *CachedDB) CreateGood(cardID uuid.UUID, quantity int, meta models.MovementMeta) error {
+	defer db.invalidate(cardID)
+	return db.InterfacePostgresDB.CreateGood(cardID, quantity, meta)
+}
+
+func (db *CachedDB) CreateSKU(cardID uuid.UUID, sku models.SKU, quantity int, meta models.MovementMeta) (uuid.UUID, error) {
+	defer db.invalidate(cardID)
+	return db.InterfacePostgresDB.CreateSKU(cardID, sku, quantity, meta)
+}
+
+func (db *CachedDB) UpdateSKU(skuID uuid.UUID, price *money.Money, weight *float64) error {
+	return db.invalidateSKUs([]uuid.UUID{skuID}, func() error {
+		return db.InterfacePostgresDB.UpdateSKU(skuID, price, weight)
+	})
+}
+
+func (db *CachedDB) DeleteGood(skuID uuid.UUID) error {
+	return db.invalidateSKUs([]uuid.UUID{skuID}, func() error {
+		return db.InterfacePostgresDB.DeleteGood(skuID)
+	})
+}
+
+func (db *CachedDB) AddCountGood(skuID uuid.UUID, warehouseID uuid.UUID, number int, meta models.MovementMeta) (int, error) {
+	var count int
+	err := db.invalidateSKUs([]uuid.UUID{skuID}, func() (err error) {
+		count, err = db.InterfacePostgresDB.AddCountGood(skuID, warehouseID, number, meta)
+		return err
+	})
+	return count, err
+}
+
+func (db *CachedDB) DeleteCountGood(skuID uuid.UUID, warehouseID uuid.UUID, number int, meta models.MovementMeta) (int, error) {
+	var count int
+	err := db.invalidateSKUs([]uuid.UUID{skuID}, func() (err error) {
+		count, err = db.InterfacePostgresDB.DeleteCountGood(skuID, warehouseID, number, meta)
+		return err
+	})
+	return count, err
+}
+
+func (db *CachedDB) AdjustStock(sellerID uuid.UUID, items []models.StockAdjustment, meta models.MovementMeta) ([]models.StockAdjustmentResult, error) {
+	skuIDs := make([]uuid.UUID, 0, len(items))
+	for _, item := range items {
+		skuIDs = append(skuIDs, item.GoodID)
+	}
+	var results []models.StockAdjustmentResult
+	err := db.invalidateSKUs(skuIDs, func() (err error) {
+		results, err = db.InterfacePostgresDB.AdjustStock(sellerID, items, meta)
+		return err
+	})
+	return results, err
+}
+
+func (db *CachedDB) ReserveGood(skuID uuid.UUID, warehouseID uuid.UUID, number int, ttl time.Duration, meta models.MovementMeta) (models.Reservation, error) {
+	var reservation models.Reservation
+	err := db.invalidateSKUs([]uuid.UUID{skuID}, func() (err error) {
+		reservation, err = db.InterfacePostgresDB.ReserveGood(skuID, warehouseID, number, ttl, meta)
+		return err
+	})
+	return reservation, err
+}
+
+func (db *CachedDB) CommitReservation(reservationID uuid.UUID, meta models.MovementMeta) error {
+	return db.invalidateReservation(reservationID, func() error {
+		return db.InterfacePostgresDB.CommitReservation(reservationID, meta)
+	})
+}
+
+func (db *CachedDB) ReleaseReservation(reservationID uuid.UUID, meta models.MovementMeta) error {
+	return db.invalidateReservation(reservationID, func() error {
+		return db.InterfacePostgresDB.ReleaseReservation(reservationID, meta)
+	})
+}
+
+// ExpireReservations сбрасывает весь кэш, если что-то истекло: истёкшие резервы известны только базе
+func (db *CachedDB) ExpireReservations(limit int) (int, error) {
+	n, err := db.InterfacePostgresDB.ExpireReservations(limit)
+	if n > 0 {
+		db.cache.Flush()
+	}
+	return n, err
+}
+
+func (db *CachedDB) ImportGoodCards(sellerID uuid.UUID, rows []models.ImportRow, meta models.MovementMeta) ([]models.ImportRowResult, error) {
+	results, err := db.InterfacePostgresDB.ImportGoodCards(sellerID, rows, meta)
+	var cardIDs []uuid.UUID
+	for _, result := range results {
+		if id, parseErr := uuid.Parse(result.ID); parseErr == nil {
+			cardIDs = append(cardIDs, id)
+		}
+	}
+	db.invalidate(cardIDs...)
+	return results, err
+}
+
+// SetAttributeSchema сбрасывает весь кэш: смена схемы меняет единицы и значения атрибутов карточек категории
+func (db *CachedDB) SetAttributeSchema(categoryID uuid.UUID, schema []models.AttributeDefinition) error {
+	err := db.InterfacePostgresDB.SetAttributeSchema(categoryID, schema)
+	if err == nil {
+		db.cache.Flush()
+	}
+	return err
+}
+
+func (db *CachedDB) CreatePriceSchedule(schedule models.PriceSchedule) (uuid.UUID, error) {
+	defer db.invalidate(schedule.CardID)
+	return db.InterfacePostgresDB.CreatePriceSchedule(schedule)
+}
+
+func (db *CachedDB) CancelPriceSchedule(cardID uuid.UUID, scheduleID uuid.UUID) error {
+	defer db.invalidate(cardID)
+	return db.InterfacePostgresDB.CancelPriceSchedule(cardID, scheduleID)
+}
+
+// ApplyPriceSchedules сбрасывает весь кэш, если расписание началось или закончилось:
+// действующая цена карточки зависит от времени
+func (db *CachedDB) ApplyPriceSchedules(limit int) (int, error) {
+	n, err := db.InterfacePostgresDB.ApplyPriceSchedules(limit)
+	if n > 0 {
+		db.cache.Flush()
+	}
+	return n, err
+}
+
+// DeleteWarehouse сбрасывает весь кэш: остатки по складам есть в вариантах всех карточек продавца
+func (db *CachedDB) DeleteWarehouse(sellerID uuid.UUID, id uuid.UUID) error {
+	err := db.InterfacePostgresDB.DeleteWarehouse(sellerID, id)
+	if err == nil {
+		db.cache.Flush()
+	}
+	return err
+}
+
+func (db *CachedDB) SetLowStockThreshold(cardID uuid.UUID, threshold *int) error {
+	defer db.invalidate(cardID)
+	return db.InterfacePostgresDB.SetLowStockThreshold(cardID, threshold)
+}
+
+func (db *CachedDB) AddMedia(media models.Media) (models.Media, error) {
+	defer db.invalidate(media.CardID)
+	return db.InterfacePostgresDB.AddMedia(media)
+}
+
+func (db *CachedDB) ReorderMedia(cardID uuid.UUID, ids []uuid.UUID) error {
+	defer db.invalidate(cardID)
+	return db.InterfacePostgresDB.ReorderMedia(cardID, ids)
+}
+
+func (db *CachedDB) DeleteMedia(cardID uuid.UUID, mediaID uuid.UUID) (models.Media, error) {
+	defer db.invalidate(cardID)
+	return db.InterfacePostgresDB.DeleteMedia(cardID, mediaID)
+}
+
+func (db *CachedDB) SubmitGoodCard(cardID uuid.UUID, actor string) error {
+	defer db.invalidate(cardID)
+	return db.InterfacePostgresDB.SubmitGoodCard(cardID, actor)
+}
+
+func (db *CachedDB) ApproveGoodCard(cardID uuid.UUID, expectedVersion int, actor string) error {
+	defer db.invalidate(cardID)
+	return db.InterfacePostgresDB.ApproveGoodCard(cardID, expectedVersion, actor)
+}
+
+func (db *CachedDB) RejectGoodCard(cardID uuid.UUID, expectedVersion int, reason string, actor string) error {
+	defer db.invalidate(cardID)
+	return db.InterfacePostgresDB.RejectGoodCard(cardID, expectedVersion, reason, actor)
+}
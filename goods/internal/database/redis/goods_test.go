@@ -0,0 +1,225 @@
+package rediscashe
+
+import (
+	postgresdb "goods/internal/database/postgres"
+	myErrors "goods/internal/errors"
+	"goods/internal/models"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+// fakeCardDB отдаёт карточки с текущим названием и считает обращения к базе
+type fakeCardDB struct {
+	postgresdb.InterfacePostgresDB
+	mu     sync.Mutex
+	names  map[uuid.UUID]string
+	skus   map[uuid.UUID]uuid.UUID // вариант -> карточка
+	reads  atomic.Int32
+	delay  time.Duration
+	during func() // вызывается посреди чтения из базы
+}
+
+func newFakeCardDB() *fakeCardDB {
+	return &fakeCardDB{names: make(map[uuid.UUID]string), skus: make(map[uuid.UUID]uuid.UUID)}
+}
+
+func (f *fakeCardDB) card(id uuid.UUID) (models.Good, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	name, ok := f.names[id]
+	return models.Good{UUID: id, Card: models.GoodCard{UUID: id, Name: name}}, ok
+}
+
+func (f *fakeCardDB) ReadGoodCard(cardID uuid.UUID) (models.Good, error) {
+	f.reads.Add(1)
+	good, ok := f.card(cardID)
+	time.Sleep(f.delay)
+	if f.during != nil {
+		f.during()
+	}
+	if !ok {
+		return models.Good{}, myErrors.ErrGoodCardNotFound
+	}
+	return good, nil
+}
+
+func (f *fakeCardDB) ReadGoodCards(ids []uuid.UUID) ([]models.Good, error) {
+	f.reads.Add(1)
+	var goods []models.Good
+	for _, id := range ids {
+		if good, ok := f.card(id); ok {
+			goods = append(goods, good)
+		}
+	}
+	return goods, nil
+}
+
+func (f *fakeCardDB) rename(id uuid.UUID, name string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.names[id] = name
+}
+
+func (f *fakeCardDB) PatchGoodCard(id uuid.UUID, patch models.GoodCardPatch, expectedVersion int) (int, error) {
+	f.rename(id, *patch.Name)
+	return 2, nil
+}
+
+func (f *fakeCardDB) SKUCards(skuIDs []uuid.UUID) ([]uuid.UUID, error) {
+	var cards []uuid.UUID
+	for _, id := range skuIDs {
+		cards = append(cards, f.skus[id])
+	}
+	return cards, nil
+}
+
+func (f *fakeCardDB) AddCountGood(skuID uuid.UUID, warehouseID uuid.UUID, number int, meta models.MovementMeta) (int, error) {
+	f.rename(f.skus[skuID], "restocked")
+	return number, nil
+}
+
+func newTestDB(t *testing.T) (*CachedDB, *fakeCardDB, *miniredis.Miniredis) {
+	t.Helper()
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr(), MaxRetries: -1, DialTimeout: 100 * time.Millisecond})
+	t.Cleanup(func() { client.Close() })
+	fake := newFakeCardDB()
+	return NewCachedDB(fake, client, time.Minute), fake, mr
+}
+
+func readName(t *testing.T, db *CachedDB, id uuid.UUID) string {
+	t.Helper()
+	good, err := db.ReadGoodCard(id)
+	if err != nil {
+		t.Fatalf("ReadGoodCard: %v", err)
+	}
+	return good.Card.Name
+}
+
+func TestReadGoodCardIsCachedAndInvalidatedOnUpdate(t *testing.T) {
+	db, fake, mr := newTestDB(t)
+	id := uuid.New()
+	fake.rename(id, "phone")
+
+	readName(t, db, id)
+	if name := readName(t, db, id); name != "phone" || fake.reads.Load() != 1 {
+		t.Fatalf("second read: name = %q, database reads = %d, want cached", name, fake.reads.Load())
+	}
+
+	name := "smartphone"
+	if _, err := db.PatchGoodCard(id, models.GoodCardPatch{Name: &name}, 0); err != nil {
+		t.Fatal(err)
+	}
+	if got := readName(t, db, id); got != name {
+		t.Fatalf("read after update = %q, want %q", got, name)
+	}
+
+	mr.FastForward(2 * time.Minute)
+	readName(t, db, id)
+	if fake.reads.Load() != 3 {
+		t.Fatalf("database reads = %d, want expired value to be reloaded", fake.reads.Load())
+	}
+}
+
+func TestStockChangeInvalidatesCardOfSKU(t *testing.T) {
+	db, fake, _ := newTestDB(t)
+	card, sku := uuid.New(), uuid.New()
+	fake.rename(card, "phone")
+	fake.skus[sku] = card
+
+	readName(t, db, card)
+	if _, err := db.AddCountGood(sku, uuid.New(), 5, models.MovementMeta{}); err != nil {
+		t.Fatal(err)
+	}
+	if got := readName(t, db, card); got != "restocked" {
+		t.Fatalf("read after stock change = %q, want fresh card", got)
+	}
+}
+
+func TestConcurrentMissesLoadOnce(t *testing.T) {
+	db, fake, _ := newTestDB(t)
+	id := uuid.New()
+	fake.rename(id, "phone")
+	fake.delay = 50 * time.Millisecond
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := db.ReadGoodCard(id); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+	if n := fake.reads.Load(); n != 1 {
+		t.Fatalf("database reads = %d, want 1 for concurrent misses", n)
+	}
+}
+
+func TestLoadInvalidatedMidwayIsNotCached(t *testing.T) {
+	db, fake, _ := newTestDB(t)
+	id := uuid.New()
+	fake.rename(id, "old")
+	// Изменение фиксируется и инвалидируется, пока чтение ещё несёт старое значение
+	fake.during = func() {
+		fake.during = nil
+		fake.rename(id, "new")
+		db.invalidate(id)
+	}
+
+	if got := readName(t, db, id); got != "old" {
+		t.Fatalf("first read = %q, want value read before the change", got)
+	}
+	if got := readName(t, db, id); got != "new" {
+		t.Fatalf("second read = %q, want stale value not to be cached", got)
+	}
+}
+
+func TestReadGoodCardsMixesCachedAndLoaded(t *testing.T) {
+	db, fake, _ := newTestDB(t)
+	a, b, missing := uuid.New(), uuid.New(), uuid.New()
+	fake.rename(a, "a")
+	fake.rename(b, "b")
+	readName(t, db, a)
+
+	goods, err := db.ReadGoodCards([]uuid.UUID{b, missing, a})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(goods) != 2 || goods[0].UUID != b || goods[1].UUID != a {
+		t.Fatalf("ReadGoodCards = %+v, want b and a in request order", goods)
+	}
+	if _, err := db.ReadGoodCards([]uuid.UUID{a, b}); err != nil {
+		t.Fatal(err)
+	}
+	if n := fake.reads.Load(); n != 2 {
+		t.Fatalf("database reads = %d, want only the first batch miss to reach the database", n)
+	}
+}
+
+func TestReadsWorkWithoutRedis(t *testing.T) {
+	db, fake, mr := newTestDB(t)
+	id := uuid.New()
+	fake.rename(id, "phone")
+	mr.Close()
+
+	for i := 0; i < 3; i++ {
+		if got := readName(t, db, id); got != "phone" {
+			t.Fatalf("read without Redis = %q", got)
+		}
+	}
+	if _, err := db.ReadGoodCards([]uuid.UUID{id}); err != nil {
+		t.Fatalf("batch read without Redis: %v", err)
+	}
+	if _, err := db.ReadGoodCard(uuid.New()); err != myErrors.ErrGoodCardNotFound {
+		t.Fatalf("err = %v, want database error to pass through", err)
+	}
+}
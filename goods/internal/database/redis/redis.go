@@ -0,0 +1,304 @@
+package rediscashe
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	config "goods/internal/cfg"
+	myLog "goods/internal/logger"
+	"math/rand"
+	"sync/atomic"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"golang.org/x/sync/singleflight"
+)
+
+const (
+	// После ошибки Redis кэш не читается столько времени, чтобы недоступный Redis не замедлял каждый запрос
+	cooldown = 5 * time.Second
+	// Сколько загрузка из базы держит блокировку ключа от других экземпляров сервиса
+	lockTTL = 2 * time.Second
+	// Сколько экземпляр без блокировки ждёт, пока значение загрузит её владелец
+	lockWait = 300 * time.Millisecond
+	lockPoll = 20 * time.Millisecond
+)
+
+// setIfCurrent записывает значение, только если версия ключа и поколение кэша не изменились с начала загрузки.
+// Иначе загрузка могла прочитать базу до изменения, инвалидация которого уже прошла, и записала бы устаревшее значение.
+var setIfCurrent = redis.NewScript(`
+if (redis.call('GET', KEYS[2]) or '') ~= ARGV[1] or (redis.call('GET', KEYS[3]) or '') ~= ARGV[2] then
+	return 0
+end
+redis.call('SET', KEYS[1], ARGV[3], 'PX', ARGV[4])
+return 1`)
+
+// Cache - кэш чтения поверх Redis. Ошибки Redis не прерывают запрос: значение загружается из базы.
+// Значения хранятся в JSON, у каждого значения есть ключ версии, который увеличивается при инвалидации.
+type Cache struct {
+	client *redis.Client
+	ttl    time.Duration
+	prefix string
+
+	group     singleflight.Group
+	downUntil atomic.Int64 // Unix-время в наносекундах, до которого кэш не читается
+}
+
+// NewClient создаёт клиент Redis с коротким таймаутом: недоступный Redis не должен задерживать запросы
+func NewClient(cfg config.Config) *redis.Client {
+	return redis.NewClient(&redis.Options{
+		Addr:         cfg.RedisAddr,
+		Password:     cfg.RedisPassword,
+		DB:           cfg.RedisDB,
+		DialTimeout:  cfg.RedisTimeout,
+		ReadTimeout:  cfg.RedisTimeout,
+		WriteTimeout: cfg.RedisTimeout,
+		MaxRetries:   -1,
+	})
+}
+
+// NewCache создаёт кэш с ключами вида prefix:...; значения живут ttl с разбросом до 10%,
+// чтобы ключи, загруженные одновременно, не истекали одновременно
+func NewCache(client *redis.Client, prefix string, ttl time.Duration) *Cache {
+	return &Cache{client: client, ttl: ttl, prefix: prefix}
+}
+
+func (c *Cache) key(kind string, id string) string {
+	return c.prefix + ":" + kind + ":" + id
+}
+
+func (c *Cache) versionKey(id string) string {
+	return c.prefix + ":version:" + id
+}
+
+// generationKey - поколение кэша: его увеличение отменяет запись всех загрузок, начатых до сброса
+func (c *Cache) generationKey() string {
+	return c.prefix + ":generation"
+}
+
+func (c *Cache) available() bool {
+	return time.Now().UnixNano() >= c.downUntil.Load()
+}
+
+func (c *Cache) fail(op string, err error) {
+	c.downUntil.Store(time.Now().Add(cooldown).UnixNano())
+	myLog.Log.Warnf("Redis %s failed, reading from database: %v", op, err)
+}
+
+func (c *Cache) expiration() time.Duration {
+	return c.ttl + time.Duration(rand.Int63n(int64(c.ttl)/10+1))
+}
+
+// load возвращает значение kind:id из кэша или загружает его через fetch.
+// Одновременные промахи по ключу внутри процесса объединяются в одну загрузку, а между экземплярами
+// загружает тот, кто занял блокировку ключа: остальные ждут значение до lockWait.
+// Версия id общая для всех kind: инвалидация id сбрасывает все его значения.
+func load[T any](c *Cache, kind string, id string, fetch func() (T, error)) (T, error) {
+	if !c.available() {
+		return fetch()
+	}
+
+	ctx := context.Background()
+	key := c.key(kind, id)
+	cached, err := c.client.Get(ctx, key).Bytes()
+	if err == nil {
+		var value T
+		if json.Unmarshal(cached, &value) == nil {
+			return value, nil
+		}
+	} else if !errors.Is(err, redis.Nil) {
+		c.fail("GET", err)
+		return fetch()
+	}
+
+	raw, err, _ := c.group.Do(key, func() (interface{}, error) {
+		return c.fill(ctx, key, id, func() (interface{}, error) { return fetch() })
+	})
+	var value T
+	if err != nil {
+		return value, err
+	}
+	// Каждый вызов получает свою копию: значения с общими срезами нельзя отдавать нескольким вызывающим
+	if err := json.Unmarshal(raw.([]byte), &value); err != nil {
+		return fetch()
+	}
+	return value, nil
+}
+
+// loadMany - load для нескольких id: значения из кэша читаются одной командой, а отсутствующие
+// загружаются одним вызовом fetch. Возвращает значения по id; то, чего не нашёл fetch, пропускается.
+func loadMany[T any](c *Cache, kind string, ids []string, fetch func(missing []string) (map[string]T, error)) (map[string]T, error) {
+	if len(ids) == 0 {
+		return map[string]T{}, nil
+	}
+	if !c.available() {
+		return fetch(ids)
+	}
+
+	ctx := context.Background()
+	// Версии читаются вместе со значениями, до загрузки из базы
+	keys := make([]string, 0, 2*len(ids)+1)
+	for _, id := range ids {
+		keys = append(keys, c.key(kind, id))
+	}
+	for _, id := range ids {
+		keys = append(keys, c.versionKey(id))
+	}
+	keys = append(keys, c.generationKey())
+	cached, err := c.client.MGet(ctx, keys...).Result()
+	if err != nil {
+		c.fail("MGET", err)
+		return fetch(ids)
+	}
+
+	values := make(map[string]T, len(ids))
+	var missing []string
+	for i, id := range ids {
+		var value T
+		if raw, ok := cached[i].(string); ok && json.Unmarshal([]byte(raw), &value) == nil {
+			values[id] = value
+			continue
+		}
+		missing = append(missing, id)
+	}
+	if len(missing) == 0 {
+		return values, nil
+	}
+
+	fetched, err := fetch(missing)
+	if err != nil {
+		return nil, err
+	}
+	generation := stringValue(cached[len(cached)-1])
+	pipe := c.client.Pipeline()
+	for i, id := range ids {
+		value, ok := fetched[id]
+		if !ok {
+			continue
+		}
+		values[id] = value
+		raw, err := json.Marshal(value)
+		if err != nil {
+			continue
+		}
+		// В конвейере EVALSHA не может повториться через EVAL, поэтому скрипт передаётся целиком
+		setIfCurrent.Eval(ctx, pipe, []string{c.key(kind, id), c.versionKey(id), c.generationKey()},
+			stringValue(cached[len(ids)+i]), generation, raw, c.expiration().Milliseconds())
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		c.fail("SET", err)
+	}
+	return values, nil
+}
+
+// fill загружает значение из базы и записывает его в кэш, если за время загрузки оно не было инвалидировано
+func (c *Cache) fill(ctx context.Context, key string, id string, fetch func() (interface{}, error)) ([]byte, error) {
+	versions, err := c.client.MGet(ctx, c.versionKey(id), c.generationKey()).Result()
+	if err != nil {
+		c.fail("MGET", err)
+		return marshalFetched(fetch)
+	}
+
+	lockKey := key + ":lock"
+	locked, err := c.client.SetNX(ctx, lockKey, 1, lockTTL).Result()
+	if err != nil {
+		c.fail("SETNX", err)
+		return marshalFetched(fetch)
+	}
+	if locked {
+		defer c.client.Del(ctx, lockKey)
+	} else if cached, ok := c.await(ctx, key); ok {
+		return cached, nil
+	}
+
+	raw, err := marshalFetched(fetch)
+	if err != nil {
+		return nil, err
+	}
+	err = setIfCurrent.Run(ctx, c.client, []string{key, c.versionKey(id), c.generationKey()},
+		stringValue(versions[0]), stringValue(versions[1]), raw, c.expiration().Milliseconds()).Err()
+	if err != nil {
+		c.fail("SET", err)
+	}
+	return raw, nil
+}
+
+// await ждёт, пока значение загрузит экземпляр, занявший блокировку
+func (c *Cache) await(ctx context.Context, key string) ([]byte, bool) {
+	deadline := time.Now().Add(lockWait)
+	for time.Now().Before(deadline) {
+		time.Sleep(lockPoll)
+		cached, err := c.client.Get(ctx, key).Bytes()
+		if err == nil {
+			return cached, true
+		}
+		if !errors.Is(err, redis.Nil) {
+			return nil, false
+		}
+	}
+	return nil, false
+}
+
+func marshalFetched(fetch func() (interface{}, error)) ([]byte, error) {
+	value, err := fetch()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(value)
+}
+
+func stringValue(v interface{}) string {
+	if s, ok := v.(string); ok {
+		return s
+	}
+	return ""
+}
+
+// Invalidate удаляет значения ids всех видов kinds и увеличивает их версии,
+// чтобы незавершённые загрузки не записали значения, прочитанные до изменения
+func (c *Cache) Invalidate(kinds []string, ids ...string) {
+	if len(ids) == 0 {
+		return
+	}
+	ctx := context.Background()
+	pipe := c.client.TxPipeline()
+	for _, id := range ids {
+		for _, kind := range kinds {
+			pipe.Del(ctx, c.key(kind, id))
+		}
+		pipe.Incr(ctx, c.versionKey(id))
+		// Версия нужна, только пока живут значения и загрузки, начатые до инвалидации
+		pipe.Expire(ctx, c.versionKey(id), c.ttl+lockTTL)
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		myLog.Log.Errorf("Redis invalidation of %v failed, cached values may be stale for up to %v: %v", ids, c.ttl, err)
+	}
+}
+
+// Flush сбрасывает все значения кэша, когда изменение затронуло неизвестный набор ключей
+func (c *Cache) Flush() {
+	ctx := context.Background()
+	if err := c.client.Incr(ctx, c.generationKey()).Err(); err != nil {
+		myLog.Log.Errorf("Redis flush failed, cached values may be stale for up to %v: %v", c.ttl, err)
+		return
+	}
+	iter := c.client.Scan(ctx, 0, c.prefix+":*", 1000).Iterator()
+	var keys []string
+	for iter.Next(ctx) {
+		// Поколение не удаляется: иначе загрузка, начатая до первого сброса, снова увидела бы пустое значение
+		if iter.Val() == c.generationKey() {
+			continue
+		}
+		keys = append(keys, iter.Val())
+		if len(keys) == 1000 {
+			c.client.Unlink(ctx, keys...)
+			keys = keys[:0]
+		}
+	}
+	if len(keys) > 0 {
+		c.client.Unlink(ctx, keys...)
+	}
+	if err := iter.Err(); err != nil {
+		myLog.Log.Errorf("Redis flush failed, cached values may be stale for up to %v: %v", c.ttl, err)
+	}
+}
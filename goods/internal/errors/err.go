@@ -47,6 +47,8 @@ var ErrCreateGoodCardInternal = NewError(fasthttp.StatusInternalServerError, "er
 var ErrUpdateGoodCardInternal = NewError(fasthttp.StatusInternalServerError, "error updating good card")
 var ErrDeleteGoodCardInternal = NewError(fasthttp.StatusInternalServerError, "error deleting good card")
 var ErrGoodCardAlreadyExists = NewError(fasthttp.StatusConflict, "error: good card already exists")
+var ErrGoodCardNotDeleted = NewError(fasthttp.StatusConflict, "error: good card is not deleted")
+var ErrPurgeInternal = NewError(fasthttp.StatusInternalServerError, "error: internal error purging deleted good cards")
 var ErrUpdateGoodCardNotFields = NewError(fasthttp.StatusBadRequest, "error: no fields to update")
 
 // Ошибки для функции AddCountGood
@@ -63,3 +65,167 @@ var (
 )
 
 var ErrReadCardInternal = NewError(fasthttp.StatusInternalServerError, "error: internal error read good")
+
+// Ошибки пакетного изменения остатка
+var (
+	ErrStockBatchEmpty    = NewError(fasthttp.StatusBadRequest, "error: stock batch must contain at least one item")
+	ErrStockBatchInternal = NewError(fasthttp.StatusInternalServerError, "error: internal error while adjusting stock")
+)
+
+// ItemError - ошибка элемента пакетной операции: Index - номер элемента в запросе
+type ItemError struct {
+	Index int
+	Err   error
+}
+
+func (e ItemError) Error() string {
+	return "item " + strconv.Itoa(e.Index) + ": " + e.Err.Error()
+}
+
+func (e ItemError) Unwrap() error {
+	return e.Err
+}
+
+// Ошибки для списка товаров
+var (
+	ErrListGoodsInternal = NewError(fasthttp.StatusInternalServerError, "error: internal error list goods")
+	ErrInvalidFilter     = NewError(fasthttp.StatusBadRequest, "error: invalid filter")
+	ErrInvalidSort       = NewError(fasthttp.StatusBadRequest, "error: invalid sort order")
+	ErrInvalidCursor     = NewError(fasthttp.StatusBadRequest, "error: invalid cursor")
+)
+
+// Ошибки резервирования товара
+var (
+	ErrReserveGoodInternal   = NewError(fasthttp.StatusInternalServerError, "error: internal error reserving good")
+	ErrReserveGoodInvalid    = NewError(fasthttp.StatusBadRequest, "error: invalid count for reserving good")
+	ErrReservationInvalidTTL = NewError(fasthttp.StatusBadRequest, "error: invalid reservation ttl")
+	ErrReservationNotFound   = NewError(fasthttp.StatusNotFound, "error: reservation not found")
+	ErrReservationNotActive  = NewError(fasthttp.StatusConflict, "error: reservation is not active")
+	ErrReservationExpired    = NewError(fasthttp.StatusGone, "error: reservation expired")
+	ErrReservationInternal   = NewError(fasthttp.StatusInternalServerError, "error: internal error processing reservation")
+)
+
+var ErrOutboxInternal = NewError(fasthttp.StatusInternalServerError, "error: internal error processing outbox")
+
+// Ошибки журнала движения товара
+var ErrLedgerInternal = NewError(fasthttp.StatusInternalServerError, "error: internal error reading inventory ledger")
+
+var ErrUnauthorized = NewError(fasthttp.StatusUnauthorized, "error: missing or invalid seller identity")
+var ErrModeratorUnauthorized = NewError(fasthttp.StatusUnauthorized, "error: missing or invalid moderator identity")
+var ErrServiceUnauthorized = NewError(fasthttp.StatusUnauthorized, "error: missing or invalid service token")
+
+// Ошибки модерации карточек
+var (
+	ErrModerationInvalidState = NewError(fasthttp.StatusConflict, "error: good card moderation status does not allow this action")
+	ErrModerationInternal     = NewError(fasthttp.StatusInternalServerError, "error: internal error while processing moderation")
+)
+
+// Ошибки импорта и экспорта каталога
+var (
+	ErrImportInternal    = NewError(fasthttp.StatusInternalServerError, "error: internal error importing good cards")
+	ErrExportInternal    = NewError(fasthttp.StatusInternalServerError, "error: internal error exporting good cards")
+	ErrUnsupportedFormat = NewError(fasthttp.StatusUnsupportedMediaType, "error: unsupported format, expected csv or ndjson")
+	ErrImportTooLarge    = NewError(fasthttp.StatusRequestEntityTooLarge, "error: import file is too large")
+)
+
+// ErrBodyTooLarge - тело обычного запроса больше, чем читается в память
+var ErrBodyTooLarge = NewError(fasthttp.StatusRequestEntityTooLarge, "error: request body is too large")
+
+// ValidationError - ошибка проверки конкретного поля карточки
+func ValidationError(field string, reason string) Error {
+	return NewError(fasthttp.StatusUnprocessableEntity, "error: invalid field "+field+": "+reason)
+}
+
+// Ошибки частичного обновления карточки товара (JSON Merge Patch)
+var (
+	ErrInvalidMergePatch    = NewError(fasthttp.StatusBadRequest, "error: merge patch must be a JSON object")
+	ErrUnsupportedMediaType = NewError(fasthttp.StatusUnsupportedMediaType, "error: unsupported media type, expected application/merge-patch+json")
+)
+
+// Ошибки версий и ревизий карточки товара
+var (
+	ErrPreconditionRequired = NewError(fasthttp.StatusPreconditionRequired, "error: If-Match header is required")
+	ErrInvalidIfMatch       = NewError(fasthttp.StatusBadRequest, "error: invalid If-Match header")
+	ErrVersionMismatch      = NewError(fasthttp.StatusPreconditionFailed, "error: good card version mismatch")
+	ErrRevisionNotFound     = NewError(fasthttp.StatusNotFound, "error: good card revision not found")
+	ErrRevisionInternal     = NewError(fasthttp.StatusInternalServerError, "error: internal error reading good card revisions")
+)
+
+// Ошибки вариантов товара (SKU)
+var (
+	ErrSKUAttributesMismatch = NewError(fasthttp.StatusUnprocessableEntity, "error: SKU attributes must match card variant axes")
+	ErrSKUAlreadyExists      = NewError(fasthttp.StatusConflict, "error: SKU with these attributes already exists")
+	ErrVariantAxesLocked     = NewError(fasthttp.StatusConflict, "error: variant axes cannot change while the card has SKUs")
+	ErrSKUInternal           = NewError(fasthttp.StatusInternalServerError, "error: internal error while processing SKU")
+)
+
+// Ошибки дерева категорий и реестра брендов
+var (
+	ErrCategoryNotFound      = NewError(fasthttp.StatusNotFound, "error: category not found")
+	ErrCategoryAlreadyExists = NewError(fasthttp.StatusConflict, "error: category with this name already exists at this level")
+	ErrCategoryNotEmpty      = NewError(fasthttp.StatusConflict, "error: category has subcategories or good cards")
+	ErrCategoryCycle         = NewError(fasthttp.StatusConflict, "error: category cannot be moved into its own subtree")
+	ErrCategoryInternal      = NewError(fasthttp.StatusInternalServerError, "error: internal error while processing category")
+	ErrBrandNotFound         = NewError(fasthttp.StatusNotFound, "error: brand not found")
+	ErrBrandAlreadyExists    = NewError(fasthttp.StatusConflict, "error: brand already exists")
+	ErrBrandInUse            = NewError(fasthttp.StatusConflict, "error: brand is used by good cards")
+	ErrBrandInternal         = NewError(fasthttp.StatusInternalServerError, "error: internal error while processing brand")
+)
+
+// Ошибки схем атрибутов категорий
+var ErrAttributeInternal = NewError(fasthttp.StatusInternalServerError, "error: internal error while processing attributes")
+
+// AttributeSchemaConflict - новая схема категории не подходит существующей карточке
+func AttributeSchemaConflict(cardID string, cause string) Error {
+	return NewError(fasthttp.StatusConflict, "error: good card "+cardID+" does not match the new schema: "+cause)
+}
+
+// Ошибки изображений карточки товара
+var (
+	ErrMediaTooLarge        = NewError(fasthttp.StatusRequestEntityTooLarge, "error: image is too large")
+	ErrMediaUnsupportedType = NewError(fasthttp.StatusUnsupportedMediaType, "error: unsupported image type, expected image/jpeg, image/png or image/gif")
+	ErrMediaInvalidImage    = NewError(fasthttp.StatusUnprocessableEntity, "error: image cannot be decoded")
+	ErrMediaNotFound        = NewError(fasthttp.StatusNotFound, "error: media not found")
+	ErrMediaOrderMismatch   = NewError(fasthttp.StatusUnprocessableEntity, "error: order must list every media of the card exactly once")
+	ErrMediaStorage         = NewError(fasthttp.StatusBadGateway, "error: media storage is unavailable")
+	ErrMediaInternal        = NewError(fasthttp.StatusInternalServerError, "error: internal error while processing media")
+)
+
+// Ошибки расписаний цен
+var (
+	ErrScheduleOverlap  = NewError(fasthttp.StatusConflict, "error: price schedule overlaps an existing schedule")
+	ErrScheduleNotFound = NewError(fasthttp.StatusNotFound, "error: price schedule not found")
+	ErrScheduleFinished = NewError(fasthttp.StatusConflict, "error: price schedule is already finished or cancelled")
+	ErrScheduleInternal = NewError(fasthttp.StatusInternalServerError, "error: internal error while processing price schedule")
+)
+
+// Ошибки складов
+var (
+	ErrWarehouseNotFound      = NewError(fasthttp.StatusNotFound, "error: warehouse not found")
+	ErrWarehouseAlreadyExists = NewError(fasthttp.StatusConflict, "error: warehouse with this name already exists")
+	ErrWarehouseNotEmpty      = NewError(fasthttp.StatusConflict, "error: warehouse still holds stock or reservations")
+	ErrWarehouseInternal      = NewError(fasthttp.StatusInternalServerError, "error: internal error while processing warehouse")
+)
+
+// Ошибки оповещений об остатке
+var (
+	ErrWebhookNotFound = NewError(fasthttp.StatusNotFound, "error: webhook is not registered")
+	ErrWebhookInternal = NewError(fasthttp.StatusInternalServerError, "error: internal error while processing webhook")
+)
+
+// Ошибки ключей идемпотентности
+var (
+	ErrIdempotencyKeyInvalid = NewError(fasthttp.StatusBadRequest, "error: Idempotency-Key must be 1 to 255 characters")
+	ErrIdempotencyKeyReused  = NewError(fasthttp.StatusUnprocessableEntity, "error: Idempotency-Key was already used with a different request")
+	ErrIdempotencyInProgress = NewError(fasthttp.StatusConflict, "error: request with this Idempotency-Key is still in progress")
+	ErrIdempotencyInternal   = NewError(fasthttp.StatusInternalServerError, "error: internal error while processing Idempotency-Key")
+	ErrIdempotencyStreaming  = NewError(fasthttp.StatusBadRequest, "error: Idempotency-Key is not supported for streamed uploads")
+)
+
+// Ошибки денежных сумм, цен в других валютах и курсов
+var (
+	ErrCurrencyMismatch      = NewError(fasthttp.StatusBadRequest, "error: price currency differs from good card currency")
+	ErrPriceListCardCurrency = NewError(fasthttp.StatusBadRequest, "error: good card currency price is set by the card itself")
+	ErrExchangeRateMissing   = NewError(fasthttp.StatusUnprocessableEntity, "error: no exchange rate for requested display currency")
+	ErrPriceListInternal     = NewError(fasthttp.StatusInternalServerError, "error: internal error while processing prices")
+)
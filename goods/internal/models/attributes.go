@@ -0,0 +1,95 @@
+package models
+
+import (
+	"fmt"
+	myErrors "goods/internal/errors"
+	"math"
+	"slices"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// Ограничение длины строкового значения атрибута
+const maxAttributeValueLength = 255
+
+// CheckAttributes проверяет значения атрибутов карточки по схеме её категории.
+// Значения - результат разбора JSON: числа приходят как float64.
+func CheckAttributes(schema []AttributeDefinition, values map[string]interface{}) error {
+	defined := make(map[string]AttributeDefinition, len(schema))
+	for _, def := range schema {
+		defined[def.Name] = def
+	}
+
+	// Имена перебираются по порядку, чтобы ошибка была одной и той же при одинаковых данных
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if _, exists := defined[name]; !exists {
+			return myErrors.ValidationError("attributes."+name, "is not defined for the category")
+		}
+	}
+
+	for _, def := range schema {
+		value, present := values[def.Name]
+		if !present || value == nil {
+			if def.Required {
+				return myErrors.ValidationError("attributes."+def.Name, "is required")
+			}
+			continue
+		}
+		if reason := checkAttributeValue(def, value); reason != "" {
+			return myErrors.ValidationError("attributes."+def.Name, reason)
+		}
+	}
+	return nil
+}
+
+func checkAttributeValue(def AttributeDefinition, value interface{}) string {
+	switch def.Type {
+	case AttributeString:
+		s, isString := value.(string)
+		if !isString || strings.TrimSpace(s) == "" {
+			return "must be a non-empty string"
+		}
+		if utf8.RuneCountInString(s) > maxAttributeValueLength {
+			return fmt.Sprintf("must be at most %d characters", maxAttributeValueLength)
+		}
+	case AttributeNumber:
+		n, isNumber := value.(float64)
+		if !isNumber || math.IsNaN(n) || math.IsInf(n, 0) {
+			return "must be a number"
+		}
+	case AttributeInteger:
+		n, isNumber := value.(float64)
+		if !isNumber || n != math.Trunc(n) || math.IsInf(n, 0) {
+			return "must be an integer"
+		}
+	case AttributeBoolean:
+		if _, isBool := value.(bool); !isBool {
+			return "must be a boolean"
+		}
+	case AttributeEnum:
+		s, isString := value.(string)
+		if !isString || !slices.Contains(def.AllowedValues, s) {
+			return "must be one of " + strings.Join(def.AllowedValues, ", ")
+		}
+	default:
+		return "has unknown type " + def.Type
+	}
+	return ""
+}
+
+// AttributeList раскладывает сохранённые значения в порядке схемы и дополняет их единицами измерения
+func AttributeList(schema []AttributeDefinition, values map[string]interface{}) []AttributeValue {
+	list := make([]AttributeValue, 0, len(values))
+	for _, def := range schema {
+		if value, present := values[def.Name]; present && value != nil {
+			list = append(list, AttributeValue{Name: def.Name, Value: value, Unit: def.Unit})
+		}
+	}
+	return list
+}
@@ -1,19 +1,472 @@
 package models
 
-import "github.com/google/uuid"
+import (
+	"encoding/json"
+	"goods/pkg/money"
+	"time"
+
+	"github.com/google/uuid"
+)
 
 type GoodCard struct {
-	UUID        uuid.UUID `json:"uuid"`        // Уникальный идентификатор товара (UUID)
-	Price       float64   `json:"price"`       // Цена товара
-	Name        string    `json:"name"`        // Название товара
-	Description string    `json:"description"` // Описание товара
-	Weight      float64   `json:"weight"`      // Вес товара
-	SellerID    uuid.UUID `json:"sellerId"`    // Уникальный идентификатор продавца (UUID)
-	IsActive    bool      `json:"isActive"`    // Статус активации товара
+	UUID uuid.UUID `json:"uuid"` // Уникальный идентификатор товара (UUID)
+	// Базовая цена товара; её валюта - валюта карточки, она задаётся при создании и не меняется
+	Price          money.Money `json:"price"`
+	EffectivePrice money.Money `json:"effectivePrice"`       // Цена с учётом действующего расписания цен (только чтение)
+	Name           string      `json:"name"`                 // Название товара
+	Description    string      `json:"description"`          // Описание товара
+	Weight         float64     `json:"weight"`               // Вес товара
+	SellerID       uuid.UUID   `json:"sellerId"`             // Уникальный идентификатор продавца (UUID)
+	IsActive       bool        `json:"isActive"`             // Статус активации товара
+	Version        int         `json:"version"`              // Версия карточки для оптимистичной блокировки
+	VariantAxes    []string    `json:"variantAxes"`          // Оси вариантов (например, size и color)
+	CategoryID     *uuid.UUID  `json:"categoryId,omitempty"` // Категория товара
+	BrandID        *uuid.UUID  `json:"brandId,omitempty"`    // Бренд товара
+	// Порог остатка для оповещения продавца; задаётся отдельным запросом (только чтение)
+	LowStockThreshold *int `json:"lowStockThreshold,omitempty"`
+	// Значения атрибутов по схеме категории карточки
+	Attributes []AttributeValue `json:"attributes"`
+	// Статус модерации (одна из констант Moderation*) и причина отклонения (только чтение)
+	ModerationStatus string `json:"moderationStatus"`
+	ModerationReason string `json:"moderationReason,omitempty"`
+	// Действующая цена в валюте, запрошенной для показа (только чтение)
+	DisplayPrice *money.Money `json:"displayPrice,omitempty"`
+}
+
+// Статусы модерации карточки: draft -> submitted -> approved или rejected.
+// Покупателям и поиску видны только одобренные активные карточки;
+// изменение одобренной карточки возвращает её на проверку.
+const (
+	ModerationDraft     = "draft"
+	ModerationSubmitted = "submitted"
+	ModerationApproved  = "approved"
+	ModerationRejected  = "rejected"
+)
+
+// ModerationActorSystem - исполнитель автоматического возврата карточки на проверку
+const ModerationActorSystem = "system"
+
+// ModerationDecision - запись журнала модерации карточки. Журнал только дополняется.
+type ModerationDecision struct {
+	ID          int64     `json:"id"`
+	CardID      uuid.UUID `json:"cardId"`
+	CardVersion int       `json:"cardVersion"`
+	FromStatus  string    `json:"fromStatus"`
+	ToStatus    string    `json:"toStatus"`
+	Reason      string    `json:"reason,omitempty"`
+	Actor       string    `json:"actor"`
+	CreatedAt   time.Time `json:"createdAt"`
+}
+
+// GoodCardPatch - частичное изменение карточки: nil означает "поле не передано"
+type GoodCardPatch struct {
+	Price       *money.Money // В валюте карточки
+	Name        *string
+	Description *string
+	Weight      *float64
+	IsActive    *bool
+	VariantAxes *[]string         // Меняются, только пока у карточки нет вариантов
+	CategoryID  *uuid.UUID        // uuid.Nil убирает категорию
+	BrandID     *uuid.UUID        // uuid.Nil убирает бренд
+	Attributes  *[]AttributeValue // Заменяет все значения атрибутов
+}
+
+// SKU - вариант товара со своими значениями осей и своим остатком в goods.
+// Товар без вариантов хранит остаток в варианте по умолчанию, UUID которого совпадает с UUID карточки.
+type SKU struct {
+	UUID       uuid.UUID         `json:"uuid"`
+	CardID     uuid.UUID         `json:"cardId"`
+	Attributes map[string]string `json:"attributes"`       // Значение для каждой оси карточки
+	Price      *money.Money      `json:"price,omitempty"`  // В валюте карточки; nil - цена карточки
+	Weight     *float64          `json:"weight,omitempty"` // nil - вес карточки
+	Quantity   int               `json:"quantity"`         // Доступное количество варианта по всем складам
+	Reserved   int               `json:"reserved"`         // Количество варианта в активных резервах
+	Stock      []WarehouseStock  `json:"stock"`            // Остаток по складам
+}
+
+// Warehouse - склад продавца. Склады с меньшим приоритетом выбираются для списания раньше,
+// склад с наименьшим приоритетом принимает начальный остаток новых вариантов.
+type Warehouse struct {
+	UUID      uuid.UUID `json:"uuid"`
+	SellerID  uuid.UUID `json:"sellerId"`
+	Name      string    `json:"name"`
+	Priority  int       `json:"priority"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// Имя склада, который создаётся продавцу без складов
+const DefaultWarehouseName = "default"
+
+// WarehouseStock - остаток варианта на одном складе
+type WarehouseStock struct {
+	WarehouseID uuid.UUID `json:"warehouseId"`
+	Quantity    int       `json:"quantity"`
+	Reserved    int       `json:"reserved"`
 }
 
 type Good struct {
 	UUID     uuid.UUID `json:"uuid"`
 	Card     GoodCard  `json:"card"`
-	Quantity int       `json:"quantity"` // Количество товара
+	Quantity int       `json:"quantity"` // Доступное количество товара по всем вариантам
+	Reserved int       `json:"reserved"` // Количество товара в активных резервах
+	SKUs     []SKU     `json:"skus"`
+	Media    []Media   `json:"media"` // Изображения карточки в порядке показа
+}
+
+// Media - изображение карточки товара. URL постоянны: ключи объектов в хранилище не меняются.
+type Media struct {
+	UUID         uuid.UUID `json:"uuid"`
+	CardID       uuid.UUID `json:"cardId"`
+	Position     int       `json:"position"`
+	ContentType  string    `json:"contentType"`
+	Size         int64     `json:"size"`
+	Width        int       `json:"width"`
+	Height       int       `json:"height"`
+	URL          string    `json:"url"`
+	ThumbnailURL string    `json:"thumbnailUrl"`
+	Key          string    `json:"-"` // Ключ оригинала в хранилище
+	ThumbnailKey string    `json:"-"` // Ключ миниатюры в хранилище
+}
+
+// GoodFilter описывает фильтры, сортировку и пагинацию списка товаров
+type GoodFilter struct {
+	SellerID   *uuid.UUID // Фильтр по продавцу
+	IsActive   *bool      // Фильтр по статусу активации
+	Currency   string     // Фильтр по валюте карточки; обязателен для фильтра по цене
+	MinPrice   *int64     // Минимальная цена в минимальных единицах Currency (включительно)
+	MaxPrice   *int64     // Максимальная цена в минимальных единицах Currency (включительно)
+	MinWeight  *float64   // Минимальный вес (включительно)
+	MaxWeight  *float64   // Максимальный вес (включительно)
+	NamePrefix string     // Префикс названия товара
+	CategoryID *uuid.UUID // Категория вместе со всеми вложенными категориями
+	BrandID    *uuid.UUID // Фильтр по бренду
+	Moderation string     // Фильтр по статусу модерации (одна из констант Moderation*)
+	Sort       string     // Порядок сортировки (одна из констант Sort*)
+	Limit      int        // Размер страницы
+	Cursor     string     // Курсор, полученный с предыдущей страницы
+}
+
+// Допустимые значения GoodFilter.Sort
+const (
+	SortDefault   = ""
+	SortPriceAsc  = "price_asc"
+	SortPriceDesc = "price_desc"
+	SortNameAsc   = "name_asc"
+	SortNameDesc  = "name_desc"
+	// Очередь модерации: раньше отправленные на проверку - первыми
+	SortModerationChanged = "moderation_changed_asc"
+)
+
+// Ограничения размера страницы
+const (
+	DefaultPageLimit = 20
+	MaxPageLimit     = 100
+)
+
+// GoodPage - страница списка товаров
+type GoodPage struct {
+	Items      []Good `json:"items"`
+	NextCursor string `json:"nextCursor,omitempty"` // Пустой, если страниц больше нет
+}
+
+// Статусы резерва товара
+const (
+	ReservationActive    = "active"
+	ReservationCommitted = "committed"
+	ReservationReleased  = "released"
+	ReservationExpired   = "expired"
+)
+
+// Reservation - удержание количества товара до подтверждения или истечения срока
+type Reservation struct {
+	UUID        uuid.UUID `json:"uuid"`
+	SKUID       uuid.UUID `json:"skuId"`
+	WarehouseID uuid.UUID `json:"warehouseId"` // Склад, с которого зарезервирован товар
+	Quantity    int       `json:"quantity"`
+	Status      string    `json:"status"`
+	ExpiresAt   time.Time `json:"expiresAt"`
+	CreatedAt   time.Time `json:"createdAt"`
+}
+
+// Типы событий об изменении товара
+const (
+	EventCardCreated  = "card_created"
+	EventCardUpdated  = "card_updated"
+	EventCardDeleted  = "card_deleted"
+	EventCardRestored = "card_restored"
+	// Карточка одобрена модератором и стала видна поиску
+	EventCardApproved = "card_approved"
+	// Карточка перестала быть видна: деактивирована или возвращена на проверку
+	EventCardUnpublished = "card_unpublished"
+	EventStockChanged    = "stock_changed"
+	EventPriceChanged    = "price_changed"
+)
+
+// OutboxEvent - событие, записанное в outbox в одной транзакции с изменением товара
+type OutboxEvent struct {
+	ID          int64     // Порядковый номер события
+	AggregateID uuid.UUID // Карточка товара
+	EventType   string
+	Payload     []byte // contracts.ProductKafkaDTO в JSON
+	CreatedAt   time.Time
+}
+
+// Коды причин движения товара
+const (
+	MovementOpeningBalance     = "opening_balance"
+	MovementInitial            = "initial"
+	MovementRestock            = "restock"
+	MovementWriteOff           = "write_off"
+	MovementReservationHold    = "reservation_hold"
+	MovementReservationRelease = "reservation_release"
+	MovementReservationExpire  = "reservation_expire"
+)
+
+// StockAdjustment - одна операция пакетного изменения остатка варианта.
+// Delta > 0 пополняет склад, Delta < 0 списывает; WarehouseID == uuid.Nil - как в AddCountGood и DeleteCountGood.
+type StockAdjustment struct {
+	GoodID      uuid.UUID `json:"good_id"`
+	WarehouseID uuid.UUID `json:"warehouse_id"`
+	Delta       int       `json:"delta"`
+}
+
+// StockAdjustmentResult - доступное количество варианта по всем складам после пакета
+type StockAdjustmentResult struct {
+	GoodID   uuid.UUID `json:"good_id"`
+	NewCount int       `json:"new_count"`
+}
+
+// MovementActorService - исполнитель движений по запросам внутренних сервисов через HTTP (резервы заказов)
+const MovementActorService = "service"
+
+// MovementMeta - кто и в рамках какого запроса меняет остаток
+type MovementMeta struct {
+	Actor         string
+	CorrelationID string
+}
+
+// InventoryMovement - запись журнала движения товара. Журнал только дополняется.
+type InventoryMovement struct {
+	ID            int64      `json:"id"`
+	SKUID         uuid.UUID  `json:"skuId"`
+	WarehouseID   *uuid.UUID `json:"warehouseId"` // nil у записей склада, который удалён
+	Delta         int        `json:"delta"`       // Изменение доступного количества на складе
+	Reason        string     `json:"reason"`
+	Actor         string     `json:"actor"`
+	CorrelationID string     `json:"correlationId"`
+	QuantityAfter int        `json:"quantityAfter"` // Доступное количество на складе после движения
+	CreatedAt     time.Time  `json:"createdAt"`
+}
+
+// MovementPage - страница истории движения товара, от новых записей к старым
+type MovementPage struct {
+	Items      []InventoryMovement `json:"items"`
+	NextCursor string              `json:"nextCursor,omitempty"`
+}
+
+// LedgerMismatch - расхождение между журналом движения и goods.quantity
+type LedgerMismatch struct {
+	SKUID          uuid.UUID `json:"skuId"`
+	WarehouseID    uuid.UUID `json:"warehouseId"`
+	LedgerQuantity int       `json:"ledgerQuantity"` // Количество по журналу
+	ActualQuantity int       `json:"actualQuantity"` // Количество в goods
+}
+
+// Результат импорта строки
+const (
+	ImportCreated  = "created"
+	ImportUpdated  = "updated"
+	ImportRejected = "rejected"
+)
+
+// MovementImport - остаток, заданный импортом каталога
+const MovementImport = "import"
+
+// ImportRow - строка импорта каталога. Если Card.UUID задан, карточка обновляется,
+// иначе создаётся с начальным количеством Quantity.
+type ImportRow struct {
+	Line     int      // Номер строки во входном файле
+	Card     GoodCard // Card.SellerID игнорируется: импорт выполняется от имени продавца
+	Quantity *int     // Количество по всем складам; nil - не задано, у обновляемой карточки остаток не меняется
+	Err      error    // Ошибка разбора строки; такая строка сразу отклоняется
+}
+
+// ImportRowResult - итог обработки строки импорта
+type ImportRowResult struct {
+	Line   int    `json:"line"`
+	Status string `json:"status"`
+	ID     string `json:"id,omitempty"`
+	Reason string `json:"reason,omitempty"`
+}
+
+// ImportReport - отчёт об импорте каталога
+type ImportReport struct {
+	Created  int               `json:"created"`
+	Updated  int               `json:"updated"`
+	Rejected int               `json:"rejected"`
+	Rows     []ImportRowResult `json:"rows"`
+}
+
+// Add добавляет итог строки в отчёт и обновляет счётчики
+func (r *ImportReport) Add(result ImportRowResult) {
+	switch result.Status {
+	case ImportCreated:
+		r.Created++
+	case ImportUpdated:
+		r.Updated++
+	case ImportRejected:
+		r.Rejected++
+	}
+	r.Rows = append(r.Rows, result)
+}
+
+// GoodCardRevision - сохранённое состояние карточки товара в конкретной версии
+type GoodCardRevision struct {
+	CardID      uuid.UUID   `json:"cardId"`
+	Version     int         `json:"version"`
+	Price       money.Money `json:"price"`
+	Name        string      `json:"name"`
+	Description string      `json:"description"`
+	Weight      float64     `json:"weight"`
+	IsActive    bool        `json:"isActive"`
+	CreatedAt   time.Time   `json:"createdAt"`
+}
+
+// Category - узел дерева категорий
+type Category struct {
+	UUID     uuid.UUID  `json:"uuid"`
+	ParentID *uuid.UUID `json:"parentId"` // nil у корневой категории
+	Name     string     `json:"name"`
+}
+
+// Типы атрибутов категории
+const (
+	AttributeString  = "string"
+	AttributeNumber  = "number"
+	AttributeInteger = "integer"
+	AttributeBoolean = "boolean"
+	AttributeEnum    = "enum" // Строка из AllowedValues
+)
+
+// AttributeDefinition - атрибут (характеристика) в схеме категории.
+// Схема действует только для карточек самой категории, вложенные категории её не наследуют.
+type AttributeDefinition struct {
+	Name          string   `json:"name"`
+	Type          string   `json:"type"`                    // Одна из констант Attribute*
+	Unit          string   `json:"unit,omitempty"`          // Единица измерения, например GB
+	AllowedValues []string `json:"allowedValues,omitempty"` // Только для enum
+	Required      bool     `json:"required"`
+}
+
+// AttributeValue - значение атрибута карточки. Unit берётся из схемы категории при чтении.
+type AttributeValue struct {
+	Name  string      `json:"name"`
+	Value interface{} `json:"value"`
+	Unit  string      `json:"unit,omitempty"`
+}
+
+// Brand - бренд из реестра брендов
+type Brand struct {
+	UUID uuid.UUID `json:"uuid"`
+	Name string    `json:"name"`
+}
+
+// Статусы расписания цены
+const (
+	ScheduleStatusPending   = "pending"
+	ScheduleStatusActive    = "active"
+	ScheduleStatusFinished  = "finished"
+	ScheduleStatusCancelled = "cancelled"
+)
+
+// PriceSchedule - временная цена карточки: абсолютная (Price, в валюте карточки) или скидка в процентах (Percent)
+type PriceSchedule struct {
+	UUID      uuid.UUID    `json:"uuid"`
+	CardID    uuid.UUID    `json:"cardId"`
+	StartsAt  time.Time    `json:"startsAt"`
+	EndsAt    time.Time    `json:"endsAt"`
+	Price     *money.Money `json:"price,omitempty"`
+	Percent   *float64     `json:"percent,omitempty"`
+	Status    string       `json:"status"`
+	CreatedAt time.Time    `json:"createdAt"`
+}
+
+// ExchangeRate - курс для показа цен в другой валюте: 1 Base = Rate Quote.
+// Курс передаётся десятичной строкой, чтобы не терять точность.
+type ExchangeRate struct {
+	Base      string    `json:"base"`
+	Quote     string    `json:"quote"`
+	Rate      string    `json:"rate"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// События оповещений об остатке
+const (
+	AlertLowStock   = "low_stock"    // Остаток карточки опустился до порога
+	AlertOutOfStock = "out_of_stock" // Остаток карточки закончился
+)
+
+// Статусы доставки оповещения
+const (
+	DeliveryPending   = "pending"
+	DeliveryDelivered = "delivered"
+	DeliveryFailed    = "failed" // Попытки исчерпаны
+)
+
+// Webhook - адрес продавца для оповещений. Secret возвращается только при регистрации.
+type Webhook struct {
+	SellerID  uuid.UUID `json:"sellerId"`
+	URL       string    `json:"url"`
+	Secret    string    `json:"secret,omitempty"` // Ключ HMAC-подписи доставок
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// StockAlert - тело оповещения об остатке
+type StockAlert struct {
+	Event      string    `json:"event"`
+	CardID     uuid.UUID `json:"cardId"`
+	SellerID   uuid.UUID `json:"sellerId"`
+	Quantity   int       `json:"quantity"`            // Доступное количество карточки после списания
+	Threshold  *int      `json:"threshold,omitempty"` // Порог карточки на момент списания
+	OccurredAt time.Time `json:"occurredAt"`
+}
+
+// WebhookDelivery - запись журнала доставки оповещения
+type WebhookDelivery struct {
+	ID            int64           `json:"id"`
+	SellerID      uuid.UUID       `json:"sellerId"`
+	CardID        uuid.UUID       `json:"cardId"`
+	Event         string          `json:"event"`
+	URL           string          `json:"url"`
+	Payload       json.RawMessage `json:"payload"`
+	Status        string          `json:"status"`
+	Attempts      int             `json:"attempts"`
+	NextAttemptAt time.Time       `json:"nextAttemptAt"`
+	ResponseCode  *int            `json:"responseCode,omitempty"` // Код ответа последней попытки
+	LastError     string          `json:"lastError,omitempty"`
+	CreatedAt     time.Time       `json:"createdAt"`
+	DeliveredAt   *time.Time      `json:"deliveredAt,omitempty"`
+	Secret        string          `json:"-"` // Ключ подписи, заполняется для отправки
+}
+
+// WebhookDeliveryPage - страница журнала доставок, от новых записей к старым
+type WebhookDeliveryPage struct {
+	Items      []WebhookDelivery `json:"items"`
+	NextCursor string            `json:"nextCursor,omitempty"`
+}
+
+// WebhookAttempt - результат попытки доставки
+type WebhookAttempt struct {
+	Status        string // DeliveryPending - будет повтор в NextAttemptAt
+	ResponseCode  *int   // nil, если ответ не получен
+	Error         string
+	NextAttemptAt time.Time
+}
+
+// IdempotentResponse - ответ на первый запрос с ключом Idempotency-Key, который повторяется для повторов
+type IdempotentResponse struct {
+	Fingerprint string // Отпечаток первого запроса
+	StatusCode  int    // 0, пока первый запрос выполняется
+	ContentType string
+	Body        []byte
 }
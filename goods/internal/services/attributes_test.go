@@ -0,0 +1,80 @@
+package services
+
+import (
+	database "goods/internal/database/postgres"
+	"goods/internal/models"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+// fakeAttributeDB запоминает, дошла ли схема до базы
+type fakeAttributeDB struct {
+	database.InterfacePostgresDB
+	called bool
+}
+
+func (f *fakeAttributeDB) SetAttributeSchema(categoryID uuid.UUID, schema []models.AttributeDefinition) error {
+	f.called = true
+	return nil
+}
+
+func TestSetAttributeSchemaValidatesDefinitions(t *testing.T) {
+	invalid := map[string][]models.AttributeDefinition{
+		"empty name":       {{Name: " ", Type: models.AttributeString}},
+		"duplicate name":   {{Name: "ram", Type: models.AttributeInteger}, {Name: "ram", Type: models.AttributeNumber}},
+		"unknown type":     {{Name: "ram", Type: "bytes"}},
+		"enum without":     {{Name: "color", Type: models.AttributeEnum}},
+		"enum duplicate":   {{Name: "color", Type: models.AttributeEnum, AllowedValues: []string{"red", "red"}}},
+		"values on string": {{Name: "model", Type: models.AttributeString, AllowedValues: []string{"x"}}},
+	}
+	for name, schema := range invalid {
+		db := &fakeAttributeDB{}
+		srv := &Srv{db: db}
+		if err := srv.SrvSetAttributeSchema(uuid.New(), schema); err == nil {
+			t.Errorf("%s: schema accepted", name)
+		}
+		if db.called {
+			t.Errorf("%s: invalid schema must not reach the database", name)
+		}
+	}
+
+	db := &fakeAttributeDB{}
+	srv := &Srv{db: db}
+	schema := []models.AttributeDefinition{
+		{Name: "ram", Type: models.AttributeInteger, Unit: "GB", Required: true},
+		{Name: "color", Type: models.AttributeEnum, AllowedValues: []string{"black", "silver"}},
+	}
+	if err := srv.SrvSetAttributeSchema(uuid.New(), schema); err != nil || !db.called {
+		t.Fatalf("valid schema: err = %v, called = %v", err, db.called)
+	}
+}
+
+func TestCheckAttributes(t *testing.T) {
+	schema := []models.AttributeDefinition{
+		{Name: "ram", Type: models.AttributeInteger, Unit: "GB", Required: true},
+		{Name: "color", Type: models.AttributeEnum, AllowedValues: []string{"black", "silver"}},
+	}
+	cases := []struct {
+		values map[string]interface{}
+		ok     bool
+	}{
+		{map[string]interface{}{"ram": 16.0, "color": "black"}, true},
+		{map[string]interface{}{"ram": 16.0}, true},
+		{map[string]interface{}{"color": "black"}, false},
+		{map[string]interface{}{"ram": 16.5}, false},
+		{map[string]interface{}{"ram": "16"}, false},
+		{map[string]interface{}{"ram": 16.0, "color": "pink"}, false},
+		{map[string]interface{}{"ram": 16.0, "cpu": "M3"}, false},
+	}
+	for _, c := range cases {
+		if err := models.CheckAttributes(schema, c.values); (err == nil) != c.ok {
+			t.Errorf("CheckAttributes(%v) = %v, want ok = %v", c.values, err, c.ok)
+		}
+	}
+
+	list := models.AttributeList(schema, map[string]interface{}{"color": "black", "ram": 16.0})
+	if len(list) != 2 || list[0].Name != "ram" || list[0].Unit != "GB" {
+		t.Fatalf("AttributeList = %+v, want schema order with units", list)
+	}
+}
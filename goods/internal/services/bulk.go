@@ -0,0 +1,100 @@
+package services
+
+import (
+	"errors"
+	myErrors "goods/internal/errors"
+	"goods/internal/models"
+	"io"
+	"sort"
+
+	"github.com/google/uuid"
+)
+
+// Сколько строк импорта записывается в одной транзакции
+const importBatchSize = 500
+
+func (srv *Srv) SrvImportGoodCards(sellerID uuid.UUID, next func() (models.ImportRow, error), meta models.MovementMeta) (models.ImportReport, error) {
+	report := models.ImportReport{Rows: make([]models.ImportRowResult, 0)}
+	batch := make([]models.ImportRow, 0, importBatchSize)
+
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		results, err := srv.db.ImportGoodCards(sellerID, batch, meta)
+		if err != nil {
+			return err
+		}
+		for _, result := range results {
+			report.Add(result)
+		}
+		batch = batch[:0]
+		return nil
+	}
+
+	for {
+		row, err := next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return report, err
+		}
+
+		if row.Err == nil {
+			row.Err = validateImportRow(row)
+		}
+		if row.Err != nil {
+			report.Add(models.ImportRowResult{Line: row.Line, Status: models.ImportRejected, Reason: row.Err.Error()})
+			continue
+		}
+
+		batch = append(batch, row)
+		if len(batch) == importBatchSize {
+			if err := flush(); err != nil {
+				return report, err
+			}
+		}
+	}
+	if err := flush(); err != nil {
+		return report, err
+	}
+
+	// Отклонённые при разборе строки попадают в отчёт раньше записанных пачек
+	sort.SliceStable(report.Rows, func(i, j int) bool { return report.Rows[i].Line < report.Rows[j].Line })
+	return report, nil
+}
+
+func validateImportRow(row models.ImportRow) error {
+	if err := validateGoodCard(row.Card); err != nil {
+		var myErr myErrors.Error
+		if errors.As(err, &myErr) {
+			return errors.New(myErr.GetCause())
+		}
+		return err
+	}
+	if row.Quantity != nil && *row.Quantity < 0 {
+		return errors.New(myErrors.ValidationError("quantity", "must not be negative").GetCause())
+	}
+	return nil
+}
+
+func (srv *Srv) SrvExportGoods(sellerID uuid.UUID, emit func(good models.Good) error) error {
+	// Каталог читается страницами по ключу, а не целиком
+	filter := models.GoodFilter{SellerID: &sellerID, Limit: models.MaxPageLimit}
+	for {
+		page, err := srv.db.ListGoods(filter)
+		if err != nil {
+			return err
+		}
+		for _, good := range page.Items {
+			if err := emit(good); err != nil {
+				return err
+			}
+		}
+		if page.NextCursor == "" {
+			return nil
+		}
+		filter.Cursor = page.NextCursor
+	}
+}
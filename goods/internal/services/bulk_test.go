@@ -0,0 +1,112 @@
+package services
+
+import (
+	database "goods/internal/database/postgres"
+	"goods/internal/models"
+	"goods/pkg/money"
+	"io"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+// fakeImportDB запоминает размеры пачек и создаёт каждую строку
+type fakeImportDB struct {
+	database.InterfacePostgresDB
+	batches []int
+}
+
+func (f *fakeImportDB) ImportGoodCards(sellerID uuid.UUID, rows []models.ImportRow, meta models.MovementMeta) ([]models.ImportRowResult, error) {
+	f.batches = append(f.batches, len(rows))
+	results := make([]models.ImportRowResult, 0, len(rows))
+	for _, row := range rows {
+		results = append(results, models.ImportRowResult{Line: row.Line, Status: models.ImportCreated})
+	}
+	return results, nil
+}
+
+// importRows отдаёт строки по одной, затем io.EOF
+func importRows(rows []models.ImportRow) func() (models.ImportRow, error) {
+	return func() (models.ImportRow, error) {
+		if len(rows) == 0 {
+			return models.ImportRow{}, io.EOF
+		}
+		row := rows[0]
+		rows = rows[1:]
+		return row, nil
+	}
+}
+
+func TestImportBatchesRowsAndReportsInLineOrder(t *testing.T) {
+	db := &fakeImportDB{}
+	srv := &Srv{db: db}
+	card := models.GoodCard{Name: "Чайник", Price: money.Money{Amount: 1000, Currency: "RUB"}, Weight: 1}
+	negative := -1
+
+	var rows []models.ImportRow
+	for line := 2; line < importBatchSize+12; line++ {
+		row := models.ImportRow{Line: line, Card: card}
+		if line == 5 {
+			row.Quantity = &negative
+		}
+		rows = append(rows, row)
+	}
+
+	report, err := srv.SrvImportGoodCards(uuid.New(), importRows(rows), models.MovementMeta{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(db.batches) != 2 || db.batches[0] != importBatchSize || db.batches[1] != len(rows)-1-importBatchSize {
+		t.Fatalf("batches = %v, want a full batch and the remainder", db.batches)
+	}
+	if report.Created != len(rows)-1 || report.Rejected != 1 {
+		t.Fatalf("report = %d created, %d rejected", report.Created, report.Rejected)
+	}
+	// Строка, отклонённая проверкой, стоит в отчёте на своём месте, а не перед записанными пачками
+	for i, row := range report.Rows {
+		if row.Line != i+2 {
+			t.Fatalf("row %d has line %d, want %d", i, row.Line, i+2)
+		}
+	}
+	if report.Rows[3].Status != models.ImportRejected || report.Rows[3].Reason == "" {
+		t.Fatalf("row with negative quantity = %+v, want rejected with a reason", report.Rows[3])
+	}
+}
+
+func TestExportReadsAllPages(t *testing.T) {
+	db := &fakePagedDB{pages: []models.GoodPage{
+		{Items: []models.Good{{Quantity: 1}, {Quantity: 2}}, NextCursor: "next"},
+		{Items: []models.Good{{Quantity: 3}}},
+	}}
+	srv := &Srv{db: db}
+	seller := uuid.New()
+
+	var quantities []int
+	err := srv.SrvExportGoods(seller, func(good models.Good) error {
+		quantities = append(quantities, good.Quantity)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(quantities) != 3 || quantities[2] != 3 {
+		t.Fatalf("exported %v, want all three goods", quantities)
+	}
+	if len(db.filters) != 2 || db.filters[1].Cursor != "next" || *db.filters[0].SellerID != seller {
+		t.Fatalf("filters = %+v, want the seller's pages in order", db.filters)
+	}
+}
+
+// fakePagedDB отдаёт заданные страницы списка по очереди
+type fakePagedDB struct {
+	database.InterfacePostgresDB
+	pages   []models.GoodPage
+	filters []models.GoodFilter
+}
+
+func (f *fakePagedDB) ListGoods(filter models.GoodFilter) (models.GoodPage, error) {
+	f.filters = append(f.filters, filter)
+	page := f.pages[0]
+	f.pages = f.pages[1:]
+	return page, nil
+}
@@ -0,0 +1,78 @@
+package services
+
+import (
+	myErrors "goods/internal/errors"
+	"goods/internal/models"
+
+	"github.com/google/uuid"
+)
+
+func (srv *Srv) SrvCreateCategory(parentID *uuid.UUID, name string) (uuid.UUID, error) {
+	if err := validateName(name); err != nil {
+		return uuid.Nil, err
+	}
+	return srv.db.CreateCategory(parentID, name)
+}
+
+func (srv *Srv) SrvReadCategory(id uuid.UUID) (models.Category, error) {
+	return srv.db.ReadCategory(id)
+}
+
+func (srv *Srv) SrvListCategories(parentID *uuid.UUID) ([]models.Category, error) {
+	return srv.db.ListCategories(parentID)
+}
+
+func (srv *Srv) SrvCategoryPath(id uuid.UUID) ([]models.Category, error) {
+	return srv.db.CategoryPath(id)
+}
+
+func (srv *Srv) SrvRenameCategory(id uuid.UUID, name string) error {
+	if err := validateName(name); err != nil {
+		return err
+	}
+	return srv.db.RenameCategory(id, name)
+}
+
+func (srv *Srv) SrvMoveCategory(id uuid.UUID, parentID *uuid.UUID) error {
+	if parentID != nil && *parentID == id {
+		return myErrors.ErrCategoryCycle
+	}
+	return srv.db.MoveCategory(id, parentID)
+}
+
+func (srv *Srv) SrvDeleteCategory(id uuid.UUID) error {
+	return srv.db.DeleteCategory(id)
+}
+
+func (srv *Srv) SrvReadAttributeSchema(categoryID uuid.UUID) ([]models.AttributeDefinition, error) {
+	return srv.db.ReadAttributeSchema(categoryID)
+}
+
+func (srv *Srv) SrvSetAttributeSchema(categoryID uuid.UUID, schema []models.AttributeDefinition) error {
+	if err := validateAttributeSchema(schema); err != nil {
+		return err
+	}
+	return srv.db.SetAttributeSchema(categoryID, schema)
+}
+
+func (srv *Srv) SrvCreateBrand(name string) (uuid.UUID, error) {
+	if err := validateName(name); err != nil {
+		return uuid.Nil, err
+	}
+	return srv.db.CreateBrand(name)
+}
+
+func (srv *Srv) SrvListBrands() ([]models.Brand, error) {
+	return srv.db.ListBrands()
+}
+
+func (srv *Srv) SrvRenameBrand(id uuid.UUID, name string) error {
+	if err := validateName(name); err != nil {
+		return err
+	}
+	return srv.db.RenameBrand(id, name)
+}
+
+func (srv *Srv) SrvDeleteBrand(id uuid.UUID) error {
+	return srv.db.DeleteBrand(id)
+}
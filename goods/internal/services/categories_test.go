@@ -0,0 +1,61 @@
+package services
+
+import (
+	database "goods/internal/database/postgres"
+	myErrors "goods/internal/errors"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+// fakeCategoriesDB запоминает, дошёл ли запрос до базы
+type fakeCategoriesDB struct {
+	database.InterfacePostgresDB
+	called bool
+}
+
+func (f *fakeCategoriesDB) CreateCategory(parentID *uuid.UUID, name string) (uuid.UUID, error) {
+	f.called = true
+	return uuid.New(), nil
+}
+
+func (f *fakeCategoriesDB) MoveCategory(id uuid.UUID, parentID *uuid.UUID) error {
+	f.called = true
+	return nil
+}
+
+func (f *fakeCategoriesDB) CreateBrand(name string) (uuid.UUID, error) {
+	f.called = true
+	return uuid.New(), nil
+}
+
+func TestCategoryAndBrandNamesAreValidated(t *testing.T) {
+	db := &fakeCategoriesDB{}
+	srv := &Srv{db: db}
+
+	for _, name := range []string{"", "   ", strings.Repeat("я", maxNameLength+1)} {
+		if _, err := srv.SrvCreateCategory(nil, name); err == nil {
+			t.Errorf("category name of %d bytes accepted", len(name))
+		}
+		if _, err := srv.SrvCreateBrand(name); err == nil {
+			t.Errorf("brand name of %d bytes accepted", len(name))
+		}
+	}
+	if db.called {
+		t.Fatal("invalid name must not reach the database")
+	}
+}
+
+func TestMoveCategoryUnderItself(t *testing.T) {
+	db := &fakeCategoriesDB{}
+	srv := &Srv{db: db}
+	id := uuid.New()
+
+	if err := srv.SrvMoveCategory(id, &id); err != myErrors.ErrCategoryCycle || db.called {
+		t.Fatalf("err = %v, called = %v, want ErrCategoryCycle before the database", err, db.called)
+	}
+	if err := srv.SrvMoveCategory(id, nil); err != nil {
+		t.Fatalf("move to root: %v", err)
+	}
+}
@@ -0,0 +1,86 @@
+package services
+
+import (
+	database "goods/internal/database/postgres"
+	myErrors "goods/internal/errors"
+	"goods/internal/models"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// fakeIdempotencyDB хранит ключи в памяти; остальные методы базы тестам не нужны
+type fakeIdempotencyDB struct {
+	database.InterfacePostgresDB
+	keys map[string]models.IdempotentResponse
+}
+
+func (f *fakeIdempotencyDB) ClaimIdempotencyKey(scope uuid.UUID, key string, fingerprint string, ttl time.Duration, lease time.Duration) (models.IdempotentResponse, bool, error) {
+	id := scope.String() + "/" + key
+	if stored, ok := f.keys[id]; ok {
+		return stored, false, nil
+	}
+	f.keys[id] = models.IdempotentResponse{Fingerprint: fingerprint}
+	return models.IdempotentResponse{}, true, nil
+}
+
+func (f *fakeIdempotencyDB) SaveIdempotentResponse(scope uuid.UUID, key string, response models.IdempotentResponse) error {
+	id := scope.String() + "/" + key
+	response.Fingerprint = f.keys[id].Fingerprint
+	f.keys[id] = response
+	return nil
+}
+
+func (f *fakeIdempotencyDB) ReleaseIdempotencyKey(scope uuid.UUID, key string) error {
+	delete(f.keys, scope.String()+"/"+key)
+	return nil
+}
+
+func TestIdempotentReplay(t *testing.T) {
+	srv := &Srv{db: &fakeIdempotencyDB{keys: map[string]models.IdempotentResponse{}}, idempotencyTTL: time.Hour}
+	scope := uuid.New()
+
+	stored, err := srv.SrvBeginIdempotent(scope, "k1", "fp")
+	if err != nil || stored != nil {
+		t.Fatalf("first request: stored = %v, err = %v; want claim", stored, err)
+	}
+	// Пока первый запрос не завершён, повтор не выполняется параллельно
+	if _, err := srv.SrvBeginIdempotent(scope, "k1", "fp"); err != myErrors.ErrIdempotencyInProgress {
+		t.Fatalf("concurrent repeat: err = %v, want ErrIdempotencyInProgress", err)
+	}
+
+	response := models.IdempotentResponse{StatusCode: 200, ContentType: "application/json", Body: []byte(`{"quantity":5}`)}
+	if err := srv.SrvFinishIdempotent(scope, "k1", response); err != nil {
+		t.Fatal(err)
+	}
+	stored, err = srv.SrvBeginIdempotent(scope, "k1", "fp")
+	if err != nil || stored == nil || stored.StatusCode != 200 || string(stored.Body) != `{"quantity":5}` {
+		t.Fatalf("repeat: stored = %+v, err = %v; want first response", stored, err)
+	}
+
+	if _, err := srv.SrvBeginIdempotent(scope, "k1", "other"); err != myErrors.ErrIdempotencyKeyReused {
+		t.Errorf("different payload: err = %v, want ErrIdempotencyKeyReused", err)
+	}
+	// Ключи разных продавцов не пересекаются
+	if stored, err := srv.SrvBeginIdempotent(uuid.New(), "k1", "other"); err != nil || stored != nil {
+		t.Errorf("other scope: stored = %v, err = %v; want claim", stored, err)
+	}
+}
+
+func TestIdempotentServerErrorReleasesKey(t *testing.T) {
+	srv := &Srv{db: &fakeIdempotencyDB{keys: map[string]models.IdempotentResponse{}}, idempotencyTTL: time.Hour}
+
+	if _, err := srv.SrvBeginIdempotent(uuid.Nil, "k", "fp"); err != nil {
+		t.Fatal(err)
+	}
+	if err := srv.SrvFinishIdempotent(uuid.Nil, "k", models.IdempotentResponse{StatusCode: 500}); err != nil {
+		t.Fatal(err)
+	}
+	if stored, err := srv.SrvBeginIdempotent(uuid.Nil, "k", "fp"); err != nil || stored != nil {
+		t.Errorf("retry after 500: stored = %v, err = %v; want claim", stored, err)
+	}
+	if _, err := srv.SrvBeginIdempotent(uuid.Nil, "", "fp"); err != myErrors.ErrIdempotencyKeyInvalid {
+		t.Errorf("empty key: err = %v, want ErrIdempotencyKeyInvalid", err)
+	}
+}
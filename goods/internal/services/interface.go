@@ -2,23 +2,138 @@ package services
 
 import (
 	"goods/internal/models"
+	"goods/pkg/money"
+	"time"
 
 	"github.com/google/uuid"
 )
 
+// Методы с параметром sellerID работают только с карточками этого продавца;
+// чужая карточка даёт myErrors.ErrGoodCardNotFound.
 type InterfaceService interface {
-	SrvCreateGoodCard(card models.GoodCard) (uuid.UUID, error)
+	SrvCreateGoodCard(sellerID uuid.UUID, card models.GoodCard) (uuid.UUID, error)
 
-	SrvDeleteGoodCard(cardId uuid.UUID) error
+	// Карточка помечается удалённой и пропадает из чтения и списков до восстановления
+	SrvDeleteGoodCard(sellerID uuid.UUID, cardId uuid.UUID) error
 
-	SrvUpdateGoodCard(uuid uuid.UUID, card models.GoodCard) error
+	SrvRestoreGoodCard(sellerID uuid.UUID, cardID uuid.UUID) error
 
-	SrvReadGood(uuid uuid.UUID) (models.Good, error)
+	// expectedVersion - версия из If-Match, 0 - любая; возвращает новую версию
+	SrvUpdateGoodCard(sellerID uuid.UUID, uuid uuid.UUID, card models.GoodCard, expectedVersion int) (int, error)
 
-	SrvAddCountGood(uuid uuid.UUID, number int) (int, error) //суммарное количество товара
+	// Меняет только поля, заданные в patch (JSON Merge Patch)
+	SrvPatchGoodCard(sellerID uuid.UUID, cardID uuid.UUID, patch models.GoodCardPatch, expectedVersion int) (int, error)
 
-	SrvDeleteCountGood(uuid uuid.UUID, number int) (int, error)
+	SrvListRevisions(sellerID uuid.UUID, cardID uuid.UUID) ([]models.GoodCardRevision, error)
 
-	SrvCreateGood(cardID uuid.UUID, quantity int) error
-	SrvDeleteGood(goodID uuid.UUID) error
+	SrvRestoreRevision(sellerID uuid.UUID, cardID uuid.UUID, version int, expectedVersion int) (int, error)
+
+	SrvReadGood(sellerID uuid.UUID, uuid uuid.UUID) (models.Good, error)
+
+	// Карточка со всеми вариантами
+	SrvReadGoodCard(sellerID uuid.UUID, cardID uuid.UUID) (models.Good, error)
+
+	// Карточки любых продавцов для внутренних сервисов, без изображений; не найденные пропускаются
+	SrvBatchReadGoodCards(ids []uuid.UUID) ([]models.Good, error)
+
+	SrvListGoods(sellerID uuid.UUID, filter models.GoodFilter) (models.GoodPage, error)
+	// Витрина для покупателей: только активные одобренные карточки; продавец - из filter.SellerID
+	SrvListStorefrontGoods(filter models.GoodFilter) (models.GoodPage, error)
+
+	// Операции с остатком принимают UUID варианта (SKU); у товара без вариантов он равен UUID карточки.
+	// warehouseID == uuid.Nil: пополняется склад по умолчанию, списание идёт с первого по приоритету склада с достаточным остатком.
+	SrvAddCountGood(sellerID uuid.UUID, uuid uuid.UUID, warehouseID uuid.UUID, number int, meta models.MovementMeta) (int, error) //суммарное количество товара
+
+	SrvDeleteCountGood(sellerID uuid.UUID, uuid uuid.UUID, warehouseID uuid.UUID, number int, meta models.MovementMeta) (int, error)
+
+	// SrvAdjustStock применяет пакет операций с остатком атомарно. Ошибка операции - myErrors.ItemError с её номером.
+	SrvAdjustStock(sellerID uuid.UUID, items []models.StockAdjustment, meta models.MovementMeta) ([]models.StockAdjustmentResult, error)
+
+	SrvCreateGood(sellerID uuid.UUID, cardID uuid.UUID, quantity int, meta models.MovementMeta) error
+	SrvDeleteGood(sellerID uuid.UUID, skuID uuid.UUID) error
+
+	SrvCreateSKU(sellerID uuid.UUID, cardID uuid.UUID, sku models.SKU, quantity int, meta models.MovementMeta) (uuid.UUID, error)
+	// nil в price или weight - значение карточки
+	SrvUpdateSKU(sellerID uuid.UUID, skuID uuid.UUID, price *money.Money, weight *float64) error
+
+	// warehouseID - предпочтительный склад, uuid.Nil - любой; ttl == 0 - срок по умолчанию
+	SrvReserveGood(skuID uuid.UUID, warehouseID uuid.UUID, number int, ttl time.Duration, meta models.MovementMeta) (models.Reservation, error)
+	SrvCommitReservation(reservationID uuid.UUID, meta models.MovementMeta) error
+	SrvReleaseReservation(reservationID uuid.UUID, meta models.MovementMeta) error
+
+	// next возвращает io.EOF после последней строки
+	SrvImportGoodCards(sellerID uuid.UUID, next func() (models.ImportRow, error), meta models.MovementMeta) (models.ImportReport, error)
+	SrvExportGoods(sellerID uuid.UUID, emit func(good models.Good) error) error
+
+	SrvListMovements(sellerID uuid.UUID, skuID uuid.UUID, limit int, cursor string) (models.MovementPage, error)
+	SrvQuantityAt(sellerID uuid.UUID, skuID uuid.UUID, at time.Time) (int, error)
+	SrvCheckLedger() ([]models.LedgerMismatch, error)
+
+	// contentType - заявленный клиентом тип; он должен совпадать с содержимым data
+	SrvUploadMedia(sellerID uuid.UUID, cardID uuid.UUID, contentType string, data []byte) (models.Media, error)
+	SrvListMedia(sellerID uuid.UUID, cardID uuid.UUID) ([]models.Media, error)
+	// ids - все изображения карточки в новом порядке
+	SrvReorderMedia(sellerID uuid.UUID, cardID uuid.UUID, ids []uuid.UUID) ([]models.Media, error)
+	SrvDeleteMedia(sellerID uuid.UUID, cardID uuid.UUID, mediaID uuid.UUID) error
+
+	// parentID == nil - корень дерева
+	SrvCreateCategory(parentID *uuid.UUID, name string) (uuid.UUID, error)
+	SrvReadCategory(id uuid.UUID) (models.Category, error)
+	SrvListCategories(parentID *uuid.UUID) ([]models.Category, error)
+	SrvCategoryPath(id uuid.UUID) ([]models.Category, error)
+	SrvRenameCategory(id uuid.UUID, name string) error
+	SrvMoveCategory(id uuid.UUID, parentID *uuid.UUID) error
+	SrvDeleteCategory(id uuid.UUID) error
+	SrvReadAttributeSchema(categoryID uuid.UUID) ([]models.AttributeDefinition, error)
+	// Схема заменяется целиком; значения удалённых атрибутов удаляются из карточек категории
+	SrvSetAttributeSchema(categoryID uuid.UUID, schema []models.AttributeDefinition) error
+
+	SrvCreateBrand(name string) (uuid.UUID, error)
+	SrvListBrands() ([]models.Brand, error)
+	SrvRenameBrand(id uuid.UUID, name string) error
+	SrvDeleteBrand(id uuid.UUID) error
+
+	// Задаётся ровно одно из schedule.Price и schedule.Percent
+	SrvCreatePriceSchedule(sellerID uuid.UUID, cardID uuid.UUID, schedule models.PriceSchedule) (uuid.UUID, error)
+	SrvListPriceSchedules(sellerID uuid.UUID, cardID uuid.UUID) ([]models.PriceSchedule, error)
+	SrvCancelPriceSchedule(sellerID uuid.UUID, cardID uuid.UUID, scheduleID uuid.UUID) error
+
+	// Цены карточки в других валютах; список заменяется целиком, валюта карточки в нём не указывается
+	SrvReadCardPrices(sellerID uuid.UUID, cardID uuid.UUID) ([]money.Money, error)
+	SrvSetCardPrices(sellerID uuid.UUID, cardID uuid.UUID, prices []money.Money) error
+	// Курсы добавляются или обновляются по паре валют
+	SrvListExchangeRates() ([]models.ExchangeRate, error)
+	SrvSetExchangeRates(rates []models.ExchangeRate) error
+	// Задаёт DisplayPrice карточкам goods; ошибка, если для какой-то карточки нет ни цены, ни курса
+	SrvFillDisplayPrices(goods []models.Good, currency string) error
+
+	SrvCreateWarehouse(sellerID uuid.UUID, warehouse models.Warehouse) (uuid.UUID, error)
+	SrvListWarehouses(sellerID uuid.UUID) ([]models.Warehouse, error)
+	SrvUpdateWarehouse(sellerID uuid.UUID, warehouse models.Warehouse) error
+	// Удаляется только склад без остатка и резервов
+	SrvDeleteWarehouse(sellerID uuid.UUID, id uuid.UUID) error
+
+	// threshold == nil отключает оповещение о пороге; оповещение об исчерпании остатка отправляется всегда
+	SrvSetLowStockThreshold(sellerID uuid.UUID, cardID uuid.UUID, threshold *int) error
+	// Возвращает адрес вместе с новым ключом подписи; ключ больше нигде не возвращается
+	SrvSetWebhook(sellerID uuid.UUID, url string) (models.Webhook, error)
+	SrvReadWebhook(sellerID uuid.UUID) (models.Webhook, error)
+	SrvDeleteWebhook(sellerID uuid.UUID) error
+	SrvListWebhookDeliveries(sellerID uuid.UUID, status string, limit int, cursor string) (models.WebhookDeliveryPage, error)
+
+	// Возвращает nil, если ключ занят за этим запросом и его нужно выполнить,
+	// иначе - сохранённый ответ на первый запрос с тем же ключом
+	SrvBeginIdempotent(scope uuid.UUID, key string, fingerprint string) (*models.IdempotentResponse, error)
+	// Запоминает ответ на запрос, занявший ключ; ответ с ошибкой сервера освобождает ключ для повтора
+	SrvFinishIdempotent(scope uuid.UUID, key string, response models.IdempotentResponse) error
+
+	// Отправляет черновик или отклонённую карточку на проверку
+	SrvSubmitGoodCard(sellerID uuid.UUID, cardID uuid.UUID) error
+	SrvListModerationLog(sellerID uuid.UUID, cardID uuid.UUID) ([]models.ModerationDecision, error)
+	// Методы модератора: очередь проверки, решения и журнал решений по любой карточке.
+	// expectedVersion - версия, которую проверил модератор (0 - без проверки)
+	SrvModerationQueue(limit int, cursor string) (models.GoodPage, error)
+	SrvModerationHistory(cardID uuid.UUID) ([]models.ModerationDecision, error)
+	SrvApproveGoodCard(moderatorID uuid.UUID, cardID uuid.UUID, expectedVersion int) error
+	SrvRejectGoodCard(moderatorID uuid.UUID, cardID uuid.UUID, expectedVersion int, reason string) error
 }
@@ -0,0 +1,26 @@
+package services
+
+import (
+	"goods/internal/models"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func (srv *Srv) SrvListMovements(sellerID uuid.UUID, skuID uuid.UUID, limit int, cursor string) (models.MovementPage, error) {
+	if err := srv.checkSKUOwner(sellerID, skuID); err != nil {
+		return models.MovementPage{}, err
+	}
+	return srv.db.ListMovements(skuID, limit, cursor)
+}
+
+func (srv *Srv) SrvQuantityAt(sellerID uuid.UUID, skuID uuid.UUID, at time.Time) (int, error) {
+	if err := srv.checkSKUOwner(sellerID, skuID); err != nil {
+		return 0, err
+	}
+	return srv.db.QuantityAt(skuID, at)
+}
+
+func (srv *Srv) SrvCheckLedger() ([]models.LedgerMismatch, error) {
+	return srv.db.CheckLedger()
+}
@@ -0,0 +1,51 @@
+package services
+
+import (
+	database "goods/internal/database/postgres"
+	"goods/internal/models"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+// fakeListDB запоминает фильтр, с которым запрошен список
+type fakeListDB struct {
+	database.InterfacePostgresDB
+	filter models.GoodFilter
+}
+
+func (f *fakeListDB) ListGoods(filter models.GoodFilter) (models.GoodPage, error) {
+	f.filter = filter
+	return models.GoodPage{}, nil
+}
+
+func TestListGoodsIsScopedToCaller(t *testing.T) {
+	db := &fakeListDB{}
+	srv := &Srv{db: db}
+	caller, other := uuid.New(), uuid.New()
+
+	if _, err := srv.SrvListGoods(caller, models.GoodFilter{SellerID: &other}); err != nil {
+		t.Fatal(err)
+	}
+	if db.filter.SellerID == nil || *db.filter.SellerID != caller {
+		t.Fatalf("seller filter = %v, want caller %v", db.filter.SellerID, caller)
+	}
+}
+
+func TestStorefrontListsOnlyPublishedCards(t *testing.T) {
+	db := &fakeListDB{}
+	srv := &Srv{db: db}
+	seller := uuid.New()
+	inactive := false
+
+	filter := models.GoodFilter{SellerID: &seller, IsActive: &inactive, Moderation: models.ModerationDraft}
+	if _, err := srv.SrvListStorefrontGoods(filter); err != nil {
+		t.Fatal(err)
+	}
+	if db.filter.SellerID == nil || *db.filter.SellerID != seller {
+		t.Fatalf("seller filter = %v, want %v", db.filter.SellerID, seller)
+	}
+	if db.filter.IsActive == nil || !*db.filter.IsActive || db.filter.Moderation != models.ModerationApproved {
+		t.Fatalf("filter = %+v, want only active approved cards", db.filter)
+	}
+}
@@ -0,0 +1,184 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	myErrors "goods/internal/errors"
+	myLog "goods/internal/logger"
+	"goods/internal/models"
+	"image"
+	"image/color"
+	_ "image/gif" // Декодер GIF для image.Decode
+	"image/jpeg"
+	_ "image/png" // Декодер PNG для image.Decode
+	"net/http"
+
+	"github.com/google/uuid"
+)
+
+// Ограничения изображений
+const (
+	maxImagePixels     = 40_000_000 // Защита от изображений, занимающих гигабайты после декодирования
+	thumbnailSize      = 320        // Длинная сторона миниатюры, px
+	thumbnailQuality   = 85
+	thumbnailMediaType = "image/jpeg"
+)
+
+// Допустимые типы изображений и расширения файлов в хранилище
+var imageExtensions = map[string]string{
+	"image/jpeg": "jpg",
+	"image/png":  "png",
+	"image/gif":  "gif",
+}
+
+// SrvUploadMedia проверяет изображение, сохраняет его вместе с миниатюрой и добавляет в конец списка изображений карточки
+func (srv *Srv) SrvUploadMedia(sellerID uuid.UUID, cardID uuid.UUID, contentType string, data []byte) (models.Media, error) {
+	if len(data) > srv.mediaMaxSize {
+		return models.Media{}, myErrors.ErrMediaTooLarge
+	}
+	ext, ok := imageExtensions[contentType]
+	// Заявленный тип должен совпадать с содержимым
+	if !ok || http.DetectContentType(data) != contentType {
+		return models.Media{}, myErrors.ErrMediaUnsupportedType
+	}
+	if err := srv.checkOwner(sellerID, cardID); err != nil {
+		return models.Media{}, err
+	}
+
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil || cfg.Width <= 0 || cfg.Height <= 0 || cfg.Width*cfg.Height > maxImagePixels {
+		return models.Media{}, myErrors.ErrMediaInvalidImage
+	}
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return models.Media{}, myErrors.ErrMediaInvalidImage
+	}
+	var thumb bytes.Buffer
+	if err := jpeg.Encode(&thumb, thumbnail(img, thumbnailSize), &jpeg.Options{Quality: thumbnailQuality}); err != nil {
+		return models.Media{}, myErrors.ErrMediaInternal
+	}
+
+	id := uuid.New()
+	prefix := "cards/" + cardID.String() + "/" + id.String()
+	media := models.Media{
+		UUID:         id,
+		CardID:       cardID,
+		ContentType:  contentType,
+		Size:         int64(len(data)),
+		Width:        cfg.Width,
+		Height:       cfg.Height,
+		Key:          prefix + "." + ext,
+		ThumbnailKey: prefix + "_thumb.jpg",
+	}
+
+	ctx := context.Background()
+	if err := srv.media.Put(ctx, media.Key, contentType, data); err != nil {
+		myLog.Log.Errorf("Failed to store image %s: %v", media.Key, err)
+		return models.Media{}, myErrors.ErrMediaStorage
+	}
+	if err := srv.media.Put(ctx, media.ThumbnailKey, thumbnailMediaType, thumb.Bytes()); err != nil {
+		myLog.Log.Errorf("Failed to store thumbnail %s: %v", media.ThumbnailKey, err)
+		srv.deleteBlobs(media)
+		return models.Media{}, myErrors.ErrMediaStorage
+	}
+
+	stored, err := srv.db.AddMedia(media)
+	if err != nil {
+		srv.deleteBlobs(media)
+		return models.Media{}, err
+	}
+	return srv.withURLs(stored), nil
+}
+
+func (srv *Srv) SrvListMedia(sellerID uuid.UUID, cardID uuid.UUID) ([]models.Media, error) {
+	if err := srv.checkOwner(sellerID, cardID); err != nil {
+		return nil, err
+	}
+	return srv.listMedia(cardID)
+}
+
+func (srv *Srv) SrvReorderMedia(sellerID uuid.UUID, cardID uuid.UUID, ids []uuid.UUID) ([]models.Media, error) {
+	if err := srv.checkOwner(sellerID, cardID); err != nil {
+		return nil, err
+	}
+	if err := srv.db.ReorderMedia(cardID, ids); err != nil {
+		return nil, err
+	}
+	return srv.listMedia(cardID)
+}
+
+func (srv *Srv) SrvDeleteMedia(sellerID uuid.UUID, cardID uuid.UUID, mediaID uuid.UUID) error {
+	if err := srv.checkOwner(sellerID, cardID); err != nil {
+		return err
+	}
+	media, err := srv.db.DeleteMedia(cardID, mediaID)
+	if err != nil {
+		return err
+	}
+	// Запись уже удалена: оставшийся в хранилище файл ни на что не ссылается
+	srv.deleteBlobs(media)
+	return nil
+}
+
+// listMedia возвращает изображения карточки с заполненными URL
+func (srv *Srv) listMedia(cardID uuid.UUID) ([]models.Media, error) {
+	media, err := srv.db.ListMedia(cardID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range media {
+		media[i] = srv.withURLs(media[i])
+	}
+	return media, nil
+}
+
+func (srv *Srv) withURLs(media models.Media) models.Media {
+	media.URL = srv.media.URL(media.Key)
+	media.ThumbnailURL = srv.media.URL(media.ThumbnailKey)
+	return media
+}
+
+// deleteBlobs удаляет файлы изображения; ошибки только логируются
+func (srv *Srv) deleteBlobs(media models.Media) {
+	ctx := context.Background()
+	for _, key := range []string{media.Key, media.ThumbnailKey} {
+		if err := srv.media.Delete(ctx, key); err != nil {
+			myLog.Log.Errorf("Failed to delete blob %s: %v", key, err)
+		}
+	}
+}
+
+// thumbnail уменьшает изображение так, чтобы длинная сторона не превышала size,
+// усредняя цвета исходных пикселей, попавших в каждый пиксель миниатюры
+func thumbnail(src image.Image, size int) image.Image {
+	b := src.Bounds()
+	w, h := b.Dx(), b.Dy()
+	if w > size || h > size {
+		if w >= h {
+			w, h = size, max(1, h*size/b.Dx())
+		} else {
+			w, h = max(1, w*size/b.Dy()), size
+		}
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		y0 := b.Min.Y + y*b.Dy()/h
+		y1 := max(y0+1, b.Min.Y+(y+1)*b.Dy()/h)
+		for x := 0; x < w; x++ {
+			x0 := b.Min.X + x*b.Dx()/w
+			x1 := max(x0+1, b.Min.X+(x+1)*b.Dx()/w)
+
+			var r, g, bl, a, n uint64
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					cr, cg, cb, ca := src.At(sx, sy).RGBA()
+					r, g, bl, a = r+uint64(cr), g+uint64(cg), bl+uint64(cb), a+uint64(ca)
+					n++
+				}
+			}
+			dst.Set(x, y, color.RGBA64{R: uint16(r / n), G: uint16(g / n), B: uint16(bl / n), A: uint16(a / n)})
+		}
+	}
+	return dst
+}
@@ -0,0 +1,117 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	database "goods/internal/database/postgres"
+	myErrors "goods/internal/errors"
+	"goods/internal/models"
+	"image"
+	"image/png"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+// fakeBlobStore хранит объекты в памяти
+type fakeBlobStore struct {
+	objects map[string][]byte
+}
+
+func (s *fakeBlobStore) Put(_ context.Context, key string, _ string, data []byte) error {
+	s.objects[key] = data
+	return nil
+}
+
+func (s *fakeBlobStore) Delete(_ context.Context, key string) error {
+	delete(s.objects, key)
+	return nil
+}
+
+func (s *fakeBlobStore) URL(key string) string {
+	return "http://cdn/" + key
+}
+
+// fakeMediaDB отдаёт владельца карточки и возвращает addErr при записи изображения
+type fakeMediaDB struct {
+	database.InterfacePostgresDB
+	owner  uuid.UUID
+	addErr error
+}
+
+func (f *fakeMediaDB) CardOwner(cardID uuid.UUID) (uuid.UUID, error) {
+	return f.owner, nil
+}
+
+func (f *fakeMediaDB) AddMedia(media models.Media) (models.Media, error) {
+	return media, f.addErr
+}
+
+func pngImage(t *testing.T, w, h int) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, w, h))); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestUploadMediaStoresImageAndThumbnail(t *testing.T) {
+	seller := uuid.New()
+	store := &fakeBlobStore{objects: map[string][]byte{}}
+	srv := &Srv{db: &fakeMediaDB{owner: seller}, media: store, mediaMaxSize: 1 << 20}
+
+	media, err := srv.SrvUploadMedia(seller, uuid.New(), "image/png", pngImage(t, 640, 320))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if media.Width != 640 || media.Height != 320 || media.URL != "http://cdn/"+media.Key {
+		t.Fatalf("media = %+v, want a 640x320 image with its URL", media)
+	}
+	thumb, _, err := image.DecodeConfig(bytes.NewReader(store.objects[media.ThumbnailKey]))
+	if err != nil || thumb.Width != thumbnailSize || thumb.Height != thumbnailSize/2 {
+		t.Fatalf("thumbnail = %+v, %v, want %dx%d", thumb, err, thumbnailSize, thumbnailSize/2)
+	}
+}
+
+func TestUploadMediaRejectsInvalidFiles(t *testing.T) {
+	seller := uuid.New()
+	store := &fakeBlobStore{objects: map[string][]byte{}}
+	srv := &Srv{db: &fakeMediaDB{owner: seller}, media: store, mediaMaxSize: 1 << 10}
+	small := pngImage(t, 4, 4)
+
+	cases := []struct {
+		name        string
+		seller      uuid.UUID
+		contentType string
+		data        []byte
+		want        error
+	}{
+		{"too large", seller, "image/png", make([]byte, 1<<10+1), myErrors.ErrMediaTooLarge},
+		{"declared type differs", seller, "image/jpeg", small, myErrors.ErrMediaUnsupportedType},
+		{"not an image type", seller, "text/plain", []byte("hello"), myErrors.ErrMediaUnsupportedType},
+		{"foreign card", uuid.New(), "image/png", small, myErrors.ErrGoodCardNotFound},
+	}
+	for _, c := range cases {
+		if _, err := srv.SrvUploadMedia(c.seller, uuid.New(), c.contentType, c.data); err != c.want {
+			t.Errorf("%s: err = %v, want %v", c.name, err, c.want)
+		}
+	}
+	if len(store.objects) != 0 {
+		t.Fatalf("rejected uploads left %d objects in the store", len(store.objects))
+	}
+}
+
+func TestUploadMediaRemovesBlobsWhenRecordFails(t *testing.T) {
+	seller := uuid.New()
+	store := &fakeBlobStore{objects: map[string][]byte{}}
+	srv := &Srv{db: &fakeMediaDB{owner: seller, addErr: errors.New("db down")}, media: store, mediaMaxSize: 1 << 20}
+
+	if _, err := srv.SrvUploadMedia(seller, uuid.New(), "image/png", pngImage(t, 8, 8)); err == nil {
+		t.Fatal("upload succeeded without a database record")
+	}
+	if len(store.objects) != 0 {
+		t.Fatalf("store keeps %d orphaned objects", len(store.objects))
+	}
+}
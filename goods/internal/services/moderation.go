@@ -0,0 +1,56 @@
+package services
+
+import (
+	myErrors "goods/internal/errors"
+	"goods/internal/models"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+// maxRejectReasonLength - ограничение длины причины отклонения в символах
+const maxRejectReasonLength = 1000
+
+func (srv *Srv) SrvSubmitGoodCard(sellerID uuid.UUID, cardID uuid.UUID) error {
+	if err := srv.checkOwner(sellerID, cardID); err != nil {
+		return err
+	}
+	return srv.db.SubmitGoodCard(cardID, sellerID.String())
+}
+
+func (srv *Srv) SrvListModerationLog(sellerID uuid.UUID, cardID uuid.UUID) ([]models.ModerationDecision, error) {
+	if err := srv.checkOwner(sellerID, cardID); err != nil {
+		return nil, err
+	}
+	return srv.db.ListModerationLog(cardID)
+}
+
+// SrvModerationQueue возвращает карточки на проверке: раньше отправленные - первыми
+func (srv *Srv) SrvModerationQueue(limit int, cursor string) (models.GoodPage, error) {
+	return srv.db.ListGoods(models.GoodFilter{
+		Moderation: models.ModerationSubmitted,
+		Sort:       models.SortModerationChanged,
+		Limit:      limit,
+		Cursor:     cursor,
+	})
+}
+
+func (srv *Srv) SrvModerationHistory(cardID uuid.UUID) ([]models.ModerationDecision, error) {
+	return srv.db.ListModerationLog(cardID)
+}
+
+func (srv *Srv) SrvApproveGoodCard(moderatorID uuid.UUID, cardID uuid.UUID, expectedVersion int) error {
+	return srv.db.ApproveGoodCard(cardID, expectedVersion, moderatorID.String())
+}
+
+func (srv *Srv) SrvRejectGoodCard(moderatorID uuid.UUID, cardID uuid.UUID, expectedVersion int, reason string) error {
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return myErrors.ValidationError("reason", "must not be empty")
+	}
+	if utf8.RuneCountInString(reason) > maxRejectReasonLength {
+		return myErrors.ValidationError("reason", "must be at most 1000 characters")
+	}
+	return srv.db.RejectGoodCard(cardID, expectedVersion, reason, moderatorID.String())
+}
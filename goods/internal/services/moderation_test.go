@@ -0,0 +1,41 @@
+package services
+
+import (
+	database "goods/internal/database/postgres"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+// fakeModerationDB запоминает причину, дошедшую до базы
+type fakeModerationDB struct {
+	database.InterfacePostgresDB
+	reason *string
+}
+
+func (f *fakeModerationDB) RejectGoodCard(cardID uuid.UUID, expectedVersion int, reason string, actor string) error {
+	f.reason = &reason
+	return nil
+}
+
+func TestRejectRequiresReason(t *testing.T) {
+	db := &fakeModerationDB{}
+	srv := &Srv{db: db}
+
+	for _, reason := range []string{"", "   ", strings.Repeat("я", maxRejectReasonLength+1)} {
+		if err := srv.SrvRejectGoodCard(uuid.New(), uuid.New(), 0, reason); err == nil {
+			t.Fatalf("reason of %d characters accepted", len(reason))
+		}
+	}
+	if db.reason != nil {
+		t.Fatal("invalid reason must not reach the database")
+	}
+
+	if err := srv.SrvRejectGoodCard(uuid.New(), uuid.New(), 0, "  blurry photo "); err != nil {
+		t.Fatalf("valid reason rejected: %v", err)
+	}
+	if db.reason == nil || *db.reason != "blurry photo" {
+		t.Fatalf("stored reason = %v, want trimmed reason", db.reason)
+	}
+}
@@ -0,0 +1,160 @@
+package services
+
+import (
+	myErrors "goods/internal/errors"
+	"goods/internal/models"
+	"goods/pkg/money"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// Ограничения колонки exchange_rates.rate: NUMERIC(20, 10)
+var (
+	maxRate = big.NewRat(10_000_000_000, 1)
+	minRate = big.NewRat(1, 10_000_000_000)
+)
+
+func (srv *Srv) SrvReadCardPrices(sellerID uuid.UUID, cardID uuid.UUID) ([]money.Money, error) {
+	if err := srv.checkOwner(sellerID, cardID); err != nil {
+		return nil, err
+	}
+	return srv.db.ReadCardPrices(cardID)
+}
+
+func (srv *Srv) SrvSetCardPrices(sellerID uuid.UUID, cardID uuid.UUID, prices []money.Money) error {
+	if err := validateCardPrices(prices); err != nil {
+		return err
+	}
+	if err := srv.checkOwner(sellerID, cardID); err != nil {
+		return err
+	}
+	return srv.db.SetCardPrices(cardID, prices)
+}
+
+func (srv *Srv) SrvListExchangeRates() ([]models.ExchangeRate, error) {
+	return srv.db.ListExchangeRates()
+}
+
+func (srv *Srv) SrvSetExchangeRates(rates []models.ExchangeRate) error {
+	if len(rates) == 0 {
+		return myErrors.ValidationError("rates", "must not be empty")
+	}
+	for i, r := range rates {
+		if err := validateExchangeRate("rates["+strconv.Itoa(i)+"]", r); err != nil {
+			return err
+		}
+	}
+	return srv.db.SetExchangeRates(rates)
+}
+
+// SrvFillDisplayPrices задаёт товарам действующую цену в валюте currency.
+// Цена карточки в этой валюте используется, пока расписание не меняет цену карточки,
+// иначе действующая цена пересчитывается по прямому или обратному курсу.
+func (srv *Srv) SrvFillDisplayPrices(goods []models.Good, currency string) error {
+	if !money.Supported(currency) {
+		return myErrors.ValidationError("display_currency", "must be a supported ISO 4217 currency code")
+	}
+	if len(goods) == 0 {
+		return nil
+	}
+
+	cardIDs := make([]uuid.UUID, 0, len(goods))
+	for _, good := range goods {
+		cardIDs = append(cardIDs, good.Card.UUID)
+	}
+	prices, err := srv.db.CardPricesIn(cardIDs, currency)
+	if err != nil {
+		return err
+	}
+	rates, err := srv.db.ListExchangeRates()
+	if err != nil {
+		return err
+	}
+
+	for i := range goods {
+		card := &goods[i].Card
+		display, err := displayPrice(*card, currency, prices, rates)
+		if err != nil {
+			return err
+		}
+		card.DisplayPrice = &display
+	}
+	return nil
+}
+
+func displayPrice(card models.GoodCard, currency string, prices map[uuid.UUID]money.Money, rates []models.ExchangeRate) (money.Money, error) {
+	if card.EffectivePrice.Currency == currency {
+		return card.EffectivePrice, nil
+	}
+	if price, ok := prices[card.UUID]; ok && card.EffectivePrice == card.Price {
+		return price, nil
+	}
+	rate, ok := findRate(rates, card.EffectivePrice.Currency, currency)
+	if !ok {
+		return money.Money{}, myErrors.ErrExchangeRateMissing
+	}
+	converted, err := card.EffectivePrice.Convert(currency, rate)
+	if err != nil {
+		return money.Money{}, myErrors.ErrPriceListInternal
+	}
+	return converted, nil
+}
+
+// findRate возвращает курс base -> quote; если задан только обратный курс, возвращает обратную ему дробь
+func findRate(rates []models.ExchangeRate, base string, quote string) (string, bool) {
+	for _, r := range rates {
+		if r.Base == base && r.Quote == quote {
+			return r.Rate, true
+		}
+	}
+	for _, r := range rates {
+		if r.Base == quote && r.Quote == base {
+			inverse, ok := new(big.Rat).SetString(r.Rate)
+			if !ok || inverse.Sign() <= 0 {
+				return "", false
+			}
+			return inverse.Inv(inverse).RatString(), true
+		}
+	}
+	return "", false
+}
+
+func validateCardPrices(prices []money.Money) error {
+	seen := make(map[string]bool, len(prices))
+	for i, price := range prices {
+		field := "prices[" + strconv.Itoa(i) + "]"
+		if err := validateMoney(field, price); err != nil {
+			return err
+		}
+		if seen[price.Currency] {
+			return myErrors.ValidationError(field+".currency", "must be unique")
+		}
+		seen[price.Currency] = true
+	}
+	return nil
+}
+
+func validateExchangeRate(field string, r models.ExchangeRate) error {
+	if !money.Supported(r.Base) {
+		return myErrors.ValidationError(field+".base", "must be a supported ISO 4217 currency code")
+	}
+	if !money.Supported(r.Quote) {
+		return myErrors.ValidationError(field+".quote", "must be a supported ISO 4217 currency code")
+	}
+	if r.Base == r.Quote {
+		return myErrors.ValidationError(field+".quote", "must differ from base")
+	}
+	// Курс записывается в NUMERIC, поэтому допускается только десятичная запись
+	units, fraction, _ := strings.Cut(r.Rate, ".")
+	rate, ok := new(big.Rat).SetString(r.Rate)
+	if units == "" || strings.ContainsAny(r.Rate, "+-eE/") || len(fraction) > 10 || !ok {
+		return myErrors.ValidationError(field+".rate", "must be a decimal number with at most 10 fractional digits")
+	}
+	if rate.Cmp(minRate) < 0 || rate.Cmp(maxRate) >= 0 {
+		return myErrors.ValidationError(field+".rate", "must be at least 0.0000000001 and less than 10000000000")
+	}
+	return nil
+}
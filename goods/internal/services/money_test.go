@@ -0,0 +1,98 @@
+package services
+
+import (
+	database "goods/internal/database/postgres"
+	myErrors "goods/internal/errors"
+	"goods/internal/models"
+	"goods/pkg/money"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+// fakeMoneyDB отдаёт заданные цены карточек и курсы
+type fakeMoneyDB struct {
+	database.InterfacePostgresDB
+	prices map[uuid.UUID]money.Money
+	rates  []models.ExchangeRate
+}
+
+func (f *fakeMoneyDB) CardPricesIn(cardIDs []uuid.UUID, currency string) (map[uuid.UUID]money.Money, error) {
+	result := make(map[uuid.UUID]money.Money)
+	for _, id := range cardIDs {
+		if price, ok := f.prices[id]; ok && price.Currency == currency {
+			result[id] = price
+		}
+	}
+	return result, nil
+}
+
+func (f *fakeMoneyDB) ListExchangeRates() ([]models.ExchangeRate, error) {
+	return f.rates, nil
+}
+
+func card(price money.Money, effective money.Money) models.Good {
+	id := uuid.New()
+	return models.Good{UUID: id, Card: models.GoodCard{UUID: id, Price: price, EffectivePrice: effective}}
+}
+
+func TestFillDisplayPrices(t *testing.T) {
+	rub := func(amount int64) money.Money { return money.Money{Amount: amount, Currency: "RUB"} }
+	usd := func(amount int64) money.Money { return money.Money{Amount: amount, Currency: "USD"} }
+
+	explicit := card(rub(10000), rub(10000))
+	discounted := card(rub(10000), rub(9000))
+	converted := card(rub(20000), rub(20000))
+	inverse := card(money.Money{Amount: 1000, Currency: "EUR"}, money.Money{Amount: 1000, Currency: "EUR"})
+	same := card(usd(500), usd(450))
+	db := &fakeMoneyDB{
+		prices: map[uuid.UUID]money.Money{explicit.UUID: usd(99), discounted.UUID: usd(99)},
+		rates: []models.ExchangeRate{
+			{Base: "RUB", Quote: "USD", Rate: "0.0125"},
+			{Base: "USD", Quote: "EUR", Rate: "0.8"},
+		},
+	}
+	srv := &Srv{db: db}
+
+	goods := []models.Good{explicit, discounted, converted, inverse, same}
+	if err := srv.SrvFillDisplayPrices(goods, "USD"); err != nil {
+		t.Fatal(err)
+	}
+	want := []money.Money{
+		usd(99),   // цена в валюте задана продавцом
+		usd(113),  // расписание меняет цену: пересчёт 90.00 RUB по курсу
+		usd(250),  // 200.00 RUB по курсу
+		usd(1250), // 10.00 EUR по обратному курсу USD -> EUR
+		usd(450),  // валюта карточки совпадает с запрошенной
+	}
+	for i, good := range goods {
+		if good.Card.DisplayPrice == nil || *good.Card.DisplayPrice != want[i] {
+			t.Errorf("goods[%d].DisplayPrice = %v, want %v", i, good.Card.DisplayPrice, want[i])
+		}
+	}
+
+	if err := srv.SrvFillDisplayPrices([]models.Good{converted}, "JPY"); err != myErrors.ErrExchangeRateMissing {
+		t.Errorf("missing rate: err = %v, want ErrExchangeRateMissing", err)
+	}
+	if err := srv.SrvFillDisplayPrices([]models.Good{converted}, "XXX"); err == nil {
+		t.Error("unsupported currency accepted")
+	}
+}
+
+func TestSetExchangeRatesValidatesRates(t *testing.T) {
+	invalid := map[string]models.ExchangeRate{
+		"same currency":    {Base: "RUB", Quote: "RUB", Rate: "1"},
+		"unknown currency": {Base: "RUB", Quote: "XXX", Rate: "1"},
+		"zero":             {Base: "RUB", Quote: "USD", Rate: "0"},
+		"negative":         {Base: "RUB", Quote: "USD", Rate: "-0.01"},
+		"fraction":         {Base: "RUB", Quote: "USD", Rate: "1/90"},
+		"too precise":      {Base: "RUB", Quote: "USD", Rate: "0.00000000001"},
+		"too large":        {Base: "RUB", Quote: "USD", Rate: "10000000000"},
+	}
+	srv := &Srv{db: &fakeMoneyDB{}}
+	for name, rate := range invalid {
+		if err := srv.SrvSetExchangeRates([]models.ExchangeRate{rate}); err == nil {
+			t.Errorf("%s: rate %+v accepted", name, rate)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package services
+
+import (
+	"context"
+	myLog "goods/internal/logger"
+	"goods/internal/models"
+	"time"
+)
+
+// Publisher публикует события outbox во внешний брокер.
+// Возвращает число событий в начале списка, которые опубликованы успешно.
+type Publisher interface {
+	Publish(ctx context.Context, events []models.OutboxEvent) (int, error)
+}
+
+// OutboxStore - часть хранилища, с которой работает ретранслятор
+type OutboxStore interface {
+	ProcessOutbox(limit int, handle func(events []models.OutboxEvent) int) (int, error)
+}
+
+// OutboxRelay переносит события из outbox в брокер с доставкой at-least-once.
+// События публикуются строго в порядке записи; после первой ошибки проход
+// прерывается, чтобы более поздние события карточки не обогнали ранние.
+type OutboxRelay struct {
+	store     OutboxStore
+	publisher Publisher
+	batchSize int
+}
+
+func NewOutboxRelay(store OutboxStore, publisher Publisher, batchSize int) *OutboxRelay {
+	return &OutboxRelay{
+		store:     store,
+		publisher: publisher,
+		batchSize: batchSize,
+	}
+}
+
+// NewOutboxRelay создаёт ретранслятор поверх хранилища сервиса
+func (srv *Srv) NewOutboxRelay(publisher Publisher, batchSize int) *OutboxRelay {
+	return NewOutboxRelay(srv.db, publisher, batchSize)
+}
+
+// RelayOnce публикует одну пачку событий и возвращает число опубликованных
+func (r *OutboxRelay) RelayOnce(ctx context.Context) (int, error) {
+	var publishErr error
+	n, err := r.store.ProcessOutbox(r.batchSize, func(events []models.OutboxEvent) int {
+		published, err := r.publisher.Publish(ctx, events)
+		publishErr = err
+		return published
+	})
+	if err != nil {
+		return n, err
+	}
+	return n, publishErr
+}
+
+// Run периодически публикует накопившиеся события, пока не отменён ctx
+func (r *OutboxRelay) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			r.drain(ctx)
+		}
+	}
+}
+
+func (r *OutboxRelay) drain(ctx context.Context) {
+	for ctx.Err() == nil {
+		n, err := r.RelayOnce(ctx)
+		if err != nil {
+			myLog.Log.Errorf("Failed to relay outbox events (published %d): %v", n, err)
+			return
+		}
+		if n < r.batchSize {
+			return
+		}
+	}
+}
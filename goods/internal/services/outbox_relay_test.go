@@ -0,0 +1,122 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"goods/internal/models"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+// memOutbox - хранилище outbox в памяти
+type memOutbox struct {
+	events    []models.OutboxEvent
+	published map[int64]bool
+}
+
+func newMemOutbox(events ...models.OutboxEvent) *memOutbox {
+	return &memOutbox{events: events, published: make(map[int64]bool)}
+}
+
+func (m *memOutbox) ProcessOutbox(limit int, handle func(events []models.OutboxEvent) int) (int, error) {
+	var pending []models.OutboxEvent
+	for _, event := range m.events {
+		if !m.published[event.ID] && len(pending) < limit {
+			pending = append(pending, event)
+		}
+	}
+	if len(pending) == 0 {
+		return 0, nil
+	}
+	n := handle(pending)
+	for _, event := range pending[:n] {
+		m.published[event.ID] = true
+	}
+	return n, nil
+}
+
+// memPublisher - брокер в памяти; accept ограничивает число принимаемых
+// событий на очередном вызове, после чего возвращается ошибка
+type memPublisher struct {
+	received []models.OutboxEvent
+	accept   []int
+}
+
+var errBrokerDown = errors.New("broker is down")
+
+func (p *memPublisher) Publish(ctx context.Context, events []models.OutboxEvent) (int, error) {
+	if len(p.accept) > 0 {
+		n := min(p.accept[0], len(events))
+		p.accept = p.accept[1:]
+		p.received = append(p.received, events[:n]...)
+		return n, errBrokerDown
+	}
+	p.received = append(p.received, events...)
+	return len(events), nil
+}
+
+func outboxEvents(cards ...uuid.UUID) []models.OutboxEvent {
+	events := make([]models.OutboxEvent, 0, len(cards))
+	for i, card := range cards {
+		events = append(events, models.OutboxEvent{ID: int64(i + 1), AggregateID: card, EventType: models.EventStockChanged})
+	}
+	return events
+}
+
+func TestOutboxRelayPublishesInOrder(t *testing.T) {
+	a, b := uuid.New(), uuid.New()
+	store := newMemOutbox(outboxEvents(a, b, a, a, b)...)
+	publisher := &memPublisher{}
+	relay := NewOutboxRelay(store, publisher, 2)
+
+	relay.drain(context.Background())
+
+	if len(publisher.received) != 5 {
+		t.Fatalf("expected 5 published events, got %d", len(publisher.received))
+	}
+	for i, event := range publisher.received {
+		if event.ID != int64(i+1) {
+			t.Fatalf("event %d published out of order: got id %d", i, event.ID)
+		}
+	}
+
+	n, err := relay.RelayOnce(context.Background())
+	if err != nil || n != 0 {
+		t.Fatalf("expected nothing left to publish, got n=%d err=%v", n, err)
+	}
+}
+
+func TestOutboxRelayRetriesAfterPartialFailure(t *testing.T) {
+	a, b := uuid.New(), uuid.New()
+	store := newMemOutbox(outboxEvents(a, b, a, b)...)
+	publisher := &memPublisher{accept: []int{1, 0}}
+	relay := NewOutboxRelay(store, publisher, 10)
+
+	n, err := relay.RelayOnce(context.Background())
+	if !errors.Is(err, errBrokerDown) || n != 1 {
+		t.Fatalf("expected 1 event and broker error, got n=%d err=%v", n, err)
+	}
+	n, err = relay.RelayOnce(context.Background())
+	if !errors.Is(err, errBrokerDown) || n != 0 {
+		t.Fatalf("expected no events and broker error, got n=%d err=%v", n, err)
+	}
+	n, err = relay.RelayOnce(context.Background())
+	if err != nil || n != 3 {
+		t.Fatalf("expected remaining 3 events, got n=%d err=%v", n, err)
+	}
+
+	// Каждое событие доставлено, и события одной карточки идут по возрастанию id
+	last := make(map[uuid.UUID]int64)
+	for _, event := range publisher.received {
+		if event.ID <= last[event.AggregateID] {
+			t.Fatalf("event %d of card %s published after %d", event.ID, event.AggregateID, last[event.AggregateID])
+		}
+		last[event.AggregateID] = event.ID
+	}
+	for _, event := range store.events {
+		if !store.published[event.ID] {
+			t.Fatalf("event %d was not marked as published", event.ID)
+		}
+	}
+}
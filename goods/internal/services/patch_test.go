@@ -0,0 +1,73 @@
+package services
+
+import (
+	database "goods/internal/database/postgres"
+	"goods/internal/models"
+	"goods/pkg/money"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+// fakePatchDB запоминает, дошло ли изменение до базы
+type fakePatchDB struct {
+	database.InterfacePostgresDB
+	owner   uuid.UUID
+	patched bool
+}
+
+func (f *fakePatchDB) CardOwner(cardID uuid.UUID) (uuid.UUID, error) {
+	return f.owner, nil
+}
+
+func (f *fakePatchDB) UpdateGoodCard(id uuid.UUID, card models.GoodCard, expectedVersion int) (int, error) {
+	f.patched = true
+	return expectedVersion + 1, nil
+}
+
+func (f *fakePatchDB) PatchGoodCard(id uuid.UUID, patch models.GoodCardPatch, expectedVersion int) (int, error) {
+	f.patched = true
+	return expectedVersion + 1, nil
+}
+
+func TestPatchValidatesOnlyPresentFields(t *testing.T) {
+	seller := uuid.New()
+	db := &fakePatchDB{owner: seller}
+	srv := &Srv{db: db}
+
+	empty := ""
+	if _, err := srv.SrvPatchGoodCard(seller, uuid.New(), models.GoodCardPatch{Name: &empty}, 1); err == nil || db.patched {
+		t.Fatalf("empty name: err = %v, patched = %v, want rejection before the database", err, db.patched)
+	}
+
+	// Заполненное описание не требует остальных полей карточки
+	description := "Новое описание"
+	version, err := srv.SrvPatchGoodCard(seller, uuid.New(), models.GoodCardPatch{Description: &description}, 1)
+	if err != nil || version != 2 {
+		t.Fatalf("patch = %d, %v, want version 2", version, err)
+	}
+}
+
+func TestUpdateValidatesWrittenFields(t *testing.T) {
+	seller := uuid.New()
+	db := &fakePatchDB{owner: seller}
+	srv := &Srv{db: db}
+
+	invalid := []models.GoodCard{
+		{Name: strings.Repeat("я", 256)},
+		{Name: "Чайник", Weight: 1e9},
+		{Name: "Чайник", Price: money.Money{Amount: 100, Currency: "XXX"}},
+		{Name: "Чайник", Price: money.Money{Amount: 100}},
+	}
+	for _, card := range invalid {
+		if _, err := srv.SrvUpdateGoodCard(seller, uuid.New(), card, 1); err == nil || db.patched {
+			t.Fatalf("%+v: err = %v, patched = %v, want rejection before the database", card, err, db.patched)
+		}
+	}
+
+	// Переименование без цены и с весом "не менять" проходит проверку
+	if _, err := srv.SrvUpdateGoodCard(seller, uuid.New(), models.GoodCard{Name: "Чайник", Weight: -1}, 1); err != nil || !db.patched {
+		t.Fatalf("rename: err = %v, patched = %v", err, db.patched)
+	}
+}
@@ -0,0 +1,92 @@
+package services
+
+import (
+	"context"
+	myErrors "goods/internal/errors"
+	myLog "goods/internal/logger"
+	"goods/internal/models"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const scheduleBatchSize = 100
+
+func (srv *Srv) SrvCreatePriceSchedule(sellerID uuid.UUID, cardID uuid.UUID, schedule models.PriceSchedule) (uuid.UUID, error) {
+	if err := validatePriceSchedule(schedule, time.Now()); err != nil {
+		return uuid.Nil, err
+	}
+	if err := srv.checkOwner(sellerID, cardID); err != nil {
+		return uuid.Nil, err
+	}
+	schedule.CardID = cardID
+	return srv.db.CreatePriceSchedule(schedule)
+}
+
+func (srv *Srv) SrvListPriceSchedules(sellerID uuid.UUID, cardID uuid.UUID) ([]models.PriceSchedule, error) {
+	if err := srv.checkOwner(sellerID, cardID); err != nil {
+		return nil, err
+	}
+	return srv.db.ListPriceSchedules(cardID)
+}
+
+func (srv *Srv) SrvCancelPriceSchedule(sellerID uuid.UUID, cardID uuid.UUID, scheduleID uuid.UUID) error {
+	if err := srv.checkOwner(sellerID, cardID); err != nil {
+		return err
+	}
+	return srv.db.CancelPriceSchedule(cardID, scheduleID)
+}
+
+// RunPriceScheduler периодически начинает и завершает наступившие расписания цен, пока не отменён ctx
+func (srv *Srv) RunPriceScheduler(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			srv.applyPriceSchedules()
+		}
+	}
+}
+
+func (srv *Srv) applyPriceSchedules() {
+	for {
+		n, err := srv.db.ApplyPriceSchedules(scheduleBatchSize)
+		if err != nil {
+			myLog.Log.Errorf("Failed to apply price schedules: %v", err)
+			return
+		}
+		if n > 0 {
+			myLog.Log.Infof("Applied price schedules: %d", n)
+		}
+		if n < scheduleBatchSize {
+			return
+		}
+	}
+}
+
+func validatePriceSchedule(schedule models.PriceSchedule, now time.Time) error {
+	if schedule.StartsAt.IsZero() {
+		return myErrors.ValidationError("startsAt", "is required")
+	}
+	if !schedule.EndsAt.After(schedule.StartsAt) {
+		return myErrors.ValidationError("endsAt", "must be after startsAt")
+	}
+	if !schedule.EndsAt.After(now) {
+		return myErrors.ValidationError("endsAt", "must be in the future")
+	}
+	if (schedule.Price == nil) == (schedule.Percent == nil) {
+		return myErrors.ValidationError("price", "exactly one of price and percent is required")
+	}
+	if schedule.Price != nil {
+		return validateMoney("price", *schedule.Price)
+	}
+	if p := *schedule.Percent; math.IsNaN(p) || p <= 0 || p >= 100 {
+		return myErrors.ValidationError("percent", "must be between 0 and 100 exclusive")
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+package services
+
+import (
+	database "goods/internal/database/postgres"
+	"goods/internal/models"
+	"goods/pkg/money"
+	"testing"
+	"time"
+)
+
+func TestValidatePriceSchedule(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	price := &money.Money{Amount: 900, Currency: "RUB"}
+	percent := func(p float64) *float64 { return &p }
+
+	valid := []models.PriceSchedule{
+		{StartsAt: now.Add(time.Hour), EndsAt: now.Add(2 * time.Hour), Price: price},
+		// Уже начавшееся расписание допустимо: оно применяется сразу
+		{StartsAt: now.Add(-time.Hour), EndsAt: now.Add(time.Hour), Percent: percent(15)},
+	}
+	for _, s := range valid {
+		if err := validatePriceSchedule(s, now); err != nil {
+			t.Errorf("%+v rejected: %v", s, err)
+		}
+	}
+
+	invalid := map[string]models.PriceSchedule{
+		"no start":          {EndsAt: now.Add(time.Hour), Price: price},
+		"ends before start": {StartsAt: now.Add(2 * time.Hour), EndsAt: now.Add(time.Hour), Price: price},
+		"already over":      {StartsAt: now.Add(-2 * time.Hour), EndsAt: now.Add(-time.Hour), Price: price},
+		"no price":          {StartsAt: now.Add(time.Hour), EndsAt: now.Add(2 * time.Hour)},
+		"price and percent": {StartsAt: now.Add(time.Hour), EndsAt: now.Add(2 * time.Hour), Price: price, Percent: percent(10)},
+		"zero percent":      {StartsAt: now.Add(time.Hour), EndsAt: now.Add(2 * time.Hour), Percent: percent(0)},
+		"full discount":     {StartsAt: now.Add(time.Hour), EndsAt: now.Add(2 * time.Hour), Percent: percent(100)},
+		"negative price":    {StartsAt: now.Add(time.Hour), EndsAt: now.Add(2 * time.Hour), Price: &money.Money{Amount: -1, Currency: "RUB"}},
+	}
+	for name, s := range invalid {
+		if err := validatePriceSchedule(s, now); err == nil {
+			t.Errorf("%s accepted", name)
+		}
+	}
+}
+
+// fakeSchedulerDB отдаёт заданные количества применённых расписаний по очереди
+type fakeSchedulerDB struct {
+	database.InterfacePostgresDB
+	applied []int
+	calls   int
+}
+
+func (f *fakeSchedulerDB) ApplyPriceSchedules(limit int) (int, error) {
+	n := f.applied[f.calls]
+	f.calls++
+	return n, nil
+}
+
+func TestApplyPriceSchedulesDrainsFullBatches(t *testing.T) {
+	db := &fakeSchedulerDB{applied: []int{scheduleBatchSize, scheduleBatchSize, 3}}
+	srv := &Srv{db: db}
+
+	srv.applyPriceSchedules()
+	if db.calls != 3 {
+		t.Fatalf("calls = %d, want 3: repeat while batches are full", db.calls)
+	}
+}
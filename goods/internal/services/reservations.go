@@ -0,0 +1,66 @@
+package services
+
+import (
+	"context"
+	myErrors "goods/internal/errors"
+	myLog "goods/internal/logger"
+	"goods/internal/models"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Сколько истёкших резервов уборщик обрабатывает в одной транзакции
+const expireBatchSize = 100
+
+func (srv *Srv) SrvReserveGood(skuID uuid.UUID, warehouseID uuid.UUID, number int, ttl time.Duration, meta models.MovementMeta) (models.Reservation, error) {
+	if number <= 0 {
+		return models.Reservation{}, myErrors.ErrReserveGoodInvalid
+	}
+	if ttl == 0 {
+		ttl = srv.reservationTTL
+	}
+	if ttl < 0 || ttl > srv.reservationMaxTTL {
+		return models.Reservation{}, myErrors.ErrReservationInvalidTTL
+	}
+	return srv.db.ReserveGood(skuID, warehouseID, number, ttl, meta)
+}
+
+func (srv *Srv) SrvCommitReservation(reservationID uuid.UUID, meta models.MovementMeta) error {
+	return srv.db.CommitReservation(reservationID, meta)
+}
+
+func (srv *Srv) SrvReleaseReservation(reservationID uuid.UUID, meta models.MovementMeta) error {
+	return srv.db.ReleaseReservation(reservationID, meta)
+}
+
+// RunReservationSweeper периодически возвращает истёкшие резервы в остаток, пока не отменён ctx
+func (srv *Srv) RunReservationSweeper(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			srv.sweepReservations()
+		}
+	}
+}
+
+func (srv *Srv) sweepReservations() {
+	for {
+		n, err := srv.db.ExpireReservations(expireBatchSize)
+		if err != nil {
+			myLog.Log.Errorf("Failed to expire reservations: %v", err)
+			return
+		}
+		if n > 0 {
+			myLog.Log.Infof("Expired reservations: %d", n)
+		}
+		if n < expireBatchSize {
+			return
+		}
+	}
+}
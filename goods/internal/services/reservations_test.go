@@ -0,0 +1,116 @@
+package services
+
+import (
+	"context"
+	"errors"
+	database "goods/internal/database/postgres"
+	myErrors "goods/internal/errors"
+	"goods/internal/models"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// fakeReservationDB запоминает срок последнего резерва и отдаёт заданные размеры пачек истёкших резервов
+type fakeReservationDB struct {
+	database.InterfacePostgresDB
+	mu      sync.Mutex
+	ttl     time.Duration
+	batches []int
+	calls   int
+	err     error
+}
+
+func (f *fakeReservationDB) ReserveGood(skuID uuid.UUID, warehouseID uuid.UUID, number int, ttl time.Duration, meta models.MovementMeta) (models.Reservation, error) {
+	f.ttl = ttl
+	return models.Reservation{}, nil
+}
+
+func (f *fakeReservationDB) ExpireReservations(limit int) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls++
+	if f.err != nil {
+		return 0, f.err
+	}
+	if len(f.batches) == 0 {
+		return 0, nil
+	}
+	n := f.batches[0]
+	f.batches = f.batches[1:]
+	return n, nil
+}
+
+func (f *fakeReservationDB) callCount() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.calls
+}
+
+func TestReserveGoodValidatesTTL(t *testing.T) {
+	db := &fakeReservationDB{}
+	srv := &Srv{db: db, reservationTTL: 15 * time.Minute, reservationMaxTTL: time.Hour}
+
+	if _, err := srv.SrvReserveGood(uuid.New(), uuid.Nil, 1, 0, models.MovementMeta{}); err != nil || db.ttl != 15*time.Minute {
+		t.Fatalf("zero ttl: err = %v, ttl = %v, want the default 15m", err, db.ttl)
+	}
+	if _, err := srv.SrvReserveGood(uuid.New(), uuid.Nil, 1, time.Hour, models.MovementMeta{}); err != nil || db.ttl != time.Hour {
+		t.Fatalf("max ttl: err = %v, ttl = %v", err, db.ttl)
+	}
+
+	db.ttl = 0
+	for _, ttl := range []time.Duration{-time.Second, time.Hour + time.Second} {
+		if _, err := srv.SrvReserveGood(uuid.New(), uuid.Nil, 1, ttl, models.MovementMeta{}); err != myErrors.ErrReservationInvalidTTL {
+			t.Errorf("ttl %v: err = %v, want ErrReservationInvalidTTL", ttl, err)
+		}
+	}
+	if _, err := srv.SrvReserveGood(uuid.New(), uuid.Nil, 0, time.Minute, models.MovementMeta{}); err != myErrors.ErrReserveGoodInvalid {
+		t.Errorf("zero quantity: err = %v, want ErrReserveGoodInvalid", err)
+	}
+	if db.ttl != 0 {
+		t.Fatal("invalid reservation reached the database")
+	}
+}
+
+func TestSweepReservationsDrainsFullBatches(t *testing.T) {
+	db := &fakeReservationDB{batches: []int{expireBatchSize, expireBatchSize, 3, expireBatchSize}}
+	srv := &Srv{db: db}
+
+	srv.sweepReservations()
+	if db.calls != 3 {
+		t.Fatalf("ExpireReservations calls = %d, want 3 (stop after a partial batch)", db.calls)
+	}
+
+	failing := &fakeReservationDB{err: errors.New("db down")}
+	(&Srv{db: failing}).sweepReservations()
+	if failing.calls != 1 {
+		t.Fatalf("calls after an error = %d, want 1", failing.calls)
+	}
+}
+
+func TestReservationSweeperRunsUntilCancelled(t *testing.T) {
+	db := &fakeReservationDB{}
+	srv := &Srv{db: db}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		srv.RunReservationSweeper(ctx, time.Millisecond)
+		close(done)
+	}()
+
+	deadline := time.Now().Add(time.Second)
+	for db.callCount() < 2 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("sweeper did not stop after cancel")
+	}
+	if db.callCount() < 2 {
+		t.Fatalf("sweeper ran %d times, want periodic runs", db.callCount())
+	}
+}
@@ -0,0 +1,53 @@
+package services
+
+import (
+	"context"
+	myLog "goods/internal/logger"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// purgeBatchSize - сколько удалённых карточек удаляется окончательно за одну транзакцию
+const purgeBatchSize = 100
+
+// SrvRestoreGoodCard восстанавливает мягко удалённую карточку продавца
+func (srv *Srv) SrvRestoreGoodCard(sellerID uuid.UUID, cardID uuid.UUID) error {
+	return srv.db.RestoreGoodCard(sellerID, cardID)
+}
+
+// RunCardPurge периодически окончательно удаляет карточки, удалённые раньше чем retention назад,
+// пока не отменён ctx
+func (srv *Srv) RunCardPurge(ctx context.Context, interval time.Duration, retention time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			srv.purgeDeletedCards(retention)
+		}
+	}
+}
+
+func (srv *Srv) purgeDeletedCards(retention time.Duration) {
+	for {
+		n, media, err := srv.db.PurgeDeletedCards(retention, purgeBatchSize)
+		if err != nil {
+			myLog.Log.Errorf("Failed to purge deleted good cards: %v", err)
+			return
+		}
+		// Файлы удаляются после фиксации транзакции, чтобы откат не оставил карточку без изображений
+		for _, m := range media {
+			srv.deleteBlobs(m)
+		}
+		if n > 0 {
+			myLog.Log.Infof("Purged deleted good cards: %d", n)
+		}
+		if n < purgeBatchSize {
+			return
+		}
+	}
+}
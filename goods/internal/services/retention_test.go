@@ -0,0 +1,67 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	database "goods/internal/database/postgres"
+	"goods/internal/models"
+	"testing"
+	"time"
+)
+
+// fakePurgeDB отдаёт заданные пачки удалённых карточек по очереди
+type fakePurgeDB struct {
+	database.InterfacePostgresDB
+	batches []int
+	err     error
+	calls   int
+}
+
+func purgedMedia(call, i int) models.Media {
+	key := fmt.Sprintf("cards/%d/%d", call, i)
+	return models.Media{Key: key, ThumbnailKey: key + "_thumb"}
+}
+
+func (f *fakePurgeDB) PurgeDeletedCards(retention time.Duration, limit int) (int, []models.Media, error) {
+	if f.err != nil {
+		return 0, nil, f.err
+	}
+	n := f.batches[f.calls]
+	media := make([]models.Media, 0, n)
+	for i := 0; i < n; i++ {
+		media = append(media, purgedMedia(f.calls, i))
+	}
+	f.calls++
+	return n, media, nil
+}
+
+func TestPurgeDrainsBatchesAndDeletesBlobs(t *testing.T) {
+	db := &fakePurgeDB{batches: []int{purgeBatchSize, 2}}
+	store := &fakeBlobStore{objects: map[string][]byte{"unrelated": nil}}
+	srv := &Srv{db: db, media: store}
+	for call, n := range db.batches {
+		for i := 0; i < n; i++ {
+			m := purgedMedia(call, i)
+			store.objects[m.Key] = nil
+			store.objects[m.ThumbnailKey] = nil
+		}
+	}
+
+	srv.purgeDeletedCards(30 * 24 * time.Hour)
+	if db.calls != 2 {
+		t.Fatalf("calls = %d, want 2: repeat while batches are full", db.calls)
+	}
+	if len(store.objects) != 1 {
+		t.Fatalf("store keeps %d objects, want only the unrelated one", len(store.objects))
+	}
+}
+
+func TestPurgeStopsOnError(t *testing.T) {
+	db := &fakePurgeDB{err: errors.New("db down")}
+	srv := &Srv{db: db}
+
+	srv.purgeDeletedCards(time.Hour)
+	if db.calls != 0 {
+		t.Fatalf("calls = %d after an error, want no further batches", db.calls)
+	}
+}
@@ -0,0 +1,53 @@
+package services
+
+import (
+	database "goods/internal/database/postgres"
+	myErrors "goods/internal/errors"
+	"goods/internal/models"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+// fakeRevisionsDB отдаёт владельца карточки и запоминает, дошёл ли запрос до ревизий
+type fakeRevisionsDB struct {
+	database.InterfacePostgresDB
+	owner    uuid.UUID
+	restored bool
+}
+
+func (f *fakeRevisionsDB) CardOwner(cardID uuid.UUID) (uuid.UUID, error) {
+	return f.owner, nil
+}
+
+func (f *fakeRevisionsDB) ListRevisions(cardID uuid.UUID) ([]models.GoodCardRevision, error) {
+	return []models.GoodCardRevision{{CardID: cardID, Version: 1}}, nil
+}
+
+func (f *fakeRevisionsDB) RestoreRevision(cardID uuid.UUID, version int, expectedVersion int) (int, error) {
+	f.restored = true
+	return expectedVersion + 1, nil
+}
+
+func TestRevisionsAreScopedToOwner(t *testing.T) {
+	owner := uuid.New()
+	db := &fakeRevisionsDB{owner: owner}
+	srv := &Srv{db: db}
+	cardID := uuid.New()
+
+	// Чужая карточка неотличима от несуществующей
+	if _, err := srv.SrvListRevisions(uuid.New(), cardID); err != myErrors.ErrGoodCardNotFound {
+		t.Fatalf("list err = %v, want ErrGoodCardNotFound", err)
+	}
+	if _, err := srv.SrvRestoreRevision(uuid.New(), cardID, 1, 2); err != myErrors.ErrGoodCardNotFound || db.restored {
+		t.Fatalf("restore err = %v, restored = %v, want ErrGoodCardNotFound without restoring", err, db.restored)
+	}
+
+	revisions, err := srv.SrvListRevisions(owner, cardID)
+	if err != nil || len(revisions) != 1 {
+		t.Fatalf("revisions = %v, %v", revisions, err)
+	}
+	if version, err := srv.SrvRestoreRevision(owner, cardID, 1, 2); err != nil || version != 3 {
+		t.Fatalf("restore = %d, %v, want version 3", version, err)
+	}
+}
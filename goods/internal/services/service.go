@@ -1,52 +1,251 @@
 package services
 
 import (
+	"goods/internal/blobstore"
 	config "goods/internal/cfg"
 	database "goods/internal/database/postgres"
+	rediscashe "goods/internal/database/redis"
+	myErrors "goods/internal/errors"
+	myLog "goods/internal/logger"
 	"goods/internal/models"
+	"goods/pkg/money"
+	"strconv"
+	"time"
 
 	"github.com/google/uuid"
 )
 
 type Srv struct {
 	db database.InterfacePostgresDB
+
+	reservationTTL    time.Duration
+	reservationMaxTTL time.Duration
+
+	media        blobstore.BlobStore
+	mediaMaxSize int
+
+	idempotencyTTL time.Duration
 }
 
 func NewSrv(cfg config.Config) *Srv {
-	base := database.NewPostgres(cfg)
+	var base database.InterfacePostgresDB = database.NewPostgres(cfg)
+	if cfg.RedisAddr != "" {
+		base = rediscashe.NewCachedDB(base, rediscashe.NewClient(cfg), cfg.CacheTTL)
+	} else {
+		myLog.Log.Warnf("REDIS_ADDR is not set, good card reads are not cached")
+	}
+	media, err := blobstore.New(cfg)
+	if err != nil {
+		myLog.Log.Fatalf("Failed to create media store: %v", err)
+	}
 	return &Srv{
-		db: base,
+		db:                base,
+		reservationTTL:    cfg.ReservationTTL,
+		reservationMaxTTL: cfg.ReservationMaxTTL,
+		media:             media,
+		mediaMaxSize:      cfg.MediaMaxSize,
+		idempotencyTTL:    cfg.IdempotencyTTL,
+	}
+}
+
+// checkOwner возвращает ErrGoodCardNotFound, если карточка не принадлежит продавцу:
+// чужие карточки для продавца неотличимы от несуществующих.
+// Продавец карточки не меняется, поэтому проверка перед операцией не устаревает.
+func (srv *Srv) checkOwner(sellerID uuid.UUID, cardID uuid.UUID) error {
+	owner, err := srv.db.CardOwner(cardID)
+	if err != nil {
+		return err
 	}
+	if owner != sellerID {
+		return myErrors.ErrGoodCardNotFound
+	}
+	return nil
+}
+
+// checkSKUOwner - checkOwner для варианта: чужой вариант неотличим от несуществующего
+func (srv *Srv) checkSKUOwner(sellerID uuid.UUID, skuID uuid.UUID) error {
+	owner, err := srv.db.SKUOwner(skuID)
+	if err != nil {
+		return err
+	}
+	if owner != sellerID {
+		return myErrors.ErrGoodNotFound
+	}
+	return nil
 }
 
-func (srv *Srv) SrvCreateGoodCard(card models.GoodCard) (uuid.UUID, error) {
+func (srv *Srv) SrvCreateGoodCard(sellerID uuid.UUID, card models.GoodCard) (uuid.UUID, error) {
+	if err := validateGoodCard(card); err != nil {
+		return uuid.Nil, err
+	}
+	card.SellerID = sellerID
 	return srv.db.CreateGoodCard(card)
 }
 
-func (srv *Srv) SrvDeleteGoodCard(cardId uuid.UUID) error {
+func (srv *Srv) SrvDeleteGoodCard(sellerID uuid.UUID, cardId uuid.UUID) error {
+	if err := srv.checkOwner(sellerID, cardId); err != nil {
+		return err
+	}
 	return srv.db.DeleteGoodCard(cardId)
 }
 
-func (srv *Srv) SrvUpdateGoodCard(id uuid.UUID, card models.GoodCard) error {
-	return srv.db.UpdateGoodCard(id, card)
+func (srv *Srv) SrvUpdateGoodCard(sellerID uuid.UUID, id uuid.UUID, card models.GoodCard, expectedVersion int) (int, error) {
+	if err := validateGoodCardUpdate(card); err != nil {
+		return 0, err
+	}
+	if err := srv.checkOwner(sellerID, id); err != nil {
+		return 0, err
+	}
+	return srv.db.UpdateGoodCard(id, card, expectedVersion)
+}
+
+func (srv *Srv) SrvPatchGoodCard(sellerID uuid.UUID, cardID uuid.UUID, patch models.GoodCardPatch, expectedVersion int) (int, error) {
+	if err := validateGoodCardPatch(patch); err != nil {
+		return 0, err
+	}
+	if err := srv.checkOwner(sellerID, cardID); err != nil {
+		return 0, err
+	}
+	return srv.db.PatchGoodCard(cardID, patch, expectedVersion)
 }
 
-func (srv *Srv) SrvReadGood(id uuid.UUID) (models.Good, error) {
-	return srv.db.ReadGood(id)
+func (srv *Srv) SrvListRevisions(sellerID uuid.UUID, cardID uuid.UUID) ([]models.GoodCardRevision, error) {
+	if err := srv.checkOwner(sellerID, cardID); err != nil {
+		return nil, err
+	}
+	return srv.db.ListRevisions(cardID)
 }
 
-func (srv *Srv) SrvAddCountGood(id uuid.UUID, number int) (int, error) {
-	return srv.db.AddCountGood(id, number)
+func (srv *Srv) SrvRestoreRevision(sellerID uuid.UUID, cardID uuid.UUID, version int, expectedVersion int) (int, error) {
+	if err := srv.checkOwner(sellerID, cardID); err != nil {
+		return 0, err
+	}
+	return srv.db.RestoreRevision(cardID, version, expectedVersion)
 }
 
-func (srv *Srv) SrvDeleteCountGood(id uuid.UUID, number int) (int, error) {
-	return srv.db.DeleteCountGood(id, number)
+func (srv *Srv) SrvReadGood(sellerID uuid.UUID, id uuid.UUID) (models.Good, error) {
+	if err := srv.checkOwner(sellerID, id); err != nil {
+		return models.Good{}, err
+	}
+	good, err := srv.db.ReadGood(id)
+	if err != nil {
+		return models.Good{}, err
+	}
+	if good.Media, err = srv.listMedia(good.Card.UUID); err != nil {
+		return models.Good{}, err
+	}
+	return good, nil
 }
 
-func (srv *Srv) SrvCreateGood(cardID uuid.UUID, quantity int) error {
-	return srv.db.CreateGood(cardID, quantity)
+func (srv *Srv) SrvReadGoodCard(sellerID uuid.UUID, cardID uuid.UUID) (models.Good, error) {
+	good, err := srv.db.ReadGoodCard(cardID)
+	if err != nil {
+		return models.Good{}, err
+	}
+	if good.Card.SellerID != sellerID {
+		return models.Good{}, myErrors.ErrGoodCardNotFound
+	}
+	if good.Media, err = srv.listMedia(cardID); err != nil {
+		return models.Good{}, err
+	}
+	return good, nil
 }
 
-func (srv *Srv) SrvDeleteGood(goodID uuid.UUID) error {
-	return srv.db.DeleteGood(goodID)
+func (srv *Srv) SrvBatchReadGoodCards(ids []uuid.UUID) ([]models.Good, error) {
+	if len(ids) > models.MaxPageLimit {
+		return nil, myErrors.ValidationError("uuids", "must contain at most "+strconv.Itoa(models.MaxPageLimit)+" ids")
+	}
+	if len(ids) == 0 {
+		return []models.Good{}, nil
+	}
+	goods, err := srv.db.ReadGoodCards(ids)
+	if err != nil {
+		return nil, err
+	}
+	// Непроверенные карточки видны только продавцу
+	approved := goods[:0]
+	for _, good := range goods {
+		if good.Card.ModerationStatus == models.ModerationApproved {
+			approved = append(approved, good)
+		}
+	}
+	return approved, nil
+}
+
+func (srv *Srv) SrvListGoods(sellerID uuid.UUID, filter models.GoodFilter) (models.GoodPage, error) {
+	filter.SellerID = &sellerID
+	return srv.db.ListGoods(filter)
+}
+
+func (srv *Srv) SrvListStorefrontGoods(filter models.GoodFilter) (models.GoodPage, error) {
+	active := true
+	filter.IsActive = &active
+	filter.Moderation = models.ModerationApproved
+	return srv.db.ListGoods(filter)
+}
+
+func (srv *Srv) SrvAddCountGood(sellerID uuid.UUID, id uuid.UUID, warehouseID uuid.UUID, number int, meta models.MovementMeta) (int, error) {
+	if err := srv.checkSKUOwner(sellerID, id); err != nil {
+		return 0, err
+	}
+	return srv.db.AddCountGood(id, warehouseID, number, meta)
+}
+
+func (srv *Srv) SrvDeleteCountGood(sellerID uuid.UUID, id uuid.UUID, warehouseID uuid.UUID, number int, meta models.MovementMeta) (int, error) {
+	if err := srv.checkSKUOwner(sellerID, id); err != nil {
+		return 0, err
+	}
+	return srv.db.DeleteCountGood(id, warehouseID, number, meta)
+}
+
+func (srv *Srv) SrvAdjustStock(sellerID uuid.UUID, items []models.StockAdjustment, meta models.MovementMeta) ([]models.StockAdjustmentResult, error) {
+	if len(items) == 0 {
+		return nil, myErrors.ErrStockBatchEmpty
+	}
+	if len(items) > models.MaxPageLimit {
+		return nil, myErrors.ValidationError("items", "must contain at most "+strconv.Itoa(models.MaxPageLimit)+" items")
+	}
+	for i, item := range items {
+		if item.Delta == 0 {
+			return nil, myErrors.ItemError{Index: i, Err: myErrors.ValidationError("delta", "must not be zero")}
+		}
+	}
+	return srv.db.AdjustStock(sellerID, items, meta)
+}
+
+func (srv *Srv) SrvCreateGood(sellerID uuid.UUID, cardID uuid.UUID, quantity int, meta models.MovementMeta) error {
+	if err := srv.checkOwner(sellerID, cardID); err != nil {
+		return err
+	}
+	return srv.db.CreateGood(cardID, quantity, meta)
+}
+
+func (srv *Srv) SrvCreateSKU(sellerID uuid.UUID, cardID uuid.UUID, sku models.SKU, quantity int, meta models.MovementMeta) (uuid.UUID, error) {
+	if err := validateSKU(sku.Attributes, sku.Price, sku.Weight); err != nil {
+		return uuid.Nil, err
+	}
+	if quantity < 0 {
+		return uuid.Nil, myErrors.ValidationError("quantity", "must not be negative")
+	}
+	if err := srv.checkOwner(sellerID, cardID); err != nil {
+		return uuid.Nil, err
+	}
+	return srv.db.CreateSKU(cardID, sku, quantity, meta)
+}
+
+func (srv *Srv) SrvUpdateSKU(sellerID uuid.UUID, skuID uuid.UUID, price *money.Money, weight *float64) error {
+	if err := validateSKU(nil, price, weight); err != nil {
+		return err
+	}
+	if err := srv.checkSKUOwner(sellerID, skuID); err != nil {
+		return err
+	}
+	return srv.db.UpdateSKU(skuID, price, weight)
+}
+
+func (srv *Srv) SrvDeleteGood(sellerID uuid.UUID, skuID uuid.UUID) error {
+	if err := srv.checkSKUOwner(sellerID, skuID); err != nil {
+		return err
+	}
+	return srv.db.DeleteGood(skuID)
 }
@@ -0,0 +1,84 @@
+package services
+
+import (
+	database "goods/internal/database/postgres"
+	myErrors "goods/internal/errors"
+	"goods/internal/models"
+	"goods/pkg/money"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+// fakeSKUDB отдаёт владельца карточек и вариантов и запоминает, что дошло до базы
+type fakeSKUDB struct {
+	database.InterfacePostgresDB
+	owner   uuid.UUID
+	created *models.SKU
+	updated bool
+}
+
+func (f *fakeSKUDB) CardOwner(cardID uuid.UUID) (uuid.UUID, error) {
+	return f.owner, nil
+}
+
+func (f *fakeSKUDB) SKUOwner(skuID uuid.UUID) (uuid.UUID, error) {
+	return f.owner, nil
+}
+
+func (f *fakeSKUDB) CreateSKU(cardID uuid.UUID, sku models.SKU, quantity int, meta models.MovementMeta) (uuid.UUID, error) {
+	f.created = &sku
+	return uuid.New(), nil
+}
+
+func (f *fakeSKUDB) UpdateSKU(skuID uuid.UUID, price *money.Money, weight *float64) error {
+	f.updated = true
+	return nil
+}
+
+func TestCreateSKUValidation(t *testing.T) {
+	seller := uuid.New()
+	db := &fakeSKUDB{owner: seller}
+	srv := &Srv{db: db}
+	negative := -1.0
+
+	invalid := []struct {
+		name     string
+		sku      models.SKU
+		quantity int
+	}{
+		{"empty axis value", models.SKU{Attributes: map[string]string{"size": " "}}, 0},
+		{"negative weight", models.SKU{Attributes: map[string]string{"size": "M"}, Weight: &negative}, 0},
+		{"negative quantity", models.SKU{Attributes: map[string]string{"size": "M"}}, -1},
+	}
+	for _, c := range invalid {
+		if _, err := srv.SrvCreateSKU(seller, uuid.New(), c.sku, c.quantity, models.MovementMeta{}); err == nil {
+			t.Errorf("%s accepted", c.name)
+		}
+	}
+	if db.created != nil {
+		t.Fatal("invalid SKU must not reach the database")
+	}
+
+	sku := models.SKU{Attributes: map[string]string{"size": "M", "colour": "red"}}
+	if _, err := srv.SrvCreateSKU(seller, uuid.New(), sku, 3, models.MovementMeta{}); err != nil {
+		t.Fatalf("valid SKU rejected: %v", err)
+	}
+	if db.created == nil || db.created.Attributes["colour"] != "red" {
+		t.Fatalf("created = %+v, want the SKU attributes", db.created)
+	}
+}
+
+func TestSKUsAreScopedToOwner(t *testing.T) {
+	db := &fakeSKUDB{owner: uuid.New()}
+	srv := &Srv{db: db}
+	price := money.Money{Amount: 100, Currency: "RUB"}
+
+	// Чужой вариант неотличим от несуществующего
+	if _, err := srv.SrvCreateSKU(uuid.New(), uuid.New(), models.SKU{}, 0, models.MovementMeta{}); err != myErrors.ErrGoodCardNotFound {
+		t.Fatalf("create err = %v, want ErrGoodCardNotFound", err)
+	}
+	if err := srv.SrvUpdateSKU(uuid.New(), uuid.New(), &price, nil); err != myErrors.ErrGoodNotFound || db.updated {
+		t.Fatalf("update err = %v, updated = %v, want ErrGoodNotFound without updating", err, db.updated)
+	}
+}
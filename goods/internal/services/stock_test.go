@@ -0,0 +1,44 @@
+package services
+
+import (
+	"errors"
+	database "goods/internal/database/postgres"
+	myErrors "goods/internal/errors"
+	"goods/internal/models"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+// fakeStockDB запоминает, дошёл ли пакет до базы
+type fakeStockDB struct {
+	database.InterfacePostgresDB
+	called bool
+}
+
+func (f *fakeStockDB) AdjustStock(sellerID uuid.UUID, items []models.StockAdjustment, meta models.MovementMeta) ([]models.StockAdjustmentResult, error) {
+	f.called = true
+	return nil, nil
+}
+
+func TestAdjustStockRejectsZeroDelta(t *testing.T) {
+	db := &fakeStockDB{}
+	srv := &Srv{db: db}
+	items := []models.StockAdjustment{
+		{GoodID: uuid.New(), Delta: -2},
+		{GoodID: uuid.New(), Delta: 0},
+	}
+
+	_, err := srv.SrvAdjustStock(uuid.New(), items, models.MovementMeta{})
+	var itemErr myErrors.ItemError
+	if !errors.As(err, &itemErr) || itemErr.Index != 1 {
+		t.Fatalf("err = %v, want ItemError for item 1", err)
+	}
+	if db.called {
+		t.Fatal("invalid batch must not reach the database")
+	}
+
+	if _, err := srv.SrvAdjustStock(uuid.New(), nil, models.MovementMeta{}); err != myErrors.ErrStockBatchEmpty {
+		t.Fatalf("empty batch: err = %v, want ErrStockBatchEmpty", err)
+	}
+}
@@ -0,0 +1,219 @@
+package services
+
+import (
+	myErrors "goods/internal/errors"
+	"goods/internal/models"
+	"goods/pkg/money"
+	"math"
+	"strings"
+	"unicode/utf8"
+)
+
+// Ограничения колонок good_cards: NUMERIC(10, 2) у веса и VARCHAR(255); цена ограничена тем же числом единиц валюты
+const (
+	maxNumeric    = 1e8
+	maxNameLength = 255
+)
+
+// Ограничения колонок category_attributes
+const (
+	maxAttributeNameLength = 64
+	maxAttributeUnitLength = 32
+)
+
+// validateGoodCard проверяет карточку перед созданием и возвращает ошибку с именем поля
+func validateGoodCard(card models.GoodCard) error {
+	if err := validateName(card.Name); err != nil {
+		return err
+	}
+	if err := validateMoney("price", card.Price); err != nil {
+		return err
+	}
+	if err := validateAmount("weight", card.Weight); err != nil {
+		return err
+	}
+	if err := validateVariantAxes(card.VariantAxes); err != nil {
+		return err
+	}
+	return validateAttributeValues(card.Attributes)
+}
+
+// validateGoodCardUpdate проверяет поля, которые PUT запишет: пустые строки, отрицательные
+// числа и незаданная цена означают "не менять" и не проверяются
+func validateGoodCardUpdate(card models.GoodCard) error {
+	if card.Name != "" {
+		if err := validateName(card.Name); err != nil {
+			return err
+		}
+	}
+	if card.Price != (money.Money{}) && card.Price.Amount >= 0 {
+		if err := validateMoney("price", card.Price); err != nil {
+			return err
+		}
+	}
+	if card.Weight >= 0 {
+		if err := validateAmount("weight", card.Weight); err != nil {
+			return err
+		}
+	}
+	if card.VariantAxes != nil {
+		return validateVariantAxes(card.VariantAxes)
+	}
+	return nil
+}
+
+// validateGoodCardPatch проверяет только переданные поля
+func validateGoodCardPatch(patch models.GoodCardPatch) error {
+	if patch.Name != nil {
+		if err := validateName(*patch.Name); err != nil {
+			return err
+		}
+	}
+	if patch.Price != nil {
+		if err := validateMoney("price", *patch.Price); err != nil {
+			return err
+		}
+	}
+	if patch.Weight != nil {
+		if err := validateAmount("weight", *patch.Weight); err != nil {
+			return err
+		}
+	}
+	if patch.VariantAxes != nil {
+		if err := validateVariantAxes(*patch.VariantAxes); err != nil {
+			return err
+		}
+	}
+	if patch.Attributes != nil {
+		return validateAttributeValues(*patch.Attributes)
+	}
+	return nil
+}
+
+// validateSKU проверяет значения осей и переопределения цены и веса варианта
+func validateSKU(attributes map[string]string, price *money.Money, weight *float64) error {
+	for axis, value := range attributes {
+		if strings.TrimSpace(value) == "" {
+			return myErrors.ValidationError("attributes."+axis, "must not be empty")
+		}
+		if utf8.RuneCountInString(value) > maxNameLength {
+			return myErrors.ValidationError("attributes."+axis, "must be at most 255 characters")
+		}
+	}
+	if price != nil {
+		if err := validateMoney("price", *price); err != nil {
+			return err
+		}
+	}
+	if weight != nil {
+		return validateAmount("weight", *weight)
+	}
+	return nil
+}
+
+// validateVariantAxes проверяет, что оси вариантов непустые и не повторяются
+func validateVariantAxes(axes []string) error {
+	seen := make(map[string]bool, len(axes))
+	for _, axis := range axes {
+		if strings.TrimSpace(axis) == "" {
+			return myErrors.ValidationError("variantAxes", "axis name must not be empty")
+		}
+		if seen[axis] {
+			return myErrors.ValidationError("variantAxes", "duplicate axis "+axis)
+		}
+		seen[axis] = true
+	}
+	return nil
+}
+
+// validateAttributeValues проверяет, что имена атрибутов непустые и не повторяются.
+// Типы значений проверяются по схеме категории при записи.
+func validateAttributeValues(values []models.AttributeValue) error {
+	seen := make(map[string]bool, len(values))
+	for _, attr := range values {
+		if strings.TrimSpace(attr.Name) == "" {
+			return myErrors.ValidationError("attributes", "attribute name must not be empty")
+		}
+		if seen[attr.Name] {
+			return myErrors.ValidationError("attributes", "duplicate attribute "+attr.Name)
+		}
+		seen[attr.Name] = true
+	}
+	return nil
+}
+
+// validateAttributeSchema проверяет определения атрибутов категории
+func validateAttributeSchema(schema []models.AttributeDefinition) error {
+	seen := make(map[string]bool, len(schema))
+	for _, def := range schema {
+		if strings.TrimSpace(def.Name) == "" {
+			return myErrors.ValidationError("name", "must not be empty")
+		}
+		if utf8.RuneCountInString(def.Name) > maxAttributeNameLength {
+			return myErrors.ValidationError(def.Name+".name", "must be at most 64 characters")
+		}
+		if seen[def.Name] {
+			return myErrors.ValidationError(def.Name+".name", "is duplicated")
+		}
+		seen[def.Name] = true
+		if utf8.RuneCountInString(def.Unit) > maxAttributeUnitLength {
+			return myErrors.ValidationError(def.Name+".unit", "must be at most 32 characters")
+		}
+
+		switch def.Type {
+		case models.AttributeString, models.AttributeNumber, models.AttributeInteger, models.AttributeBoolean:
+			if len(def.AllowedValues) > 0 {
+				return myErrors.ValidationError(def.Name+".allowedValues", "is allowed only for enum attributes")
+			}
+		case models.AttributeEnum:
+			if len(def.AllowedValues) == 0 {
+				return myErrors.ValidationError(def.Name+".allowedValues", "must not be empty for enum attributes")
+			}
+			values := make(map[string]bool, len(def.AllowedValues))
+			for _, v := range def.AllowedValues {
+				if strings.TrimSpace(v) == "" || values[v] {
+					return myErrors.ValidationError(def.Name+".allowedValues", "must be non-empty and unique")
+				}
+				values[v] = true
+			}
+		default:
+			return myErrors.ValidationError(def.Name+".type", "must be one of string, number, integer, boolean, enum")
+		}
+	}
+	return nil
+}
+
+func validateName(name string) error {
+	if strings.TrimSpace(name) == "" {
+		return myErrors.ValidationError("name", "must not be empty")
+	}
+	if utf8.RuneCountInString(name) > maxNameLength {
+		return myErrors.ValidationError("name", "must be at most 255 characters")
+	}
+	return nil
+}
+
+func validateAmount(field string, v float64) error {
+	if math.IsNaN(v) || math.IsInf(v, 0) || v < 0 {
+		return myErrors.ValidationError(field, "must be a non-negative number")
+	}
+	if v >= maxNumeric {
+		return myErrors.ValidationError(field, "must be less than 100000000")
+	}
+	return nil
+}
+
+// validateMoney проверяет валюту и сумму: сумма неотрицательна и меньше 100000000 единиц валюты
+func validateMoney(field string, m money.Money) error {
+	exp, err := money.Exponent(m.Currency)
+	if err != nil {
+		return myErrors.ValidationError(field+".currency", "must be a supported ISO 4217 currency code")
+	}
+	if m.Amount < 0 {
+		return myErrors.ValidationError(field+".amount", "must be non-negative")
+	}
+	if float64(m.Amount) >= maxNumeric*math.Pow10(exp) {
+		return myErrors.ValidationError(field+".amount", "must be less than 100000000 currency units")
+	}
+	return nil
+}
@@ -0,0 +1,31 @@
+package services
+
+import (
+	"goods/internal/models"
+
+	"github.com/google/uuid"
+)
+
+func (srv *Srv) SrvCreateWarehouse(sellerID uuid.UUID, warehouse models.Warehouse) (uuid.UUID, error) {
+	if err := validateName(warehouse.Name); err != nil {
+		return uuid.Nil, err
+	}
+	warehouse.SellerID = sellerID
+	return srv.db.CreateWarehouse(warehouse)
+}
+
+func (srv *Srv) SrvListWarehouses(sellerID uuid.UUID) ([]models.Warehouse, error) {
+	return srv.db.ListWarehouses(sellerID)
+}
+
+func (srv *Srv) SrvUpdateWarehouse(sellerID uuid.UUID, warehouse models.Warehouse) error {
+	if err := validateName(warehouse.Name); err != nil {
+		return err
+	}
+	warehouse.SellerID = sellerID
+	return srv.db.UpdateWarehouse(warehouse)
+}
+
+func (srv *Srv) SrvDeleteWarehouse(sellerID uuid.UUID, id uuid.UUID) error {
+	return srv.db.DeleteWarehouse(sellerID, id)
+}
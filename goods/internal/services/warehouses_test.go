@@ -0,0 +1,60 @@
+package services
+
+import (
+	database "goods/internal/database/postgres"
+	"goods/internal/models"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+// fakeWarehousesDB запоминает склад, дошедший до базы
+type fakeWarehousesDB struct {
+	database.InterfacePostgresDB
+	warehouse *models.Warehouse
+}
+
+func (f *fakeWarehousesDB) CreateWarehouse(warehouse models.Warehouse) (uuid.UUID, error) {
+	f.warehouse = &warehouse
+	return uuid.New(), nil
+}
+
+func (f *fakeWarehousesDB) UpdateWarehouse(warehouse models.Warehouse) error {
+	f.warehouse = &warehouse
+	return nil
+}
+
+func TestWarehousesBelongToCaller(t *testing.T) {
+	db := &fakeWarehousesDB{}
+	srv := &Srv{db: db}
+	caller := uuid.New()
+
+	if _, err := srv.SrvCreateWarehouse(caller, models.Warehouse{Name: "Main", SellerID: uuid.New()}); err != nil {
+		t.Fatal(err)
+	}
+	if db.warehouse.SellerID != caller {
+		t.Fatalf("created for %v, want caller %v", db.warehouse.SellerID, caller)
+	}
+
+	if err := srv.SrvUpdateWarehouse(caller, models.Warehouse{UUID: uuid.New(), Name: "Main", SellerID: uuid.New()}); err != nil {
+		t.Fatal(err)
+	}
+	if db.warehouse.SellerID != caller {
+		t.Fatalf("updated as %v, want caller %v", db.warehouse.SellerID, caller)
+	}
+}
+
+func TestWarehouseNameIsRequired(t *testing.T) {
+	db := &fakeWarehousesDB{}
+	srv := &Srv{db: db}
+
+	if _, err := srv.SrvCreateWarehouse(uuid.New(), models.Warehouse{Name: " "}); err == nil {
+		t.Fatal("empty name accepted on create")
+	}
+	if err := srv.SrvUpdateWarehouse(uuid.New(), models.Warehouse{UUID: uuid.New()}); err == nil {
+		t.Fatal("empty name accepted on update")
+	}
+	if db.warehouse != nil {
+		t.Fatal("invalid warehouse must not reach the database")
+	}
+}
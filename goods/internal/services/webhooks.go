@@ -0,0 +1,296 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	myErrors "goods/internal/errors"
+	myLog "goods/internal/logger"
+	"goods/internal/models"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Заголовки доставки оповещения. Подпись - HMAC-SHA256 от "<timestamp>.<тело>"
+// на ключе продавца; получатель сверяет её и отбрасывает доставки со старым timestamp.
+const (
+	headerWebhookID        = "X-Webhook-Id"
+	headerWebhookEvent     = "X-Webhook-Event"
+	headerWebhookTimestamp = "X-Webhook-Timestamp"
+	headerWebhookSignature = "X-Webhook-Signature"
+)
+
+// Ограничения доставки
+const (
+	webhookSecretSize = 32
+	maxWebhookBackoff = time.Hour
+	maxResponseDrain  = 64 << 10 // Сколько тела ответа дочитывается ради переиспользования соединения
+)
+
+func (srv *Srv) SrvSetLowStockThreshold(sellerID uuid.UUID, cardID uuid.UUID, threshold *int) error {
+	if threshold != nil && *threshold < 0 {
+		return myErrors.ValidationError("threshold", "must be a non-negative integer")
+	}
+	if err := srv.checkOwner(sellerID, cardID); err != nil {
+		return err
+	}
+	return srv.db.SetLowStockThreshold(cardID, threshold)
+}
+
+func (srv *Srv) SrvSetWebhook(sellerID uuid.UUID, rawURL string) (models.Webhook, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Hostname() == "" {
+		return models.Webhook{}, myErrors.ValidationError("url", "must be an absolute http or https URL")
+	}
+	if reason := checkWebhookHost(u.Hostname()); reason != "" {
+		return models.Webhook{}, myErrors.ValidationError("url", reason)
+	}
+	secret := make([]byte, webhookSecretSize)
+	if _, err := rand.Read(secret); err != nil {
+		return models.Webhook{}, myErrors.ErrWebhookInternal
+	}
+	return srv.db.SetWebhook(models.Webhook{SellerID: sellerID, URL: u.String(), Secret: hex.EncodeToString(secret)})
+}
+
+func (srv *Srv) SrvReadWebhook(sellerID uuid.UUID) (models.Webhook, error) {
+	return srv.db.ReadWebhook(sellerID)
+}
+
+func (srv *Srv) SrvDeleteWebhook(sellerID uuid.UUID) error {
+	return srv.db.DeleteWebhook(sellerID)
+}
+
+func (srv *Srv) SrvListWebhookDeliveries(sellerID uuid.UUID, status string, limit int, cursor string) (models.WebhookDeliveryPage, error) {
+	switch status {
+	case "", models.DeliveryPending, models.DeliveryDelivered, models.DeliveryFailed:
+	default:
+		return models.WebhookDeliveryPage{}, myErrors.ValidationError("status", "must be pending, delivered or failed")
+	}
+	return srv.db.ListWebhookDeliveries(sellerID, status, limit, cursor)
+}
+
+// WebhookStore - часть хранилища, с которой работает рассыльщик оповещений
+type WebhookStore interface {
+	ClaimWebhookDeliveries(limit int, lease time.Duration) ([]models.WebhookDelivery, error)
+	RecordWebhookAttempt(id int64, attempt models.WebhookAttempt) error
+}
+
+// WebhookDispatcher отправляет оповещения продавцам с доставкой at-least-once.
+// Неудачная попытка повторяется с экспоненциальной задержкой, пока не исчерпан maxAttempts.
+type WebhookDispatcher struct {
+	store       WebhookStore
+	client      *http.Client
+	batchSize   int
+	maxAttempts int
+	backoff     time.Duration // Задержка перед первым повтором, дальше удваивается
+	lease       time.Duration // На это время забранные доставки скрыты от других экземпляров
+}
+
+func NewWebhookDispatcher(store WebhookStore, client *http.Client, batchSize int, maxAttempts int, backoff time.Duration) *WebhookDispatcher {
+	return &WebhookDispatcher{
+		store:       store,
+		client:      client,
+		batchSize:   batchSize,
+		maxAttempts: maxAttempts,
+		backoff:     backoff,
+		lease:       client.Timeout + time.Minute,
+	}
+}
+
+// NewWebhookDispatcher создаёт рассыльщик поверх хранилища сервиса
+func (srv *Srv) NewWebhookDispatcher(timeout time.Duration, batchSize int, maxAttempts int, backoff time.Duration) *WebhookDispatcher {
+	return NewWebhookDispatcher(srv.db, newWebhookClient(timeout), batchSize, maxAttempts, backoff)
+}
+
+// webhookLookupTimeout ограничивает разрешение имени хоста при сохранении адреса оповещений
+const webhookLookupTimeout = 5 * time.Second
+
+// errWebhookAddressForbidden - адрес получателя ведёт во внутреннюю сеть
+var errWebhookAddressForbidden = errors.New("webhook address is not a public address")
+
+// sharedAddressSpace - адреса операторского NAT (RFC 6598), net.IP их частными не считает
+var sharedAddressSpace = &net.IPNet{IP: net.IPv4(100, 64, 0, 0), Mask: net.CIDRMask(10, 32)}
+
+// isPublicIP сообщает, можно ли слать оповещения на ip. Продавец задаёт адрес сам, поэтому
+// loopback, частные, link-local (в том числе метаданные облака 169.254.169.254) и прочие
+// служебные адреса запрещены: иначе через оповещения можно обращаться к внутренним сервисам.
+func isPublicIP(ip net.IP) bool {
+	return !(ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast() || ip.IsUnspecified() || sharedAddressSpace.Contains(ip))
+}
+
+// checkWebhookHost возвращает причину отказа, если host - внутренний адрес или имя, разрешающееся в него
+func checkWebhookHost(host string) string {
+	if ip := net.ParseIP(host); ip != nil {
+		if !isPublicIP(ip) {
+			return "must point to a public address"
+		}
+		return ""
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), webhookLookupTimeout)
+	defer cancel()
+	addrs, err := net.DefaultResolver.LookupIPAddr(ctx, host)
+	if err != nil || len(addrs) == 0 {
+		return "host cannot be resolved"
+	}
+	for _, addr := range addrs {
+		if !isPublicIP(addr.IP) {
+			return "must point to a public address"
+		}
+	}
+	return ""
+}
+
+// newWebhookClient создаёт HTTP-клиент рассыльщика. Адрес проверяется ещё раз при установке
+// соединения: имя могло начать разрешаться во внутренний адрес после сохранения (DNS rebinding),
+// а ответ получателя - перенаправить запрос. Прокси не используется, чтобы проверка видела сам адрес.
+func newWebhookClient(timeout time.Duration) *http.Client {
+	dialer := &net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: 30 * time.Second,
+		Control: func(network string, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if ip := net.ParseIP(host); ip == nil || !isPublicIP(ip) {
+				return errWebhookAddressForbidden
+			}
+			return nil
+		},
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+	return &http.Client{Timeout: timeout, Transport: transport}
+}
+
+// DispatchOnce отправляет одну пачку наступивших доставок параллельно
+// и возвращает число обработанных
+func (d *WebhookDispatcher) DispatchOnce(ctx context.Context) (int, error) {
+	deliveries, err := d.store.ClaimWebhookDeliveries(d.batchSize, d.lease)
+	if err != nil {
+		return 0, err
+	}
+
+	var wg sync.WaitGroup
+	for _, delivery := range deliveries {
+		wg.Add(1)
+		go func(delivery models.WebhookDelivery) {
+			defer wg.Done()
+			attempt := d.deliver(ctx, delivery)
+			if err := d.store.RecordWebhookAttempt(delivery.ID, attempt); err != nil {
+				// Доставка вернётся в очередь по истечении аренды
+				myLog.Log.Errorf("Failed to record webhook attempt %d: %v", delivery.ID, err)
+			}
+		}(delivery)
+	}
+	wg.Wait()
+	return len(deliveries), nil
+}
+
+// Run периодически отправляет накопившиеся оповещения, пока не отменён ctx
+func (d *WebhookDispatcher) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			d.drain(ctx)
+		}
+	}
+}
+
+func (d *WebhookDispatcher) drain(ctx context.Context) {
+	for ctx.Err() == nil {
+		n, err := d.DispatchOnce(ctx)
+		if err != nil {
+			myLog.Log.Errorf("Failed to dispatch webhooks: %v", err)
+			return
+		}
+		if n < d.batchSize {
+			return
+		}
+	}
+}
+
+// deliver выполняет одну попытку доставки и возвращает её результат
+func (d *WebhookDispatcher) deliver(ctx context.Context, delivery models.WebhookDelivery) models.WebhookAttempt {
+	now := time.Now()
+	code, err := d.post(ctx, delivery, now)
+	attempt := models.WebhookAttempt{ResponseCode: code, NextAttemptAt: now}
+	switch {
+	case err == nil && *code >= 200 && *code < 300:
+		attempt.Status = models.DeliveryDelivered
+		return attempt
+	case err != nil:
+		attempt.Error = err.Error()
+	default:
+		attempt.Error = fmt.Sprintf("unexpected response status %d", *code)
+	}
+
+	attempts := delivery.Attempts + 1
+	if attempts >= d.maxAttempts {
+		attempt.Status = models.DeliveryFailed
+		return attempt
+	}
+	attempt.Status = models.DeliveryPending
+	attempt.NextAttemptAt = now.Add(webhookBackoff(d.backoff, attempts))
+	return attempt
+}
+
+// post отправляет подписанное оповещение; code == nil, если ответ не получен
+func (d *WebhookDispatcher) post(ctx context.Context, delivery models.WebhookDelivery, now time.Time) (*int, error) {
+	timestamp := now.Unix()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, delivery.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(headerWebhookID, strconv.FormatInt(delivery.ID, 10))
+	req.Header.Set(headerWebhookEvent, delivery.Event)
+	req.Header.Set(headerWebhookTimestamp, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(headerWebhookSignature, signWebhook(delivery.Secret, timestamp, delivery.Payload))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxResponseDrain))
+	return &resp.StatusCode, nil
+}
+
+// signWebhook возвращает значение заголовка подписи для тела body
+func signWebhook(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// webhookBackoff - задержка перед повтором после attempts неудачных попыток
+func webhookBackoff(base time.Duration, attempts int) time.Duration {
+	delay := base
+	for i := 1; i < attempts && delay < maxWebhookBackoff; i++ {
+		delay *= 2
+	}
+	return min(delay, maxWebhookBackoff)
+}
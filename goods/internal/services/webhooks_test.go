@@ -0,0 +1,178 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"goods/internal/models"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// memWebhooks - очередь доставок в памяти
+type memWebhooks struct {
+	mu         sync.Mutex
+	deliveries []models.WebhookDelivery
+	attempts   map[int64][]models.WebhookAttempt
+}
+
+func newMemWebhooks(deliveries ...models.WebhookDelivery) *memWebhooks {
+	return &memWebhooks{deliveries: deliveries, attempts: make(map[int64][]models.WebhookAttempt)}
+}
+
+func (m *memWebhooks) ClaimWebhookDeliveries(limit int, lease time.Duration) ([]models.WebhookDelivery, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var claimed []models.WebhookDelivery
+	for _, d := range m.deliveries {
+		if d.Status == models.DeliveryPending && len(claimed) < limit {
+			claimed = append(claimed, d)
+		}
+	}
+	return claimed, nil
+}
+
+func (m *memWebhooks) RecordWebhookAttempt(id int64, attempt models.WebhookAttempt) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for i := range m.deliveries {
+		if m.deliveries[i].ID == id {
+			m.deliveries[i].Status = attempt.Status
+			m.deliveries[i].Attempts++
+		}
+	}
+	m.attempts[id] = append(m.attempts[id], attempt)
+	return nil
+}
+
+func testDelivery(id int64, url string) models.WebhookDelivery {
+	payload, _ := json.Marshal(models.StockAlert{Event: models.AlertOutOfStock, CardID: uuid.New()})
+	return models.WebhookDelivery{
+		ID: id, Event: models.AlertOutOfStock, URL: url, Payload: payload,
+		Status: models.DeliveryPending, Secret: "secret",
+	}
+}
+
+func TestWebhookDispatcherSignsDeliveries(t *testing.T) {
+	var got []string
+	var mu sync.Mutex
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		timestamp, err := strconv.ParseInt(r.Header.Get(headerWebhookTimestamp), 10, 64)
+		if err != nil || r.Header.Get(headerWebhookSignature) != signWebhook("secret", timestamp, body) {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		mu.Lock()
+		got = append(got, r.Header.Get(headerWebhookID))
+		mu.Unlock()
+	}))
+	defer server.Close()
+
+	store := newMemWebhooks(testDelivery(1, server.URL), testDelivery(2, server.URL))
+	dispatcher := NewWebhookDispatcher(store, server.Client(), 10, 3, time.Second)
+
+	n, err := dispatcher.DispatchOnce(context.Background())
+	if err != nil || n != 2 {
+		t.Fatalf("expected 2 dispatched deliveries, got n=%d err=%v", n, err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("expected 2 verified deliveries, got %v", got)
+	}
+	for _, d := range store.deliveries {
+		if d.Status != models.DeliveryDelivered {
+			t.Fatalf("delivery %d: expected status delivered, got %s", d.ID, d.Status)
+		}
+	}
+}
+
+func TestWebhookDispatcherRetriesWithBackoff(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	store := newMemWebhooks(testDelivery(1, server.URL))
+	dispatcher := NewWebhookDispatcher(store, server.Client(), 10, 3, time.Second)
+
+	for i := 0; i < 4; i++ {
+		if _, err := dispatcher.DispatchOnce(context.Background()); err != nil {
+			t.Fatalf("dispatch %d: %v", i, err)
+		}
+	}
+
+	attempts := store.attempts[1]
+	if len(attempts) != 3 {
+		t.Fatalf("expected 3 attempts before giving up, got %d", len(attempts))
+	}
+	var prevDelay time.Duration
+	for i, attempt := range attempts[:2] {
+		if attempt.Status != models.DeliveryPending || attempt.ResponseCode == nil || *attempt.ResponseCode != http.StatusServiceUnavailable {
+			t.Fatalf("attempt %d: unexpected result %+v", i, attempt)
+		}
+		delay := time.Until(attempt.NextAttemptAt)
+		if delay <= prevDelay {
+			t.Fatalf("attempt %d: backoff did not grow: %v after %v", i, delay, prevDelay)
+		}
+		prevDelay = delay
+	}
+	if attempts[2].Status != models.DeliveryFailed {
+		t.Fatalf("expected last attempt to fail the delivery, got %s", attempts[2].Status)
+	}
+}
+
+func TestWebhookBackoffIsCapped(t *testing.T) {
+	if got := webhookBackoff(time.Second, 1); got != time.Second {
+		t.Fatalf("first retry: expected 1s, got %v", got)
+	}
+	if got := webhookBackoff(time.Second, 3); got != 4*time.Second {
+		t.Fatalf("third retry: expected 4s, got %v", got)
+	}
+	if got := webhookBackoff(time.Minute, 100); got != maxWebhookBackoff {
+		t.Fatalf("expected backoff capped at %v, got %v", maxWebhookBackoff, got)
+	}
+}
+
+func TestSetWebhookRejectsInternalAddresses(t *testing.T) {
+	srv := &Srv{}
+	for _, rawURL := range []string{
+		"http://127.0.0.1/hook",
+		"http://localhost:8080/hook",
+		"http://10.0.0.5/hook",
+		"https://192.168.1.1/hook",
+		"http://169.254.169.254/latest/meta-data",
+		"http://[::1]/hook",
+		"http://[fd00::1]/hook",
+		"http://100.64.0.1/hook",
+		"http://0.0.0.0/hook",
+	} {
+		if _, err := srv.SrvSetWebhook(uuid.New(), rawURL); err == nil {
+			t.Errorf("%s: expected validation error", rawURL)
+		}
+	}
+}
+
+func TestWebhookClientRefusesInternalAddressesAtDial(t *testing.T) {
+	var called bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	}))
+	defer server.Close()
+
+	store := newMemWebhooks(testDelivery(1, server.URL))
+	dispatcher := NewWebhookDispatcher(store, newWebhookClient(time.Second), 10, 3, time.Second)
+	if _, err := dispatcher.DispatchOnce(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	attempts := store.attempts[1]
+	if called || len(attempts) != 1 || attempts[0].ResponseCode != nil || attempts[0].Status != models.DeliveryPending {
+		t.Fatalf("expected the loopback delivery to be refused before connecting, got called=%v attempts=%+v", called, attempts)
+	}
+}
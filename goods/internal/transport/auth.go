@@ -0,0 +1,99 @@
+package transport
+
+import (
+	"crypto/subtle"
+	myErrors "goods/internal/errors"
+
+	"github.com/google/uuid"
+	"github.com/valyala/fasthttp"
+)
+
+// Ключ, под которым middleware сохраняет продавца в контексте запроса
+const sellerIDKey = "sellerID"
+
+// SellerResolver определяет продавца, от имени которого выполняется запрос
+type SellerResolver func(ctx *fasthttp.RequestCtx) (uuid.UUID, error)
+
+// HeaderSellerResolver берёт SupplierID из заголовка X-Supplier-ID,
+// который проставляет шлюз после аутентификации продавца
+func HeaderSellerResolver(ctx *fasthttp.RequestCtx) (uuid.UUID, error) {
+	raw := ctx.Request.Header.Peek("X-Supplier-ID")
+	if len(raw) == 0 {
+		return uuid.Nil, myErrors.ErrUnauthorized
+	}
+	id, err := uuid.ParseBytes(raw)
+	if err != nil || id == uuid.Nil {
+		return uuid.Nil, myErrors.ErrUnauthorized
+	}
+	return id, nil
+}
+
+// withSeller пропускает запрос к обработчику только с установленным продавцом
+func (hb *HandlersBuilder) withSeller(next fasthttp.RequestHandler) fasthttp.RequestHandler {
+	return func(ctx *fasthttp.RequestCtx) {
+		sellerID, err := hb.resolveSeller(ctx)
+		if err != nil {
+			serviceErrorResponse(ctx, err, "Unauthorized")
+			return
+		}
+		ctx.SetUserValue(sellerIDKey, sellerID)
+		next(ctx)
+	}
+}
+
+// sellerFromCtx возвращает продавца, установленного withSeller
+func sellerFromCtx(ctx *fasthttp.RequestCtx) uuid.UUID {
+	id, _ := ctx.UserValue(sellerIDKey).(uuid.UUID)
+	return id
+}
+
+// Ключ, под которым withModerator сохраняет модератора в контексте запроса
+const moderatorIDKey = "moderatorID"
+
+// withModerator пропускает запрос к обработчику только с заголовком X-Moderator-ID,
+// который шлюз проставляет после аутентификации сотрудника модерации
+func (hb *HandlersBuilder) withModerator(next fasthttp.RequestHandler) fasthttp.RequestHandler {
+	return func(ctx *fasthttp.RequestCtx) {
+		id, err := uuid.ParseBytes(ctx.Request.Header.Peek("X-Moderator-ID"))
+		if err != nil || id == uuid.Nil {
+			serviceErrorResponse(ctx, myErrors.ErrModeratorUnauthorized, "Unauthorized")
+			return
+		}
+		ctx.SetUserValue(moderatorIDKey, id)
+		next(ctx)
+	}
+}
+
+// moderatorFromCtx возвращает модератора, установленного withModerator
+func moderatorFromCtx(ctx *fasthttp.RequestCtx) uuid.UUID {
+	id, _ := ctx.UserValue(moderatorIDKey).(uuid.UUID)
+	return id
+}
+
+// Ключ, под которым withService отмечает запрос внутреннего сервиса
+const serviceKey = "service"
+
+// withService пропускает запрос к обработчику только с заголовком X-Service-Token, равным общему
+// секрету внутренних сервисов (SERVICE_TOKEN). Без настроенного секрета такие запросы отклоняются.
+func (hb *HandlersBuilder) withService(next fasthttp.RequestHandler) fasthttp.RequestHandler {
+	return func(ctx *fasthttp.RequestCtx) {
+		if !hb.validServiceToken(ctx) {
+			serviceErrorResponse(ctx, myErrors.ErrServiceUnauthorized, "Unauthorized")
+			return
+		}
+		ctx.SetUserValue(serviceKey, true)
+		next(ctx)
+	}
+}
+
+// validServiceToken сверяет X-Service-Token с общим секретом за постоянное время
+func (hb *HandlersBuilder) validServiceToken(ctx *fasthttp.RequestCtx) bool {
+	token := ctx.Request.Header.Peek("X-Service-Token")
+	return hb.serviceToken != "" && subtle.ConstantTimeCompare(token, []byte(hb.serviceToken)) == 1
+}
+
+// fromService сообщает, пропущен ли запрос через withService
+func fromService(ctx *fasthttp.RequestCtx) bool {
+	ok, _ := ctx.UserValue(serviceKey).(bool)
+	return ok
+}
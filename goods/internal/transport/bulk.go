@@ -0,0 +1,302 @@
+package transport
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	myErrors "goods/internal/errors"
+	myLog "goods/internal/logger"
+	"goods/internal/models"
+	"goods/pkg/money"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/valyala/fasthttp"
+)
+
+// Форматы импорта и экспорта каталога
+const (
+	formatCSV    = "csv"
+	formatNDJSON = "ndjson"
+)
+
+// Колонки CSV; при импорте обязательны все, кроме uuid и quantity. Цена - десятичная запись в валюте currency.
+// quantity - количество по всем складам: при обновлении карточки остаток доводится до него.
+var csvColumns = []string{"uuid", "name", "description", "price", "currency", "weight", "is_active", "quantity"}
+
+// Максимальная длина строки NDJSON
+const maxNDJSONLine = 1 << 20
+
+// Максимальный размер файла импорта
+const maxImportSize = 256 << 20
+
+// limitedBody возвращает ErrImportTooLarge, если прочитано больше limit байт
+type limitedBody struct {
+	r     io.Reader
+	limit int64
+}
+
+func (b *limitedBody) Read(p []byte) (int, error) {
+	if b.limit < 0 {
+		return 0, myErrors.ErrImportTooLarge
+	}
+	// Читаем на байт больше предела, чтобы отличить файл ровно в limit байт от более длинного
+	if int64(len(p)) > b.limit+1 {
+		p = p[:b.limit+1]
+	}
+	n, err := b.r.Read(p)
+	b.limit -= int64(n)
+	if b.limit < 0 {
+		return n, myErrors.ErrImportTooLarge
+	}
+	return n, err
+}
+
+// bulkFormat определяет формат по параметру ?format=, а при его отсутствии - по Content-Type
+func bulkFormat(ctx *fasthttp.RequestCtx) (string, error) {
+	format := string(ctx.QueryArgs().Peek("format"))
+	if format == "" {
+		contentType := string(ctx.Request.Header.ContentType())
+		switch {
+		case strings.HasPrefix(contentType, "text/csv"):
+			format = formatCSV
+		case strings.HasPrefix(contentType, "application/x-ndjson"), strings.HasPrefix(contentType, "application/ndjson"):
+			format = formatNDJSON
+		}
+	}
+	if format != formatCSV && format != formatNDJSON {
+		return "", myErrors.ErrUnsupportedFormat
+	}
+	return format, nil
+}
+
+func (hb *HandlersBuilder) HandleImportGoodCards() func(ctx *fasthttp.RequestCtx) {
+	return metrics(func(ctx *fasthttp.RequestCtx) {
+		if !ctx.IsPost() {
+			httpErrorResponse(ctx, fasthttp.StatusMethodNotAllowed, "Method not allowed")
+			return
+		}
+
+		format, err := bulkFormat(ctx)
+		if err != nil {
+			serviceErrorResponse(ctx, err, "Unsupported format")
+			return
+		}
+
+		// Тело читается потоком, не загружая весь файл в память
+		var body io.Reader = ctx.RequestBodyStream()
+		if body == nil {
+			body = bytes.NewReader(ctx.PostBody())
+		}
+		body = &limitedBody{r: body, limit: maxImportSize}
+
+		var next func() (models.ImportRow, error)
+		if format == formatCSV {
+			next, err = csvRowReader(body)
+		} else {
+			next = ndjsonRowReader(body)
+		}
+		if err != nil {
+			httpErrorResponse(ctx, fasthttp.StatusBadRequest, err.Error())
+			return
+		}
+
+		report, err := hb.srv.SrvImportGoodCards(sellerFromCtx(ctx), next, movementMeta(ctx))
+		if err != nil {
+			serviceErrorResponse(ctx, err, "Failed to import good cards")
+			return
+		}
+
+		ctx.SetStatusCode(fasthttp.StatusOK)
+		jsonResponse(ctx, report)
+	}, "HandleImportGoodCards")
+}
+
+// csvRowReader читает заголовок CSV и возвращает функцию чтения следующей строки
+func csvRowReader(r io.Reader) (func() (models.ImportRow, error), error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.ReuseRecord = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("Invalid CSV header")
+	}
+	index := make(map[string]int, len(header))
+	for i, name := range header {
+		index[strings.TrimSpace(strings.ToLower(name))] = i
+	}
+	for _, name := range []string{"name", "description", "price", "currency", "weight", "is_active"} {
+		if _, ok := index[name]; !ok {
+			return nil, fmt.Errorf("CSV header is missing column %s", name)
+		}
+	}
+
+	return func() (models.ImportRow, error) {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return models.ImportRow{}, io.EOF
+		}
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			return models.ImportRow{Line: parseErr.StartLine, Err: fmt.Errorf("malformed CSV: %v", parseErr.Err)}, nil
+		}
+		if err != nil {
+			return models.ImportRow{}, err
+		}
+		// Номер строки файла, с которой начинается запись (поля в кавычках могут быть многострочными)
+		line, _ := reader.FieldPos(0)
+		row := models.ImportRow{Line: line}
+
+		field := func(name string) string {
+			i, ok := index[name]
+			if !ok || i >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[i])
+		}
+		row.Card, row.Quantity, row.Err = parseCSVRecord(field)
+		return row, nil
+	}, nil
+}
+
+func parseCSVRecord(field func(name string) string) (models.GoodCard, *int, error) {
+	card := models.GoodCard{
+		Name:        field("name"),
+		Description: field("description"),
+	}
+	var err error
+	if v := field("uuid"); v != "" {
+		if card.UUID, err = uuid.Parse(v); err != nil {
+			return card, nil, fmt.Errorf("invalid field uuid")
+		}
+	}
+	if !money.Supported(field("currency")) {
+		return card, nil, fmt.Errorf("invalid field currency")
+	}
+	if card.Price, err = money.Parse(field("price"), field("currency")); err != nil {
+		return card, nil, fmt.Errorf("invalid field price")
+	}
+	if card.Weight, err = strconv.ParseFloat(field("weight"), 64); err != nil {
+		return card, nil, fmt.Errorf("invalid field weight")
+	}
+	if card.IsActive, err = strconv.ParseBool(field("is_active")); err != nil {
+		return card, nil, fmt.Errorf("invalid field is_active")
+	}
+	// Пустое количество не задаёт остаток: у обновляемой карточки он не меняется
+	v := field("quantity")
+	if v == "" {
+		return card, nil, nil
+	}
+	quantity, err := strconv.Atoi(v)
+	if err != nil {
+		return card, nil, fmt.Errorf("invalid field quantity")
+	}
+	return card, &quantity, nil
+}
+
+// ndjsonRecord - строка NDJSON: поля карточки в формате models.GoodCard и количество по всем складам
+type ndjsonRecord struct {
+	models.GoodCard
+	Quantity *int `json:"quantity"`
+}
+
+// ndjsonRowReader возвращает функцию чтения следующей непустой строки NDJSON
+func ndjsonRowReader(r io.Reader) func() (models.ImportRow, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxNDJSONLine)
+
+	line := 0
+	return func() (models.ImportRow, error) {
+		for scanner.Scan() {
+			line++
+			raw := bytes.TrimSpace(scanner.Bytes())
+			if len(raw) == 0 {
+				continue
+			}
+			row := models.ImportRow{Line: line}
+			var record ndjsonRecord
+			if err := json.Unmarshal(raw, &record); err != nil {
+				row.Err = fmt.Errorf("malformed JSON")
+				return row, nil
+			}
+			row.Card = record.GoodCard
+			row.Quantity = record.Quantity
+			return row, nil
+		}
+		if err := scanner.Err(); err != nil {
+			return models.ImportRow{}, err
+		}
+		return models.ImportRow{}, io.EOF
+	}
+}
+
+func (hb *HandlersBuilder) HandleExportGoodCards() func(ctx *fasthttp.RequestCtx) {
+	return metrics(func(ctx *fasthttp.RequestCtx) {
+		if !ctx.IsGet() {
+			httpErrorResponse(ctx, fasthttp.StatusMethodNotAllowed, "Method not allowed")
+			return
+		}
+
+		format := string(ctx.QueryArgs().Peek("format"))
+		if format == "" {
+			format = formatNDJSON
+		}
+		if format != formatCSV && format != formatNDJSON {
+			serviceErrorResponse(ctx, myErrors.ErrUnsupportedFormat, "Unsupported format")
+			return
+		}
+
+		sellerID := sellerFromCtx(ctx)
+		if format == formatCSV {
+			ctx.SetContentType("text/csv")
+		} else {
+			ctx.SetContentType("application/x-ndjson")
+		}
+		ctx.SetStatusCode(fasthttp.StatusOK)
+
+		// Ответ пишется потоком по мере чтения страниц каталога
+		ctx.SetBodyStreamWriter(func(w *bufio.Writer) {
+			var emit func(good models.Good) error
+			if format == formatCSV {
+				cw := csv.NewWriter(w)
+				if err := cw.Write(csvColumns); err != nil {
+					return
+				}
+				emit = func(good models.Good) error {
+					err := cw.Write([]string{
+						good.Card.UUID.String(),
+						good.Card.Name,
+						good.Card.Description,
+						good.Card.Price.Decimal(),
+						good.Card.Price.Currency,
+						strconv.FormatFloat(good.Card.Weight, 'f', -1, 64),
+						strconv.FormatBool(good.Card.IsActive),
+						strconv.Itoa(good.Quantity),
+					})
+					if err != nil {
+						return err
+					}
+					cw.Flush()
+					return cw.Error()
+				}
+			} else {
+				enc := json.NewEncoder(w)
+				emit = func(good models.Good) error {
+					return enc.Encode(ndjsonRecord{GoodCard: good.Card, Quantity: &good.Quantity})
+				}
+			}
+
+			if err := hb.srv.SrvExportGoods(sellerID, emit); err != nil {
+				// Статус уже отправлен: обрываем поток, клиент увидит неполный ответ
+				myLog.Log.Errorf("Failed to export good cards: %v", err)
+			}
+			w.Flush()
+		})
+	}, "HandleExportGoodCards")
+}
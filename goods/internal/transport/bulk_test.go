@@ -0,0 +1,78 @@
+package transport
+
+import (
+	"bytes"
+	"errors"
+	myErrors "goods/internal/errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestCSVRowReader(t *testing.T) {
+	input := "name,description,price,currency,weight,is_active,quantity\n" +
+		"Чайник,Стальной,1500.50,RUB,1.2,true,7\n" +
+		"Кружка,,100,RUB,0.3,false,\n" +
+		"Ложка,,abc,RUB,0.1,true,1\n"
+	next, err := csvRowReader(strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	row, err := next()
+	if err != nil || row.Err != nil {
+		t.Fatalf("row = %+v, %v", row, err)
+	}
+	if row.Line != 2 || row.Card.Price.Amount != 150050 || row.Quantity == nil || *row.Quantity != 7 {
+		t.Fatalf("row = %+v, want line 2 priced 150050 with quantity 7", row)
+	}
+
+	// Пустое количество не задаёт остаток
+	if row, _ = next(); row.Err != nil || row.Quantity != nil {
+		t.Fatalf("row = %+v, want no quantity", row)
+	}
+	if row, _ = next(); row.Err == nil || row.Line != 4 {
+		t.Fatalf("row = %+v, want an error for the invalid price on line 4", row)
+	}
+	if _, err := next(); err != io.EOF {
+		t.Fatalf("err = %v, want io.EOF", err)
+	}
+}
+
+func TestCSVRowReaderRequiresColumns(t *testing.T) {
+	if _, err := csvRowReader(strings.NewReader("name,price\n")); err == nil {
+		t.Fatal("header without required columns accepted")
+	}
+}
+
+func TestNDJSONRowReader(t *testing.T) {
+	input := `{"name":"Чайник","quantity":3}` + "\n\n" + `{"name":` + "\n" + `{"name":"Кружка"}` + "\n"
+	next := ndjsonRowReader(strings.NewReader(input))
+
+	row, _ := next()
+	if row.Line != 1 || row.Card.Name != "Чайник" || row.Quantity == nil || *row.Quantity != 3 {
+		t.Fatalf("row = %+v, want line 1 with quantity 3", row)
+	}
+	// Пустые строки пропускаются, но учитываются в номерах строк
+	if row, _ = next(); row.Line != 3 || row.Err == nil {
+		t.Fatalf("row = %+v, want malformed JSON on line 3", row)
+	}
+	if row, _ = next(); row.Line != 4 || row.Quantity != nil {
+		t.Fatalf("row = %+v, want line 4 without quantity", row)
+	}
+	if _, err := next(); err != io.EOF {
+		t.Fatalf("err = %v, want io.EOF", err)
+	}
+}
+
+func TestLimitedBody(t *testing.T) {
+	exact := &limitedBody{r: bytes.NewReader(make([]byte, 10)), limit: 10}
+	if data, err := io.ReadAll(exact); err != nil || len(data) != 10 {
+		t.Fatalf("ReadAll = %d bytes, %v, want the whole body", len(data), err)
+	}
+
+	long := &limitedBody{r: bytes.NewReader(make([]byte, 11)), limit: 10}
+	if _, err := io.ReadAll(long); !errors.Is(err, myErrors.ErrImportTooLarge) {
+		t.Fatalf("err = %v, want ErrImportTooLarge", err)
+	}
+}
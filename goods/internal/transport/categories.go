@@ -0,0 +1,356 @@
+package transport
+
+import (
+	"encoding/json"
+	"goods/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/valyala/fasthttp"
+)
+
+// parseIDParam разбирает UUID из параметра пути name
+func parseIDParam(ctx *fasthttp.RequestCtx, name string) (uuid.UUID, bool) {
+	id, err := uuid.Parse(ctx.UserValue(name).(string))
+	if err != nil {
+		httpErrorResponse(ctx, fasthttp.StatusBadRequest, "Invalid UUID")
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+func (hb *HandlersBuilder) HandleCreateCategory() func(ctx *fasthttp.RequestCtx) {
+	return metrics(func(ctx *fasthttp.RequestCtx) {
+		if !ctx.IsPost() {
+			httpErrorResponse(ctx, fasthttp.StatusMethodNotAllowed, "Method not allowed")
+			return
+		}
+
+		var req struct {
+			Name     string     `json:"name"`
+			ParentID *uuid.UUID `json:"parentId"`
+		}
+		if err := json.Unmarshal(ctx.PostBody(), &req); err != nil {
+			httpErrorResponse(ctx, fasthttp.StatusBadRequest, "Invalid request body")
+			return
+		}
+
+		id, err := hb.srv.SrvCreateCategory(req.ParentID, req.Name)
+		if err != nil {
+			serviceErrorResponse(ctx, err, "Failed to create category")
+			return
+		}
+
+		ctx.SetStatusCode(fasthttp.StatusCreated)
+		jsonResponse(ctx, map[string]string{"id": id.String()})
+	}, "HandleCreateCategory")
+}
+
+// HandleListCategories возвращает дочерние категории ?parent=, без параметра - корневые
+func (hb *HandlersBuilder) HandleListCategories() func(ctx *fasthttp.RequestCtx) {
+	return metrics(func(ctx *fasthttp.RequestCtx) {
+		if !ctx.IsGet() {
+			httpErrorResponse(ctx, fasthttp.StatusMethodNotAllowed, "Method not allowed")
+			return
+		}
+
+		var parentID *uuid.UUID
+		if v := ctx.QueryArgs().Peek("parent"); len(v) > 0 {
+			id, err := uuid.ParseBytes(v)
+			if err != nil {
+				httpErrorResponse(ctx, fasthttp.StatusBadRequest, "Invalid parent")
+				return
+			}
+			parentID = &id
+		}
+
+		categories, err := hb.srv.SrvListCategories(parentID)
+		if err != nil {
+			serviceErrorResponse(ctx, err, "Failed to list categories")
+			return
+		}
+
+		ctx.SetStatusCode(fasthttp.StatusOK)
+		jsonResponse(ctx, categories)
+	}, "HandleListCategories")
+}
+
+func (hb *HandlersBuilder) HandleReadCategory() func(ctx *fasthttp.RequestCtx) {
+	return metrics(func(ctx *fasthttp.RequestCtx) {
+		if !ctx.IsGet() {
+			httpErrorResponse(ctx, fasthttp.StatusMethodNotAllowed, "Method not allowed")
+			return
+		}
+
+		id, ok := parseIDParam(ctx, "id")
+		if !ok {
+			return
+		}
+
+		category, err := hb.srv.SrvReadCategory(id)
+		if err != nil {
+			serviceErrorResponse(ctx, err, "Failed to read category")
+			return
+		}
+
+		ctx.SetStatusCode(fasthttp.StatusOK)
+		jsonResponse(ctx, category)
+	}, "HandleReadCategory")
+}
+
+func (hb *HandlersBuilder) HandleCategoryPath() func(ctx *fasthttp.RequestCtx) {
+	return metrics(func(ctx *fasthttp.RequestCtx) {
+		if !ctx.IsGet() {
+			httpErrorResponse(ctx, fasthttp.StatusMethodNotAllowed, "Method not allowed")
+			return
+		}
+
+		id, ok := parseIDParam(ctx, "id")
+		if !ok {
+			return
+		}
+
+		path, err := hb.srv.SrvCategoryPath(id)
+		if err != nil {
+			serviceErrorResponse(ctx, err, "Failed to read category path")
+			return
+		}
+
+		ctx.SetStatusCode(fasthttp.StatusOK)
+		jsonResponse(ctx, path)
+	}, "HandleCategoryPath")
+}
+
+func (hb *HandlersBuilder) HandleReadAttributeSchema() func(ctx *fasthttp.RequestCtx) {
+	return metrics(func(ctx *fasthttp.RequestCtx) {
+		if !ctx.IsGet() {
+			httpErrorResponse(ctx, fasthttp.StatusMethodNotAllowed, "Method not allowed")
+			return
+		}
+
+		id, ok := parseIDParam(ctx, "id")
+		if !ok {
+			return
+		}
+
+		schema, err := hb.srv.SrvReadAttributeSchema(id)
+		if err != nil {
+			serviceErrorResponse(ctx, err, "Failed to read attribute schema")
+			return
+		}
+		if schema == nil {
+			schema = []models.AttributeDefinition{}
+		}
+
+		ctx.SetStatusCode(fasthttp.StatusOK)
+		jsonResponse(ctx, map[string]interface{}{"attributes": schema})
+	}, "HandleReadAttributeSchema")
+}
+
+// HandleSetAttributeSchema заменяет схему атрибутов категории целиком.
+// Если существующие карточки не соответствуют новой схеме, отвечает 409 с первой такой карточкой.
+func (hb *HandlersBuilder) HandleSetAttributeSchema() func(ctx *fasthttp.RequestCtx) {
+	return metrics(func(ctx *fasthttp.RequestCtx) {
+		if !ctx.IsPut() {
+			httpErrorResponse(ctx, fasthttp.StatusMethodNotAllowed, "Method not allowed")
+			return
+		}
+
+		id, ok := parseIDParam(ctx, "id")
+		if !ok {
+			return
+		}
+
+		var req struct {
+			Attributes []models.AttributeDefinition `json:"attributes"`
+		}
+		if err := json.Unmarshal(ctx.PostBody(), &req); err != nil {
+			httpErrorResponse(ctx, fasthttp.StatusBadRequest, "Invalid request body")
+			return
+		}
+
+		if err := hb.srv.SrvSetAttributeSchema(id, req.Attributes); err != nil {
+			serviceErrorResponse(ctx, err, "Failed to update attribute schema")
+			return
+		}
+
+		ctx.SetStatusCode(fasthttp.StatusOK)
+		jsonResponse(ctx, map[string]string{"status": "updated"})
+	}, "HandleSetAttributeSchema")
+}
+
+func (hb *HandlersBuilder) HandleRenameCategory() func(ctx *fasthttp.RequestCtx) {
+	return metrics(func(ctx *fasthttp.RequestCtx) {
+		if !ctx.IsPut() {
+			httpErrorResponse(ctx, fasthttp.StatusMethodNotAllowed, "Method not allowed")
+			return
+		}
+
+		id, ok := parseIDParam(ctx, "id")
+		if !ok {
+			return
+		}
+
+		var req struct {
+			Name string `json:"name"`
+		}
+		if err := json.Unmarshal(ctx.PostBody(), &req); err != nil {
+			httpErrorResponse(ctx, fasthttp.StatusBadRequest, "Invalid request body")
+			return
+		}
+
+		if err := hb.srv.SrvRenameCategory(id, req.Name); err != nil {
+			serviceErrorResponse(ctx, err, "Failed to rename category")
+			return
+		}
+
+		ctx.SetStatusCode(fasthttp.StatusOK)
+		jsonResponse(ctx, map[string]string{"status": "updated"})
+	}, "HandleRenameCategory")
+}
+
+func (hb *HandlersBuilder) HandleMoveCategory() func(ctx *fasthttp.RequestCtx) {
+	return metrics(func(ctx *fasthttp.RequestCtx) {
+		if !ctx.IsPost() {
+			httpErrorResponse(ctx, fasthttp.StatusMethodNotAllowed, "Method not allowed")
+			return
+		}
+
+		id, ok := parseIDParam(ctx, "id")
+		if !ok {
+			return
+		}
+
+		// parentId: null переносит категорию в корень
+		var req struct {
+			ParentID *uuid.UUID `json:"parentId"`
+		}
+		if err := json.Unmarshal(ctx.PostBody(), &req); err != nil {
+			httpErrorResponse(ctx, fasthttp.StatusBadRequest, "Invalid request body")
+			return
+		}
+
+		if err := hb.srv.SrvMoveCategory(id, req.ParentID); err != nil {
+			serviceErrorResponse(ctx, err, "Failed to move category")
+			return
+		}
+
+		ctx.SetStatusCode(fasthttp.StatusOK)
+		jsonResponse(ctx, map[string]string{"status": "moved"})
+	}, "HandleMoveCategory")
+}
+
+func (hb *HandlersBuilder) HandleDeleteCategory() func(ctx *fasthttp.RequestCtx) {
+	return metrics(func(ctx *fasthttp.RequestCtx) {
+		if !ctx.IsDelete() {
+			httpErrorResponse(ctx, fasthttp.StatusMethodNotAllowed, "Method not allowed")
+			return
+		}
+
+		id, ok := parseIDParam(ctx, "id")
+		if !ok {
+			return
+		}
+
+		if err := hb.srv.SrvDeleteCategory(id); err != nil {
+			serviceErrorResponse(ctx, err, "Failed to delete category")
+			return
+		}
+
+		ctx.SetStatusCode(fasthttp.StatusNoContent)
+	}, "HandleDeleteCategory")
+}
+
+func (hb *HandlersBuilder) HandleCreateBrand() func(ctx *fasthttp.RequestCtx) {
+	return metrics(func(ctx *fasthttp.RequestCtx) {
+		if !ctx.IsPost() {
+			httpErrorResponse(ctx, fasthttp.StatusMethodNotAllowed, "Method not allowed")
+			return
+		}
+
+		var req struct {
+			Name string `json:"name"`
+		}
+		if err := json.Unmarshal(ctx.PostBody(), &req); err != nil {
+			httpErrorResponse(ctx, fasthttp.StatusBadRequest, "Invalid request body")
+			return
+		}
+
+		id, err := hb.srv.SrvCreateBrand(req.Name)
+		if err != nil {
+			serviceErrorResponse(ctx, err, "Failed to create brand")
+			return
+		}
+
+		ctx.SetStatusCode(fasthttp.StatusCreated)
+		jsonResponse(ctx, map[string]string{"id": id.String()})
+	}, "HandleCreateBrand")
+}
+
+func (hb *HandlersBuilder) HandleListBrands() func(ctx *fasthttp.RequestCtx) {
+	return metrics(func(ctx *fasthttp.RequestCtx) {
+		if !ctx.IsGet() {
+			httpErrorResponse(ctx, fasthttp.StatusMethodNotAllowed, "Method not allowed")
+			return
+		}
+
+		brands, err := hb.srv.SrvListBrands()
+		if err != nil {
+			serviceErrorResponse(ctx, err, "Failed to list brands")
+			return
+		}
+
+		ctx.SetStatusCode(fasthttp.StatusOK)
+		jsonResponse(ctx, brands)
+	}, "HandleListBrands")
+}
+
+func (hb *HandlersBuilder) HandleRenameBrand() func(ctx *fasthttp.RequestCtx) {
+	return metrics(func(ctx *fasthttp.RequestCtx) {
+		if !ctx.IsPut() {
+			httpErrorResponse(ctx, fasthttp.StatusMethodNotAllowed, "Method not allowed")
+			return
+		}
+
+		id, ok := parseIDParam(ctx, "id")
+		if !ok {
+			return
+		}
+
+		var req struct {
+			Name string `json:"name"`
+		}
+		if err := json.Unmarshal(ctx.PostBody(), &req); err != nil {
+			httpErrorResponse(ctx, fasthttp.StatusBadRequest, "Invalid request body")
+			return
+		}
+
+		if err := hb.srv.SrvRenameBrand(id, req.Name); err != nil {
+			serviceErrorResponse(ctx, err, "Failed to rename brand")
+			return
+		}
+
+		ctx.SetStatusCode(fasthttp.StatusOK)
+		jsonResponse(ctx, map[string]string{"status": "updated"})
+	}, "HandleRenameBrand")
+}
+
+func (hb *HandlersBuilder) HandleDeleteBrand() func(ctx *fasthttp.RequestCtx) {
+	return metrics(func(ctx *fasthttp.RequestCtx) {
+		if !ctx.IsDelete() {
+			httpErrorResponse(ctx, fasthttp.StatusMethodNotAllowed, "Method not allowed")
+			return
+		}
+
+		id, ok := parseIDParam(ctx, "id")
+		if !ok {
+			return
+		}
+
+		if err := hb.srv.SrvDeleteBrand(id); err != nil {
+			serviceErrorResponse(ctx, err, "Failed to delete brand")
+			return
+		}
+
+		ctx.SetStatusCode(fasthttp.StatusNoContent)
+	}, "HandleDeleteBrand")
+}
@@ -0,0 +1,160 @@
+package grpctransport
+
+import (
+	"goods/internal/models"
+	"goods/pkg/goodsv1"
+	"goods/pkg/money"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// parseID разбирает обязательный UUID из поля запроса field
+func parseID(field string, raw string) (uuid.UUID, error) {
+	id, err := uuid.Parse(raw)
+	if err != nil {
+		return uuid.Nil, status.Error(codes.InvalidArgument, "invalid "+field)
+	}
+	return id, nil
+}
+
+// parseOptionalID разбирает необязательный UUID; незаданное поле даёт nil
+func parseOptionalID(field string, raw *string) (*uuid.UUID, error) {
+	if raw == nil {
+		return nil, nil
+	}
+	id, err := parseID(field, *raw)
+	if err != nil {
+		return nil, err
+	}
+	return &id, nil
+}
+
+// parseWarehouseID разбирает склад; пустая строка - выбор склада сервисом (uuid.Nil)
+func parseWarehouseID(raw string) (uuid.UUID, error) {
+	if raw == "" {
+		return uuid.Nil, nil
+	}
+	return parseID("warehouse_id", raw)
+}
+
+// fromProtoCard переносит изменяемые поля карточки; вычисляемые поля и поля только для чтения игнорируются
+func fromProtoCard(card *goodsv1.GoodCard) (models.GoodCard, error) {
+	result := models.GoodCard{
+		Price:       fromProtoMoney(card.GetPrice()),
+		Name:        card.GetName(),
+		Description: card.GetDescription(),
+		Weight:      card.GetWeight(),
+		IsActive:    card.GetIsActive(),
+		VariantAxes: card.GetVariantAxes(),
+	}
+	var err error
+	if result.CategoryID, err = parseOptionalID("card.category_id", card.CategoryId); err != nil {
+		return models.GoodCard{}, err
+	}
+	if result.BrandID, err = parseOptionalID("card.brand_id", card.BrandId); err != nil {
+		return models.GoodCard{}, err
+	}
+	return result, nil
+}
+
+func fromProtoMoney(m *goodsv1.Money) money.Money {
+	return money.Money{Amount: m.GetAmount(), Currency: m.GetCurrency()}
+}
+
+// fromProtoOptionalMoney - незаданная сумма даёт nil
+func fromProtoOptionalMoney(m *goodsv1.Money) *money.Money {
+	if m == nil {
+		return nil
+	}
+	result := fromProtoMoney(m)
+	return &result
+}
+
+func toProtoMoney(m money.Money) *goodsv1.Money {
+	return &goodsv1.Money{Amount: m.Amount, Currency: m.Currency}
+}
+
+func optionalID(id *uuid.UUID) *string {
+	if id == nil {
+		return nil
+	}
+	s := id.String()
+	return &s
+}
+
+func toProtoCard(card models.GoodCard) *goodsv1.GoodCard {
+	result := &goodsv1.GoodCard{
+		Uuid:           card.UUID.String(),
+		Price:          toProtoMoney(card.Price),
+		EffectivePrice: toProtoMoney(card.EffectivePrice),
+		Name:           card.Name,
+		Description:    card.Description,
+		Weight:         card.Weight,
+		SellerId:       card.SellerID.String(),
+		IsActive:       card.IsActive,
+		Version:        int32(card.Version),
+		VariantAxes:    card.VariantAxes,
+		CategoryId:     optionalID(card.CategoryID),
+		BrandId:        optionalID(card.BrandID),
+	}
+	if card.LowStockThreshold != nil {
+		threshold := int32(*card.LowStockThreshold)
+		result.LowStockThreshold = &threshold
+	}
+	return result
+}
+
+func toProtoGood(good models.Good) *goodsv1.Good {
+	result := &goodsv1.Good{
+		Card:     toProtoCard(good.Card),
+		Quantity: int32(good.Quantity),
+		Reserved: int32(good.Reserved),
+		Skus:     make([]*goodsv1.SKU, 0, len(good.SKUs)),
+	}
+	for _, sku := range good.SKUs {
+		protoSKU := &goodsv1.SKU{
+			Uuid:       sku.UUID.String(),
+			CardId:     sku.CardID.String(),
+			Attributes: sku.Attributes,
+			Weight:     sku.Weight,
+			Quantity:   int32(sku.Quantity),
+			Reserved:   int32(sku.Reserved),
+			Stock:      make([]*goodsv1.WarehouseStock, 0, len(sku.Stock)),
+		}
+		for _, stock := range sku.Stock {
+			protoSKU.Stock = append(protoSKU.Stock, &goodsv1.WarehouseStock{
+				WarehouseId: stock.WarehouseID.String(),
+				Quantity:    int32(stock.Quantity),
+				Reserved:    int32(stock.Reserved),
+			})
+		}
+		if sku.Price != nil {
+			protoSKU.Price = toProtoMoney(*sku.Price)
+		}
+		result.Skus = append(result.Skus, protoSKU)
+	}
+	return result
+}
+
+func toProtoReservation(reservation models.Reservation) *goodsv1.Reservation {
+	return &goodsv1.Reservation{
+		Uuid:          reservation.UUID.String(),
+		SkuId:         reservation.SKUID.String(),
+		WarehouseId:   reservation.WarehouseID.String(),
+		Quantity:      int32(reservation.Quantity),
+		Status:        reservation.Status,
+		ExpiresAtUnix: reservation.ExpiresAt.Unix(),
+		CreatedAtUnix: reservation.CreatedAt.Unix(),
+	}
+}
+
+func toProtoWarehouse(warehouse models.Warehouse) *goodsv1.Warehouse {
+	return &goodsv1.Warehouse{
+		Uuid:          warehouse.UUID.String(),
+		Name:          warehouse.Name,
+		Priority:      int32(warehouse.Priority),
+		CreatedAtUnix: warehouse.CreatedAt.Unix(),
+	}
+}
@@ -0,0 +1,64 @@
+package grpctransport
+
+import (
+	"errors"
+	myErrors "goods/internal/errors"
+	"strconv"
+
+	"github.com/valyala/fasthttp"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// statusError переводит ошибку сервиса в статус gRPC; причина из myErrors.Error передаётся клиенту.
+// Ошибка операции пакета получает код своей причины и номер операции в сообщении.
+func statusError(err error) error {
+	var itemErr myErrors.ItemError
+	if errors.As(err, &itemErr) {
+		st := status.Convert(statusError(itemErr.Err))
+		return status.Error(st.Code(), "item "+strconv.Itoa(itemErr.Index)+": "+st.Message())
+	}
+
+	var myErr myErrors.Error
+	if !errors.As(err, &myErr) {
+		return status.Error(codes.Internal, "internal error")
+	}
+	return status.Error(codeOf(myErr), myErr.GetCause())
+}
+
+// codeOf сопоставляет ошибке код gRPC по её HTTP-коду
+func codeOf(err myErrors.Error) codes.Code {
+	// Нехватка остатка в HTTP - 400, но для вызывающего это состояние склада, а не ошибка запроса
+	if err == myErrors.ErrNotEnoughQuantity || err == myErrors.ErrInsufficientQuantity {
+		return codes.FailedPrecondition
+	}
+
+	switch err.GetHttpCode() {
+	case fasthttp.StatusBadRequest, fasthttp.StatusRequestEntityTooLarge, fasthttp.StatusUnsupportedMediaType, fasthttp.StatusUnprocessableEntity:
+		return codes.InvalidArgument
+	case fasthttp.StatusUnauthorized:
+		return codes.Unauthenticated
+	case fasthttp.StatusForbidden:
+		return codes.PermissionDenied
+	case fasthttp.StatusNotFound:
+		return codes.NotFound
+	case fasthttp.StatusConflict:
+		// Конфликт с существующей записью отличается от конфликта состояния
+		if err == myErrors.ErrGoodCardAlreadyExists || err == myErrors.ErrGoodAlreadyExists || err == myErrors.ErrSKUAlreadyExists ||
+			err == myErrors.ErrCategoryAlreadyExists || err == myErrors.ErrBrandAlreadyExists || err == myErrors.ErrWarehouseAlreadyExists {
+			return codes.AlreadyExists
+		}
+		return codes.FailedPrecondition
+	case fasthttp.StatusGone, fasthttp.StatusPreconditionRequired:
+		return codes.FailedPrecondition
+	case fasthttp.StatusPreconditionFailed:
+		// Версия изменилась между чтением и записью: повторить чтение и изменение
+		return codes.Aborted
+	case fasthttp.StatusBadGateway, fasthttp.StatusServiceUnavailable:
+		return codes.Unavailable
+	case fasthttp.StatusInternalServerError:
+		return codes.Internal
+	default:
+		return codes.Unknown
+	}
+}
@@ -0,0 +1,450 @@
+// Package grpctransport - gRPC API сервиса товаров для внутренних вызывающих.
+// Работает рядом с HTTP API поверх того же services.InterfaceService.
+package grpctransport
+
+import (
+	"context"
+	"crypto/subtle"
+	myErrors "goods/internal/errors"
+	myLog "goods/internal/logger"
+	"goods/internal/models"
+	"goods/internal/services"
+	"goods/internal/transport"
+	"goods/pkg/goodsv1"
+	"net"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+type Server struct {
+	goodsv1.UnimplementedGoodsServiceServer
+	srv services.InterfaceService
+}
+
+func NewServer(srv services.InterfaceService) *Server {
+	return &Server{srv: srv}
+}
+
+// Serve слушает addr и обслуживает gRPC-запросы; возвращается только при ошибке.
+// Каждый вызов должен нести serviceToken в метаданных x-service-token.
+func Serve(addr string, srv services.InterfaceService, serviceToken string) error {
+	lis, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	server := grpc.NewServer(grpc.ChainUnaryInterceptor(metrics, authenticate(serviceToken)))
+	goodsv1.RegisterGoodsServiceServer(server, NewServer(srv))
+	myLog.Log.Infof("gRPC server listening on %s", addr)
+	return server.Serve(lis)
+}
+
+// metrics учитывает запросы в тех же счётчиках, что и HTTP API; статус - код gRPC
+func metrics(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	start := time.Now()
+	resp, err := handler(ctx, req)
+	code := strconv.Itoa(int(status.Code(err)))
+	transport.RequestCounter.WithLabelValues(info.FullMethod, code).Inc()
+	transport.TimeCounter.WithLabelValues(info.FullMethod, code).Add(float64(time.Since(start)))
+	return resp, err
+}
+
+// authenticate пропускает вызов только с метаданными x-service-token, равными общему секрету
+// внутренних сервисов, как withService в HTTP API. Без настроенного секрета все вызовы отклоняются.
+func authenticate(serviceToken string) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		md, _ := metadata.FromIncomingContext(ctx)
+		values := md.Get(goodsv1.MetadataServiceToken)
+		if serviceToken == "" || len(values) == 0 || subtle.ConstantTimeCompare([]byte(values[0]), []byte(serviceToken)) != 1 {
+			return nil, statusError(myErrors.ErrServiceUnauthorized)
+		}
+		return handler(ctx, req)
+	}
+}
+
+// supplierFromContext возвращает продавца из метаданных x-supplier-id
+func supplierFromContext(ctx context.Context) (uuid.UUID, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get(goodsv1.MetadataSupplierID)
+	if len(values) == 0 {
+		return uuid.Nil, myErrors.ErrUnauthorized
+	}
+	id, err := uuid.Parse(values[0])
+	if err != nil || id == uuid.Nil {
+		return uuid.Nil, myErrors.ErrUnauthorized
+	}
+	return id, nil
+}
+
+// movementMeta собирает исполнителя и идентификатор запроса для журнала движения так же,
+// как HTTP API; идентификатор запроса возвращается в заголовке ответа
+func movementMeta(ctx context.Context, sellerID uuid.UUID) models.MovementMeta {
+	md, _ := metadata.FromIncomingContext(ctx)
+	first := func(key string) string {
+		if values := md.Get(key); len(values) > 0 {
+			return values[0]
+		}
+		return ""
+	}
+
+	correlationID := first(goodsv1.MetadataCorrelationID)
+	if correlationID == "" {
+		correlationID = uuid.NewString()
+	}
+	grpc.SetHeader(ctx, metadata.Pairs(goodsv1.MetadataCorrelationID, correlationID))
+
+	actor := first(goodsv1.MetadataActor)
+	if sellerID != uuid.Nil {
+		actor = sellerID.String()
+	}
+	return models.MovementMeta{Actor: actor, CorrelationID: correlationID}
+}
+
+func (s *Server) CreateGoodCard(ctx context.Context, req *goodsv1.CreateGoodCardRequest) (*goodsv1.CreateGoodCardResponse, error) {
+	sellerID, err := supplierFromContext(ctx)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	card, err := fromProtoCard(req.GetCard())
+	if err != nil {
+		return nil, err
+	}
+	id, err := s.srv.SrvCreateGoodCard(sellerID, card)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return &goodsv1.CreateGoodCardResponse{Uuid: id.String()}, nil
+}
+
+func (s *Server) GetGoodCard(ctx context.Context, req *goodsv1.GetGoodCardRequest) (*goodsv1.Good, error) {
+	sellerID, err := supplierFromContext(ctx)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	id, err := parseID("uuid", req.GetUuid())
+	if err != nil {
+		return nil, err
+	}
+	good, err := s.srv.SrvReadGoodCard(sellerID, id)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return toProtoGood(good), nil
+}
+
+func (s *Server) ListGoods(ctx context.Context, req *goodsv1.ListGoodsRequest) (*goodsv1.ListGoodsResponse, error) {
+	sellerID, err := supplierFromContext(ctx)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	filter := models.GoodFilter{
+		IsActive:   req.IsActive,
+		Currency:   req.GetCurrency(),
+		MinPrice:   req.MinPrice,
+		MaxPrice:   req.MaxPrice,
+		MinWeight:  req.MinWeight,
+		MaxWeight:  req.MaxWeight,
+		NamePrefix: req.GetNamePrefix(),
+		Sort:       req.GetSort(),
+		Limit:      int(req.GetLimit()),
+		Cursor:     req.GetCursor(),
+	}
+	if filter.CategoryID, err = parseOptionalID("category_id", req.CategoryId); err != nil {
+		return nil, err
+	}
+	if filter.BrandID, err = parseOptionalID("brand_id", req.BrandId); err != nil {
+		return nil, err
+	}
+
+	page, err := s.srv.SrvListGoods(sellerID, filter)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	resp := &goodsv1.ListGoodsResponse{NextCursor: page.NextCursor}
+	for _, good := range page.Items {
+		resp.Items = append(resp.Items, toProtoGood(good))
+	}
+	return resp, nil
+}
+
+func (s *Server) UpdateGoodCard(ctx context.Context, req *goodsv1.UpdateGoodCardRequest) (*goodsv1.UpdateGoodCardResponse, error) {
+	sellerID, err := supplierFromContext(ctx)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	id, err := parseID("card.uuid", req.GetCard().GetUuid())
+	if err != nil {
+		return nil, err
+	}
+	card, err := fromProtoCard(req.GetCard())
+	if err != nil {
+		return nil, err
+	}
+	version, err := s.srv.SrvUpdateGoodCard(sellerID, id, card, int(req.GetExpectedVersion()))
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return &goodsv1.UpdateGoodCardResponse{Version: int32(version)}, nil
+}
+
+func (s *Server) DeleteGoodCard(ctx context.Context, req *goodsv1.DeleteGoodCardRequest) (*goodsv1.DeleteGoodCardResponse, error) {
+	sellerID, err := supplierFromContext(ctx)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	id, err := parseID("uuid", req.GetUuid())
+	if err != nil {
+		return nil, err
+	}
+	if err := s.srv.SrvDeleteGoodCard(sellerID, id); err != nil {
+		return nil, statusError(err)
+	}
+	return &goodsv1.DeleteGoodCardResponse{}, nil
+}
+
+func (s *Server) RestoreGoodCard(ctx context.Context, req *goodsv1.RestoreGoodCardRequest) (*goodsv1.RestoreGoodCardResponse, error) {
+	sellerID, err := supplierFromContext(ctx)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	id, err := parseID("uuid", req.GetUuid())
+	if err != nil {
+		return nil, err
+	}
+	if err := s.srv.SrvRestoreGoodCard(sellerID, id); err != nil {
+		return nil, statusError(err)
+	}
+	return &goodsv1.RestoreGoodCardResponse{}, nil
+}
+
+func (s *Server) BatchGetGoodCards(ctx context.Context, req *goodsv1.BatchGetGoodCardsRequest) (*goodsv1.BatchGetGoodCardsResponse, error) {
+	ids := make([]uuid.UUID, 0, len(req.GetUuids()))
+	for _, raw := range req.GetUuids() {
+		id, err := parseID("uuids", raw)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	goods, err := s.srv.SrvBatchReadGoodCards(ids)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	resp := &goodsv1.BatchGetGoodCardsResponse{Goods: make([]*goodsv1.Good, 0, len(goods))}
+	for _, good := range goods {
+		resp.Goods = append(resp.Goods, toProtoGood(good))
+	}
+	return resp, nil
+}
+
+func (s *Server) AddStock(ctx context.Context, req *goodsv1.ChangeStockRequest) (*goodsv1.ChangeStockResponse, error) {
+	return s.changeStock(ctx, req, s.srv.SrvAddCountGood, myErrors.ErrAddCountGoodInvalid)
+}
+
+func (s *Server) RemoveStock(ctx context.Context, req *goodsv1.ChangeStockRequest) (*goodsv1.ChangeStockResponse, error) {
+	return s.changeStock(ctx, req, s.srv.SrvDeleteCountGood, myErrors.ErrDeleteCountGoodInvalid)
+}
+
+func (s *Server) changeStock(ctx context.Context, req *goodsv1.ChangeStockRequest,
+	change func(sellerID uuid.UUID, skuID uuid.UUID, warehouseID uuid.UUID, number int, meta models.MovementMeta) (int, error),
+	invalid error) (*goodsv1.ChangeStockResponse, error) {
+	sellerID, err := supplierFromContext(ctx)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	skuID, err := parseID("sku_id", req.GetSkuId())
+	if err != nil {
+		return nil, err
+	}
+	warehouseID, err := parseWarehouseID(req.GetWarehouseId())
+	if err != nil {
+		return nil, err
+	}
+	if req.GetCount() <= 0 {
+		return nil, statusError(invalid)
+	}
+
+	quantity, err := change(sellerID, skuID, warehouseID, int(req.GetCount()), movementMeta(ctx, sellerID))
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return &goodsv1.ChangeStockResponse{Quantity: int32(quantity)}, nil
+}
+
+func (s *Server) AdjustStock(ctx context.Context, req *goodsv1.AdjustStockRequest) (*goodsv1.AdjustStockResponse, error) {
+	sellerID, err := supplierFromContext(ctx)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	items := make([]models.StockAdjustment, 0, len(req.GetItems()))
+	for _, item := range req.GetItems() {
+		skuID, err := parseID("items.sku_id", item.GetSkuId())
+		if err != nil {
+			return nil, err
+		}
+		warehouseID, err := parseWarehouseID(item.GetWarehouseId())
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, models.StockAdjustment{GoodID: skuID, WarehouseID: warehouseID, Delta: int(item.GetDelta())})
+	}
+
+	results, err := s.srv.SrvAdjustStock(sellerID, items, movementMeta(ctx, sellerID))
+	if err != nil {
+		return nil, statusError(err)
+	}
+	resp := &goodsv1.AdjustStockResponse{Results: make([]*goodsv1.StockAdjustmentResult, 0, len(results))}
+	for _, result := range results {
+		resp.Results = append(resp.Results, &goodsv1.StockAdjustmentResult{SkuId: result.GoodID.String(), Quantity: int32(result.NewCount)})
+	}
+	return resp, nil
+}
+
+func (s *Server) CreateSKU(ctx context.Context, req *goodsv1.CreateSKURequest) (*goodsv1.CreateSKUResponse, error) {
+	sellerID, err := supplierFromContext(ctx)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	cardID, err := parseID("card_id", req.GetCardId())
+	if err != nil {
+		return nil, err
+	}
+	sku := models.SKU{Attributes: req.GetAttributes(), Price: fromProtoOptionalMoney(req.GetPrice()), Weight: req.Weight}
+	id, err := s.srv.SrvCreateSKU(sellerID, cardID, sku, int(req.GetQuantity()), movementMeta(ctx, sellerID))
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return &goodsv1.CreateSKUResponse{Uuid: id.String()}, nil
+}
+
+func (s *Server) UpdateSKU(ctx context.Context, req *goodsv1.UpdateSKURequest) (*goodsv1.UpdateSKUResponse, error) {
+	sellerID, err := supplierFromContext(ctx)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	id, err := parseID("uuid", req.GetUuid())
+	if err != nil {
+		return nil, err
+	}
+	if err := s.srv.SrvUpdateSKU(sellerID, id, fromProtoOptionalMoney(req.GetPrice()), req.Weight); err != nil {
+		return nil, statusError(err)
+	}
+	return &goodsv1.UpdateSKUResponse{}, nil
+}
+
+func (s *Server) DeleteSKU(ctx context.Context, req *goodsv1.DeleteSKURequest) (*goodsv1.DeleteSKUResponse, error) {
+	sellerID, err := supplierFromContext(ctx)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	id, err := parseID("uuid", req.GetUuid())
+	if err != nil {
+		return nil, err
+	}
+	if err := s.srv.SrvDeleteGood(sellerID, id); err != nil {
+		return nil, statusError(err)
+	}
+	return &goodsv1.DeleteSKUResponse{}, nil
+}
+
+func (s *Server) CreateWarehouse(ctx context.Context, req *goodsv1.CreateWarehouseRequest) (*goodsv1.CreateWarehouseResponse, error) {
+	sellerID, err := supplierFromContext(ctx)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	id, err := s.srv.SrvCreateWarehouse(sellerID, models.Warehouse{Name: req.GetName(), Priority: int(req.GetPriority())})
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return &goodsv1.CreateWarehouseResponse{Uuid: id.String()}, nil
+}
+
+func (s *Server) ListWarehouses(ctx context.Context, req *goodsv1.ListWarehousesRequest) (*goodsv1.ListWarehousesResponse, error) {
+	sellerID, err := supplierFromContext(ctx)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	warehouses, err := s.srv.SrvListWarehouses(sellerID)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	resp := &goodsv1.ListWarehousesResponse{Warehouses: make([]*goodsv1.Warehouse, 0, len(warehouses))}
+	for _, warehouse := range warehouses {
+		resp.Warehouses = append(resp.Warehouses, toProtoWarehouse(warehouse))
+	}
+	return resp, nil
+}
+
+func (s *Server) UpdateWarehouse(ctx context.Context, req *goodsv1.UpdateWarehouseRequest) (*goodsv1.UpdateWarehouseResponse, error) {
+	sellerID, err := supplierFromContext(ctx)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	id, err := parseID("uuid", req.GetUuid())
+	if err != nil {
+		return nil, err
+	}
+	warehouse := models.Warehouse{UUID: id, Name: req.GetName(), Priority: int(req.GetPriority())}
+	if err := s.srv.SrvUpdateWarehouse(sellerID, warehouse); err != nil {
+		return nil, statusError(err)
+	}
+	return &goodsv1.UpdateWarehouseResponse{}, nil
+}
+
+func (s *Server) DeleteWarehouse(ctx context.Context, req *goodsv1.DeleteWarehouseRequest) (*goodsv1.DeleteWarehouseResponse, error) {
+	sellerID, err := supplierFromContext(ctx)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	id, err := parseID("uuid", req.GetUuid())
+	if err != nil {
+		return nil, err
+	}
+	if err := s.srv.SrvDeleteWarehouse(sellerID, id); err != nil {
+		return nil, statusError(err)
+	}
+	return &goodsv1.DeleteWarehouseResponse{}, nil
+}
+
+func (s *Server) ReserveStock(ctx context.Context, req *goodsv1.ReserveStockRequest) (*goodsv1.Reservation, error) {
+	skuID, err := parseID("sku_id", req.GetSkuId())
+	if err != nil {
+		return nil, err
+	}
+	warehouseID, err := parseWarehouseID(req.GetWarehouseId())
+	if err != nil {
+		return nil, err
+	}
+	ttl := time.Duration(req.GetTtlSeconds()) * time.Second
+	reservation, err := s.srv.SrvReserveGood(skuID, warehouseID, int(req.GetQuantity()), ttl, movementMeta(ctx, uuid.Nil))
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return toProtoReservation(reservation), nil
+}
+
+func (s *Server) CommitReservation(ctx context.Context, req *goodsv1.CommitReservationRequest) (*goodsv1.CommitReservationResponse, error) {
+	id, err := parseID("uuid", req.GetUuid())
+	if err != nil {
+		return nil, err
+	}
+	if err := s.srv.SrvCommitReservation(id, movementMeta(ctx, uuid.Nil)); err != nil {
+		return nil, statusError(err)
+	}
+	return &goodsv1.CommitReservationResponse{}, nil
+}
+
+func (s *Server) ReleaseReservation(ctx context.Context, req *goodsv1.ReleaseReservationRequest) (*goodsv1.ReleaseReservationResponse, error) {
+	id, err := parseID("uuid", req.GetUuid())
+	if err != nil {
+		return nil, err
+	}
+	if err := s.srv.SrvReleaseReservation(id, movementMeta(ctx, uuid.Nil)); err != nil {
+		return nil, statusError(err)
+	}
+	return &goodsv1.ReleaseReservationResponse{}, nil
+}
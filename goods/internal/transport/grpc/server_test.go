@@ -0,0 +1,217 @@
+package grpctransport
+
+import (
+	"context"
+	myErrors "goods/internal/errors"
+	"goods/internal/models"
+	"goods/internal/services"
+	"goods/pkg/goodsv1"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// fakeService реализует только методы, которые вызывают тесты
+type fakeService struct {
+	services.InterfaceService
+	goods      map[uuid.UUID]models.Good
+	removed    models.MovementMeta
+	adjusted   []models.StockAdjustment
+	warehouses []models.Warehouse
+}
+
+func (f *fakeService) SrvBatchReadGoodCards(ids []uuid.UUID) ([]models.Good, error) {
+	var result []models.Good
+	for _, id := range ids {
+		if good, ok := f.goods[id]; ok {
+			result = append(result, good)
+		}
+	}
+	return result, nil
+}
+
+func (f *fakeService) SrvDeleteCountGood(sellerID uuid.UUID, skuID uuid.UUID, warehouseID uuid.UUID, number int, meta models.MovementMeta) (int, error) {
+	f.removed = meta
+	return 0, myErrors.ErrNotEnoughQuantity
+}
+
+func (f *fakeService) SrvAdjustStock(sellerID uuid.UUID, items []models.StockAdjustment, meta models.MovementMeta) ([]models.StockAdjustmentResult, error) {
+	f.adjusted = items
+	return nil, myErrors.ItemError{Index: 1, Err: myErrors.ErrNotEnoughQuantity}
+}
+
+func (f *fakeService) SrvListWarehouses(sellerID uuid.UUID) ([]models.Warehouse, error) {
+	return f.warehouses, nil
+}
+
+const testServiceToken = "service-secret"
+
+func newTestClient(t *testing.T, srv services.InterfaceService) goodsv1.GoodsServiceClient {
+	t.Helper()
+	lis := bufconn.Listen(1 << 20)
+	server := grpc.NewServer(grpc.ChainUnaryInterceptor(metrics, authenticate(testServiceToken)))
+	goodsv1.RegisterGoodsServiceServer(server, NewServer(srv))
+	go server.Serve(lis)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return goodsv1.NewGoodsServiceClient(conn)
+}
+
+// serviceCtx - контекст вызова внутреннего сервиса с верным секретом
+func serviceCtx() context.Context {
+	return goodsv1.WithServiceToken(context.Background(), testServiceToken)
+}
+
+func TestRejectsCallsWithoutServiceToken(t *testing.T) {
+	client := newTestClient(t, &fakeService{})
+	req := &goodsv1.ReserveStockRequest{SkuId: uuid.NewString(), Quantity: 1}
+
+	for name, ctx := range map[string]context.Context{
+		"no token":    context.Background(),
+		"wrong token": goodsv1.WithServiceToken(context.Background(), "guess"),
+	} {
+		_, err := client.ReserveStock(goodsv1.WithSupplier(ctx, uuid.NewString()), req)
+		if status.Code(err) != codes.Unauthenticated {
+			t.Errorf("%s: code = %v, want Unauthenticated", name, status.Code(err))
+		}
+	}
+}
+
+func TestAuthenticateRejectsEmptyToken(t *testing.T) {
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(goodsv1.MetadataServiceToken, ""))
+	called := false
+	_, err := authenticate("")(ctx, nil, &grpc.UnaryServerInfo{}, func(context.Context, any) (any, error) {
+		called = true
+		return nil, nil
+	})
+	if called || status.Code(err) != codes.Unauthenticated {
+		t.Errorf("called = %v, code = %v", called, status.Code(err))
+	}
+}
+
+func TestBatchGetGoodCardsKeepsRequestOrder(t *testing.T) {
+	first, second, missing := uuid.New(), uuid.New(), uuid.New()
+	srv := &fakeService{goods: map[uuid.UUID]models.Good{
+		first:  {UUID: first, Card: models.GoodCard{UUID: first, Name: "first"}, Quantity: 3},
+		second: {UUID: second, Card: models.GoodCard{UUID: second, Name: "second"}},
+	}}
+	client := newTestClient(t, srv)
+
+	resp, err := client.BatchGetGoodCards(serviceCtx(), &goodsv1.BatchGetGoodCardsRequest{
+		Uuids: []string{second.String(), missing.String(), first.String()},
+	})
+	if err != nil {
+		t.Fatalf("BatchGetGoodCards: %v", err)
+	}
+	if len(resp.Goods) != 2 || resp.Goods[0].Card.Name != "second" || resp.Goods[1].Card.Name != "first" {
+		t.Fatalf("unexpected goods: %v", resp.Goods)
+	}
+	if resp.Goods[1].Quantity != 3 {
+		t.Errorf("quantity = %d, want 3", resp.Goods[1].Quantity)
+	}
+
+	_, err = client.BatchGetGoodCards(serviceCtx(), &goodsv1.BatchGetGoodCardsRequest{Uuids: []string{"not-a-uuid"}})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("invalid id: code = %v, want InvalidArgument", status.Code(err))
+	}
+}
+
+func TestRemoveStockMapsErrorsAndMetadata(t *testing.T) {
+	srv := &fakeService{}
+	client := newTestClient(t, srv)
+	req := &goodsv1.ChangeStockRequest{SkuId: uuid.NewString(), Count: 5}
+
+	_, err := client.RemoveStock(serviceCtx(), req)
+	if status.Code(err) != codes.Unauthenticated {
+		t.Fatalf("without supplier: code = %v, want Unauthenticated", status.Code(err))
+	}
+
+	sellerID := uuid.New()
+	ctx := goodsv1.WithMovementMeta(goodsv1.WithSupplier(serviceCtx(), sellerID.String()), "orders", "order-42")
+	_, err = client.RemoveStock(ctx, req)
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("not enough quantity: code = %v, want FailedPrecondition", status.Code(err))
+	}
+	if status.Convert(err).Message() != myErrors.ErrNotEnoughQuantity.GetCause() {
+		t.Errorf("message = %q", status.Convert(err).Message())
+	}
+	// Для запроса продавца исполнитель - сам продавец, как в HTTP API
+	if srv.removed.Actor != sellerID.String() || srv.removed.CorrelationID != "order-42" {
+		t.Errorf("meta = %+v", srv.removed)
+	}
+}
+
+func TestAdjustStockReportsFailedItem(t *testing.T) {
+	srv := &fakeService{}
+	client := newTestClient(t, srv)
+	ctx := goodsv1.WithSupplier(serviceCtx(), uuid.NewString())
+	skuID := uuid.New()
+
+	_, err := client.AdjustStock(ctx, &goodsv1.AdjustStockRequest{Items: []*goodsv1.StockAdjustment{
+		{SkuId: skuID.String(), Delta: 4},
+		{SkuId: skuID.String(), Delta: -10},
+	}})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("code = %v, want FailedPrecondition", status.Code(err))
+	}
+	if want := "item 1: " + myErrors.ErrNotEnoughQuantity.GetCause(); status.Convert(err).Message() != want {
+		t.Errorf("message = %q, want %q", status.Convert(err).Message(), want)
+	}
+	if len(srv.adjusted) != 2 || srv.adjusted[1].Delta != -10 || srv.adjusted[0].WarehouseID != uuid.Nil {
+		t.Errorf("adjusted = %+v", srv.adjusted)
+	}
+}
+
+func TestListWarehouses(t *testing.T) {
+	id := uuid.New()
+	srv := &fakeService{warehouses: []models.Warehouse{{UUID: id, Name: "main", Priority: 2, CreatedAt: time.Unix(1700000000, 0)}}}
+	client := newTestClient(t, srv)
+
+	resp, err := client.ListWarehouses(goodsv1.WithSupplier(serviceCtx(), uuid.NewString()), &goodsv1.ListWarehousesRequest{})
+	if err != nil {
+		t.Fatalf("ListWarehouses: %v", err)
+	}
+	if len(resp.Warehouses) != 1 {
+		t.Fatalf("warehouses = %v", resp.Warehouses)
+	}
+	got := resp.Warehouses[0]
+	if got.Uuid != id.String() || got.Name != "main" || got.Priority != 2 || got.CreatedAtUnix != 1700000000 {
+		t.Errorf("warehouse = %v", got)
+	}
+}
+
+func TestCodeOf(t *testing.T) {
+	cases := []struct {
+		err  myErrors.Error
+		want codes.Code
+	}{
+		{myErrors.ErrGoodCardNotFound, codes.NotFound},
+		{myErrors.ErrGoodCardAlreadyExists, codes.AlreadyExists},
+		{myErrors.ErrReservationNotActive, codes.FailedPrecondition},
+		{myErrors.ErrVersionMismatch, codes.Aborted},
+		{myErrors.ValidationError("name", "is required"), codes.InvalidArgument},
+		{myErrors.ErrUnauthorized, codes.Unauthenticated},
+		{myErrors.ErrMediaStorage, codes.Unavailable},
+		{myErrors.ErrReadCardInternal, codes.Internal},
+	}
+	for _, c := range cases {
+		if got := codeOf(c.err); got != c.want {
+			t.Errorf("codeOf(%q) = %v, want %v", c.err.GetCause(), got, c.want)
+		}
+	}
+}
@@ -2,11 +2,17 @@ package transport
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"strconv"
 
+	"goods/internal/blobstore"
 	config "goods/internal/cfg"
+	myErrors "goods/internal/errors"
 	"goods/internal/models"
 	service "goods/internal/services"
+	"goods/pkg/money"
+	"io"
 	"net/http"
 
 	"github.com/fasthttp/router"
@@ -16,16 +22,30 @@ import (
 	"github.com/valyala/fasthttp"
 )
 
+// Максимальный размер тела, которое читается в память целиком. Больше может быть только файл импорта.
+const maxBufferedBodySize = fasthttp.DefaultMaxRequestBodySize
+
+// streamingRoutes - маршруты, которые читают тело потоком через RequestBodyStream
+var streamingRoutes = map[string]bool{
+	"POST /goodcards/import": true,
+}
+
 type HandlersBuilder struct {
-	srv  service.InterfaceService
-	rout *router.Router
+	srv           service.InterfaceService
+	rout          *router.Router
+	resolveSeller SellerResolver
+	mediaMaxSize  int
+	serviceToken  string
 }
 
 func HandleCreate(cfg config.Config, s service.InterfaceService) {
 
 	hb := HandlersBuilder{
-		srv:  s,
-		rout: router.New(),
+		srv:           s,
+		rout:          router.New(),
+		resolveSeller: HeaderSellerResolver,
+		mediaMaxSize:  cfg.MediaMaxSize,
+		serviceToken:  cfg.ServiceToken,
 	}
 
 	go func() {
@@ -34,30 +54,183 @@ func HandleCreate(cfg config.Config, s service.InterfaceService) {
 	}()
 
 	// Создать новую карточку товара
-	hb.rout.POST("/goodcards/create", hb.HandleCreateGoodCard())
+	hb.rout.POST("/goodcards/create", hb.withSeller(hb.HandleCreateGoodCard()))
 
 	// Удалить карточку товара по UUID
-	hb.rout.DELETE("/goodcards/{id}", hb.HandleDeleteGoodCard())
+	hb.rout.DELETE("/goodcards/{id}", hb.withSeller(hb.HandleDeleteGoodCard()))
+
+	// Восстановить удалённую карточку товара до окончательного удаления
+	hb.rout.POST("/goodcards/{id}/restore", hb.withSeller(hb.HandleRestoreGoodCard()))
+
+	// Обновить карточку товара по UUID (требуется If-Match с ETag карточки)
+	hb.rout.PUT("/goodcards/{id}", hb.withSeller(hb.HandleUpdateGoodCard()))
+
+	// Частично обновить карточку товара (JSON Merge Patch, требуется If-Match)
+	hb.rout.PATCH("/goodcards/{id}", hb.withSeller(hb.HandlePatchGoodCard()))
+
+	// Получить карточку товара со всеми вариантами
+	hb.rout.GET("/goodcards/{id}", hb.withSeller(hb.HandleReadGoodCard()))
+
+	// Создать вариант (SKU) карточки товара с начальным остатком
+	hb.rout.POST("/goodcards/{id}/skus", hb.withSeller(hb.HandleCreateSKU()))
+
+	// Изменить цену и вес варианта
+	hb.rout.PUT("/skus/{id}", hb.withSeller(hb.HandleUpdateSKU()))
 
-	// Обновить карточку товара по UUID
-	hb.rout.PUT("/goodcards/{id}", hb.HandleUpdateGoodCard())
+	// Удалить вариант вместе с остатком
+	hb.rout.DELETE("/skus/{id}", hb.withSeller(hb.HandleDeleteSKU()))
 
 	// Создать товар с привязкой к карточке товара
-	hb.rout.POST("/goods/create", hb.HandleCreateGood())
+	hb.rout.POST("/goods/create", hb.withSeller(hb.HandleCreateGood()))
 
 	// Удалить товар по UUID
-	hb.rout.DELETE("/goods/{id}", hb.HandleDeleteGood())
+	hb.rout.DELETE("/goods/{id}", hb.withSeller(hb.HandleDeleteGood()))
 
 	// Увеличить количество товара по UUID
-	hb.rout.POST("/goods/{id}/add", hb.HandleAddCountGood())
+	hb.rout.POST("/goods/{id}/add", hb.withSeller(hb.HandleAddCountGood()))
 
 	// Уменьшить количество товара по UUID
-	hb.rout.POST("/goods/{id}/remove", hb.HandleDeleteCountGood())
+	hb.rout.POST("/goods/{id}/remove", hb.withSeller(hb.HandleDeleteCountGood()))
+
+	// Изменить остаток нескольких товаров в одной транзакции
+	hb.rout.POST("/goods/stock/batch", hb.withSeller(hb.HandleAdjustStock()))
 
 	// Получить информацию о товаре и его карточке по UUID товара
-	hb.rout.GET("/goods/{id}", hb.HandleReadCard())
+	hb.rout.GET("/goods/{id}", hb.withSeller(hb.HandleReadCard()))
+
+	// Получить список товаров с фильтрами и пагинацией по курсору
+	hb.rout.GET("/goodcards", hb.withSeller(hb.HandleListGoods()))
+
+	// Витрина: активные одобренные карточки любого продавца (?seller_id=), без авторизации
+	hb.rout.GET("/storefront/goodcards", hb.HandleListStorefrontGoods())
+
+	// История ревизий карточки товара
+	hb.rout.GET("/goodcards/{id}/revisions", hb.withSeller(hb.HandleListRevisions()))
+
+	// Восстановить карточку из ревизии (требуется If-Match)
+	hb.rout.POST("/goodcards/{id}/revisions/{version}/restore", hb.withSeller(hb.HandleRestoreRevision()))
+
+	// Импорт каталога из CSV или NDJSON
+	hb.rout.POST("/goodcards/import", hb.withSeller(hb.HandleImportGoodCards()))
+
+	// Экспорт каталога продавца в CSV или NDJSON
+	hb.rout.GET("/goodcards/export", hb.withSeller(hb.HandleExportGoodCards()))
+
+	// Резервы создаёт и закрывает сервис заказов (требуется X-Service-Token)
+	// Зарезервировать количество товара на время
+	hb.rout.POST("/goods/{id}/reserve", hb.withService(hb.HandleReserveGood()))
+
+	// Подтвердить резерв (окончательное списание)
+	hb.rout.POST("/reservations/{id}/commit", hb.withService(hb.HandleCommitReservation()))
 
-	fmt.Println(fasthttp.ListenAndServe(":8080", hb.rout.Handler))
+	// Отменить резерв (возврат в остаток)
+	hb.rout.POST("/reservations/{id}/release", hb.withService(hb.HandleReleaseReservation()))
+
+	// История движения товара
+	hb.rout.GET("/goods/{id}/movements", hb.withSeller(hb.HandleListMovements()))
+
+	// Количество товара на момент времени (?at=RFC3339)
+	hb.rout.GET("/goods/{id}/quantity", hb.withSeller(hb.HandleQuantityAt()))
+
+	// Сверка журнала движения с текущими остатками всех продавцов (требуется X-Moderator-ID)
+	hb.rout.GET("/inventory/consistency", hb.withModerator(hb.HandleCheckLedger()))
+
+	// Изображения карточки товара
+	hb.rout.POST("/goodcards/{id}/media", hb.withSeller(hb.HandleUploadMedia()))
+	hb.rout.GET("/goodcards/{id}/media", hb.withSeller(hb.HandleListMedia()))
+	hb.rout.PUT("/goodcards/{id}/media/order", hb.withSeller(hb.HandleReorderMedia()))
+	hb.rout.DELETE("/goodcards/{id}/media/{mediaId}", hb.withSeller(hb.HandleDeleteMedia()))
+
+	// Склады продавца
+	hb.rout.POST("/warehouses", hb.withSeller(hb.HandleCreateWarehouse()))
+	hb.rout.GET("/warehouses", hb.withSeller(hb.HandleListWarehouses()))
+	hb.rout.PUT("/warehouses/{id}", hb.withSeller(hb.HandleUpdateWarehouse()))
+	hb.rout.DELETE("/warehouses/{id}", hb.withSeller(hb.HandleDeleteWarehouse()))
+
+	// Оповещения о низком остатке: порог карточки, адрес продавца и журнал доставок
+	hb.rout.PUT("/goodcards/{id}/low-stock-threshold", hb.withSeller(hb.HandleSetLowStockThreshold()))
+	hb.rout.PUT("/webhook", hb.withSeller(hb.HandleSetWebhook()))
+	hb.rout.GET("/webhook", hb.withSeller(hb.HandleReadWebhook()))
+	hb.rout.DELETE("/webhook", hb.withSeller(hb.HandleDeleteWebhook()))
+	hb.rout.GET("/webhook/deliveries", hb.withSeller(hb.HandleListWebhookDeliveries()))
+
+	// Расписания цен и скидок карточки товара
+	hb.rout.POST("/goodcards/{id}/price-schedules", hb.withSeller(hb.HandleCreatePriceSchedule()))
+	hb.rout.GET("/goodcards/{id}/price-schedules", hb.withSeller(hb.HandleListPriceSchedules()))
+	hb.rout.DELETE("/goodcards/{id}/price-schedules/{scheduleId}", hb.withSeller(hb.HandleCancelPriceSchedule()))
+
+	// Цены карточки в других валютах и курсы для показа цен (?display_currency= у чтения и списка карточек)
+	hb.rout.GET("/goodcards/{id}/prices", hb.withSeller(hb.HandleReadCardPrices()))
+	hb.rout.PUT("/goodcards/{id}/prices", hb.withSeller(hb.HandleSetCardPrices()))
+	hb.rout.GET("/exchange-rates", hb.HandleListExchangeRates())
+	// Курсы меняет сотрудник (требуется X-Moderator-ID)
+	hb.rout.PUT("/admin/exchange-rates", hb.withModerator(hb.HandleSetExchangeRates()))
+
+	// Локальное хранилище изображений раздаётся самим сервисом
+	if cfg.MediaStore == blobstore.StoreLocal {
+		hb.rout.GET("/media/{filepath:*}", fasthttp.FSHandler(cfg.MediaLocalDir, 1))
+	}
+
+	// Модерация карточки: отправка на проверку и журнал решений для продавца
+	hb.rout.POST("/goodcards/{id}/submit", hb.withSeller(hb.HandleSubmitGoodCard()))
+	hb.rout.GET("/goodcards/{id}/moderation", hb.withSeller(hb.HandleListModerationLog()))
+
+	// Очередь и решения модератора (требуется X-Moderator-ID)
+	hb.rout.GET("/admin/moderation/queue", hb.withModerator(hb.HandleModerationQueue()))
+	hb.rout.GET("/admin/goodcards/{id}/moderation", hb.withModerator(hb.HandleModerationHistory()))
+	hb.rout.POST("/admin/goodcards/{id}/approve", hb.withModerator(hb.HandleApproveGoodCard()))
+	hb.rout.POST("/admin/goodcards/{id}/reject", hb.withModerator(hb.HandleRejectGoodCard()))
+
+	// Дерево категорий; категории общие для всех продавцов, поэтому меняет их сотрудник (требуется X-Moderator-ID)
+	hb.rout.POST("/categories", hb.withModerator(hb.HandleCreateCategory()))
+	hb.rout.GET("/categories", hb.HandleListCategories())
+	hb.rout.GET("/categories/{id}", hb.HandleReadCategory())
+	hb.rout.GET("/categories/{id}/path", hb.HandleCategoryPath())
+	hb.rout.GET("/categories/{id}/attributes", hb.HandleReadAttributeSchema())
+	hb.rout.PUT("/categories/{id}/attributes", hb.withModerator(hb.HandleSetAttributeSchema()))
+	hb.rout.PUT("/categories/{id}", hb.withModerator(hb.HandleRenameCategory()))
+	hb.rout.POST("/categories/{id}/move", hb.withModerator(hb.HandleMoveCategory()))
+	hb.rout.DELETE("/categories/{id}", hb.withModerator(hb.HandleDeleteCategory()))
+
+	// Реестр брендов; бренды меняет сотрудник (требуется X-Moderator-ID)
+	hb.rout.POST("/brands", hb.withModerator(hb.HandleCreateBrand()))
+	hb.rout.GET("/brands", hb.HandleListBrands())
+	hb.rout.PUT("/brands/{id}", hb.withModerator(hb.HandleRenameBrand()))
+	hb.rout.DELETE("/brands/{id}", hb.withModerator(hb.HandleDeleteBrand()))
+
+	server := &fasthttp.Server{
+		Handler: bufferBody(hb.idempotent(hb.rout.Handler)),
+		// StreamRequestBody действует на весь сервер, хотя поток нужен только импорту каталога:
+		// тело длиннее MaxRequestBodySize и любое chunked-тело приходят потоком без ограничения
+		// размера. Остальным маршрутам тело с тем же пределом собирает bufferBody.
+		StreamRequestBody:  true,
+		MaxRequestBodySize: maxBufferedBodySize,
+	}
+	fmt.Println(server.ListenAndServe(":8080"))
+}
+
+// bufferBody читает тело запросов, кроме streamingRoutes, в память не больше maxBufferedBodySize.
+// Без неё PostBody() потокового тела прочитал бы его целиком, каким бы большим оно ни было.
+func bufferBody(next fasthttp.RequestHandler) fasthttp.RequestHandler {
+	return func(ctx *fasthttp.RequestCtx) {
+		stream := ctx.RequestBodyStream()
+		if stream == nil || streamingRoutes[string(ctx.Method())+" "+string(ctx.Path())] {
+			next(ctx)
+			return
+		}
+
+		body, err := io.ReadAll(io.LimitReader(stream, maxBufferedBodySize+1))
+		if err != nil {
+			httpErrorResponse(ctx, fasthttp.StatusBadRequest, "Invalid request body")
+			return
+		}
+		if len(body) > maxBufferedBodySize {
+			serviceErrorResponse(ctx, myErrors.ErrBodyTooLarge, "Request body is too large")
+			return
+		}
+		ctx.Request.SetBody(body)
+		next(ctx)
+	}
 }
 
 // Вспомогательная функция для отправки JSON ответа
@@ -79,6 +252,17 @@ func httpErrorResponse(ctx *fasthttp.RequestCtx, statusCode int, message string)
 	jsonResponse(ctx, response)
 }
 
+// Вспомогательная функция для отправки ошибки сервиса: для myErrors.Error
+// используется его код и причина, для остальных ошибок - 500 и message
+func serviceErrorResponse(ctx *fasthttp.RequestCtx, err error, message string) {
+	var myErr myErrors.Error
+	if errors.As(err, &myErr) {
+		httpErrorResponse(ctx, myErr.GetHttpCode(), myErr.GetCause())
+		return
+	}
+	httpErrorResponse(ctx, fasthttp.StatusInternalServerError, message)
+}
+
 func (hb *HandlersBuilder) HandleCreateGoodCard() func(ctx *fasthttp.RequestCtx) {
 	return metrics(func(ctx *fasthttp.RequestCtx) {
 		if !ctx.IsPost() {
@@ -92,9 +276,9 @@ func (hb *HandlersBuilder) HandleCreateGoodCard() func(ctx *fasthttp.RequestCtx)
 			return
 		}
 
-		id, err := hb.srv.SrvCreateGoodCard(card)
+		id, err := hb.srv.SrvCreateGoodCard(sellerFromCtx(ctx), card)
 		if err != nil {
-			httpErrorResponse(ctx, fasthttp.StatusInternalServerError, "Failed to create good card")
+			serviceErrorResponse(ctx, err, "Failed to create good card")
 			return
 		}
 
@@ -117,8 +301,8 @@ func (hb *HandlersBuilder) HandleDeleteGoodCard() func(ctx *fasthttp.RequestCtx)
 			return
 		}
 
-		if err := hb.srv.SrvDeleteGoodCard(id); err != nil {
-			httpErrorResponse(ctx, fasthttp.StatusInternalServerError, "Failed to delete good card")
+		if err := hb.srv.SrvDeleteGoodCard(sellerFromCtx(ctx), id); err != nil {
+			serviceErrorResponse(ctx, err, "Failed to delete good card")
 			return
 		}
 
@@ -126,6 +310,27 @@ func (hb *HandlersBuilder) HandleDeleteGoodCard() func(ctx *fasthttp.RequestCtx)
 	}, "HandleDeleteGoodCard")
 }
 
+func (hb *HandlersBuilder) HandleRestoreGoodCard() func(ctx *fasthttp.RequestCtx) {
+	return metrics(func(ctx *fasthttp.RequestCtx) {
+		if !ctx.IsPost() {
+			httpErrorResponse(ctx, fasthttp.StatusMethodNotAllowed, "Method not allowed")
+			return
+		}
+
+		id, ok := parseIDParam(ctx, "id")
+		if !ok {
+			return
+		}
+
+		if err := hb.srv.SrvRestoreGoodCard(sellerFromCtx(ctx), id); err != nil {
+			serviceErrorResponse(ctx, err, "Failed to restore good card")
+			return
+		}
+
+		ctx.SetStatusCode(fasthttp.StatusNoContent)
+	}, "HandleRestoreGoodCard")
+}
+
 func (hb *HandlersBuilder) HandleUpdateGoodCard() func(ctx *fasthttp.RequestCtx) {
 	return metrics(func(ctx *fasthttp.RequestCtx) {
 		if !ctx.IsPut() {
@@ -140,19 +345,27 @@ func (hb *HandlersBuilder) HandleUpdateGoodCard() func(ctx *fasthttp.RequestCtx)
 			return
 		}
 
+		expectedVersion, err := parseIfMatch(ctx)
+		if err != nil {
+			serviceErrorResponse(ctx, err, "Invalid If-Match")
+			return
+		}
+
 		var card models.GoodCard
 		if err := json.Unmarshal(ctx.PostBody(), &card); err != nil {
 			httpErrorResponse(ctx, fasthttp.StatusBadRequest, "Invalid request body")
 			return
 		}
 
-		if err := hb.srv.SrvUpdateGoodCard(id, card); err != nil {
-			httpErrorResponse(ctx, fasthttp.StatusInternalServerError, "Failed to update good card")
+		version, err := hb.srv.SrvUpdateGoodCard(sellerFromCtx(ctx), id, card, expectedVersion)
+		if err != nil {
+			serviceErrorResponse(ctx, err, "Failed to update good card")
 			return
 		}
 
+		setETag(ctx, version)
 		ctx.SetStatusCode(fasthttp.StatusOK)
-		jsonResponse(ctx, map[string]string{"status": "updated"})
+		jsonResponse(ctx, map[string]interface{}{"status": "updated", "version": version})
 	}, "HandleUpdateGoodCard")
 }
 
@@ -170,12 +383,18 @@ func (hb *HandlersBuilder) HandleReadCard() func(ctx *fasthttp.RequestCtx) {
 			return
 		}
 
-		card, err := hb.srv.SrvReadGood(id)
+		card, err := hb.srv.SrvReadGood(sellerFromCtx(ctx), id)
 		if err != nil {
-			httpErrorResponse(ctx, fasthttp.StatusInternalServerError, "Failed to read good card")
+			serviceErrorResponse(ctx, err, "Failed to read good card")
+			return
+		}
+		goods := []models.Good{card}
+		if !hb.fillDisplayPrices(ctx, goods) {
 			return
 		}
+		card = goods[0]
 
+		setETag(ctx, card.Card.Version)
 		ctx.SetStatusCode(fasthttp.StatusOK)
 		jsonResponse(ctx, card)
 	}, "HandleReadCard")
@@ -196,16 +415,17 @@ func (hb *HandlersBuilder) HandleAddCountGood() func(ctx *fasthttp.RequestCtx) {
 		}
 
 		var req struct {
-			Number int `json:"number"`
+			Number      int       `json:"number"`
+			WarehouseID uuid.UUID `json:"warehouse_id"` // Необязательно, без него пополняется склад по умолчанию
 		}
 		if err := json.Unmarshal(ctx.PostBody(), &req); err != nil || req.Number <= 0 {
 			httpErrorResponse(ctx, fasthttp.StatusBadRequest, "Invalid number")
 			return
 		}
 
-		newCount, err := hb.srv.SrvAddCountGood(id, req.Number)
+		newCount, err := hb.srv.SrvAddCountGood(sellerFromCtx(ctx), id, req.WarehouseID, req.Number, movementMeta(ctx))
 		if err != nil {
-			httpErrorResponse(ctx, fasthttp.StatusInternalServerError, "Failed to add count")
+			serviceErrorResponse(ctx, err, "Failed to add count")
 			return
 		}
 
@@ -229,16 +449,17 @@ func (hb *HandlersBuilder) HandleDeleteCountGood() func(ctx *fasthttp.RequestCtx
 		}
 
 		var req struct {
-			Number int `json:"number"`
+			Number      int       `json:"number"`
+			WarehouseID uuid.UUID `json:"warehouse_id"` // Необязательно, по умолчанию склад выбирается по приоритету
 		}
 		if err := json.Unmarshal(ctx.PostBody(), &req); err != nil || req.Number <= 0 {
 			httpErrorResponse(ctx, fasthttp.StatusBadRequest, "Invalid number")
 			return
 		}
 
-		newCount, err := hb.srv.SrvDeleteCountGood(id, req.Number)
+		newCount, err := hb.srv.SrvDeleteCountGood(sellerFromCtx(ctx), id, req.WarehouseID, req.Number, movementMeta(ctx))
 		if err != nil {
-			httpErrorResponse(ctx, fasthttp.StatusInternalServerError, "Failed to delete count")
+			serviceErrorResponse(ctx, err, "Failed to delete count")
 			return
 		}
 
@@ -261,9 +482,9 @@ func (hb *HandlersBuilder) HandleCreateGood() func(ctx *fasthttp.RequestCtx) {
 		}
 
 		// Создаём карточку товара, что является созданием товара
-		id, err := hb.srv.SrvCreateGoodCard(card)
+		id, err := hb.srv.SrvCreateGoodCard(sellerFromCtx(ctx), card)
 		if err != nil {
-			httpErrorResponse(ctx, fasthttp.StatusInternalServerError, "Failed to create good")
+			serviceErrorResponse(ctx, err, "Failed to create good")
 			return
 		}
 
@@ -287,11 +508,165 @@ func (hb *HandlersBuilder) HandleDeleteGood() func(ctx *fasthttp.RequestCtx) {
 		}
 
 		// Удаляем карточку товара по cardId
-		if err := hb.srv.SrvDeleteGoodCard(id); err != nil {
-			httpErrorResponse(ctx, fasthttp.StatusInternalServerError, "Failed to delete good")
+		if err := hb.srv.SrvDeleteGoodCard(sellerFromCtx(ctx), id); err != nil {
+			serviceErrorResponse(ctx, err, "Failed to delete good")
 			return
 		}
 
 		ctx.SetStatusCode(fasthttp.StatusNoContent)
 	}, "HandleDeleteGood")
 }
+
+func (hb *HandlersBuilder) HandleListGoods() func(ctx *fasthttp.RequestCtx) {
+	return metrics(func(ctx *fasthttp.RequestCtx) {
+		if !ctx.IsGet() {
+			httpErrorResponse(ctx, fasthttp.StatusMethodNotAllowed, "Method not allowed")
+			return
+		}
+
+		filter, err := parseGoodFilter(ctx.QueryArgs())
+		if err != nil {
+			serviceErrorResponse(ctx, err, "Invalid filter")
+			return
+		}
+
+		page, err := hb.srv.SrvListGoods(sellerFromCtx(ctx), filter)
+		if err != nil {
+			serviceErrorResponse(ctx, err, "Failed to list goods")
+			return
+		}
+		if !hb.fillDisplayPrices(ctx, page.Items) {
+			return
+		}
+
+		ctx.SetStatusCode(fasthttp.StatusOK)
+		jsonResponse(ctx, page)
+	}, "HandleListGoods")
+}
+
+func (hb *HandlersBuilder) HandleListStorefrontGoods() func(ctx *fasthttp.RequestCtx) {
+	return metrics(func(ctx *fasthttp.RequestCtx) {
+		if !ctx.IsGet() {
+			httpErrorResponse(ctx, fasthttp.StatusMethodNotAllowed, "Method not allowed")
+			return
+		}
+
+		filter, err := parseGoodFilter(ctx.QueryArgs())
+		if err != nil {
+			serviceErrorResponse(ctx, err, "Invalid filter")
+			return
+		}
+
+		page, err := hb.srv.SrvListStorefrontGoods(filter)
+		if err != nil {
+			serviceErrorResponse(ctx, err, "Failed to list goods")
+			return
+		}
+		if !hb.fillDisplayPrices(ctx, page.Items) {
+			return
+		}
+
+		ctx.SetStatusCode(fasthttp.StatusOK)
+		jsonResponse(ctx, page)
+	}, "HandleListStorefrontGoods")
+}
+
+// parseGoodFilter разбирает query-параметры списка товаров.
+// seller_id учитывается только витриной: в кабинете список всегда ограничен продавцом из заголовка.
+func parseGoodFilter(args *fasthttp.Args) (models.GoodFilter, error) {
+	filter := models.GoodFilter{
+		NamePrefix: string(args.Peek("name_prefix")),
+		Sort:       string(args.Peek("sort")),
+		Cursor:     string(args.Peek("cursor")),
+	}
+
+	if v := args.Peek("is_active"); len(v) > 0 {
+		active, err := strconv.ParseBool(string(v))
+		if err != nil {
+			return filter, myErrors.ErrInvalidFilter
+		}
+		filter.IsActive = &active
+	}
+	switch v := string(args.Peek("moderation_status")); v {
+	case "", models.ModerationDraft, models.ModerationSubmitted, models.ModerationApproved, models.ModerationRejected:
+		filter.Moderation = v
+	default:
+		return filter, myErrors.ErrInvalidFilter
+	}
+	ids := []struct {
+		name string
+		dst  **uuid.UUID
+	}{
+		{"seller_id", &filter.SellerID},
+		{"category", &filter.CategoryID},
+		{"brand", &filter.BrandID},
+	}
+	for _, f := range ids {
+		v := args.Peek(f.name)
+		if len(v) == 0 {
+			continue
+		}
+		id, err := uuid.ParseBytes(v)
+		if err != nil {
+			return filter, myErrors.ErrInvalidFilter
+		}
+		*f.dst = &id
+	}
+	if v := args.Peek("limit"); len(v) > 0 {
+		limit, err := strconv.Atoi(string(v))
+		if err != nil || limit <= 0 {
+			return filter, myErrors.ErrInvalidFilter
+		}
+		filter.Limit = limit
+	}
+
+	// Границы цены - десятичная запись в валюте currency, без неё цены разных валют несравнимы
+	if v := args.Peek("currency"); len(v) > 0 {
+		if !money.Supported(string(v)) {
+			return filter, myErrors.ErrInvalidFilter
+		}
+		filter.Currency = string(v)
+	}
+	prices := []struct {
+		name string
+		dst  **int64
+	}{
+		{"min_price", &filter.MinPrice},
+		{"max_price", &filter.MaxPrice},
+	}
+	for _, f := range prices {
+		v := args.Peek(f.name)
+		if len(v) == 0 {
+			continue
+		}
+		if filter.Currency == "" {
+			return filter, myErrors.ErrInvalidFilter
+		}
+		price, err := money.Parse(string(v), filter.Currency)
+		if err != nil {
+			return filter, myErrors.ErrInvalidFilter
+		}
+		*f.dst = &price.Amount
+	}
+
+	floats := []struct {
+		name string
+		dst  **float64
+	}{
+		{"min_weight", &filter.MinWeight},
+		{"max_weight", &filter.MaxWeight},
+	}
+	for _, f := range floats {
+		v := args.Peek(f.name)
+		if len(v) == 0 {
+			continue
+		}
+		num, err := strconv.ParseFloat(string(v), 64)
+		if err != nil {
+			return filter, myErrors.ErrInvalidFilter
+		}
+		*f.dst = &num
+	}
+
+	return filter, nil
+}
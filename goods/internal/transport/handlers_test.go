@@ -0,0 +1,56 @@
+package transport
+
+import (
+	myErrors "goods/internal/errors"
+	"goods/internal/models"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/valyala/fasthttp"
+)
+
+func queryArgs(query string) *fasthttp.Args {
+	var args fasthttp.Args
+	args.Parse(query)
+	return &args
+}
+
+func TestParseGoodFilter(t *testing.T) {
+	seller := uuid.New()
+	filter, err := parseGoodFilter(queryArgs("seller_id=" + seller.String() +
+		"&is_active=true&moderation_status=approved&currency=RUB&min_price=10.50&max_weight=2.5&limit=5&sort=price_asc"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if filter.SellerID == nil || *filter.SellerID != seller {
+		t.Fatalf("seller = %v, want %v", filter.SellerID, seller)
+	}
+	if filter.IsActive == nil || !*filter.IsActive || filter.Moderation != models.ModerationApproved {
+		t.Fatalf("filter = %+v, want active approved", filter)
+	}
+	if filter.MinPrice == nil || *filter.MinPrice != 1050 || filter.MaxWeight == nil || *filter.MaxWeight != 2.5 {
+		t.Fatalf("filter = %+v, want min price 1050 and max weight 2.5", filter)
+	}
+	if filter.Limit != 5 || filter.Sort != models.SortPriceAsc {
+		t.Fatalf("filter = %+v, want limit 5 sorted by price", filter)
+	}
+}
+
+func TestParseGoodFilterRejectsInvalidValues(t *testing.T) {
+	for _, query := range []string{
+		"is_active=maybe",
+		"moderation_status=unknown",
+		"seller_id=not-a-uuid",
+		"category=1",
+		"limit=0",
+		"limit=ten",
+		"currency=XXX",
+		"min_price=10",
+		"currency=RUB&max_price=abc",
+		"min_weight=heavy",
+	} {
+		if _, err := parseGoodFilter(queryArgs(query)); err != myErrors.ErrInvalidFilter {
+			t.Errorf("%s: err = %v, want ErrInvalidFilter", query, err)
+		}
+	}
+}
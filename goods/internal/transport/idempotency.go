@@ -0,0 +1,92 @@
+package transport
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	myErrors "goods/internal/errors"
+	myLog "goods/internal/logger"
+	"goods/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/valyala/fasthttp"
+)
+
+// Пространство имён, из которого выводятся области ключей модераторов и внутренних сервисов,
+// чтобы они не совпадали с областями продавцов
+var idempotencyScopeSpace = uuid.MustParse("5b0f8a5e-3c1d-4f7a-9e62-0d4c8b1a7f30")
+
+// idempotent повторяет ответ на первый изменяющий запрос с тем же заголовком Idempotency-Key
+// вместо повторного выполнения. Ключи продавцов, модераторов и внутренних сервисов не пересекаются;
+// повтор ключа с другим методом, путём или телом получает 422. Запросы без заголовка выполняются как обычно.
+// Потоковые маршруты ключ не принимают: для отпечатка пришлось бы прочитать тело целиком.
+func (hb *HandlersBuilder) idempotent(next fasthttp.RequestHandler) fasthttp.RequestHandler {
+	return func(ctx *fasthttp.RequestCtx) {
+		key := string(ctx.Request.Header.Peek("Idempotency-Key"))
+		if key == "" || !isMutating(ctx) {
+			next(ctx)
+			return
+		}
+		if streamingRoutes[string(ctx.Method())+" "+string(ctx.Path())] {
+			serviceErrorResponse(ctx, myErrors.ErrIdempotencyStreaming, "Idempotency-Key is not supported")
+			return
+		}
+
+		scope := hb.idempotencyScope(ctx)
+		fingerprint := requestFingerprint(ctx)
+
+		stored, err := hb.srv.SrvBeginIdempotent(scope, key, fingerprint)
+		if err != nil {
+			serviceErrorResponse(ctx, err, "Failed to process Idempotency-Key")
+			return
+		}
+		if stored != nil {
+			ctx.Response.Header.Set("Idempotent-Replayed", "true")
+			ctx.SetStatusCode(stored.StatusCode)
+			ctx.SetContentType(stored.ContentType)
+			ctx.SetBody(stored.Body)
+			return
+		}
+
+		next(ctx)
+
+		response := models.IdempotentResponse{
+			StatusCode:  ctx.Response.StatusCode(),
+			ContentType: string(ctx.Response.Header.ContentType()),
+			Body:        ctx.Response.Body(),
+		}
+		if err := hb.srv.SrvFinishIdempotent(scope, key, response); err != nil {
+			// Ответ уже сформирован; повтор с этим ключом получит 409, пока ключ не освободится по таймауту
+			myLog.Log.Errorf("Failed to store idempotent response: %v", err)
+		}
+	}
+}
+
+// idempotencyScope возвращает область ключей вызывающего: продавца, модератора или внутреннего сервиса.
+// Запрос без проверяемой личности получает общую область; ответ на него всё равно определит обработчик.
+func (hb *HandlersBuilder) idempotencyScope(ctx *fasthttp.RequestCtx) uuid.UUID {
+	if sellerID, err := hb.resolveSeller(ctx); err == nil {
+		return sellerID
+	}
+	if moderatorID, err := uuid.ParseBytes(ctx.Request.Header.Peek("X-Moderator-ID")); err == nil && moderatorID != uuid.Nil {
+		return uuid.NewSHA1(idempotencyScopeSpace, []byte("moderator:"+moderatorID.String()))
+	}
+	if hb.validServiceToken(ctx) {
+		return uuid.NewSHA1(idempotencyScopeSpace, []byte("service"))
+	}
+	return uuid.Nil
+}
+
+func isMutating(ctx *fasthttp.RequestCtx) bool {
+	return ctx.IsPost() || ctx.IsPut() || ctx.IsPatch() || ctx.IsDelete()
+}
+
+// requestFingerprint - отпечаток метода, пути с параметрами и тела запроса
+func requestFingerprint(ctx *fasthttp.RequestCtx) string {
+	h := sha256.New()
+	h.Write(ctx.Method())
+	h.Write([]byte{' '})
+	h.Write(ctx.RequestURI())
+	h.Write([]byte{'\n'})
+	h.Write(ctx.PostBody())
+	return hex.EncodeToString(h.Sum(nil))
+}
@@ -0,0 +1,55 @@
+package transport
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/valyala/fasthttp"
+)
+
+func TestIdempotencyScopeSeparatesCallers(t *testing.T) {
+	hb := &HandlersBuilder{resolveSeller: HeaderSellerResolver, serviceToken: "secret"}
+	id := uuid.New()
+	scope := func(header, value string) uuid.UUID {
+		var ctx fasthttp.RequestCtx
+		if header != "" {
+			ctx.Request.Header.Set(header, value)
+		}
+		return hb.idempotencyScope(&ctx)
+	}
+
+	seller := scope("X-Supplier-ID", id.String())
+	moderator := scope("X-Moderator-ID", id.String())
+	service := scope("X-Service-Token", "secret")
+	if seller != id {
+		t.Errorf("seller scope = %v, want %v", seller, id)
+	}
+	// Модератор с тем же UUID, что у продавца, не попадает в область продавца
+	if moderator == uuid.Nil || moderator == seller || moderator == service {
+		t.Errorf("moderator scope = %v", moderator)
+	}
+	if service == uuid.Nil || service == seller {
+		t.Errorf("service scope = %v", service)
+	}
+	if other := scope("X-Moderator-ID", uuid.NewString()); other == moderator {
+		t.Error("different moderators share a scope")
+	}
+	if got := scope("X-Service-Token", "guess"); got != uuid.Nil {
+		t.Errorf("wrong service token: scope = %v, want shared scope", got)
+	}
+}
+
+func TestIdempotentRejectsStreamingRoutes(t *testing.T) {
+	called := false
+	handler := (&HandlersBuilder{}).idempotent(func(*fasthttp.RequestCtx) { called = true })
+
+	var ctx fasthttp.RequestCtx
+	ctx.Request.Header.SetMethod(fasthttp.MethodPost)
+	ctx.Request.SetRequestURI("/goodcards/import")
+	ctx.Request.Header.Set("Idempotency-Key", "import-1")
+	handler(&ctx)
+
+	if called || ctx.Response.StatusCode() != fasthttp.StatusBadRequest {
+		t.Fatalf("called = %v, status = %d, want 400 without running the import", called, ctx.Response.StatusCode())
+	}
+}
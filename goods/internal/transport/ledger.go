@@ -0,0 +1,119 @@
+package transport
+
+import (
+	"goods/internal/models"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/valyala/fasthttp"
+)
+
+// movementMeta собирает исполнителя и идентификатор корреляции для журнала движения.
+// Если клиент не передал X-Correlation-ID, он генерируется и возвращается в ответе.
+func movementMeta(ctx *fasthttp.RequestCtx) models.MovementMeta {
+	correlationID := string(ctx.Request.Header.Peek("X-Correlation-ID"))
+	if correlationID == "" {
+		correlationID = uuid.NewString()
+	}
+	ctx.Response.Header.Set("X-Correlation-ID", correlationID)
+
+	// Исполнитель берётся только из проверенной личности: продавец из withSeller или сервис из withService
+	var actor string
+	if sellerID := sellerFromCtx(ctx); sellerID != uuid.Nil {
+		actor = sellerID.String()
+	} else if fromService(ctx) {
+		actor = models.MovementActorService
+	}
+
+	return models.MovementMeta{
+		Actor:         actor,
+		CorrelationID: correlationID,
+	}
+}
+
+func (hb *HandlersBuilder) HandleListMovements() func(ctx *fasthttp.RequestCtx) {
+	return metrics(func(ctx *fasthttp.RequestCtx) {
+		if !ctx.IsGet() {
+			httpErrorResponse(ctx, fasthttp.StatusMethodNotAllowed, "Method not allowed")
+			return
+		}
+
+		idStr := ctx.UserValue("id").(string)
+		id, err := uuid.Parse(idStr)
+		if err != nil {
+			httpErrorResponse(ctx, fasthttp.StatusBadRequest, "Invalid UUID")
+			return
+		}
+
+		limit := 0
+		if v := ctx.QueryArgs().Peek("limit"); len(v) > 0 {
+			limit, err = strconv.Atoi(string(v))
+			if err != nil || limit <= 0 {
+				httpErrorResponse(ctx, fasthttp.StatusBadRequest, "Invalid limit")
+				return
+			}
+		}
+
+		page, err := hb.srv.SrvListMovements(sellerFromCtx(ctx), id, limit, string(ctx.QueryArgs().Peek("cursor")))
+		if err != nil {
+			serviceErrorResponse(ctx, err, "Failed to list movements")
+			return
+		}
+
+		ctx.SetStatusCode(fasthttp.StatusOK)
+		jsonResponse(ctx, page)
+	}, "HandleListMovements")
+}
+
+func (hb *HandlersBuilder) HandleQuantityAt() func(ctx *fasthttp.RequestCtx) {
+	return metrics(func(ctx *fasthttp.RequestCtx) {
+		if !ctx.IsGet() {
+			httpErrorResponse(ctx, fasthttp.StatusMethodNotAllowed, "Method not allowed")
+			return
+		}
+
+		idStr := ctx.UserValue("id").(string)
+		id, err := uuid.Parse(idStr)
+		if err != nil {
+			httpErrorResponse(ctx, fasthttp.StatusBadRequest, "Invalid UUID")
+			return
+		}
+
+		at := time.Now()
+		if v := ctx.QueryArgs().Peek("at"); len(v) > 0 {
+			at, err = time.Parse(time.RFC3339, string(v))
+			if err != nil {
+				httpErrorResponse(ctx, fasthttp.StatusBadRequest, "Invalid at, expected RFC3339")
+				return
+			}
+		}
+
+		quantity, err := hb.srv.SrvQuantityAt(sellerFromCtx(ctx), id, at)
+		if err != nil {
+			serviceErrorResponse(ctx, err, "Failed to compute quantity")
+			return
+		}
+
+		ctx.SetStatusCode(fasthttp.StatusOK)
+		jsonResponse(ctx, map[string]interface{}{"quantity": quantity, "at": at})
+	}, "HandleQuantityAt")
+}
+
+func (hb *HandlersBuilder) HandleCheckLedger() func(ctx *fasthttp.RequestCtx) {
+	return metrics(func(ctx *fasthttp.RequestCtx) {
+		if !ctx.IsGet() {
+			httpErrorResponse(ctx, fasthttp.StatusMethodNotAllowed, "Method not allowed")
+			return
+		}
+
+		mismatches, err := hb.srv.SrvCheckLedger()
+		if err != nil {
+			serviceErrorResponse(ctx, err, "Failed to check ledger")
+			return
+		}
+
+		ctx.SetStatusCode(fasthttp.StatusOK)
+		jsonResponse(ctx, map[string]interface{}{"consistent": len(mismatches) == 0, "mismatches": mismatches})
+	}, "HandleCheckLedger")
+}
@@ -0,0 +1,124 @@
+package transport
+
+import (
+	"encoding/json"
+	myErrors "goods/internal/errors"
+	"mime"
+
+	"github.com/google/uuid"
+	"github.com/valyala/fasthttp"
+)
+
+// HandleUploadMedia принимает изображение в теле запроса как есть, тип - из Content-Type
+func (hb *HandlersBuilder) HandleUploadMedia() func(ctx *fasthttp.RequestCtx) {
+	return metrics(func(ctx *fasthttp.RequestCtx) {
+		if !ctx.IsPost() {
+			httpErrorResponse(ctx, fasthttp.StatusMethodNotAllowed, "Method not allowed")
+			return
+		}
+
+		cardID, ok := parseIDParam(ctx, "id")
+		if !ok {
+			return
+		}
+
+		mediaType, _, err := mime.ParseMediaType(string(ctx.Request.Header.ContentType()))
+		if err != nil {
+			serviceErrorResponse(ctx, myErrors.ErrMediaUnsupportedType, "Unsupported media type")
+			return
+		}
+		// Заведомо слишком большой файл отклоняем до чтения тела
+		if n := ctx.Request.Header.ContentLength(); n > hb.mediaMaxSize {
+			serviceErrorResponse(ctx, myErrors.ErrMediaTooLarge, "Image is too large")
+			return
+		}
+
+		media, err := hb.srv.SrvUploadMedia(sellerFromCtx(ctx), cardID, mediaType, ctx.PostBody())
+		if err != nil {
+			serviceErrorResponse(ctx, err, "Failed to upload image")
+			return
+		}
+
+		ctx.SetStatusCode(fasthttp.StatusCreated)
+		jsonResponse(ctx, media)
+	}, "HandleUploadMedia")
+}
+
+func (hb *HandlersBuilder) HandleListMedia() func(ctx *fasthttp.RequestCtx) {
+	return metrics(func(ctx *fasthttp.RequestCtx) {
+		if !ctx.IsGet() {
+			httpErrorResponse(ctx, fasthttp.StatusMethodNotAllowed, "Method not allowed")
+			return
+		}
+
+		cardID, ok := parseIDParam(ctx, "id")
+		if !ok {
+			return
+		}
+
+		media, err := hb.srv.SrvListMedia(sellerFromCtx(ctx), cardID)
+		if err != nil {
+			serviceErrorResponse(ctx, err, "Failed to list images")
+			return
+		}
+
+		ctx.SetStatusCode(fasthttp.StatusOK)
+		jsonResponse(ctx, media)
+	}, "HandleListMedia")
+}
+
+func (hb *HandlersBuilder) HandleReorderMedia() func(ctx *fasthttp.RequestCtx) {
+	return metrics(func(ctx *fasthttp.RequestCtx) {
+		if !ctx.IsPut() {
+			httpErrorResponse(ctx, fasthttp.StatusMethodNotAllowed, "Method not allowed")
+			return
+		}
+
+		cardID, ok := parseIDParam(ctx, "id")
+		if !ok {
+			return
+		}
+
+		var req struct {
+			IDs []uuid.UUID `json:"ids"`
+		}
+		if err := json.Unmarshal(ctx.PostBody(), &req); err != nil {
+			httpErrorResponse(ctx, fasthttp.StatusBadRequest, "Invalid request body")
+			return
+		}
+
+		media, err := hb.srv.SrvReorderMedia(sellerFromCtx(ctx), cardID, req.IDs)
+		if err != nil {
+			serviceErrorResponse(ctx, err, "Failed to reorder images")
+			return
+		}
+
+		ctx.SetStatusCode(fasthttp.StatusOK)
+		jsonResponse(ctx, media)
+	}, "HandleReorderMedia")
+}
+
+func (hb *HandlersBuilder) HandleDeleteMedia() func(ctx *fasthttp.RequestCtx) {
+	return metrics(func(ctx *fasthttp.RequestCtx) {
+		if !ctx.IsDelete() {
+			httpErrorResponse(ctx, fasthttp.StatusMethodNotAllowed, "Method not allowed")
+			return
+		}
+
+		cardID, ok := parseIDParam(ctx, "id")
+		if !ok {
+			return
+		}
+		mediaID, ok := parseIDParam(ctx, "mediaId")
+		if !ok {
+			return
+		}
+
+		if err := hb.srv.SrvDeleteMedia(sellerFromCtx(ctx), cardID, mediaID); err != nil {
+			serviceErrorResponse(ctx, err, "Failed to delete image")
+			return
+		}
+
+		ctx.SetStatusCode(fasthttp.StatusNoContent)
+	}, "HandleDeleteMedia")
+}
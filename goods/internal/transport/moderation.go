@@ -0,0 +1,156 @@
+package transport
+
+import (
+	"encoding/json"
+	"strconv"
+
+	"github.com/valyala/fasthttp"
+)
+
+func (hb *HandlersBuilder) HandleSubmitGoodCard() func(ctx *fasthttp.RequestCtx) {
+	return metrics(func(ctx *fasthttp.RequestCtx) {
+		if !ctx.IsPost() {
+			httpErrorResponse(ctx, fasthttp.StatusMethodNotAllowed, "Method not allowed")
+			return
+		}
+
+		id, ok := parseIDParam(ctx, "id")
+		if !ok {
+			return
+		}
+		if err := hb.srv.SrvSubmitGoodCard(sellerFromCtx(ctx), id); err != nil {
+			serviceErrorResponse(ctx, err, "Failed to submit good card")
+			return
+		}
+		ctx.SetStatusCode(fasthttp.StatusNoContent)
+	}, "HandleSubmitGoodCard")
+}
+
+func (hb *HandlersBuilder) HandleListModerationLog() func(ctx *fasthttp.RequestCtx) {
+	return metrics(func(ctx *fasthttp.RequestCtx) {
+		if !ctx.IsGet() {
+			httpErrorResponse(ctx, fasthttp.StatusMethodNotAllowed, "Method not allowed")
+			return
+		}
+
+		id, ok := parseIDParam(ctx, "id")
+		if !ok {
+			return
+		}
+		decisions, err := hb.srv.SrvListModerationLog(sellerFromCtx(ctx), id)
+		if err != nil {
+			serviceErrorResponse(ctx, err, "Failed to list moderation log")
+			return
+		}
+
+		ctx.SetStatusCode(fasthttp.StatusOK)
+		jsonResponse(ctx, decisions)
+	}, "HandleListModerationLog")
+}
+
+func (hb *HandlersBuilder) HandleModerationQueue() func(ctx *fasthttp.RequestCtx) {
+	return metrics(func(ctx *fasthttp.RequestCtx) {
+		if !ctx.IsGet() {
+			httpErrorResponse(ctx, fasthttp.StatusMethodNotAllowed, "Method not allowed")
+			return
+		}
+
+		limit := 0
+		if v := ctx.QueryArgs().Peek("limit"); len(v) > 0 {
+			var err error
+			limit, err = strconv.Atoi(string(v))
+			if err != nil || limit <= 0 {
+				httpErrorResponse(ctx, fasthttp.StatusBadRequest, "Invalid limit")
+				return
+			}
+		}
+
+		page, err := hb.srv.SrvModerationQueue(limit, string(ctx.QueryArgs().Peek("cursor")))
+		if err != nil {
+			serviceErrorResponse(ctx, err, "Failed to list moderation queue")
+			return
+		}
+
+		ctx.SetStatusCode(fasthttp.StatusOK)
+		jsonResponse(ctx, page)
+	}, "HandleModerationQueue")
+}
+
+func (hb *HandlersBuilder) HandleModerationHistory() func(ctx *fasthttp.RequestCtx) {
+	return metrics(func(ctx *fasthttp.RequestCtx) {
+		if !ctx.IsGet() {
+			httpErrorResponse(ctx, fasthttp.StatusMethodNotAllowed, "Method not allowed")
+			return
+		}
+
+		id, ok := parseIDParam(ctx, "id")
+		if !ok {
+			return
+		}
+		decisions, err := hb.srv.SrvModerationHistory(id)
+		if err != nil {
+			serviceErrorResponse(ctx, err, "Failed to list moderation log")
+			return
+		}
+
+		ctx.SetStatusCode(fasthttp.StatusOK)
+		jsonResponse(ctx, decisions)
+	}, "HandleModerationHistory")
+}
+
+// HandleApproveGoodCard одобряет карточку; If-Match - версия, которую проверил модератор
+func (hb *HandlersBuilder) HandleApproveGoodCard() func(ctx *fasthttp.RequestCtx) {
+	return metrics(func(ctx *fasthttp.RequestCtx) {
+		if !ctx.IsPost() {
+			httpErrorResponse(ctx, fasthttp.StatusMethodNotAllowed, "Method not allowed")
+			return
+		}
+
+		id, ok := parseIDParam(ctx, "id")
+		if !ok {
+			return
+		}
+		expectedVersion, err := parseIfMatch(ctx)
+		if err != nil {
+			serviceErrorResponse(ctx, err, "Invalid If-Match")
+			return
+		}
+		if err := hb.srv.SrvApproveGoodCard(moderatorFromCtx(ctx), id, expectedVersion); err != nil {
+			serviceErrorResponse(ctx, err, "Failed to approve good card")
+			return
+		}
+		ctx.SetStatusCode(fasthttp.StatusNoContent)
+	}, "HandleApproveGoodCard")
+}
+
+// HandleRejectGoodCard отклоняет карточку с причиной; If-Match - версия, которую проверил модератор
+func (hb *HandlersBuilder) HandleRejectGoodCard() func(ctx *fasthttp.RequestCtx) {
+	return metrics(func(ctx *fasthttp.RequestCtx) {
+		if !ctx.IsPost() {
+			httpErrorResponse(ctx, fasthttp.StatusMethodNotAllowed, "Method not allowed")
+			return
+		}
+
+		id, ok := parseIDParam(ctx, "id")
+		if !ok {
+			return
+		}
+		expectedVersion, err := parseIfMatch(ctx)
+		if err != nil {
+			serviceErrorResponse(ctx, err, "Invalid If-Match")
+			return
+		}
+		var req struct {
+			Reason string `json:"reason"`
+		}
+		if err := json.Unmarshal(ctx.PostBody(), &req); err != nil {
+			httpErrorResponse(ctx, fasthttp.StatusBadRequest, "Invalid request body")
+			return
+		}
+		if err := hb.srv.SrvRejectGoodCard(moderatorFromCtx(ctx), id, expectedVersion, req.Reason); err != nil {
+			serviceErrorResponse(ctx, err, "Failed to reject good card")
+			return
+		}
+		ctx.SetStatusCode(fasthttp.StatusNoContent)
+	}, "HandleRejectGoodCard")
+}
@@ -0,0 +1,141 @@
+package transport
+
+import (
+	"encoding/json"
+	myErrors "goods/internal/errors"
+	"goods/internal/models"
+	"goods/pkg/money"
+	"mime"
+
+	"github.com/google/uuid"
+	"github.com/valyala/fasthttp"
+)
+
+// Тип содержимого документа JSON Merge Patch (RFC 7396)
+const mergePatchContentType = "application/merge-patch+json"
+
+// parseMergePatch разбирает документ merge patch: изменяются только присутствующие поля,
+// null очищает необязательное поле
+func parseMergePatch(body []byte) (models.GoodCardPatch, error) {
+	var patch models.GoodCardPatch
+
+	var doc map[string]json.RawMessage
+	if err := json.Unmarshal(body, &doc); err != nil || doc == nil {
+		return patch, myErrors.ErrInvalidMergePatch
+	}
+
+	for field, raw := range doc {
+		isNull := string(raw) == "null"
+		var err error
+		switch field {
+		case "description":
+			// Описание необязательно: null очищает его
+			description := ""
+			if !isNull {
+				err = json.Unmarshal(raw, &description)
+			}
+			patch.Description = &description
+		case "name":
+			if isNull {
+				return patch, myErrors.ValidationError(field, "is required and cannot be null")
+			}
+			patch.Name = new(string)
+			err = json.Unmarshal(raw, patch.Name)
+		case "price":
+			if isNull {
+				return patch, myErrors.ValidationError(field, "is required and cannot be null")
+			}
+			patch.Price = new(money.Money)
+			err = json.Unmarshal(raw, patch.Price)
+		case "weight":
+			if isNull {
+				return patch, myErrors.ValidationError(field, "is required and cannot be null")
+			}
+			patch.Weight = new(float64)
+			err = json.Unmarshal(raw, patch.Weight)
+		case "isActive":
+			if isNull {
+				return patch, myErrors.ValidationError(field, "is required and cannot be null")
+			}
+			patch.IsActive = new(bool)
+			err = json.Unmarshal(raw, patch.IsActive)
+		case "variantAxes":
+			// null снимает оси вариантов
+			axes := []string{}
+			if !isNull {
+				err = json.Unmarshal(raw, &axes)
+			}
+			patch.VariantAxes = &axes
+		case "attributes":
+			// null удаляет все значения атрибутов
+			attributes := []models.AttributeValue{}
+			if !isNull {
+				err = json.Unmarshal(raw, &attributes)
+			}
+			patch.Attributes = &attributes
+		case "categoryId", "brandId":
+			// null убирает категорию или бренд
+			id := uuid.Nil
+			if !isNull {
+				err = json.Unmarshal(raw, &id)
+			}
+			if field == "categoryId" {
+				patch.CategoryID = &id
+			} else {
+				patch.BrandID = &id
+			}
+		case "uuid", "sellerId", "version", "effectivePrice", "displayPrice", "lowStockThreshold":
+			return patch, myErrors.ValidationError(field, "is read-only")
+		default:
+			return patch, myErrors.ValidationError(field, "unknown field")
+		}
+		if err != nil {
+			return patch, myErrors.ValidationError(field, "has wrong type")
+		}
+	}
+	return patch, nil
+}
+
+func (hb *HandlersBuilder) HandlePatchGoodCard() func(ctx *fasthttp.RequestCtx) {
+	return metrics(func(ctx *fasthttp.RequestCtx) {
+		if !ctx.IsPatch() {
+			httpErrorResponse(ctx, fasthttp.StatusMethodNotAllowed, "Method not allowed")
+			return
+		}
+
+		mediaType, _, err := mime.ParseMediaType(string(ctx.Request.Header.ContentType()))
+		if err != nil || (mediaType != mergePatchContentType && mediaType != "application/json") {
+			serviceErrorResponse(ctx, myErrors.ErrUnsupportedMediaType, "Unsupported media type")
+			return
+		}
+
+		idStr := ctx.UserValue("id").(string)
+		id, err := uuid.Parse(idStr)
+		if err != nil {
+			httpErrorResponse(ctx, fasthttp.StatusBadRequest, "Invalid UUID")
+			return
+		}
+
+		expectedVersion, err := parseIfMatch(ctx)
+		if err != nil {
+			serviceErrorResponse(ctx, err, "Invalid If-Match")
+			return
+		}
+
+		patch, err := parseMergePatch(ctx.PostBody())
+		if err != nil {
+			serviceErrorResponse(ctx, err, "Invalid merge patch")
+			return
+		}
+
+		version, err := hb.srv.SrvPatchGoodCard(sellerFromCtx(ctx), id, patch, expectedVersion)
+		if err != nil {
+			serviceErrorResponse(ctx, err, "Failed to update good card")
+			return
+		}
+
+		setETag(ctx, version)
+		ctx.SetStatusCode(fasthttp.StatusOK)
+		jsonResponse(ctx, map[string]interface{}{"status": "updated", "version": version})
+	}, "HandlePatchGoodCard")
+}
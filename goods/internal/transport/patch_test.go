@@ -0,0 +1,51 @@
+package transport
+
+import (
+	myErrors "goods/internal/errors"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestParseMergePatch(t *testing.T) {
+	patch, err := parseMergePatch([]byte(`{"name":"Чайник","description":null,"price":{"amount":1000,"currency":"RUB"},"categoryId":null}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if patch.Name == nil || *patch.Name != "Чайник" {
+		t.Fatalf("name = %v, want Чайник", patch.Name)
+	}
+	// null очищает необязательное поле, а не оставляет его без изменений
+	if patch.Description == nil || *patch.Description != "" {
+		t.Fatalf("description = %v, want cleared", patch.Description)
+	}
+	if patch.CategoryID == nil || *patch.CategoryID != uuid.Nil {
+		t.Fatalf("category = %v, want cleared", patch.CategoryID)
+	}
+	if patch.Price == nil || patch.Price.Amount != 1000 {
+		t.Fatalf("price = %v, want 1000", patch.Price)
+	}
+	// Отсутствующие поля не меняются
+	if patch.Weight != nil || patch.IsActive != nil || patch.BrandID != nil || patch.VariantAxes != nil {
+		t.Fatalf("patch = %+v, want absent fields untouched", patch)
+	}
+}
+
+func TestParseMergePatchRejects(t *testing.T) {
+	cases := []struct {
+		body string
+		want error
+	}{
+		{`[1]`, myErrors.ErrInvalidMergePatch},
+		{`null`, myErrors.ErrInvalidMergePatch},
+		{`{"name":null}`, myErrors.ValidationError("name", "is required and cannot be null")},
+		{`{"weight":"heavy"}`, myErrors.ValidationError("weight", "has wrong type")},
+		{`{"version":3}`, myErrors.ValidationError("version", "is read-only")},
+		{`{"colour":"red"}`, myErrors.ValidationError("colour", "unknown field")},
+	}
+	for _, c := range cases {
+		if _, err := parseMergePatch([]byte(c.body)); err != c.want {
+			t.Errorf("%s: err = %v, want %v", c.body, err, c.want)
+		}
+	}
+}
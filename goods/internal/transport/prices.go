@@ -0,0 +1,206 @@
+package transport
+
+import (
+	"encoding/json"
+	"goods/internal/models"
+	"goods/pkg/money"
+	"time"
+
+	"github.com/valyala/fasthttp"
+)
+
+func (hb *HandlersBuilder) HandleCreatePriceSchedule() func(ctx *fasthttp.RequestCtx) {
+	return metrics(func(ctx *fasthttp.RequestCtx) {
+		if !ctx.IsPost() {
+			httpErrorResponse(ctx, fasthttp.StatusMethodNotAllowed, "Method not allowed")
+			return
+		}
+
+		cardID, ok := parseIDParam(ctx, "id")
+		if !ok {
+			return
+		}
+
+		// Задаётся либо новая цена в валюте карточки, либо скидка в процентах от цены карточки
+		var req struct {
+			StartsAt time.Time    `json:"startsAt"`
+			EndsAt   time.Time    `json:"endsAt"`
+			Price    *money.Money `json:"price"`
+			Percent  *float64     `json:"percent"`
+		}
+		if err := json.Unmarshal(ctx.PostBody(), &req); err != nil {
+			httpErrorResponse(ctx, fasthttp.StatusBadRequest, "Invalid request body")
+			return
+		}
+
+		schedule := models.PriceSchedule{StartsAt: req.StartsAt, EndsAt: req.EndsAt, Price: req.Price, Percent: req.Percent}
+		id, err := hb.srv.SrvCreatePriceSchedule(sellerFromCtx(ctx), cardID, schedule)
+		if err != nil {
+			serviceErrorResponse(ctx, err, "Failed to create price schedule")
+			return
+		}
+
+		ctx.SetStatusCode(fasthttp.StatusCreated)
+		jsonResponse(ctx, map[string]string{"id": id.String()})
+	}, "HandleCreatePriceSchedule")
+}
+
+func (hb *HandlersBuilder) HandleListPriceSchedules() func(ctx *fasthttp.RequestCtx) {
+	return metrics(func(ctx *fasthttp.RequestCtx) {
+		if !ctx.IsGet() {
+			httpErrorResponse(ctx, fasthttp.StatusMethodNotAllowed, "Method not allowed")
+			return
+		}
+
+		cardID, ok := parseIDParam(ctx, "id")
+		if !ok {
+			return
+		}
+
+		schedules, err := hb.srv.SrvListPriceSchedules(sellerFromCtx(ctx), cardID)
+		if err != nil {
+			serviceErrorResponse(ctx, err, "Failed to list price schedules")
+			return
+		}
+
+		ctx.SetStatusCode(fasthttp.StatusOK)
+		jsonResponse(ctx, schedules)
+	}, "HandleListPriceSchedules")
+}
+
+func (hb *HandlersBuilder) HandleCancelPriceSchedule() func(ctx *fasthttp.RequestCtx) {
+	return metrics(func(ctx *fasthttp.RequestCtx) {
+		if !ctx.IsDelete() {
+			httpErrorResponse(ctx, fasthttp.StatusMethodNotAllowed, "Method not allowed")
+			return
+		}
+
+		cardID, ok := parseIDParam(ctx, "id")
+		if !ok {
+			return
+		}
+		scheduleID, ok := parseIDParam(ctx, "scheduleId")
+		if !ok {
+			return
+		}
+
+		if err := hb.srv.SrvCancelPriceSchedule(sellerFromCtx(ctx), cardID, scheduleID); err != nil {
+			serviceErrorResponse(ctx, err, "Failed to cancel price schedule")
+			return
+		}
+
+		ctx.SetStatusCode(fasthttp.StatusNoContent)
+	}, "HandleCancelPriceSchedule")
+}
+
+// fillDisplayPrices задаёт товарам цену в валюте ?display_currency=; без параметра ничего не делает.
+// При ошибке отвечает клиенту и возвращает false.
+func (hb *HandlersBuilder) fillDisplayPrices(ctx *fasthttp.RequestCtx, goods []models.Good) bool {
+	currency := string(ctx.QueryArgs().Peek("display_currency"))
+	if currency == "" {
+		return true
+	}
+	if err := hb.srv.SrvFillDisplayPrices(goods, currency); err != nil {
+		serviceErrorResponse(ctx, err, "Failed to convert prices")
+		return false
+	}
+	return true
+}
+
+func (hb *HandlersBuilder) HandleReadCardPrices() func(ctx *fasthttp.RequestCtx) {
+	return metrics(func(ctx *fasthttp.RequestCtx) {
+		if !ctx.IsGet() {
+			httpErrorResponse(ctx, fasthttp.StatusMethodNotAllowed, "Method not allowed")
+			return
+		}
+
+		cardID, ok := parseIDParam(ctx, "id")
+		if !ok {
+			return
+		}
+
+		prices, err := hb.srv.SrvReadCardPrices(sellerFromCtx(ctx), cardID)
+		if err != nil {
+			serviceErrorResponse(ctx, err, "Failed to read prices")
+			return
+		}
+
+		ctx.SetStatusCode(fasthttp.StatusOK)
+		jsonResponse(ctx, map[string]interface{}{"prices": prices})
+	}, "HandleReadCardPrices")
+}
+
+// HandleSetCardPrices заменяет цены карточки в других валютах; пустой список удаляет их
+func (hb *HandlersBuilder) HandleSetCardPrices() func(ctx *fasthttp.RequestCtx) {
+	return metrics(func(ctx *fasthttp.RequestCtx) {
+		if !ctx.IsPut() {
+			httpErrorResponse(ctx, fasthttp.StatusMethodNotAllowed, "Method not allowed")
+			return
+		}
+
+		cardID, ok := parseIDParam(ctx, "id")
+		if !ok {
+			return
+		}
+
+		var req struct {
+			Prices []money.Money `json:"prices"`
+		}
+		if err := json.Unmarshal(ctx.PostBody(), &req); err != nil {
+			httpErrorResponse(ctx, fasthttp.StatusBadRequest, "Invalid request body")
+			return
+		}
+
+		if err := hb.srv.SrvSetCardPrices(sellerFromCtx(ctx), cardID, req.Prices); err != nil {
+			serviceErrorResponse(ctx, err, "Failed to update prices")
+			return
+		}
+
+		ctx.SetStatusCode(fasthttp.StatusOK)
+		jsonResponse(ctx, map[string]string{"status": "updated"})
+	}, "HandleSetCardPrices")
+}
+
+func (hb *HandlersBuilder) HandleListExchangeRates() func(ctx *fasthttp.RequestCtx) {
+	return metrics(func(ctx *fasthttp.RequestCtx) {
+		if !ctx.IsGet() {
+			httpErrorResponse(ctx, fasthttp.StatusMethodNotAllowed, "Method not allowed")
+			return
+		}
+
+		rates, err := hb.srv.SrvListExchangeRates()
+		if err != nil {
+			serviceErrorResponse(ctx, err, "Failed to list exchange rates")
+			return
+		}
+
+		ctx.SetStatusCode(fasthttp.StatusOK)
+		jsonResponse(ctx, map[string]interface{}{"rates": rates})
+	}, "HandleListExchangeRates")
+}
+
+// HandleSetExchangeRates добавляет или обновляет курсы переданных пар валют
+func (hb *HandlersBuilder) HandleSetExchangeRates() func(ctx *fasthttp.RequestCtx) {
+	return metrics(func(ctx *fasthttp.RequestCtx) {
+		if !ctx.IsPut() {
+			httpErrorResponse(ctx, fasthttp.StatusMethodNotAllowed, "Method not allowed")
+			return
+		}
+
+		var req struct {
+			Rates []models.ExchangeRate `json:"rates"`
+		}
+		if err := json.Unmarshal(ctx.PostBody(), &req); err != nil {
+			httpErrorResponse(ctx, fasthttp.StatusBadRequest, "Invalid request body")
+			return
+		}
+
+		if err := hb.srv.SrvSetExchangeRates(req.Rates); err != nil {
+			serviceErrorResponse(ctx, err, "Failed to update exchange rates")
+			return
+		}
+
+		ctx.SetStatusCode(fasthttp.StatusOK)
+		jsonResponse(ctx, map[string]string{"status": "updated"})
+	}, "HandleSetExchangeRates")
+}
@@ -0,0 +1,92 @@
+package transport
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/valyala/fasthttp"
+)
+
+func (hb *HandlersBuilder) HandleReserveGood() func(ctx *fasthttp.RequestCtx) {
+	return metrics(func(ctx *fasthttp.RequestCtx) {
+		if !ctx.IsPost() {
+			httpErrorResponse(ctx, fasthttp.StatusMethodNotAllowed, "Method not allowed")
+			return
+		}
+
+		idStr := ctx.UserValue("id").(string)
+		id, err := uuid.Parse(idStr)
+		if err != nil {
+			httpErrorResponse(ctx, fasthttp.StatusBadRequest, "Invalid UUID")
+			return
+		}
+
+		var req struct {
+			Number      int       `json:"number"`
+			TTLSeconds  int       `json:"ttl_seconds"`  // Необязательно, 0 - срок по умолчанию
+			WarehouseID uuid.UUID `json:"warehouse_id"` // Необязательно, предпочтительный склад
+		}
+		if err := json.Unmarshal(ctx.PostBody(), &req); err != nil || req.Number <= 0 || req.TTLSeconds < 0 {
+			httpErrorResponse(ctx, fasthttp.StatusBadRequest, "Invalid request body")
+			return
+		}
+
+		reservation, err := hb.srv.SrvReserveGood(id, req.WarehouseID, req.Number, time.Duration(req.TTLSeconds)*time.Second, movementMeta(ctx))
+		if err != nil {
+			serviceErrorResponse(ctx, err, "Failed to reserve good")
+			return
+		}
+
+		ctx.SetStatusCode(fasthttp.StatusCreated)
+		jsonResponse(ctx, reservation)
+	}, "HandleReserveGood")
+}
+
+func (hb *HandlersBuilder) HandleCommitReservation() func(ctx *fasthttp.RequestCtx) {
+	return metrics(func(ctx *fasthttp.RequestCtx) {
+		if !ctx.IsPost() {
+			httpErrorResponse(ctx, fasthttp.StatusMethodNotAllowed, "Method not allowed")
+			return
+		}
+
+		idStr := ctx.UserValue("id").(string)
+		id, err := uuid.Parse(idStr)
+		if err != nil {
+			httpErrorResponse(ctx, fasthttp.StatusBadRequest, "Invalid UUID")
+			return
+		}
+
+		if err := hb.srv.SrvCommitReservation(id, movementMeta(ctx)); err != nil {
+			serviceErrorResponse(ctx, err, "Failed to commit reservation")
+			return
+		}
+
+		ctx.SetStatusCode(fasthttp.StatusOK)
+		jsonResponse(ctx, map[string]string{"status": "committed"})
+	}, "HandleCommitReservation")
+}
+
+func (hb *HandlersBuilder) HandleReleaseReservation() func(ctx *fasthttp.RequestCtx) {
+	return metrics(func(ctx *fasthttp.RequestCtx) {
+		if !ctx.IsPost() {
+			httpErrorResponse(ctx, fasthttp.StatusMethodNotAllowed, "Method not allowed")
+			return
+		}
+
+		idStr := ctx.UserValue("id").(string)
+		id, err := uuid.Parse(idStr)
+		if err != nil {
+			httpErrorResponse(ctx, fasthttp.StatusBadRequest, "Invalid UUID")
+			return
+		}
+
+		if err := hb.srv.SrvReleaseReservation(id, movementMeta(ctx)); err != nil {
+			serviceErrorResponse(ctx, err, "Failed to release reservation")
+			return
+		}
+
+		ctx.SetStatusCode(fasthttp.StatusOK)
+		jsonResponse(ctx, map[string]string{"status": "released"})
+	}, "HandleReleaseReservation")
+}
@@ -0,0 +1,95 @@
+package transport
+
+import (
+	myErrors "goods/internal/errors"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/valyala/fasthttp"
+)
+
+// setETag выставляет ETag карточки - её версию
+func setETag(ctx *fasthttp.RequestCtx, version int) {
+	ctx.Response.Header.Set(fasthttp.HeaderETag, `"`+strconv.Itoa(version)+`"`)
+}
+
+// parseIfMatch возвращает версию из обязательного заголовка If-Match.
+// "*" означает любую версию и возвращается как 0.
+func parseIfMatch(ctx *fasthttp.RequestCtx) (int, error) {
+	raw := strings.TrimSpace(string(ctx.Request.Header.Peek(fasthttp.HeaderIfMatch)))
+	if raw == "" {
+		return 0, myErrors.ErrPreconditionRequired
+	}
+	if raw == "*" {
+		return 0, nil
+	}
+	version, err := strconv.Atoi(strings.Trim(raw, `"`))
+	if err != nil || version <= 0 {
+		return 0, myErrors.ErrInvalidIfMatch
+	}
+	return version, nil
+}
+
+func (hb *HandlersBuilder) HandleListRevisions() func(ctx *fasthttp.RequestCtx) {
+	return metrics(func(ctx *fasthttp.RequestCtx) {
+		if !ctx.IsGet() {
+			httpErrorResponse(ctx, fasthttp.StatusMethodNotAllowed, "Method not allowed")
+			return
+		}
+
+		idStr := ctx.UserValue("id").(string)
+		id, err := uuid.Parse(idStr)
+		if err != nil {
+			httpErrorResponse(ctx, fasthttp.StatusBadRequest, "Invalid UUID")
+			return
+		}
+
+		revisions, err := hb.srv.SrvListRevisions(sellerFromCtx(ctx), id)
+		if err != nil {
+			serviceErrorResponse(ctx, err, "Failed to list revisions")
+			return
+		}
+
+		ctx.SetStatusCode(fasthttp.StatusOK)
+		jsonResponse(ctx, revisions)
+	}, "HandleListRevisions")
+}
+
+func (hb *HandlersBuilder) HandleRestoreRevision() func(ctx *fasthttp.RequestCtx) {
+	return metrics(func(ctx *fasthttp.RequestCtx) {
+		if !ctx.IsPost() {
+			httpErrorResponse(ctx, fasthttp.StatusMethodNotAllowed, "Method not allowed")
+			return
+		}
+
+		idStr := ctx.UserValue("id").(string)
+		id, err := uuid.Parse(idStr)
+		if err != nil {
+			httpErrorResponse(ctx, fasthttp.StatusBadRequest, "Invalid UUID")
+			return
+		}
+
+		version, err := strconv.Atoi(ctx.UserValue("version").(string))
+		if err != nil || version <= 0 {
+			httpErrorResponse(ctx, fasthttp.StatusBadRequest, "Invalid version")
+			return
+		}
+
+		expectedVersion, err := parseIfMatch(ctx)
+		if err != nil {
+			serviceErrorResponse(ctx, err, "Invalid If-Match")
+			return
+		}
+
+		newVersion, err := hb.srv.SrvRestoreRevision(sellerFromCtx(ctx), id, version, expectedVersion)
+		if err != nil {
+			serviceErrorResponse(ctx, err, "Failed to restore revision")
+			return
+		}
+
+		setETag(ctx, newVersion)
+		ctx.SetStatusCode(fasthttp.StatusOK)
+		jsonResponse(ctx, map[string]interface{}{"status": "restored", "version": newVersion})
+	}, "HandleRestoreRevision")
+}
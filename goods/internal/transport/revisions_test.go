@@ -0,0 +1,41 @@
+package transport
+
+import (
+	myErrors "goods/internal/errors"
+	"testing"
+
+	"github.com/valyala/fasthttp"
+)
+
+func TestParseIfMatch(t *testing.T) {
+	cases := []struct {
+		header  string
+		version int
+		err     error
+	}{
+		{"", 0, myErrors.ErrPreconditionRequired},
+		{"*", 0, nil},
+		{`"3"`, 3, nil},
+		{"3", 3, nil},
+		{`"0"`, 0, myErrors.ErrInvalidIfMatch},
+		{`W/"3"`, 0, myErrors.ErrInvalidIfMatch},
+	}
+	for _, c := range cases {
+		var ctx fasthttp.RequestCtx
+		if c.header != "" {
+			ctx.Request.Header.Set(fasthttp.HeaderIfMatch, c.header)
+		}
+		version, err := parseIfMatch(&ctx)
+		if version != c.version || err != c.err {
+			t.Errorf("If-Match %q: got %d, %v, want %d, %v", c.header, version, err, c.version, c.err)
+		}
+	}
+}
+
+func TestSetETagQuotesVersion(t *testing.T) {
+	var ctx fasthttp.RequestCtx
+	setETag(&ctx, 7)
+	if etag := string(ctx.Response.Header.Peek(fasthttp.HeaderETag)); etag != `"7"` {
+		t.Fatalf("ETag = %s, want \"7\"", etag)
+	}
+}
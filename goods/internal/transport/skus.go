@@ -0,0 +1,135 @@
+package transport
+
+import (
+	"encoding/json"
+	"goods/internal/models"
+	"goods/pkg/money"
+
+	"github.com/google/uuid"
+	"github.com/valyala/fasthttp"
+)
+
+func (hb *HandlersBuilder) HandleReadGoodCard() func(ctx *fasthttp.RequestCtx) {
+	return metrics(func(ctx *fasthttp.RequestCtx) {
+		if !ctx.IsGet() {
+			httpErrorResponse(ctx, fasthttp.StatusMethodNotAllowed, "Method not allowed")
+			return
+		}
+
+		idStr := ctx.UserValue("id").(string)
+		id, err := uuid.Parse(idStr)
+		if err != nil {
+			httpErrorResponse(ctx, fasthttp.StatusBadRequest, "Invalid UUID")
+			return
+		}
+
+		good, err := hb.srv.SrvReadGoodCard(sellerFromCtx(ctx), id)
+		if err != nil {
+			serviceErrorResponse(ctx, err, "Failed to read good card")
+			return
+		}
+		goods := []models.Good{good}
+		if !hb.fillDisplayPrices(ctx, goods) {
+			return
+		}
+		good = goods[0]
+
+		setETag(ctx, good.Card.Version)
+		ctx.SetStatusCode(fasthttp.StatusOK)
+		jsonResponse(ctx, good)
+	}, "HandleReadGoodCard")
+}
+
+func (hb *HandlersBuilder) HandleCreateSKU() func(ctx *fasthttp.RequestCtx) {
+	return metrics(func(ctx *fasthttp.RequestCtx) {
+		if !ctx.IsPost() {
+			httpErrorResponse(ctx, fasthttp.StatusMethodNotAllowed, "Method not allowed")
+			return
+		}
+
+		idStr := ctx.UserValue("id").(string)
+		cardID, err := uuid.Parse(idStr)
+		if err != nil {
+			httpErrorResponse(ctx, fasthttp.StatusBadRequest, "Invalid UUID")
+			return
+		}
+
+		var req struct {
+			Attributes map[string]string `json:"attributes"`
+			Price      *money.Money      `json:"price"`
+			Weight     *float64          `json:"weight"`
+			Quantity   int               `json:"quantity"`
+		}
+		if err := json.Unmarshal(ctx.PostBody(), &req); err != nil {
+			httpErrorResponse(ctx, fasthttp.StatusBadRequest, "Invalid request body")
+			return
+		}
+
+		sku := models.SKU{Attributes: req.Attributes, Price: req.Price, Weight: req.Weight}
+		id, err := hb.srv.SrvCreateSKU(sellerFromCtx(ctx), cardID, sku, req.Quantity, movementMeta(ctx))
+		if err != nil {
+			serviceErrorResponse(ctx, err, "Failed to create SKU")
+			return
+		}
+
+		ctx.SetStatusCode(fasthttp.StatusCreated)
+		jsonResponse(ctx, map[string]string{"id": id.String()})
+	}, "HandleCreateSKU")
+}
+
+func (hb *HandlersBuilder) HandleUpdateSKU() func(ctx *fasthttp.RequestCtx) {
+	return metrics(func(ctx *fasthttp.RequestCtx) {
+		if !ctx.IsPut() {
+			httpErrorResponse(ctx, fasthttp.StatusMethodNotAllowed, "Method not allowed")
+			return
+		}
+
+		idStr := ctx.UserValue("id").(string)
+		id, err := uuid.Parse(idStr)
+		if err != nil {
+			httpErrorResponse(ctx, fasthttp.StatusBadRequest, "Invalid UUID")
+			return
+		}
+
+		// Отсутствующее или null поле означает значение карточки
+		var req struct {
+			Price  *money.Money `json:"price"`
+			Weight *float64     `json:"weight"`
+		}
+		if err := json.Unmarshal(ctx.PostBody(), &req); err != nil {
+			httpErrorResponse(ctx, fasthttp.StatusBadRequest, "Invalid request body")
+			return
+		}
+
+		if err := hb.srv.SrvUpdateSKU(sellerFromCtx(ctx), id, req.Price, req.Weight); err != nil {
+			serviceErrorResponse(ctx, err, "Failed to update SKU")
+			return
+		}
+
+		ctx.SetStatusCode(fasthttp.StatusOK)
+		jsonResponse(ctx, map[string]string{"status": "updated"})
+	}, "HandleUpdateSKU")
+}
+
+func (hb *HandlersBuilder) HandleDeleteSKU() func(ctx *fasthttp.RequestCtx) {
+	return metrics(func(ctx *fasthttp.RequestCtx) {
+		if !ctx.IsDelete() {
+			httpErrorResponse(ctx, fasthttp.StatusMethodNotAllowed, "Method not allowed")
+			return
+		}
+
+		idStr := ctx.UserValue("id").(string)
+		id, err := uuid.Parse(idStr)
+		if err != nil {
+			httpErrorResponse(ctx, fasthttp.StatusBadRequest, "Invalid UUID")
+			return
+		}
+
+		if err := hb.srv.SrvDeleteGood(sellerFromCtx(ctx), id); err != nil {
+			serviceErrorResponse(ctx, err, "Failed to delete SKU")
+			return
+		}
+
+		ctx.SetStatusCode(fasthttp.StatusNoContent)
+	}, "HandleDeleteSKU")
+}
@@ -0,0 +1,57 @@
+package transport
+
+import (
+	"encoding/json"
+	"errors"
+	myErrors "goods/internal/errors"
+	"goods/internal/models"
+
+	"github.com/valyala/fasthttp"
+)
+
+// HandleAdjustStock применяет пакет операций с остатком: либо все, либо ни одной.
+// При ошибке отвечает кодом первой неудачной операции, её номером и UUID товара.
+func (hb *HandlersBuilder) HandleAdjustStock() func(ctx *fasthttp.RequestCtx) {
+	return metrics(func(ctx *fasthttp.RequestCtx) {
+		if !ctx.IsPost() {
+			httpErrorResponse(ctx, fasthttp.StatusMethodNotAllowed, "Method not allowed")
+			return
+		}
+
+		var req struct {
+			Items []models.StockAdjustment `json:"items"`
+		}
+		if err := json.Unmarshal(ctx.PostBody(), &req); err != nil {
+			httpErrorResponse(ctx, fasthttp.StatusBadRequest, "Invalid request body")
+			return
+		}
+
+		results, err := hb.srv.SrvAdjustStock(sellerFromCtx(ctx), req.Items, movementMeta(ctx))
+		var itemErr myErrors.ItemError
+		if errors.As(err, &itemErr) {
+			itemErrorResponse(ctx, itemErr, req.Items[itemErr.Index])
+			return
+		}
+		if err != nil {
+			serviceErrorResponse(ctx, err, "Failed to adjust stock")
+			return
+		}
+
+		ctx.SetStatusCode(fasthttp.StatusOK)
+		jsonResponse(ctx, map[string]interface{}{"items": results})
+	}, "HandleAdjustStock")
+}
+
+func itemErrorResponse(ctx *fasthttp.RequestCtx, itemErr myErrors.ItemError, item models.StockAdjustment) {
+	code, cause := fasthttp.StatusInternalServerError, "Failed to adjust stock"
+	var myErr myErrors.Error
+	if errors.As(itemErr.Err, &myErr) {
+		code, cause = myErr.GetHttpCode(), myErr.GetCause()
+	}
+	ctx.SetStatusCode(code)
+	jsonResponse(ctx, map[string]interface{}{
+		"error":   cause,
+		"index":   itemErr.Index,
+		"good_id": item.GoodID,
+	})
+}
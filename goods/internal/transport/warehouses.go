@@ -0,0 +1,106 @@
+package transport
+
+import (
+	"encoding/json"
+	"goods/internal/models"
+
+	"github.com/valyala/fasthttp"
+)
+
+type warehouseRequest struct {
+	Name     string `json:"name"`
+	Priority int    `json:"priority"` // Меньше - раньше выбирается для списания
+}
+
+func (hb *HandlersBuilder) HandleCreateWarehouse() func(ctx *fasthttp.RequestCtx) {
+	return metrics(func(ctx *fasthttp.RequestCtx) {
+		if !ctx.IsPost() {
+			httpErrorResponse(ctx, fasthttp.StatusMethodNotAllowed, "Method not allowed")
+			return
+		}
+
+		var req warehouseRequest
+		if err := json.Unmarshal(ctx.PostBody(), &req); err != nil {
+			httpErrorResponse(ctx, fasthttp.StatusBadRequest, "Invalid request body")
+			return
+		}
+
+		warehouse := models.Warehouse{Name: req.Name, Priority: req.Priority}
+		id, err := hb.srv.SrvCreateWarehouse(sellerFromCtx(ctx), warehouse)
+		if err != nil {
+			serviceErrorResponse(ctx, err, "Failed to create warehouse")
+			return
+		}
+
+		ctx.SetStatusCode(fasthttp.StatusCreated)
+		jsonResponse(ctx, map[string]string{"id": id.String()})
+	}, "HandleCreateWarehouse")
+}
+
+func (hb *HandlersBuilder) HandleListWarehouses() func(ctx *fasthttp.RequestCtx) {
+	return metrics(func(ctx *fasthttp.RequestCtx) {
+		if !ctx.IsGet() {
+			httpErrorResponse(ctx, fasthttp.StatusMethodNotAllowed, "Method not allowed")
+			return
+		}
+
+		warehouses, err := hb.srv.SrvListWarehouses(sellerFromCtx(ctx))
+		if err != nil {
+			serviceErrorResponse(ctx, err, "Failed to list warehouses")
+			return
+		}
+
+		ctx.SetStatusCode(fasthttp.StatusOK)
+		jsonResponse(ctx, warehouses)
+	}, "HandleListWarehouses")
+}
+
+func (hb *HandlersBuilder) HandleUpdateWarehouse() func(ctx *fasthttp.RequestCtx) {
+	return metrics(func(ctx *fasthttp.RequestCtx) {
+		if !ctx.IsPut() {
+			httpErrorResponse(ctx, fasthttp.StatusMethodNotAllowed, "Method not allowed")
+			return
+		}
+
+		id, ok := parseIDParam(ctx, "id")
+		if !ok {
+			return
+		}
+
+		var req warehouseRequest
+		if err := json.Unmarshal(ctx.PostBody(), &req); err != nil {
+			httpErrorResponse(ctx, fasthttp.StatusBadRequest, "Invalid request body")
+			return
+		}
+
+		warehouse := models.Warehouse{UUID: id, Name: req.Name, Priority: req.Priority}
+		if err := hb.srv.SrvUpdateWarehouse(sellerFromCtx(ctx), warehouse); err != nil {
+			serviceErrorResponse(ctx, err, "Failed to update warehouse")
+			return
+		}
+
+		ctx.SetStatusCode(fasthttp.StatusOK)
+		jsonResponse(ctx, map[string]string{"status": "updated"})
+	}, "HandleUpdateWarehouse")
+}
+
+func (hb *HandlersBuilder) HandleDeleteWarehouse() func(ctx *fasthttp.RequestCtx) {
+	return metrics(func(ctx *fasthttp.RequestCtx) {
+		if !ctx.IsDelete() {
+			httpErrorResponse(ctx, fasthttp.StatusMethodNotAllowed, "Method not allowed")
+			return
+		}
+
+		id, ok := parseIDParam(ctx, "id")
+		if !ok {
+			return
+		}
+
+		if err := hb.srv.SrvDeleteWarehouse(sellerFromCtx(ctx), id); err != nil {
+			serviceErrorResponse(ctx, err, "Failed to delete warehouse")
+			return
+		}
+
+		ctx.SetStatusCode(fasthttp.StatusNoContent)
+	}, "HandleDeleteWarehouse")
+}
@@ -0,0 +1,130 @@
+package transport
+
+import (
+	"encoding/json"
+	"strconv"
+
+	"github.com/valyala/fasthttp"
+)
+
+func (hb *HandlersBuilder) HandleSetLowStockThreshold() func(ctx *fasthttp.RequestCtx) {
+	return metrics(func(ctx *fasthttp.RequestCtx) {
+		if !ctx.IsPut() {
+			httpErrorResponse(ctx, fasthttp.StatusMethodNotAllowed, "Method not allowed")
+			return
+		}
+
+		cardID, ok := parseIDParam(ctx, "id")
+		if !ok {
+			return
+		}
+
+		// null отключает оповещение о пороге
+		var req struct {
+			Threshold *int `json:"threshold"`
+		}
+		if err := json.Unmarshal(ctx.PostBody(), &req); err != nil {
+			httpErrorResponse(ctx, fasthttp.StatusBadRequest, "Invalid request body")
+			return
+		}
+
+		if err := hb.srv.SrvSetLowStockThreshold(sellerFromCtx(ctx), cardID, req.Threshold); err != nil {
+			serviceErrorResponse(ctx, err, "Failed to set low-stock threshold")
+			return
+		}
+
+		ctx.SetStatusCode(fasthttp.StatusOK)
+		jsonResponse(ctx, map[string]string{"status": "updated"})
+	}, "HandleSetLowStockThreshold")
+}
+
+// HandleSetWebhook регистрирует адрес оповещений и выдаёт новый ключ подписи
+func (hb *HandlersBuilder) HandleSetWebhook() func(ctx *fasthttp.RequestCtx) {
+	return metrics(func(ctx *fasthttp.RequestCtx) {
+		if !ctx.IsPut() {
+			httpErrorResponse(ctx, fasthttp.StatusMethodNotAllowed, "Method not allowed")
+			return
+		}
+
+		var req struct {
+			URL string `json:"url"`
+		}
+		if err := json.Unmarshal(ctx.PostBody(), &req); err != nil {
+			httpErrorResponse(ctx, fasthttp.StatusBadRequest, "Invalid request body")
+			return
+		}
+
+		webhook, err := hb.srv.SrvSetWebhook(sellerFromCtx(ctx), req.URL)
+		if err != nil {
+			serviceErrorResponse(ctx, err, "Failed to register webhook")
+			return
+		}
+
+		ctx.SetStatusCode(fasthttp.StatusOK)
+		jsonResponse(ctx, webhook)
+	}, "HandleSetWebhook")
+}
+
+func (hb *HandlersBuilder) HandleReadWebhook() func(ctx *fasthttp.RequestCtx) {
+	return metrics(func(ctx *fasthttp.RequestCtx) {
+		if !ctx.IsGet() {
+			httpErrorResponse(ctx, fasthttp.StatusMethodNotAllowed, "Method not allowed")
+			return
+		}
+
+		webhook, err := hb.srv.SrvReadWebhook(sellerFromCtx(ctx))
+		if err != nil {
+			serviceErrorResponse(ctx, err, "Failed to read webhook")
+			return
+		}
+
+		ctx.SetStatusCode(fasthttp.StatusOK)
+		jsonResponse(ctx, webhook)
+	}, "HandleReadWebhook")
+}
+
+func (hb *HandlersBuilder) HandleDeleteWebhook() func(ctx *fasthttp.RequestCtx) {
+	return metrics(func(ctx *fasthttp.RequestCtx) {
+		if !ctx.IsDelete() {
+			httpErrorResponse(ctx, fasthttp.StatusMethodNotAllowed, "Method not allowed")
+			return
+		}
+
+		if err := hb.srv.SrvDeleteWebhook(sellerFromCtx(ctx)); err != nil {
+			serviceErrorResponse(ctx, err, "Failed to delete webhook")
+			return
+		}
+
+		ctx.SetStatusCode(fasthttp.StatusNoContent)
+	}, "HandleDeleteWebhook")
+}
+
+// HandleListWebhookDeliveries отдаёт журнал доставок (?status=, ?limit=, ?cursor=)
+func (hb *HandlersBuilder) HandleListWebhookDeliveries() func(ctx *fasthttp.RequestCtx) {
+	return metrics(func(ctx *fasthttp.RequestCtx) {
+		if !ctx.IsGet() {
+			httpErrorResponse(ctx, fasthttp.StatusMethodNotAllowed, "Method not allowed")
+			return
+		}
+
+		limit := 0
+		if v := ctx.QueryArgs().Peek("limit"); len(v) > 0 {
+			var err error
+			limit, err = strconv.Atoi(string(v))
+			if err != nil || limit <= 0 {
+				httpErrorResponse(ctx, fasthttp.StatusBadRequest, "Invalid limit")
+				return
+			}
+		}
+
+		args := ctx.QueryArgs()
+		page, err := hb.srv.SrvListWebhookDeliveries(sellerFromCtx(ctx), string(args.Peek("status")), limit, string(args.Peek("cursor")))
+		if err != nil {
+			serviceErrorResponse(ctx, err, "Failed to list webhook deliveries")
+			return
+		}
+
+		ctx.SetStatusCode(fasthttp.StatusOK)
+		jsonResponse(ctx, page)
+	}, "HandleListWebhookDeliveries")
+}
@@ -0,0 +1,34 @@
+// Package goodsv1 - gRPC-клиент и сообщения API сервиса товаров.
+// goods.pb.go и goods_grpc.pb.go сгенерированы из api/goods/v1/goods.proto.
+package goodsv1
+
+//go:generate protoc -I ../../api --go_out=../.. --go_opt=module=goods --go-grpc_out=../.. --go-grpc_opt=module=goods goods/v1/goods.proto
+
+import (
+	"context"
+
+	"google.golang.org/grpc/metadata"
+)
+
+// Ключи метаданных запроса; значения совпадают с HTTP-заголовками сервиса
+const (
+	MetadataSupplierID    = "x-supplier-id"
+	MetadataActor         = "x-actor"
+	MetadataCorrelationID = "x-correlation-id"
+	MetadataServiceToken  = "x-service-token"
+)
+
+// WithServiceToken добавляет к исходящему запросу секрет внутренних сервисов; без него сервер отклоняет вызов
+func WithServiceToken(ctx context.Context, token string) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, MetadataServiceToken, token)
+}
+
+// WithSupplier добавляет к исходящему запросу продавца, от имени которого он выполняется
+func WithSupplier(ctx context.Context, supplierID string) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, MetadataSupplierID, supplierID)
+}
+
+// WithMovementMeta добавляет к исходящему запросу исполнителя и идентификатор запроса для журнала движения
+func WithMovementMeta(ctx context.Context, actor string, correlationID string) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, MetadataActor, actor, MetadataCorrelationID, correlationID)
+}
@@ -0,0 +1,2813 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v5.29.3
+// source: goods/v1/goods.proto
+
+// API сервиса товаров для внутренних вызывающих (заказы, корзина).
+// Каждый вызов несёт общий секрет внутренних сервисов в метаданных x-service-token.
+// Продавец запросов, работающих с его карточками, передаётся в метаданных x-supplier-id,
+// исполнитель и идентификатор запроса для журнала движения - в x-actor и x-correlation-id.
+
+package goodsv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Сумма в минимальных единицах валюты (копейках) и код валюты ISO 4217
+type Money struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Amount        int64                  `protobuf:"varint,1,opt,name=amount,proto3" json:"amount,omitempty"`
+	Currency      string                 `protobuf:"bytes,2,opt,name=currency,proto3" json:"currency,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Money) Reset() {
+	*x = Money{}
+	mi := &file_goods_v1_goods_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Money) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Money) ProtoMessage() {}
+
+func (x *Money) ProtoReflect() protoreflect.Message {
+	mi := &file_goods_v1_goods_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Money.ProtoReflect.Descriptor instead.
+func (*Money) Descriptor() ([]byte, []int) {
+	return file_goods_v1_goods_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Money) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *Money) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+type GoodCard struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Uuid              string                 `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`
+	Price             *Money                 `protobuf:"bytes,14,opt,name=price,proto3" json:"price,omitempty"`                                         // Валюта цены - валюта карточки, после создания не меняется
+	EffectivePrice    *Money                 `protobuf:"bytes,15,opt,name=effective_price,json=effectivePrice,proto3" json:"effective_price,omitempty"` // Цена с учётом действующего расписания цен
+	Name              string                 `protobuf:"bytes,4,opt,name=name,proto3" json:"name,omitempty"`
+	Description       string                 `protobuf:"bytes,5,opt,name=description,proto3" json:"description,omitempty"`
+	Weight            float64                `protobuf:"fixed64,6,opt,name=weight,proto3" json:"weight,omitempty"`
+	SellerId          string                 `protobuf:"bytes,7,opt,name=seller_id,json=sellerId,proto3" json:"seller_id,omitempty"`
+	IsActive          bool                   `protobuf:"varint,8,opt,name=is_active,json=isActive,proto3" json:"is_active,omitempty"`
+	Version           int32                  `protobuf:"varint,9,opt,name=version,proto3" json:"version,omitempty"`
+	VariantAxes       []string               `protobuf:"bytes,10,rep,name=variant_axes,json=variantAxes,proto3" json:"variant_axes,omitempty"`
+	CategoryId        *string                `protobuf:"bytes,11,opt,name=category_id,json=categoryId,proto3,oneof" json:"category_id,omitempty"`
+	BrandId           *string                `protobuf:"bytes,12,opt,name=brand_id,json=brandId,proto3,oneof" json:"brand_id,omitempty"`
+	LowStockThreshold *int32                 `protobuf:"varint,13,opt,name=low_stock_threshold,json=lowStockThreshold,proto3,oneof" json:"low_stock_threshold,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *GoodCard) Reset() {
+	*x = GoodCard{}
+	mi := &file_goods_v1_goods_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GoodCard) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GoodCard) ProtoMessage() {}
+
+func (x *GoodCard) ProtoReflect() protoreflect.Message {
+	mi := &file_goods_v1_goods_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GoodCard.ProtoReflect.Descriptor instead.
+func (*GoodCard) Descriptor() ([]byte, []int) {
+	return file_goods_v1_goods_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GoodCard) GetUuid() string {
+	if x != nil {
+		return x.Uuid
+	}
+	return ""
+}
+
+func (x *GoodCard) GetPrice() *Money {
+	if x != nil {
+		return x.Price
+	}
+	return nil
+}
+
+func (x *GoodCard) GetEffectivePrice() *Money {
+	if x != nil {
+		return x.EffectivePrice
+	}
+	return nil
+}
+
+func (x *GoodCard) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *GoodCard) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *GoodCard) GetWeight() float64 {
+	if x != nil {
+		return x.Weight
+	}
+	return 0
+}
+
+func (x *GoodCard) GetSellerId() string {
+	if x != nil {
+		return x.SellerId
+	}
+	return ""
+}
+
+func (x *GoodCard) GetIsActive() bool {
+	if x != nil {
+		return x.IsActive
+	}
+	return false
+}
+
+func (x *GoodCard) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *GoodCard) GetVariantAxes() []string {
+	if x != nil {
+		return x.VariantAxes
+	}
+	return nil
+}
+
+func (x *GoodCard) GetCategoryId() string {
+	if x != nil && x.CategoryId != nil {
+		return *x.CategoryId
+	}
+	return ""
+}
+
+func (x *GoodCard) GetBrandId() string {
+	if x != nil && x.BrandId != nil {
+		return *x.BrandId
+	}
+	return ""
+}
+
+func (x *GoodCard) GetLowStockThreshold() int32 {
+	if x != nil && x.LowStockThreshold != nil {
+		return *x.LowStockThreshold
+	}
+	return 0
+}
+
+type WarehouseStock struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WarehouseId   string                 `protobuf:"bytes,1,opt,name=warehouse_id,json=warehouseId,proto3" json:"warehouse_id,omitempty"`
+	Quantity      int32                  `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	Reserved      int32                  `protobuf:"varint,3,opt,name=reserved,proto3" json:"reserved,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WarehouseStock) Reset() {
+	*x = WarehouseStock{}
+	mi := &file_goods_v1_goods_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WarehouseStock) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WarehouseStock) ProtoMessage() {}
+
+func (x *WarehouseStock) ProtoReflect() protoreflect.Message {
+	mi := &file_goods_v1_goods_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WarehouseStock.ProtoReflect.Descriptor instead.
+func (*WarehouseStock) Descriptor() ([]byte, []int) {
+	return file_goods_v1_goods_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *WarehouseStock) GetWarehouseId() string {
+	if x != nil {
+		return x.WarehouseId
+	}
+	return ""
+}
+
+func (x *WarehouseStock) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *WarehouseStock) GetReserved() int32 {
+	if x != nil {
+		return x.Reserved
+	}
+	return 0
+}
+
+type SKU struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Uuid          string                 `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`
+	CardId        string                 `protobuf:"bytes,2,opt,name=card_id,json=cardId,proto3" json:"card_id,omitempty"`
+	Attributes    map[string]string      `protobuf:"bytes,3,rep,name=attributes,proto3" json:"attributes,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Price         *Money                 `protobuf:"bytes,9,opt,name=price,proto3" json:"price,omitempty"`           // В валюте карточки; не задана - цена карточки
+	Weight        *float64               `protobuf:"fixed64,5,opt,name=weight,proto3,oneof" json:"weight,omitempty"` // Не задан - вес карточки
+	Quantity      int32                  `protobuf:"varint,6,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	Reserved      int32                  `protobuf:"varint,7,opt,name=reserved,proto3" json:"reserved,omitempty"`
+	Stock         []*WarehouseStock      `protobuf:"bytes,8,rep,name=stock,proto3" json:"stock,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SKU) Reset() {
+	*x = SKU{}
+	mi := &file_goods_v1_goods_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SKU) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SKU) ProtoMessage() {}
+
+func (x *SKU) ProtoReflect() protoreflect.Message {
+	mi := &file_goods_v1_goods_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SKU.ProtoReflect.Descriptor instead.
+func (*SKU) Descriptor() ([]byte, []int) {
+	return file_goods_v1_goods_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *SKU) GetUuid() string {
+	if x != nil {
+		return x.Uuid
+	}
+	return ""
+}
+
+func (x *SKU) GetCardId() string {
+	if x != nil {
+		return x.CardId
+	}
+	return ""
+}
+
+func (x *SKU) GetAttributes() map[string]string {
+	if x != nil {
+		return x.Attributes
+	}
+	return nil
+}
+
+func (x *SKU) GetPrice() *Money {
+	if x != nil {
+		return x.Price
+	}
+	return nil
+}
+
+func (x *SKU) GetWeight() float64 {
+	if x != nil && x.Weight != nil {
+		return *x.Weight
+	}
+	return 0
+}
+
+func (x *SKU) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *SKU) GetReserved() int32 {
+	if x != nil {
+		return x.Reserved
+	}
+	return 0
+}
+
+func (x *SKU) GetStock() []*WarehouseStock {
+	if x != nil {
+		return x.Stock
+	}
+	return nil
+}
+
+type Good struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Card          *GoodCard              `protobuf:"bytes,1,opt,name=card,proto3" json:"card,omitempty"`
+	Quantity      int32                  `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"` // Доступное количество по всем вариантам
+	Reserved      int32                  `protobuf:"varint,3,opt,name=reserved,proto3" json:"reserved,omitempty"`
+	Skus          []*SKU                 `protobuf:"bytes,4,rep,name=skus,proto3" json:"skus,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Good) Reset() {
+	*x = Good{}
+	mi := &file_goods_v1_goods_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Good) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Good) ProtoMessage() {}
+
+func (x *Good) ProtoReflect() protoreflect.Message {
+	mi := &file_goods_v1_goods_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Good.ProtoReflect.Descriptor instead.
+func (*Good) Descriptor() ([]byte, []int) {
+	return file_goods_v1_goods_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Good) GetCard() *GoodCard {
+	if x != nil {
+		return x.Card
+	}
+	return nil
+}
+
+func (x *Good) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *Good) GetReserved() int32 {
+	if x != nil {
+		return x.Reserved
+	}
+	return 0
+}
+
+func (x *Good) GetSkus() []*SKU {
+	if x != nil {
+		return x.Skus
+	}
+	return nil
+}
+
+type Reservation struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Uuid          string                 `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`
+	SkuId         string                 `protobuf:"bytes,2,opt,name=sku_id,json=skuId,proto3" json:"sku_id,omitempty"`
+	WarehouseId   string                 `protobuf:"bytes,3,opt,name=warehouse_id,json=warehouseId,proto3" json:"warehouse_id,omitempty"`
+	Quantity      int32                  `protobuf:"varint,4,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	Status        string                 `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"`
+	ExpiresAtUnix int64                  `protobuf:"varint,6,opt,name=expires_at_unix,json=expiresAtUnix,proto3" json:"expires_at_unix,omitempty"`
+	CreatedAtUnix int64                  `protobuf:"varint,7,opt,name=created_at_unix,json=createdAtUnix,proto3" json:"created_at_unix,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Reservation) Reset() {
+	*x = Reservation{}
+	mi := &file_goods_v1_goods_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Reservation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Reservation) ProtoMessage() {}
+
+func (x *Reservation) ProtoReflect() protoreflect.Message {
+	mi := &file_goods_v1_goods_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Reservation.ProtoReflect.Descriptor instead.
+func (*Reservation) Descriptor() ([]byte, []int) {
+	return file_goods_v1_goods_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Reservation) GetUuid() string {
+	if x != nil {
+		return x.Uuid
+	}
+	return ""
+}
+
+func (x *Reservation) GetSkuId() string {
+	if x != nil {
+		return x.SkuId
+	}
+	return ""
+}
+
+func (x *Reservation) GetWarehouseId() string {
+	if x != nil {
+		return x.WarehouseId
+	}
+	return ""
+}
+
+func (x *Reservation) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *Reservation) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Reservation) GetExpiresAtUnix() int64 {
+	if x != nil {
+		return x.ExpiresAtUnix
+	}
+	return 0
+}
+
+func (x *Reservation) GetCreatedAtUnix() int64 {
+	if x != nil {
+		return x.CreatedAtUnix
+	}
+	return 0
+}
+
+type CreateGoodCardRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Card          *GoodCard              `protobuf:"bytes,1,opt,name=card,proto3" json:"card,omitempty"` // uuid, seller_id, version и вычисляемые поля игнорируются
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateGoodCardRequest) Reset() {
+	*x = CreateGoodCardRequest{}
+	mi := &file_goods_v1_goods_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateGoodCardRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateGoodCardRequest) ProtoMessage() {}
+
+func (x *CreateGoodCardRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_goods_v1_goods_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateGoodCardRequest.ProtoReflect.Descriptor instead.
+func (*CreateGoodCardRequest) Descriptor() ([]byte, []int) {
+	return file_goods_v1_goods_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *CreateGoodCardRequest) GetCard() *GoodCard {
+	if x != nil {
+		return x.Card
+	}
+	return nil
+}
+
+type CreateGoodCardResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Uuid          string                 `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateGoodCardResponse) Reset() {
+	*x = CreateGoodCardResponse{}
+	mi := &file_goods_v1_goods_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateGoodCardResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateGoodCardResponse) ProtoMessage() {}
+
+func (x *CreateGoodCardResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_goods_v1_goods_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateGoodCardResponse.ProtoReflect.Descriptor instead.
+func (*CreateGoodCardResponse) Descriptor() ([]byte, []int) {
+	return file_goods_v1_goods_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *CreateGoodCardResponse) GetUuid() string {
+	if x != nil {
+		return x.Uuid
+	}
+	return ""
+}
+
+type GetGoodCardRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Uuid          string                 `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetGoodCardRequest) Reset() {
+	*x = GetGoodCardRequest{}
+	mi := &file_goods_v1_goods_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetGoodCardRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetGoodCardRequest) ProtoMessage() {}
+
+func (x *GetGoodCardRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_goods_v1_goods_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetGoodCardRequest.ProtoReflect.Descriptor instead.
+func (*GetGoodCardRequest) Descriptor() ([]byte, []int) {
+	return file_goods_v1_goods_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *GetGoodCardRequest) GetUuid() string {
+	if x != nil {
+		return x.Uuid
+	}
+	return ""
+}
+
+type ListGoodsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	IsActive      *bool                  `protobuf:"varint,1,opt,name=is_active,json=isActive,proto3,oneof" json:"is_active,omitempty"`
+	Currency      string                 `protobuf:"bytes,12,opt,name=currency,proto3" json:"currency,omitempty"`                        // Валюта карточек; обязательна для границ цены
+	MinPrice      *int64                 `protobuf:"varint,13,opt,name=min_price,json=minPrice,proto3,oneof" json:"min_price,omitempty"` // В минимальных единицах currency
+	MaxPrice      *int64                 `protobuf:"varint,14,opt,name=max_price,json=maxPrice,proto3,oneof" json:"max_price,omitempty"`
+	MinWeight     *float64               `protobuf:"fixed64,4,opt,name=min_weight,json=minWeight,proto3,oneof" json:"min_weight,omitempty"`
+	MaxWeight     *float64               `protobuf:"fixed64,5,opt,name=max_weight,json=maxWeight,proto3,oneof" json:"max_weight,omitempty"`
+	NamePrefix    string                 `protobuf:"bytes,6,opt,name=name_prefix,json=namePrefix,proto3" json:"name_prefix,omitempty"`
+	CategoryId    *string                `protobuf:"bytes,7,opt,name=category_id,json=categoryId,proto3,oneof" json:"category_id,omitempty"`
+	BrandId       *string                `protobuf:"bytes,8,opt,name=brand_id,json=brandId,proto3,oneof" json:"brand_id,omitempty"`
+	Sort          string                 `protobuf:"bytes,9,opt,name=sort,proto3" json:"sort,omitempty"` // price_asc, price_desc, name_asc, name_desc; пусто - по uuid
+	Limit         int32                  `protobuf:"varint,10,opt,name=limit,proto3" json:"limit,omitempty"`
+	Cursor        string                 `protobuf:"bytes,11,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListGoodsRequest) Reset() {
+	*x = ListGoodsRequest{}
+	mi := &file_goods_v1_goods_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListGoodsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListGoodsRequest) ProtoMessage() {}
+
+func (x *ListGoodsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_goods_v1_goods_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListGoodsRequest.ProtoReflect.Descriptor instead.
+func (*ListGoodsRequest) Descriptor() ([]byte, []int) {
+	return file_goods_v1_goods_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ListGoodsRequest) GetIsActive() bool {
+	if x != nil && x.IsActive != nil {
+		return *x.IsActive
+	}
+	return false
+}
+
+func (x *ListGoodsRequest) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *ListGoodsRequest) GetMinPrice() int64 {
+	if x != nil && x.MinPrice != nil {
+		return *x.MinPrice
+	}
+	return 0
+}
+
+func (x *ListGoodsRequest) GetMaxPrice() int64 {
+	if x != nil && x.MaxPrice != nil {
+		return *x.MaxPrice
+	}
+	return 0
+}
+
+func (x *ListGoodsRequest) GetMinWeight() float64 {
+	if x != nil && x.MinWeight != nil {
+		return *x.MinWeight
+	}
+	return 0
+}
+
+func (x *ListGoodsRequest) GetMaxWeight() float64 {
+	if x != nil && x.MaxWeight != nil {
+		return *x.MaxWeight
+	}
+	return 0
+}
+
+func (x *ListGoodsRequest) GetNamePrefix() string {
+	if x != nil {
+		return x.NamePrefix
+	}
+	return ""
+}
+
+func (x *ListGoodsRequest) GetCategoryId() string {
+	if x != nil && x.CategoryId != nil {
+		return *x.CategoryId
+	}
+	return ""
+}
+
+func (x *ListGoodsRequest) GetBrandId() string {
+	if x != nil && x.BrandId != nil {
+		return *x.BrandId
+	}
+	return ""
+}
+
+func (x *ListGoodsRequest) GetSort() string {
+	if x != nil {
+		return x.Sort
+	}
+	return ""
+}
+
+func (x *ListGoodsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListGoodsRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+type ListGoodsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Items         []*Good                `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	NextCursor    string                 `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListGoodsResponse) Reset() {
+	*x = ListGoodsResponse{}
+	mi := &file_goods_v1_goods_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListGoodsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListGoodsResponse) ProtoMessage() {}
+
+func (x *ListGoodsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_goods_v1_goods_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListGoodsResponse.ProtoReflect.Descriptor instead.
+func (*ListGoodsResponse) Descriptor() ([]byte, []int) {
+	return file_goods_v1_goods_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ListGoodsResponse) GetItems() []*Good {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *ListGoodsResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+type UpdateGoodCardRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Card            *GoodCard              `protobuf:"bytes,1,opt,name=card,proto3" json:"card,omitempty"`
+	ExpectedVersion int32                  `protobuf:"varint,2,opt,name=expected_version,json=expectedVersion,proto3" json:"expected_version,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *UpdateGoodCardRequest) Reset() {
+	*x = UpdateGoodCardRequest{}
+	mi := &file_goods_v1_goods_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateGoodCardRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateGoodCardRequest) ProtoMessage() {}
+
+func (x *UpdateGoodCardRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_goods_v1_goods_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateGoodCardRequest.ProtoReflect.Descriptor instead.
+func (*UpdateGoodCardRequest) Descriptor() ([]byte, []int) {
+	return file_goods_v1_goods_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *UpdateGoodCardRequest) GetCard() *GoodCard {
+	if x != nil {
+		return x.Card
+	}
+	return nil
+}
+
+func (x *UpdateGoodCardRequest) GetExpectedVersion() int32 {
+	if x != nil {
+		return x.ExpectedVersion
+	}
+	return 0
+}
+
+type UpdateGoodCardResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Version       int32                  `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateGoodCardResponse) Reset() {
+	*x = UpdateGoodCardResponse{}
+	mi := &file_goods_v1_goods_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateGoodCardResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateGoodCardResponse) ProtoMessage() {}
+
+func (x *UpdateGoodCardResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_goods_v1_goods_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateGoodCardResponse.ProtoReflect.Descriptor instead.
+func (*UpdateGoodCardResponse) Descriptor() ([]byte, []int) {
+	return file_goods_v1_goods_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *UpdateGoodCardResponse) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type DeleteGoodCardRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Uuid          string                 `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteGoodCardRequest) Reset() {
+	*x = DeleteGoodCardRequest{}
+	mi := &file_goods_v1_goods_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteGoodCardRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteGoodCardRequest) ProtoMessage() {}
+
+func (x *DeleteGoodCardRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_goods_v1_goods_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteGoodCardRequest.ProtoReflect.Descriptor instead.
+func (*DeleteGoodCardRequest) Descriptor() ([]byte, []int) {
+	return file_goods_v1_goods_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *DeleteGoodCardRequest) GetUuid() string {
+	if x != nil {
+		return x.Uuid
+	}
+	return ""
+}
+
+type DeleteGoodCardResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteGoodCardResponse) Reset() {
+	*x = DeleteGoodCardResponse{}
+	mi := &file_goods_v1_goods_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteGoodCardResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteGoodCardResponse) ProtoMessage() {}
+
+func (x *DeleteGoodCardResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_goods_v1_goods_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteGoodCardResponse.ProtoReflect.Descriptor instead.
+func (*DeleteGoodCardResponse) Descriptor() ([]byte, []int) {
+	return file_goods_v1_goods_proto_rawDescGZIP(), []int{14}
+}
+
+type RestoreGoodCardRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Uuid          string                 `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestoreGoodCardRequest) Reset() {
+	*x = RestoreGoodCardRequest{}
+	mi := &file_goods_v1_goods_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreGoodCardRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreGoodCardRequest) ProtoMessage() {}
+
+func (x *RestoreGoodCardRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_goods_v1_goods_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreGoodCardRequest.ProtoReflect.Descriptor instead.
+func (*RestoreGoodCardRequest) Descriptor() ([]byte, []int) {
+	return file_goods_v1_goods_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *RestoreGoodCardRequest) GetUuid() string {
+	if x != nil {
+		return x.Uuid
+	}
+	return ""
+}
+
+type RestoreGoodCardResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestoreGoodCardResponse) Reset() {
+	*x = RestoreGoodCardResponse{}
+	mi := &file_goods_v1_goods_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreGoodCardResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreGoodCardResponse) ProtoMessage() {}
+
+func (x *RestoreGoodCardResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_goods_v1_goods_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreGoodCardResponse.ProtoReflect.Descriptor instead.
+func (*RestoreGoodCardResponse) Descriptor() ([]byte, []int) {
+	return file_goods_v1_goods_proto_rawDescGZIP(), []int{16}
+}
+
+type BatchGetGoodCardsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Uuids         []string               `protobuf:"bytes,1,rep,name=uuids,proto3" json:"uuids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchGetGoodCardsRequest) Reset() {
+	*x = BatchGetGoodCardsRequest{}
+	mi := &file_goods_v1_goods_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchGetGoodCardsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchGetGoodCardsRequest) ProtoMessage() {}
+
+func (x *BatchGetGoodCardsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_goods_v1_goods_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchGetGoodCardsRequest.ProtoReflect.Descriptor instead.
+func (*BatchGetGoodCardsRequest) Descriptor() ([]byte, []int) {
+	return file_goods_v1_goods_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *BatchGetGoodCardsRequest) GetUuids() []string {
+	if x != nil {
+		return x.Uuids
+	}
+	return nil
+}
+
+type BatchGetGoodCardsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Goods         []*Good                `protobuf:"bytes,1,rep,name=goods,proto3" json:"goods,omitempty"` // В порядке запроса
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchGetGoodCardsResponse) Reset() {
+	*x = BatchGetGoodCardsResponse{}
+	mi := &file_goods_v1_goods_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchGetGoodCardsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchGetGoodCardsResponse) ProtoMessage() {}
+
+func (x *BatchGetGoodCardsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_goods_v1_goods_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchGetGoodCardsResponse.ProtoReflect.Descriptor instead.
+func (*BatchGetGoodCardsResponse) Descriptor() ([]byte, []int) {
+	return file_goods_v1_goods_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *BatchGetGoodCardsResponse) GetGoods() []*Good {
+	if x != nil {
+		return x.Goods
+	}
+	return nil
+}
+
+type ChangeStockRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SkuId         string                 `protobuf:"bytes,1,opt,name=sku_id,json=skuId,proto3" json:"sku_id,omitempty"`
+	WarehouseId   string                 `protobuf:"bytes,2,opt,name=warehouse_id,json=warehouseId,proto3" json:"warehouse_id,omitempty"`
+	Count         int32                  `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChangeStockRequest) Reset() {
+	*x = ChangeStockRequest{}
+	mi := &file_goods_v1_goods_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChangeStockRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChangeStockRequest) ProtoMessage() {}
+
+func (x *ChangeStockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_goods_v1_goods_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChangeStockRequest.ProtoReflect.Descriptor instead.
+func (*ChangeStockRequest) Descriptor() ([]byte, []int) {
+	return file_goods_v1_goods_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ChangeStockRequest) GetSkuId() string {
+	if x != nil {
+		return x.SkuId
+	}
+	return ""
+}
+
+func (x *ChangeStockRequest) GetWarehouseId() string {
+	if x != nil {
+		return x.WarehouseId
+	}
+	return ""
+}
+
+func (x *ChangeStockRequest) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type ChangeStockResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Quantity      int32                  `protobuf:"varint,1,opt,name=quantity,proto3" json:"quantity,omitempty"` // Доступное количество варианта после изменения
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChangeStockResponse) Reset() {
+	*x = ChangeStockResponse{}
+	mi := &file_goods_v1_goods_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChangeStockResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChangeStockResponse) ProtoMessage() {}
+
+func (x *ChangeStockResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_goods_v1_goods_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChangeStockResponse.ProtoReflect.Descriptor instead.
+func (*ChangeStockResponse) Descriptor() ([]byte, []int) {
+	return file_goods_v1_goods_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ChangeStockResponse) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+type StockAdjustment struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SkuId         string                 `protobuf:"bytes,1,opt,name=sku_id,json=skuId,proto3" json:"sku_id,omitempty"`
+	WarehouseId   string                 `protobuf:"bytes,2,opt,name=warehouse_id,json=warehouseId,proto3" json:"warehouse_id,omitempty"` // Пусто - склад выбирается автоматически
+	Delta         int32                  `protobuf:"varint,3,opt,name=delta,proto3" json:"delta,omitempty"`                               // Положительное - пополнение, отрицательное - списание, 0 недопустим
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StockAdjustment) Reset() {
+	*x = StockAdjustment{}
+	mi := &file_goods_v1_goods_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StockAdjustment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StockAdjustment) ProtoMessage() {}
+
+func (x *StockAdjustment) ProtoReflect() protoreflect.Message {
+	mi := &file_goods_v1_goods_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StockAdjustment.ProtoReflect.Descriptor instead.
+func (*StockAdjustment) Descriptor() ([]byte, []int) {
+	return file_goods_v1_goods_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *StockAdjustment) GetSkuId() string {
+	if x != nil {
+		return x.SkuId
+	}
+	return ""
+}
+
+func (x *StockAdjustment) GetWarehouseId() string {
+	if x != nil {
+		return x.WarehouseId
+	}
+	return ""
+}
+
+func (x *StockAdjustment) GetDelta() int32 {
+	if x != nil {
+		return x.Delta
+	}
+	return 0
+}
+
+type AdjustStockRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Items         []*StockAdjustment     `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AdjustStockRequest) Reset() {
+	*x = AdjustStockRequest{}
+	mi := &file_goods_v1_goods_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AdjustStockRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdjustStockRequest) ProtoMessage() {}
+
+func (x *AdjustStockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_goods_v1_goods_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdjustStockRequest.ProtoReflect.Descriptor instead.
+func (*AdjustStockRequest) Descriptor() ([]byte, []int) {
+	return file_goods_v1_goods_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *AdjustStockRequest) GetItems() []*StockAdjustment {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+type StockAdjustmentResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SkuId         string                 `protobuf:"bytes,1,opt,name=sku_id,json=skuId,proto3" json:"sku_id,omitempty"`
+	Quantity      int32                  `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"` // Доступное количество варианта по всем складам после пакета
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StockAdjustmentResult) Reset() {
+	*x = StockAdjustmentResult{}
+	mi := &file_goods_v1_goods_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StockAdjustmentResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StockAdjustmentResult) ProtoMessage() {}
+
+func (x *StockAdjustmentResult) ProtoReflect() protoreflect.Message {
+	mi := &file_goods_v1_goods_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StockAdjustmentResult.ProtoReflect.Descriptor instead.
+func (*StockAdjustmentResult) Descriptor() ([]byte, []int) {
+	return file_goods_v1_goods_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *StockAdjustmentResult) GetSkuId() string {
+	if x != nil {
+		return x.SkuId
+	}
+	return ""
+}
+
+func (x *StockAdjustmentResult) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+type AdjustStockResponse struct {
+	state         protoimpl.MessageState   `protogen:"open.v1"`
+	Results       []*StockAdjustmentResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"` // В порядке операций запроса
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AdjustStockResponse) Reset() {
+	*x = AdjustStockResponse{}
+	mi := &file_goods_v1_goods_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AdjustStockResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdjustStockResponse) ProtoMessage() {}
+
+func (x *AdjustStockResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_goods_v1_goods_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdjustStockResponse.ProtoReflect.Descriptor instead.
+func (*AdjustStockResponse) Descriptor() ([]byte, []int) {
+	return file_goods_v1_goods_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *AdjustStockResponse) GetResults() []*StockAdjustmentResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+type CreateSKURequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CardId        string                 `protobuf:"bytes,1,opt,name=card_id,json=cardId,proto3" json:"card_id,omitempty"`
+	Attributes    map[string]string      `protobuf:"bytes,2,rep,name=attributes,proto3" json:"attributes,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // Значение для каждой оси карточки
+	Price         *Money                 `protobuf:"bytes,3,opt,name=price,proto3" json:"price,omitempty"`                                                                                     // В валюте карточки; не задана - цена карточки
+	Weight        *float64               `protobuf:"fixed64,4,opt,name=weight,proto3,oneof" json:"weight,omitempty"`                                                                           // Не задан - вес карточки
+	Quantity      int32                  `protobuf:"varint,5,opt,name=quantity,proto3" json:"quantity,omitempty"`                                                                              // Начальный остаток на складе по умолчанию
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateSKURequest) Reset() {
+	*x = CreateSKURequest{}
+	mi := &file_goods_v1_goods_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateSKURequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateSKURequest) ProtoMessage() {}
+
+func (x *CreateSKURequest) ProtoReflect() protoreflect.Message {
+	mi := &file_goods_v1_goods_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateSKURequest.ProtoReflect.Descriptor instead.
+func (*CreateSKURequest) Descriptor() ([]byte, []int) {
+	return file_goods_v1_goods_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *CreateSKURequest) GetCardId() string {
+	if x != nil {
+		return x.CardId
+	}
+	return ""
+}
+
+func (x *CreateSKURequest) GetAttributes() map[string]string {
+	if x != nil {
+		return x.Attributes
+	}
+	return nil
+}
+
+func (x *CreateSKURequest) GetPrice() *Money {
+	if x != nil {
+		return x.Price
+	}
+	return nil
+}
+
+func (x *CreateSKURequest) GetWeight() float64 {
+	if x != nil && x.Weight != nil {
+		return *x.Weight
+	}
+	return 0
+}
+
+func (x *CreateSKURequest) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+type CreateSKUResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Uuid          string                 `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateSKUResponse) Reset() {
+	*x = CreateSKUResponse{}
+	mi := &file_goods_v1_goods_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateSKUResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateSKUResponse) ProtoMessage() {}
+
+func (x *CreateSKUResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_goods_v1_goods_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateSKUResponse.ProtoReflect.Descriptor instead.
+func (*CreateSKUResponse) Descriptor() ([]byte, []int) {
+	return file_goods_v1_goods_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *CreateSKUResponse) GetUuid() string {
+	if x != nil {
+		return x.Uuid
+	}
+	return ""
+}
+
+type UpdateSKURequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Uuid          string                 `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`
+	Price         *Money                 `protobuf:"bytes,2,opt,name=price,proto3" json:"price,omitempty"`           // Не задана - цена карточки
+	Weight        *float64               `protobuf:"fixed64,3,opt,name=weight,proto3,oneof" json:"weight,omitempty"` // Не задан - вес карточки
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateSKURequest) Reset() {
+	*x = UpdateSKURequest{}
+	mi := &file_goods_v1_goods_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateSKURequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateSKURequest) ProtoMessage() {}
+
+func (x *UpdateSKURequest) ProtoReflect() protoreflect.Message {
+	mi := &file_goods_v1_goods_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateSKURequest.ProtoReflect.Descriptor instead.
+func (*UpdateSKURequest) Descriptor() ([]byte, []int) {
+	return file_goods_v1_goods_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *UpdateSKURequest) GetUuid() string {
+	if x != nil {
+		return x.Uuid
+	}
+	return ""
+}
+
+func (x *UpdateSKURequest) GetPrice() *Money {
+	if x != nil {
+		return x.Price
+	}
+	return nil
+}
+
+func (x *UpdateSKURequest) GetWeight() float64 {
+	if x != nil && x.Weight != nil {
+		return *x.Weight
+	}
+	return 0
+}
+
+type UpdateSKUResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateSKUResponse) Reset() {
+	*x = UpdateSKUResponse{}
+	mi := &file_goods_v1_goods_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateSKUResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateSKUResponse) ProtoMessage() {}
+
+func (x *UpdateSKUResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_goods_v1_goods_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateSKUResponse.ProtoReflect.Descriptor instead.
+func (*UpdateSKUResponse) Descriptor() ([]byte, []int) {
+	return file_goods_v1_goods_proto_rawDescGZIP(), []int{28}
+}
+
+type DeleteSKURequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Uuid          string                 `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteSKURequest) Reset() {
+	*x = DeleteSKURequest{}
+	mi := &file_goods_v1_goods_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteSKURequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteSKURequest) ProtoMessage() {}
+
+func (x *DeleteSKURequest) ProtoReflect() protoreflect.Message {
+	mi := &file_goods_v1_goods_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteSKURequest.ProtoReflect.Descriptor instead.
+func (*DeleteSKURequest) Descriptor() ([]byte, []int) {
+	return file_goods_v1_goods_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *DeleteSKURequest) GetUuid() string {
+	if x != nil {
+		return x.Uuid
+	}
+	return ""
+}
+
+type DeleteSKUResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteSKUResponse) Reset() {
+	*x = DeleteSKUResponse{}
+	mi := &file_goods_v1_goods_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteSKUResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteSKUResponse) ProtoMessage() {}
+
+func (x *DeleteSKUResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_goods_v1_goods_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteSKUResponse.ProtoReflect.Descriptor instead.
+func (*DeleteSKUResponse) Descriptor() ([]byte, []int) {
+	return file_goods_v1_goods_proto_rawDescGZIP(), []int{30}
+}
+
+type Warehouse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Uuid          string                 `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Priority      int32                  `protobuf:"varint,3,opt,name=priority,proto3" json:"priority,omitempty"` // Меньше - раньше выбирается для списания
+	CreatedAtUnix int64                  `protobuf:"varint,4,opt,name=created_at_unix,json=createdAtUnix,proto3" json:"created_at_unix,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Warehouse) Reset() {
+	*x = Warehouse{}
+	mi := &file_goods_v1_goods_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Warehouse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Warehouse) ProtoMessage() {}
+
+func (x *Warehouse) ProtoReflect() protoreflect.Message {
+	mi := &file_goods_v1_goods_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Warehouse.ProtoReflect.Descriptor instead.
+func (*Warehouse) Descriptor() ([]byte, []int) {
+	return file_goods_v1_goods_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *Warehouse) GetUuid() string {
+	if x != nil {
+		return x.Uuid
+	}
+	return ""
+}
+
+func (x *Warehouse) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Warehouse) GetPriority() int32 {
+	if x != nil {
+		return x.Priority
+	}
+	return 0
+}
+
+func (x *Warehouse) GetCreatedAtUnix() int64 {
+	if x != nil {
+		return x.CreatedAtUnix
+	}
+	return 0
+}
+
+type CreateWarehouseRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Priority      int32                  `protobuf:"varint,2,opt,name=priority,proto3" json:"priority,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateWarehouseRequest) Reset() {
+	*x = CreateWarehouseRequest{}
+	mi := &file_goods_v1_goods_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateWarehouseRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateWarehouseRequest) ProtoMessage() {}
+
+func (x *CreateWarehouseRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_goods_v1_goods_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateWarehouseRequest.ProtoReflect.Descriptor instead.
+func (*CreateWarehouseRequest) Descriptor() ([]byte, []int) {
+	return file_goods_v1_goods_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *CreateWarehouseRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateWarehouseRequest) GetPriority() int32 {
+	if x != nil {
+		return x.Priority
+	}
+	return 0
+}
+
+type CreateWarehouseResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Uuid          string                 `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateWarehouseResponse) Reset() {
+	*x = CreateWarehouseResponse{}
+	mi := &file_goods_v1_goods_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateWarehouseResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateWarehouseResponse) ProtoMessage() {}
+
+func (x *CreateWarehouseResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_goods_v1_goods_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateWarehouseResponse.ProtoReflect.Descriptor instead.
+func (*CreateWarehouseResponse) Descriptor() ([]byte, []int) {
+	return file_goods_v1_goods_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *CreateWarehouseResponse) GetUuid() string {
+	if x != nil {
+		return x.Uuid
+	}
+	return ""
+}
+
+type ListWarehousesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListWarehousesRequest) Reset() {
+	*x = ListWarehousesRequest{}
+	mi := &file_goods_v1_goods_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWarehousesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWarehousesRequest) ProtoMessage() {}
+
+func (x *ListWarehousesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_goods_v1_goods_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWarehousesRequest.ProtoReflect.Descriptor instead.
+func (*ListWarehousesRequest) Descriptor() ([]byte, []int) {
+	return file_goods_v1_goods_proto_rawDescGZIP(), []int{34}
+}
+
+type ListWarehousesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Warehouses    []*Warehouse           `protobuf:"bytes,1,rep,name=warehouses,proto3" json:"warehouses,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListWarehousesResponse) Reset() {
+	*x = ListWarehousesResponse{}
+	mi := &file_goods_v1_goods_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWarehousesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWarehousesResponse) ProtoMessage() {}
+
+func (x *ListWarehousesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_goods_v1_goods_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWarehousesResponse.ProtoReflect.Descriptor instead.
+func (*ListWarehousesResponse) Descriptor() ([]byte, []int) {
+	return file_goods_v1_goods_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *ListWarehousesResponse) GetWarehouses() []*Warehouse {
+	if x != nil {
+		return x.Warehouses
+	}
+	return nil
+}
+
+type UpdateWarehouseRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Uuid          string                 `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Priority      int32                  `protobuf:"varint,3,opt,name=priority,proto3" json:"priority,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateWarehouseRequest) Reset() {
+	*x = UpdateWarehouseRequest{}
+	mi := &file_goods_v1_goods_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateWarehouseRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateWarehouseRequest) ProtoMessage() {}
+
+func (x *UpdateWarehouseRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_goods_v1_goods_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateWarehouseRequest.ProtoReflect.Descriptor instead.
+func (*UpdateWarehouseRequest) Descriptor() ([]byte, []int) {
+	return file_goods_v1_goods_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *UpdateWarehouseRequest) GetUuid() string {
+	if x != nil {
+		return x.Uuid
+	}
+	return ""
+}
+
+func (x *UpdateWarehouseRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *UpdateWarehouseRequest) GetPriority() int32 {
+	if x != nil {
+		return x.Priority
+	}
+	return 0
+}
+
+type UpdateWarehouseResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateWarehouseResponse) Reset() {
+	*x = UpdateWarehouseResponse{}
+	mi := &file_goods_v1_goods_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateWarehouseResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateWarehouseResponse) ProtoMessage() {}
+
+func (x *UpdateWarehouseResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_goods_v1_goods_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateWarehouseResponse.ProtoReflect.Descriptor instead.
+func (*UpdateWarehouseResponse) Descriptor() ([]byte, []int) {
+	return file_goods_v1_goods_proto_rawDescGZIP(), []int{37}
+}
+
+type DeleteWarehouseRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Uuid          string                 `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteWarehouseRequest) Reset() {
+	*x = DeleteWarehouseRequest{}
+	mi := &file_goods_v1_goods_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteWarehouseRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteWarehouseRequest) ProtoMessage() {}
+
+func (x *DeleteWarehouseRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_goods_v1_goods_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteWarehouseRequest.ProtoReflect.Descriptor instead.
+func (*DeleteWarehouseRequest) Descriptor() ([]byte, []int) {
+	return file_goods_v1_goods_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *DeleteWarehouseRequest) GetUuid() string {
+	if x != nil {
+		return x.Uuid
+	}
+	return ""
+}
+
+type DeleteWarehouseResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteWarehouseResponse) Reset() {
+	*x = DeleteWarehouseResponse{}
+	mi := &file_goods_v1_goods_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteWarehouseResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteWarehouseResponse) ProtoMessage() {}
+
+func (x *DeleteWarehouseResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_goods_v1_goods_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteWarehouseResponse.ProtoReflect.Descriptor instead.
+func (*DeleteWarehouseResponse) Descriptor() ([]byte, []int) {
+	return file_goods_v1_goods_proto_rawDescGZIP(), []int{39}
+}
+
+type ReserveStockRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SkuId         string                 `protobuf:"bytes,1,opt,name=sku_id,json=skuId,proto3" json:"sku_id,omitempty"`
+	WarehouseId   string                 `protobuf:"bytes,2,opt,name=warehouse_id,json=warehouseId,proto3" json:"warehouse_id,omitempty"` // Предпочтительный склад; пусто - любой
+	Quantity      int32                  `protobuf:"varint,3,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	TtlSeconds    int64                  `protobuf:"varint,4,opt,name=ttl_seconds,json=ttlSeconds,proto3" json:"ttl_seconds,omitempty"` // 0 - срок по умолчанию
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReserveStockRequest) Reset() {
+	*x = ReserveStockRequest{}
+	mi := &file_goods_v1_goods_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReserveStockRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReserveStockRequest) ProtoMessage() {}
+
+func (x *ReserveStockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_goods_v1_goods_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReserveStockRequest.ProtoReflect.Descriptor instead.
+func (*ReserveStockRequest) Descriptor() ([]byte, []int) {
+	return file_goods_v1_goods_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *ReserveStockRequest) GetSkuId() string {
+	if x != nil {
+		return x.SkuId
+	}
+	return ""
+}
+
+func (x *ReserveStockRequest) GetWarehouseId() string {
+	if x != nil {
+		return x.WarehouseId
+	}
+	return ""
+}
+
+func (x *ReserveStockRequest) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *ReserveStockRequest) GetTtlSeconds() int64 {
+	if x != nil {
+		return x.TtlSeconds
+	}
+	return 0
+}
+
+type CommitReservationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Uuid          string                 `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CommitReservationRequest) Reset() {
+	*x = CommitReservationRequest{}
+	mi := &file_goods_v1_goods_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CommitReservationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CommitReservationRequest) ProtoMessage() {}
+
+func (x *CommitReservationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_goods_v1_goods_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CommitReservationRequest.ProtoReflect.Descriptor instead.
+func (*CommitReservationRequest) Descriptor() ([]byte, []int) {
+	return file_goods_v1_goods_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *CommitReservationRequest) GetUuid() string {
+	if x != nil {
+		return x.Uuid
+	}
+	return ""
+}
+
+type CommitReservationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CommitReservationResponse) Reset() {
+	*x = CommitReservationResponse{}
+	mi := &file_goods_v1_goods_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CommitReservationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CommitReservationResponse) ProtoMessage() {}
+
+func (x *CommitReservationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_goods_v1_goods_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CommitReservationResponse.ProtoReflect.Descriptor instead.
+func (*CommitReservationResponse) Descriptor() ([]byte, []int) {
+	return file_goods_v1_goods_proto_rawDescGZIP(), []int{42}
+}
+
+type ReleaseReservationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Uuid          string                 `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReleaseReservationRequest) Reset() {
+	*x = ReleaseReservationRequest{}
+	mi := &file_goods_v1_goods_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReleaseReservationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReleaseReservationRequest) ProtoMessage() {}
+
+func (x *ReleaseReservationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_goods_v1_goods_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReleaseReservationRequest.ProtoReflect.Descriptor instead.
+func (*ReleaseReservationRequest) Descriptor() ([]byte, []int) {
+	return file_goods_v1_goods_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *ReleaseReservationRequest) GetUuid() string {
+	if x != nil {
+		return x.Uuid
+	}
+	return ""
+}
+
+type ReleaseReservationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReleaseReservationResponse) Reset() {
+	*x = ReleaseReservationResponse{}
+	mi := &file_goods_v1_goods_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReleaseReservationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReleaseReservationResponse) ProtoMessage() {}
+
+func (x *ReleaseReservationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_goods_v1_goods_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReleaseReservationResponse.ProtoReflect.Descriptor instead.
+func (*ReleaseReservationResponse) Descriptor() ([]byte, []int) {
+	return file_goods_v1_goods_proto_rawDescGZIP(), []int{44}
+}
+
+var File_goods_v1_goods_proto protoreflect.FileDescriptor
+
+const file_goods_v1_goods_proto_rawDesc = "" +
+	"\n" +
+	"\x14goods/v1/goods.proto\x12\bgoods.v1\";\n" +
+	"\x05Money\x12\x16\n" +
+	"\x06amount\x18\x01 \x01(\x03R\x06amount\x12\x1a\n" +
+	"\bcurrency\x18\x02 \x01(\tR\bcurrency\"\x80\x04\n" +
+	"\bGoodCard\x12\x12\n" +
+	"\x04uuid\x18\x01 \x01(\tR\x04uuid\x12%\n" +
+	"\x05price\x18\x0e \x01(\v2\x0f.goods.v1.MoneyR\x05price\x128\n" +
+	"\x0feffective_price\x18\x0f \x01(\v2\x0f.goods.v1.MoneyR\x0eeffectivePrice\x12\x12\n" +
+	"\x04name\x18\x04 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x05 \x01(\tR\vdescription\x12\x16\n" +
+	"\x06weight\x18\x06 \x01(\x01R\x06weight\x12\x1b\n" +
+	"\tseller_id\x18\a \x01(\tR\bsellerId\x12\x1b\n" +
+	"\tis_active\x18\b \x01(\bR\bisActive\x12\x18\n" +
+	"\aversion\x18\t \x01(\x05R\aversion\x12!\n" +
+	"\fvariant_axes\x18\n" +
+	" \x03(\tR\vvariantAxes\x12$\n" +
+	"\vcategory_id\x18\v \x01(\tH\x00R\n" +
+	"categoryId\x88\x01\x01\x12\x1e\n" +
+	"\bbrand_id\x18\f \x01(\tH\x01R\abrandId\x88\x01\x01\x123\n" +
+	"\x13low_stock_threshold\x18\r \x01(\x05H\x02R\x11lowStockThreshold\x88\x01\x01B\x0e\n" +
+	"\f_category_idB\v\n" +
+	"\t_brand_idB\x16\n" +
+	"\x14_low_stock_thresholdJ\x04\b\x02\x10\x03J\x04\b\x03\x10\x04\"k\n" +
+	"\x0eWarehouseStock\x12!\n" +
+	"\fwarehouse_id\x18\x01 \x01(\tR\vwarehouseId\x12\x1a\n" +
+	"\bquantity\x18\x02 \x01(\x05R\bquantity\x12\x1a\n" +
+	"\breserved\x18\x03 \x01(\x05R\breserved\"\xed\x02\n" +
+	"\x03SKU\x12\x12\n" +
+	"\x04uuid\x18\x01 \x01(\tR\x04uuid\x12\x17\n" +
+	"\acard_id\x18\x02 \x01(\tR\x06cardId\x12=\n" +
+	"\n" +
+	"attributes\x18\x03 \x03(\v2\x1d.goods.v1.SKU.AttributesEntryR\n" +
+	"attributes\x12%\n" +
+	"\x05price\x18\t \x01(\v2\x0f.goods.v1.MoneyR\x05price\x12\x1b\n" +
+	"\x06weight\x18\x05 \x01(\x01H\x00R\x06weight\x88\x01\x01\x12\x1a\n" +
+	"\bquantity\x18\x06 \x01(\x05R\bquantity\x12\x1a\n" +
+	"\breserved\x18\a \x01(\x05R\breserved\x12.\n" +
+	"\x05stock\x18\b \x03(\v2\x18.goods.v1.WarehouseStockR\x05stock\x1a=\n" +
+	"\x0fAttributesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01B\t\n" +
+	"\a_weightJ\x04\b\x04\x10\x05\"\x89\x01\n" +
+	"\x04Good\x12&\n" +
+	"\x04card\x18\x01 \x01(\v2\x12.goods.v1.GoodCardR\x04card\x12\x1a\n" +
+	"\bquantity\x18\x02 \x01(\x05R\bquantity\x12\x1a\n" +
+	"\breserved\x18\x03 \x01(\x05R\breserved\x12!\n" +
+	"\x04skus\x18\x04 \x03(\v2\r.goods.v1.SKUR\x04skus\"\xdf\x01\n" +
+	"\vReservation\x12\x12\n" +
+	"\x04uuid\x18\x01 \x01(\tR\x04uuid\x12\x15\n" +
+	"\x06sku_id\x18\x02 \x01(\tR\x05skuId\x12!\n" +
+	"\fwarehouse_id\x18\x03 \x01(\tR\vwarehouseId\x12\x1a\n" +
+	"\bquantity\x18\x04 \x01(\x05R\bquantity\x12\x16\n" +
+	"\x06status\x18\x05 \x01(\tR\x06status\x12&\n" +
+	"\x0fexpires_at_unix\x18\x06 \x01(\x03R\rexpiresAtUnix\x12&\n" +
+	"\x0fcreated_at_unix\x18\a \x01(\x03R\rcreatedAtUnix\"?\n" +
+	"\x15CreateGoodCardRequest\x12&\n" +
+	"\x04card\x18\x01 \x01(\v2\x12.goods.v1.GoodCardR\x04card\",\n" +
+	"\x16CreateGoodCardResponse\x12\x12\n" +
+	"\x04uuid\x18\x01 \x01(\tR\x04uuid\"(\n" +
+	"\x12GetGoodCardRequest\x12\x12\n" +
+	"\x04uuid\x18\x01 \x01(\tR\x04uuid\"\xf6\x03\n" +
+	"\x10ListGoodsRequest\x12 \n" +
+	"\tis_active\x18\x01 \x01(\bH\x00R\bisActive\x88\x01\x01\x12\x1a\n" +
+	"\bcurrency\x18\f \x01(\tR\bcurrency\x12 \n" +
+	"\tmin_price\x18\r \x01(\x03H\x01R\bminPrice\x88\x01\x01\x12 \n" +
+	"\tmax_price\x18\x0e \x01(\x03H\x02R\bmaxPrice\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"min_weight\x18\x04 \x01(\x01H\x03R\tminWeight\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"max_weight\x18\x05 \x01(\x01H\x04R\tmaxWeight\x88\x01\x01\x12\x1f\n" +
+	"\vname_prefix\x18\x06 \x01(\tR\n" +
+	"namePrefix\x12$\n" +
+	"\vcategory_id\x18\a \x01(\tH\x05R\n" +
+	"categoryId\x88\x01\x01\x12\x1e\n" +
+	"\bbrand_id\x18\b \x01(\tH\x06R\abrandId\x88\x01\x01\x12\x12\n" +
+	"\x04sort\x18\t \x01(\tR\x04sort\x12\x14\n" +
+	"\x05limit\x18\n" +
+	" \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06cursor\x18\v \x01(\tR\x06cursorB\f\n" +
+	"\n" +
+	"_is_activeB\f\n" +
+	"\n" +
+	"_min_priceB\f\n" +
+	"\n" +
+	"_max_priceB\r\n" +
+	"\v_min_weightB\r\n" +
+	"\v_max_weightB\x0e\n" +
+	"\f_category_idB\v\n" +
+	"\t_brand_idJ\x04\b\x02\x10\x03J\x04\b\x03\x10\x04\"Z\n" +
+	"\x11ListGoodsResponse\x12$\n" +
+	"\x05items\x18\x01 \x03(\v2\x0e.goods.v1.GoodR\x05items\x12\x1f\n" +
+	"\vnext_cursor\x18\x02 \x01(\tR\n" +
+	"nextCursor\"j\n" +
+	"\x15UpdateGoodCardRequest\x12&\n" +
+	"\x04card\x18\x01 \x01(\v2\x12.goods.v1.GoodCardR\x04card\x12)\n" +
+	"\x10expected_version\x18\x02 \x01(\x05R\x0fexpectedVersion\"2\n" +
+	"\x16UpdateGoodCardResponse\x12\x18\n" +
+	"\aversion\x18\x01 \x01(\x05R\aversion\"+\n" +
+	"\x15DeleteGoodCardRequest\x12\x12\n" +
+	"\x04uuid\x18\x01 \x01(\tR\x04uuid\"\x18\n" +
+	"\x16DeleteGoodCardResponse\",\n" +
+	"\x16RestoreGoodCardRequest\x12\x12\n" +
+	"\x04uuid\x18\x01 \x01(\tR\x04uuid\"\x19\n" +
+	"\x17RestoreGoodCardResponse\"0\n" +
+	"\x18BatchGetGoodCardsRequest\x12\x14\n" +
+	"\x05uuids\x18\x01 \x03(\tR\x05uuids\"A\n" +
+	"\x19BatchGetGoodCardsResponse\x12$\n" +
+	"\x05goods\x18\x01 \x03(\v2\x0e.goods.v1.GoodR\x05goods\"d\n" +
+	"\x12ChangeStockRequest\x12\x15\n" +
+	"\x06sku_id\x18\x01 \x01(\tR\x05skuId\x12!\n" +
+	"\fwarehouse_id\x18\x02 \x01(\tR\vwarehouseId\x12\x14\n" +
+	"\x05count\x18\x03 \x01(\x05R\x05count\"1\n" +
+	"\x13ChangeStockResponse\x12\x1a\n" +
+	"\bquantity\x18\x01 \x01(\x05R\bquantity\"a\n" +
+	"\x0fStockAdjustment\x12\x15\n" +
+	"\x06sku_id\x18\x01 \x01(\tR\x05skuId\x12!\n" +
+	"\fwarehouse_id\x18\x02 \x01(\tR\vwarehouseId\x12\x14\n" +
+	"\x05delta\x18\x03 \x01(\x05R\x05delta\"E\n" +
+	"\x12AdjustStockRequest\x12/\n" +
+	"\x05items\x18\x01 \x03(\v2\x19.goods.v1.StockAdjustmentR\x05items\"J\n" +
+	"\x15StockAdjustmentResult\x12\x15\n" +
+	"\x06sku_id\x18\x01 \x01(\tR\x05skuId\x12\x1a\n" +
+	"\bquantity\x18\x02 \x01(\x05R\bquantity\"P\n" +
+	"\x13AdjustStockResponse\x129\n" +
+	"\aresults\x18\x01 \x03(\v2\x1f.goods.v1.StockAdjustmentResultR\aresults\"\xa1\x02\n" +
+	"\x10CreateSKURequest\x12\x17\n" +
+	"\acard_id\x18\x01 \x01(\tR\x06cardId\x12J\n" +
+	"\n" +
+	"attributes\x18\x02 \x03(\v2*.goods.v1.CreateSKURequest.AttributesEntryR\n" +
+	"attributes\x12%\n" +
+	"\x05price\x18\x03 \x01(\v2\x0f.goods.v1.MoneyR\x05price\x12\x1b\n" +
+	"\x06weight\x18\x04 \x01(\x01H\x00R\x06weight\x88\x01\x01\x12\x1a\n" +
+	"\bquantity\x18\x05 \x01(\x05R\bquantity\x1a=\n" +
+	"\x0fAttributesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01B\t\n" +
+	"\a_weight\"'\n" +
+	"\x11CreateSKUResponse\x12\x12\n" +
+	"\x04uuid\x18\x01 \x01(\tR\x04uuid\"u\n" +
+	"\x10UpdateSKURequest\x12\x12\n" +
+	"\x04uuid\x18\x01 \x01(\tR\x04uuid\x12%\n" +
+	"\x05price\x18\x02 \x01(\v2\x0f.goods.v1.MoneyR\x05price\x12\x1b\n" +
+	"\x06weight\x18\x03 \x01(\x01H\x00R\x06weight\x88\x01\x01B\t\n" +
+	"\a_weight\"\x13\n" +
+	"\x11UpdateSKUResponse\"&\n" +
+	"\x10DeleteSKURequest\x12\x12\n" +
+	"\x04uuid\x18\x01 \x01(\tR\x04uuid\"\x13\n" +
+	"\x11DeleteSKUResponse\"w\n" +
+	"\tWarehouse\x12\x12\n" +
+	"\x04uuid\x18\x01 \x01(\tR\x04uuid\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1a\n" +
+	"\bpriority\x18\x03 \x01(\x05R\bpriority\x12&\n" +
+	"\x0fcreated_at_unix\x18\x04 \x01(\x03R\rcreatedAtUnix\"H\n" +
+	"\x16CreateWarehouseRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1a\n" +
+	"\bpriority\x18\x02 \x01(\x05R\bpriority\"-\n" +
+	"\x17CreateWarehouseResponse\x12\x12\n" +
+	"\x04uuid\x18\x01 \x01(\tR\x04uuid\"\x17\n" +
+	"\x15ListWarehousesRequest\"M\n" +
+	"\x16ListWarehousesResponse\x123\n" +
+	"\n" +
+	"warehouses\x18\x01 \x03(\v2\x13.goods.v1.WarehouseR\n" +
+	"warehouses\"\\\n" +
+	"\x16UpdateWarehouseRequest\x12\x12\n" +
+	"\x04uuid\x18\x01 \x01(\tR\x04uuid\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1a\n" +
+	"\bpriority\x18\x03 \x01(\x05R\bpriority\"\x19\n" +
+	"\x17UpdateWarehouseResponse\",\n" +
+	"\x16DeleteWarehouseRequest\x12\x12\n" +
+	"\x04uuid\x18\x01 \x01(\tR\x04uuid\"\x19\n" +
+	"\x17DeleteWarehouseResponse\"\x8c\x01\n" +
+	"\x13ReserveStockRequest\x12\x15\n" +
+	"\x06sku_id\x18\x01 \x01(\tR\x05skuId\x12!\n" +
+	"\fwarehouse_id\x18\x02 \x01(\tR\vwarehouseId\x12\x1a\n" +
+	"\bquantity\x18\x03 \x01(\x05R\bquantity\x12\x1f\n" +
+	"\vttl_seconds\x18\x04 \x01(\x03R\n" +
+	"ttlSeconds\".\n" +
+	"\x18CommitReservationRequest\x12\x12\n" +
+	"\x04uuid\x18\x01 \x01(\tR\x04uuid\"\x1b\n" +
+	"\x19CommitReservationResponse\"/\n" +
+	"\x19ReleaseReservationRequest\x12\x12\n" +
+	"\x04uuid\x18\x01 \x01(\tR\x04uuid\"\x1c\n" +
+	"\x1aReleaseReservationResponse2\xdb\f\n" +
+	"\fGoodsService\x12S\n" +
+	"\x0eCreateGoodCard\x12\x1f.goods.v1.CreateGoodCardRequest\x1a .goods.v1.CreateGoodCardResponse\x12;\n" +
+	"\vGetGoodCard\x12\x1c.goods.v1.GetGoodCardRequest\x1a\x0e.goods.v1.Good\x12D\n" +
+	"\tListGoods\x12\x1a.goods.v1.ListGoodsRequest\x1a\x1b.goods.v1.ListGoodsResponse\x12S\n" +
+	"\x0eUpdateGoodCard\x12\x1f.goods.v1.UpdateGoodCardRequest\x1a .goods.v1.UpdateGoodCardResponse\x12S\n" +
+	"\x0eDeleteGoodCard\x12\x1f.goods.v1.DeleteGoodCardRequest\x1a .goods.v1.DeleteGoodCardResponse\x12V\n" +
+	"\x0fRestoreGoodCard\x12 .goods.v1.RestoreGoodCardRequest\x1a!.goods.v1.RestoreGoodCardResponse\x12\\\n" +
+	"\x11BatchGetGoodCards\x12\".goods.v1.BatchGetGoodCardsRequest\x1a#.goods.v1.BatchGetGoodCardsResponse\x12G\n" +
+	"\bAddStock\x12\x1c.goods.v1.ChangeStockRequest\x1a\x1d.goods.v1.ChangeStockResponse\x12J\n" +
+	"\vRemoveStock\x12\x1c.goods.v1.ChangeStockRequest\x1a\x1d.goods.v1.ChangeStockResponse\x12J\n" +
+	"\vAdjustStock\x12\x1c.goods.v1.AdjustStockRequest\x1a\x1d.goods.v1.AdjustStockResponse\x12D\n" +
+	"\tCreateSKU\x12\x1a.goods.v1.CreateSKURequest\x1a\x1b.goods.v1.CreateSKUResponse\x12D\n" +
+	"\tUpdateSKU\x12\x1a.goods.v1.UpdateSKURequest\x1a\x1b.goods.v1.UpdateSKUResponse\x12D\n" +
+	"\tDeleteSKU\x12\x1a.goods.v1.DeleteSKURequest\x1a\x1b.goods.v1.DeleteSKUResponse\x12V\n" +
+	"\x0fCreateWarehouse\x12 .goods.v1.CreateWarehouseRequest\x1a!.goods.v1.CreateWarehouseResponse\x12S\n" +
+	"\x0eListWarehouses\x12\x1f.goods.v1.ListWarehousesRequest\x1a .goods.v1.ListWarehousesResponse\x12V\n" +
+	"\x0fUpdateWarehouse\x12 .goods.v1.UpdateWarehouseRequest\x1a!.goods.v1.UpdateWarehouseResponse\x12V\n" +
+	"\x0fDeleteWarehouse\x12 .goods.v1.DeleteWarehouseRequest\x1a!.goods.v1.DeleteWarehouseResponse\x12D\n" +
+	"\fReserveStock\x12\x1d.goods.v1.ReserveStockRequest\x1a\x15.goods.v1.Reservation\x12\\\n" +
+	"\x11CommitReservation\x12\".goods.v1.CommitReservationRequest\x1a#.goods.v1.CommitReservationResponse\x12_\n" +
+	"\x12ReleaseReservation\x12#.goods.v1.ReleaseReservationRequest\x1a$.goods.v1.ReleaseReservationResponseB\x1bZ\x19goods/pkg/goodsv1;goodsv1b\x06proto3"
+
+var (
+	file_goods_v1_goods_proto_rawDescOnce sync.Once
+	file_goods_v1_goods_proto_rawDescData []byte
+)
+
+func file_goods_v1_goods_proto_rawDescGZIP() []byte {
+	file_goods_v1_goods_proto_rawDescOnce.Do(func() {
+		file_goods_v1_goods_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_goods_v1_goods_proto_rawDesc), len(file_goods_v1_goods_proto_rawDesc)))
+	})
+	return file_goods_v1_goods_proto_rawDescData
+}
+
+var file_goods_v1_goods_proto_msgTypes = make([]protoimpl.MessageInfo, 47)
+var file_goods_v1_goods_proto_goTypes = []any{
+	(*Money)(nil),                      // 0: goods.v1.Money
+	(*GoodCard)(nil),                   // 1: goods.v1.GoodCard
+	(*WarehouseStock)(nil),             // 2: goods.v1.WarehouseStock
+	(*SKU)(nil),                        // 3: goods.v1.SKU
+	(*Good)(nil),                       // 4: goods.v1.Good
+	(*Reservation)(nil),                // 5: goods.v1.Reservation
+	(*CreateGoodCardRequest)(nil),      // 6: goods.v1.CreateGoodCardRequest
+	(*CreateGoodCardResponse)(nil),     // 7: goods.v1.CreateGoodCardResponse
+	(*GetGoodCardRequest)(nil),         // 8: goods.v1.GetGoodCardRequest
+	(*ListGoodsRequest)(nil),           // 9: goods.v1.ListGoodsRequest
+	(*ListGoodsResponse)(nil),          // 10: goods.v1.ListGoodsResponse
+	(*UpdateGoodCardRequest)(nil),      // 11: goods.v1.UpdateGoodCardRequest
+	(*UpdateGoodCardResponse)(nil),     // 12: goods.v1.UpdateGoodCardResponse
+	(*DeleteGoodCardRequest)(nil),      // 13: goods.v1.DeleteGoodCardRequest
+	(*DeleteGoodCardResponse)(nil),     // 14: goods.v1.DeleteGoodCardResponse
+	(*RestoreGoodCardRequest)(nil),     // 15: goods.v1.RestoreGoodCardRequest
+	(*RestoreGoodCardResponse)(nil),    // 16: goods.v1.RestoreGoodCardResponse
+	(*BatchGetGoodCardsRequest)(nil),   // 17: goods.v1.BatchGetGoodCardsRequest
+	(*BatchGetGoodCardsResponse)(nil),  // 18: goods.v1.BatchGetGoodCardsResponse
+	(*ChangeStockRequest)(nil),         // 19: goods.v1.ChangeStockRequest
+	(*ChangeStockResponse)(nil),        // 20: goods.v1.ChangeStockResponse
+	(*StockAdjustment)(nil),            // 21: goods.v1.StockAdjustment
+	(*AdjustStockRequest)(nil),         // 22: goods.v1.AdjustStockRequest
+	(*StockAdjustmentResult)(nil),      // 23: goods.v1.StockAdjustmentResult
+	(*AdjustStockResponse)(nil),        // 24: goods.v1.AdjustStockResponse
+	(*CreateSKURequest)(nil),           // 25: goods.v1.CreateSKURequest
+	(*CreateSKUResponse)(nil),          // 26: goods.v1.CreateSKUResponse
+	(*UpdateSKURequest)(nil),           // 27: goods.v1.UpdateSKURequest
+	(*UpdateSKUResponse)(nil),          // 28: goods.v1.UpdateSKUResponse
+	(*DeleteSKURequest)(nil),           // 29: goods.v1.DeleteSKURequest
+	(*DeleteSKUResponse)(nil),          // 30: goods.v1.DeleteSKUResponse
+	(*Warehouse)(nil),                  // 31: goods.v1.Warehouse
+	(*CreateWarehouseRequest)(nil),     // 32: goods.v1.CreateWarehouseRequest
+	(*CreateWarehouseResponse)(nil),    // 33: goods.v1.CreateWarehouseResponse
+	(*ListWarehousesRequest)(nil),      // 34: goods.v1.ListWarehousesRequest
+	(*ListWarehousesResponse)(nil),     // 35: goods.v1.ListWarehousesResponse
+	(*UpdateWarehouseRequest)(nil),     // 36: goods.v1.UpdateWarehouseRequest
+	(*UpdateWarehouseResponse)(nil),    // 37: goods.v1.UpdateWarehouseResponse
+	(*DeleteWarehouseRequest)(nil),     // 38: goods.v1.DeleteWarehouseRequest
+	(*DeleteWarehouseResponse)(nil),    // 39: goods.v1.DeleteWarehouseResponse
+	(*ReserveStockRequest)(nil),        // 40: goods.v1.ReserveStockRequest
+	(*CommitReservationRequest)(nil),   // 41: goods.v1.CommitReservationRequest
+	(*CommitReservationResponse)(nil),  // 42: goods.v1.CommitReservationResponse
+	(*ReleaseReservationRequest)(nil),  // 43: goods.v1.ReleaseReservationRequest
+	(*ReleaseReservationResponse)(nil), // 44: goods.v1.ReleaseReservationResponse
+	nil,                                // 45: goods.v1.SKU.AttributesEntry
+	nil,                                // 46: goods.v1.CreateSKURequest.AttributesEntry
+}
+var file_goods_v1_goods_proto_depIdxs = []int32{
+	0,  // 0: goods.v1.GoodCard.price:type_name -> goods.v1.Money
+	0,  // 1: goods.v1.GoodCard.effective_price:type_name -> goods.v1.Money
+	45, // 2: goods.v1.SKU.attributes:type_name -> goods.v1.SKU.AttributesEntry
+	0,  // 3: goods.v1.SKU.price:type_name -> goods.v1.Money
+	2,  // 4: goods.v1.SKU.stock:type_name -> goods.v1.WarehouseStock
+	1,  // 5: goods.v1.Good.card:type_name -> goods.v1.GoodCard
+	3,  // 6: goods.v1.Good.skus:type_name -> goods.v1.SKU
+	1,  // 7: goods.v1.CreateGoodCardRequest.card:type_name -> goods.v1.GoodCard
+	4,  // 8: goods.v1.ListGoodsResponse.items:type_name -> goods.v1.Good
+	1,  // 9: goods.v1.UpdateGoodCardRequest.card:type_name -> goods.v1.GoodCard
+	4,  // 10: goods.v1.BatchGetGoodCardsResponse.goods:type_name -> goods.v1.Good
+	21, // 11: goods.v1.AdjustStockRequest.items:type_name -> goods.v1.StockAdjustment
+	23, // 12: goods.v1.AdjustStockResponse.results:type_name -> goods.v1.StockAdjustmentResult
+	46, // 13: goods.v1.CreateSKURequest.attributes:type_name -> goods.v1.CreateSKURequest.AttributesEntry
+	0,  // 14: goods.v1.CreateSKURequest.price:type_name -> goods.v1.Money
+	0,  // 15: goods.v1.UpdateSKURequest.price:type_name -> goods.v1.Money
+	31, // 16: goods.v1.ListWarehousesResponse.warehouses:type_name -> goods.v1.Warehouse
+	6,  // 17: goods.v1.GoodsService.CreateGoodCard:input_type -> goods.v1.CreateGoodCardRequest
+	8,  // 18: goods.v1.GoodsService.GetGoodCard:input_type -> goods.v1.GetGoodCardRequest
+	9,  // 19: goods.v1.GoodsService.ListGoods:input_type -> goods.v1.ListGoodsRequest
+	11, // 20: goods.v1.GoodsService.UpdateGoodCard:input_type -> goods.v1.UpdateGoodCardRequest
+	13, // 21: goods.v1.GoodsService.DeleteGoodCard:input_type -> goods.v1.DeleteGoodCardRequest
+	15, // 22: goods.v1.GoodsService.RestoreGoodCard:input_type -> goods.v1.RestoreGoodCardRequest
+	17, // 23: goods.v1.GoodsService.BatchGetGoodCards:input_type -> goods.v1.BatchGetGoodCardsRequest
+	19, // 24: goods.v1.GoodsService.AddStock:input_type -> goods.v1.ChangeStockRequest
+	19, // 25: goods.v1.GoodsService.RemoveStock:input_type -> goods.v1.ChangeStockRequest
+	22, // 26: goods.v1.GoodsService.AdjustStock:input_type -> goods.v1.AdjustStockRequest
+	25, // 27: goods.v1.GoodsService.CreateSKU:input_type -> goods.v1.CreateSKURequest
+	27, // 28: goods.v1.GoodsService.UpdateSKU:input_type -> goods.v1.UpdateSKURequest
+	29, // 29: goods.v1.GoodsService.DeleteSKU:input_type -> goods.v1.DeleteSKURequest
+	32, // 30: goods.v1.GoodsService.CreateWarehouse:input_type -> goods.v1.CreateWarehouseRequest
+	34, // 31: goods.v1.GoodsService.ListWarehouses:input_type -> goods.v1.ListWarehousesRequest
+	36, // 32: goods.v1.GoodsService.UpdateWarehouse:input_type -> goods.v1.UpdateWarehouseRequest
+	38, // 33: goods.v1.GoodsService.DeleteWarehouse:input_type -> goods.v1.DeleteWarehouseRequest
+	40, // 34: goods.v1.GoodsService.ReserveStock:input_type -> goods.v1.ReserveStockRequest
+	41, // 35: goods.v1.GoodsService.CommitReservation:input_type -> goods.v1.CommitReservationRequest
+	43, // 36: goods.v1.GoodsService.ReleaseReservation:input_type -> goods.v1.ReleaseReservationRequest
+	7,  // 37: goods.v1.GoodsService.CreateGoodCard:output_type -> goods.v1.CreateGoodCardResponse
+	4,  // 38: goods.v1.GoodsService.GetGoodCard:output_type -> goods.v1.Good
+	10, // 39: goods.v1.GoodsService.ListGoods:output_type -> goods.v1.ListGoodsResponse
+	12, // 40: goods.v1.GoodsService.UpdateGoodCard:output_type -> goods.v1.UpdateGoodCardResponse
+	14, // 41: goods.v1.GoodsService.DeleteGoodCard:output_type -> goods.v1.DeleteGoodCardResponse
+	16, // 42: goods.v1.GoodsService.RestoreGoodCard:output_type -> goods.v1.RestoreGoodCardResponse
+	18, // 43: goods.v1.GoodsService.BatchGetGoodCards:output_type -> goods.v1.BatchGetGoodCardsResponse
+	20, // 44: goods.v1.GoodsService.AddStock:output_type -> goods.v1.ChangeStockResponse
+	20, // 45: goods.v1.GoodsService.RemoveStock:output_type -> goods.v1.ChangeStockResponse
+	24, // 46: goods.v1.GoodsService.AdjustStock:output_type -> goods.v1.AdjustStockResponse
+	26, // 47: goods.v1.GoodsService.CreateSKU:output_type -> goods.v1.CreateSKUResponse
+	28, // 48: goods.v1.GoodsService.UpdateSKU:output_type -> goods.v1.UpdateSKUResponse
+	30, // 49: goods.v1.GoodsService.DeleteSKU:output_type -> goods.v1.DeleteSKUResponse
+	33, // 50: goods.v1.GoodsService.CreateWarehouse:output_type -> goods.v1.CreateWarehouseResponse
+	35, // 51: goods.v1.GoodsService.ListWarehouses:output_type -> goods.v1.ListWarehousesResponse
+	37, // 52: goods.v1.GoodsService.UpdateWarehouse:output_type -> goods.v1.UpdateWarehouseResponse
+	39, // 53: goods.v1.GoodsService.DeleteWarehouse:output_type -> goods.v1.DeleteWarehouseResponse
+	5,  // 54: goods.v1.GoodsService.ReserveStock:output_type -> goods.v1.Reservation
+	42, // 55: goods.v1.GoodsService.CommitReservation:output_type -> goods.v1.CommitReservationResponse
+	44, // 56: goods.v1.GoodsService.ReleaseReservation:output_type -> goods.v1.ReleaseReservationResponse
+	37, // [37:57] is the sub-list for method output_type
+	17, // [17:37] is the sub-list for method input_type
+	17, // [17:17] is the sub-list for extension type_name
+	17, // [17:17] is the sub-list for extension extendee
+	0,  // [0:17] is the sub-list for field type_name
+}
+
+func init() { file_goods_v1_goods_proto_init() }
+func file_goods_v1_goods_proto_init() {
+	if File_goods_v1_goods_proto != nil {
+		return
+	}
+	file_goods_v1_goods_proto_msgTypes[1].OneofWrappers = []any{}
+	file_goods_v1_goods_proto_msgTypes[3].OneofWrappers = []any{}
+	file_goods_v1_goods_proto_msgTypes[9].OneofWrappers = []any{}
+	file_goods_v1_goods_proto_msgTypes[25].OneofWrappers = []any{}
+	file_goods_v1_goods_proto_msgTypes[27].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_goods_v1_goods_proto_rawDesc), len(file_goods_v1_goods_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   47,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_goods_v1_goods_proto_goTypes,
+		DependencyIndexes: file_goods_v1_goods_proto_depIdxs,
+		MessageInfos:      file_goods_v1_goods_proto_msgTypes,
+	}.Build()
+	File_goods_v1_goods_proto = out.File
+	file_goods_v1_goods_proto_goTypes = nil
+	file_goods_v1_goods_proto_depIdxs = nil
+}
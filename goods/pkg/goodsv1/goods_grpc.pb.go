@@ -0,0 +1,866 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v5.29.3
+// source: goods/v1/goods.proto
+
+// API сервиса товаров для внутренних вызывающих (заказы, корзина).
+// Каждый вызов несёт общий секрет внутренних сервисов в метаданных x-service-token.
+// Продавец запросов, работающих с его карточками, передаётся в метаданных x-supplier-id,
+// исполнитель и идентификатор запроса для журнала движения - в x-actor и x-correlation-id.
+
+package goodsv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	GoodsService_CreateGoodCard_FullMethodName     = "/goods.v1.GoodsService/CreateGoodCard"
+	GoodsService_GetGoodCard_FullMethodName        = "/goods.v1.GoodsService/GetGoodCard"
+	GoodsService_ListGoods_FullMethodName          = "/goods.v1.GoodsService/ListGoods"
+	GoodsService_UpdateGoodCard_FullMethodName     = "/goods.v1.GoodsService/UpdateGoodCard"
+	GoodsService_DeleteGoodCard_FullMethodName     = "/goods.v1.GoodsService/DeleteGoodCard"
+	GoodsService_RestoreGoodCard_FullMethodName    = "/goods.v1.GoodsService/RestoreGoodCard"
+	GoodsService_BatchGetGoodCards_FullMethodName  = "/goods.v1.GoodsService/BatchGetGoodCards"
+	GoodsService_AddStock_FullMethodName           = "/goods.v1.GoodsService/AddStock"
+	GoodsService_RemoveStock_FullMethodName        = "/goods.v1.GoodsService/RemoveStock"
+	GoodsService_AdjustStock_FullMethodName        = "/goods.v1.GoodsService/AdjustStock"
+	GoodsService_CreateSKU_FullMethodName          = "/goods.v1.GoodsService/CreateSKU"
+	GoodsService_UpdateSKU_FullMethodName          = "/goods.v1.GoodsService/UpdateSKU"
+	GoodsService_DeleteSKU_FullMethodName          = "/goods.v1.GoodsService/DeleteSKU"
+	GoodsService_CreateWarehouse_FullMethodName    = "/goods.v1.GoodsService/CreateWarehouse"
+	GoodsService_ListWarehouses_FullMethodName     = "/goods.v1.GoodsService/ListWarehouses"
+	GoodsService_UpdateWarehouse_FullMethodName    = "/goods.v1.GoodsService/UpdateWarehouse"
+	GoodsService_DeleteWarehouse_FullMethodName    = "/goods.v1.GoodsService/DeleteWarehouse"
+	GoodsService_ReserveStock_FullMethodName       = "/goods.v1.GoodsService/ReserveStock"
+	GoodsService_CommitReservation_FullMethodName  = "/goods.v1.GoodsService/CommitReservation"
+	GoodsService_ReleaseReservation_FullMethodName = "/goods.v1.GoodsService/ReleaseReservation"
+)
+
+// GoodsServiceClient is the client API for GoodsService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type GoodsServiceClient interface {
+	// Карточки товаров продавца
+	CreateGoodCard(ctx context.Context, in *CreateGoodCardRequest, opts ...grpc.CallOption) (*CreateGoodCardResponse, error)
+	GetGoodCard(ctx context.Context, in *GetGoodCardRequest, opts ...grpc.CallOption) (*Good, error)
+	ListGoods(ctx context.Context, in *ListGoodsRequest, opts ...grpc.CallOption) (*ListGoodsResponse, error)
+	// expected_version == 0 - обновить любую версию
+	UpdateGoodCard(ctx context.Context, in *UpdateGoodCardRequest, opts ...grpc.CallOption) (*UpdateGoodCardResponse, error)
+	DeleteGoodCard(ctx context.Context, in *DeleteGoodCardRequest, opts ...grpc.CallOption) (*DeleteGoodCardResponse, error)
+	RestoreGoodCard(ctx context.Context, in *RestoreGoodCardRequest, opts ...grpc.CallOption) (*RestoreGoodCardResponse, error)
+	// Карточки любых продавцов по идентификаторам; не найденные и удалённые пропускаются
+	BatchGetGoodCards(ctx context.Context, in *BatchGetGoodCardsRequest, opts ...grpc.CallOption) (*BatchGetGoodCardsResponse, error)
+	// Остаток варианта (SKU) продавца; пустой warehouse_id - склад выбирается автоматически
+	AddStock(ctx context.Context, in *ChangeStockRequest, opts ...grpc.CallOption) (*ChangeStockResponse, error)
+	RemoveStock(ctx context.Context, in *ChangeStockRequest, opts ...grpc.CallOption) (*ChangeStockResponse, error)
+	// Пакет операций с остатком выполняется целиком или не выполняется вовсе.
+	// Ошибка операции возвращается с сообщением "item <номер>: <причина>".
+	AdjustStock(ctx context.Context, in *AdjustStockRequest, opts ...grpc.CallOption) (*AdjustStockResponse, error)
+	// Варианты (SKU) карточек продавца
+	CreateSKU(ctx context.Context, in *CreateSKURequest, opts ...grpc.CallOption) (*CreateSKUResponse, error)
+	UpdateSKU(ctx context.Context, in *UpdateSKURequest, opts ...grpc.CallOption) (*UpdateSKUResponse, error)
+	DeleteSKU(ctx context.Context, in *DeleteSKURequest, opts ...grpc.CallOption) (*DeleteSKUResponse, error)
+	// Склады продавца; удаляется только склад без остатка и резервов
+	CreateWarehouse(ctx context.Context, in *CreateWarehouseRequest, opts ...grpc.CallOption) (*CreateWarehouseResponse, error)
+	ListWarehouses(ctx context.Context, in *ListWarehousesRequest, opts ...grpc.CallOption) (*ListWarehousesResponse, error)
+	UpdateWarehouse(ctx context.Context, in *UpdateWarehouseRequest, opts ...grpc.CallOption) (*UpdateWarehouseResponse, error)
+	DeleteWarehouse(ctx context.Context, in *DeleteWarehouseRequest, opts ...grpc.CallOption) (*DeleteWarehouseResponse, error)
+	// Резервы под заказы
+	ReserveStock(ctx context.Context, in *ReserveStockRequest, opts ...grpc.CallOption) (*Reservation, error)
+	CommitReservation(ctx context.Context, in *CommitReservationRequest, opts ...grpc.CallOption) (*CommitReservationResponse, error)
+	ReleaseReservation(ctx context.Context, in *ReleaseReservationRequest, opts ...grpc.CallOption) (*ReleaseReservationResponse, error)
+}
+
+type goodsServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewGoodsServiceClient(cc grpc.ClientConnInterface) GoodsServiceClient {
+	return &goodsServiceClient{cc}
+}
+
+func (c *goodsServiceClient) CreateGoodCard(ctx context.Context, in *CreateGoodCardRequest, opts ...grpc.CallOption) (*CreateGoodCardResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateGoodCardResponse)
+	err := c.cc.Invoke(ctx, GoodsService_CreateGoodCard_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goodsServiceClient) GetGoodCard(ctx context.Context, in *GetGoodCardRequest, opts ...grpc.CallOption) (*Good, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Good)
+	err := c.cc.Invoke(ctx, GoodsService_GetGoodCard_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goodsServiceClient) ListGoods(ctx context.Context, in *ListGoodsRequest, opts ...grpc.CallOption) (*ListGoodsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListGoodsResponse)
+	err := c.cc.Invoke(ctx, GoodsService_ListGoods_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goodsServiceClient) UpdateGoodCard(ctx context.Context, in *UpdateGoodCardRequest, opts ...grpc.CallOption) (*UpdateGoodCardResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateGoodCardResponse)
+	err := c.cc.Invoke(ctx, GoodsService_UpdateGoodCard_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goodsServiceClient) DeleteGoodCard(ctx context.Context, in *DeleteGoodCardRequest, opts ...grpc.CallOption) (*DeleteGoodCardResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteGoodCardResponse)
+	err := c.cc.Invoke(ctx, GoodsService_DeleteGoodCard_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goodsServiceClient) RestoreGoodCard(ctx context.Context, in *RestoreGoodCardRequest, opts ...grpc.CallOption) (*RestoreGoodCardResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RestoreGoodCardResponse)
+	err := c.cc.Invoke(ctx, GoodsService_RestoreGoodCard_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goodsServiceClient) BatchGetGoodCards(ctx context.Context, in *BatchGetGoodCardsRequest, opts ...grpc.CallOption) (*BatchGetGoodCardsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BatchGetGoodCardsResponse)
+	err := c.cc.Invoke(ctx, GoodsService_BatchGetGoodCards_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goodsServiceClient) AddStock(ctx context.Context, in *ChangeStockRequest, opts ...grpc.CallOption) (*ChangeStockResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ChangeStockResponse)
+	err := c.cc.Invoke(ctx, GoodsService_AddStock_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goodsServiceClient) RemoveStock(ctx context.Context, in *ChangeStockRequest, opts ...grpc.CallOption) (*ChangeStockResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ChangeStockResponse)
+	err := c.cc.Invoke(ctx, GoodsService_RemoveStock_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goodsServiceClient) AdjustStock(ctx context.Context, in *AdjustStockRequest, opts ...grpc.CallOption) (*AdjustStockResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AdjustStockResponse)
+	err := c.cc.Invoke(ctx, GoodsService_AdjustStock_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goodsServiceClient) CreateSKU(ctx context.Context, in *CreateSKURequest, opts ...grpc.CallOption) (*CreateSKUResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateSKUResponse)
+	err := c.cc.Invoke(ctx, GoodsService_CreateSKU_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goodsServiceClient) UpdateSKU(ctx context.Context, in *UpdateSKURequest, opts ...grpc.CallOption) (*UpdateSKUResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateSKUResponse)
+	err := c.cc.Invoke(ctx, GoodsService_UpdateSKU_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goodsServiceClient) DeleteSKU(ctx context.Context, in *DeleteSKURequest, opts ...grpc.CallOption) (*DeleteSKUResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteSKUResponse)
+	err := c.cc.Invoke(ctx, GoodsService_DeleteSKU_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goodsServiceClient) CreateWarehouse(ctx context.Context, in *CreateWarehouseRequest, opts ...grpc.CallOption) (*CreateWarehouseResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateWarehouseResponse)
+	err := c.cc.Invoke(ctx, GoodsService_CreateWarehouse_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goodsServiceClient) ListWarehouses(ctx context.Context, in *ListWarehousesRequest, opts ...grpc.CallOption) (*ListWarehousesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListWarehousesResponse)
+	err := c.cc.Invoke(ctx, GoodsService_ListWarehouses_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goodsServiceClient) UpdateWarehouse(ctx context.Context, in *UpdateWarehouseRequest, opts ...grpc.CallOption) (*UpdateWarehouseResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateWarehouseResponse)
+	err := c.cc.Invoke(ctx, GoodsService_UpdateWarehouse_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goodsServiceClient) DeleteWarehouse(ctx context.Context, in *DeleteWarehouseRequest, opts ...grpc.CallOption) (*DeleteWarehouseResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteWarehouseResponse)
+	err := c.cc.Invoke(ctx, GoodsService_DeleteWarehouse_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goodsServiceClient) ReserveStock(ctx context.Context, in *ReserveStockRequest, opts ...grpc.CallOption) (*Reservation, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Reservation)
+	err := c.cc.Invoke(ctx, GoodsService_ReserveStock_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goodsServiceClient) CommitReservation(ctx context.Context, in *CommitReservationRequest, opts ...grpc.CallOption) (*CommitReservationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CommitReservationResponse)
+	err := c.cc.Invoke(ctx, GoodsService_CommitReservation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goodsServiceClient) ReleaseReservation(ctx context.Context, in *ReleaseReservationRequest, opts ...grpc.CallOption) (*ReleaseReservationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReleaseReservationResponse)
+	err := c.cc.Invoke(ctx, GoodsService_ReleaseReservation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GoodsServiceServer is the server API for GoodsService service.
+// All implementations must embed UnimplementedGoodsServiceServer
+// for forward compatibility.
+type GoodsServiceServer interface {
+	// Карточки товаров продавца
+	CreateGoodCard(context.Context, *CreateGoodCardRequest) (*CreateGoodCardResponse, error)
+	GetGoodCard(context.Context, *GetGoodCardRequest) (*Good, error)
+	ListGoods(context.Context, *ListGoodsRequest) (*ListGoodsResponse, error)
+	// expected_version == 0 - обновить любую версию
+	UpdateGoodCard(context.Context, *UpdateGoodCardRequest) (*UpdateGoodCardResponse, error)
+	DeleteGoodCard(context.Context, *DeleteGoodCardRequest) (*DeleteGoodCardResponse, error)
+	RestoreGoodCard(context.Context, *RestoreGoodCardRequest) (*RestoreGoodCardResponse, error)
+	// Карточки любых продавцов по идентификаторам; не найденные и удалённые пропускаются
+	BatchGetGoodCards(context.Context, *BatchGetGoodCardsRequest) (*BatchGetGoodCardsResponse, error)
+	// Остаток варианта (SKU) продавца; пустой warehouse_id - склад выбирается автоматически
+	AddStock(context.Context, *ChangeStockRequest) (*ChangeStockResponse, error)
+	RemoveStock(context.Context, *ChangeStockRequest) (*ChangeStockResponse, error)
+	// Пакет операций с остатком выполняется целиком или не выполняется вовсе.
+	// Ошибка операции возвращается с сообщением "item <номер>: <причина>".
+	AdjustStock(context.Context, *AdjustStockRequest) (*AdjustStockResponse, error)
+	// Варианты (SKU) карточек продавца
+	CreateSKU(context.Context, *CreateSKURequest) (*CreateSKUResponse, error)
+	UpdateSKU(context.Context, *UpdateSKURequest) (*UpdateSKUResponse, error)
+	DeleteSKU(context.Context, *DeleteSKURequest) (*DeleteSKUResponse, error)
+	// Склады продавца; удаляется только склад без остатка и резервов
+	CreateWarehouse(context.Context, *CreateWarehouseRequest) (*CreateWarehouseResponse, error)
+	ListWarehouses(context.Context, *ListWarehousesRequest) (*ListWarehousesResponse, error)
+	UpdateWarehouse(context.Context, *UpdateWarehouseRequest) (*UpdateWarehouseResponse, error)
+	DeleteWarehouse(context.Context, *DeleteWarehouseRequest) (*DeleteWarehouseResponse, error)
+	// Резервы под заказы
+	ReserveStock(context.Context, *ReserveStockRequest) (*Reservation, error)
+	CommitReservation(context.Context, *CommitReservationRequest) (*CommitReservationResponse, error)
+	ReleaseReservation(context.Context, *ReleaseReservationRequest) (*ReleaseReservationResponse, error)
+	mustEmbedUnimplementedGoodsServiceServer()
+}
+
+// UnimplementedGoodsServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedGoodsServiceServer struct{}
+
+func (UnimplementedGoodsServiceServer) CreateGoodCard(context.Context, *CreateGoodCardRequest) (*CreateGoodCardResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateGoodCard not implemented")
+}
+func (UnimplementedGoodsServiceServer) GetGoodCard(context.Context, *GetGoodCardRequest) (*Good, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetGoodCard not implemented")
+}
+func (UnimplementedGoodsServiceServer) ListGoods(context.Context, *ListGoodsRequest) (*ListGoodsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListGoods not implemented")
+}
+func (UnimplementedGoodsServiceServer) UpdateGoodCard(context.Context, *UpdateGoodCardRequest) (*UpdateGoodCardResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateGoodCard not implemented")
+}
+func (UnimplementedGoodsServiceServer) DeleteGoodCard(context.Context, *DeleteGoodCardRequest) (*DeleteGoodCardResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteGoodCard not implemented")
+}
+func (UnimplementedGoodsServiceServer) RestoreGoodCard(context.Context, *RestoreGoodCardRequest) (*RestoreGoodCardResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreGoodCard not implemented")
+}
+func (UnimplementedGoodsServiceServer) BatchGetGoodCards(context.Context, *BatchGetGoodCardsRequest) (*BatchGetGoodCardsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchGetGoodCards not implemented")
+}
+func (UnimplementedGoodsServiceServer) AddStock(context.Context, *ChangeStockRequest) (*ChangeStockResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddStock not implemented")
+}
+func (UnimplementedGoodsServiceServer) RemoveStock(context.Context, *ChangeStockRequest) (*ChangeStockResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveStock not implemented")
+}
+func (UnimplementedGoodsServiceServer) AdjustStock(context.Context, *AdjustStockRequest) (*AdjustStockResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AdjustStock not implemented")
+}
+func (UnimplementedGoodsServiceServer) CreateSKU(context.Context, *CreateSKURequest) (*CreateSKUResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateSKU not implemented")
+}
+func (UnimplementedGoodsServiceServer) UpdateSKU(context.Context, *UpdateSKURequest) (*UpdateSKUResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateSKU not implemented")
+}
+func (UnimplementedGoodsServiceServer) DeleteSKU(context.Context, *DeleteSKURequest) (*DeleteSKUResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteSKU not implemented")
+}
+func (UnimplementedGoodsServiceServer) CreateWarehouse(context.Context, *CreateWarehouseRequest) (*CreateWarehouseResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateWarehouse not implemented")
+}
+func (UnimplementedGoodsServiceServer) ListWarehouses(context.Context, *ListWarehousesRequest) (*ListWarehousesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListWarehouses not implemented")
+}
+func (UnimplementedGoodsServiceServer) UpdateWarehouse(context.Context, *UpdateWarehouseRequest) (*UpdateWarehouseResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateWarehouse not implemented")
+}
+func (UnimplementedGoodsServiceServer) DeleteWarehouse(context.Context, *DeleteWarehouseRequest) (*DeleteWarehouseResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteWarehouse not implemented")
+}
+func (UnimplementedGoodsServiceServer) ReserveStock(context.Context, *ReserveStockRequest) (*Reservation, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReserveStock not implemented")
+}
+func (UnimplementedGoodsServiceServer) CommitReservation(context.Context, *CommitReservationRequest) (*CommitReservationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CommitReservation not implemented")
+}
+func (UnimplementedGoodsServiceServer) ReleaseReservation(context.Context, *ReleaseReservationRequest) (*ReleaseReservationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReleaseReservation not implemented")
+}
+func (UnimplementedGoodsServiceServer) mustEmbedUnimplementedGoodsServiceServer() {}
+func (UnimplementedGoodsServiceServer) testEmbeddedByValue()                      {}
+
+// UnsafeGoodsServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to GoodsServiceServer will
+// result in compilation errors.
+type UnsafeGoodsServiceServer interface {
+	mustEmbedUnimplementedGoodsServiceServer()
+}
+
+func RegisterGoodsServiceServer(s grpc.ServiceRegistrar, srv GoodsServiceServer) {
+	// If the following call pancis, it indicates UnimplementedGoodsServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&GoodsService_ServiceDesc, srv)
+}
+
+func _GoodsService_CreateGoodCard_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateGoodCardRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoodsServiceServer).CreateGoodCard(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GoodsService_CreateGoodCard_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoodsServiceServer).CreateGoodCard(ctx, req.(*CreateGoodCardRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoodsService_GetGoodCard_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetGoodCardRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoodsServiceServer).GetGoodCard(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GoodsService_GetGoodCard_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoodsServiceServer).GetGoodCard(ctx, req.(*GetGoodCardRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoodsService_ListGoods_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListGoodsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoodsServiceServer).ListGoods(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GoodsService_ListGoods_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoodsServiceServer).ListGoods(ctx, req.(*ListGoodsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoodsService_UpdateGoodCard_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateGoodCardRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoodsServiceServer).UpdateGoodCard(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GoodsService_UpdateGoodCard_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoodsServiceServer).UpdateGoodCard(ctx, req.(*UpdateGoodCardRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoodsService_DeleteGoodCard_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteGoodCardRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoodsServiceServer).DeleteGoodCard(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GoodsService_DeleteGoodCard_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoodsServiceServer).DeleteGoodCard(ctx, req.(*DeleteGoodCardRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoodsService_RestoreGoodCard_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreGoodCardRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoodsServiceServer).RestoreGoodCard(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GoodsService_RestoreGoodCard_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoodsServiceServer).RestoreGoodCard(ctx, req.(*RestoreGoodCardRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoodsService_BatchGetGoodCards_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchGetGoodCardsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoodsServiceServer).BatchGetGoodCards(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GoodsService_BatchGetGoodCards_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoodsServiceServer).BatchGetGoodCards(ctx, req.(*BatchGetGoodCardsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoodsService_AddStock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChangeStockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoodsServiceServer).AddStock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GoodsService_AddStock_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoodsServiceServer).AddStock(ctx, req.(*ChangeStockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoodsService_RemoveStock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChangeStockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoodsServiceServer).RemoveStock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GoodsService_RemoveStock_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoodsServiceServer).RemoveStock(ctx, req.(*ChangeStockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoodsService_AdjustStock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AdjustStockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoodsServiceServer).AdjustStock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GoodsService_AdjustStock_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoodsServiceServer).AdjustStock(ctx, req.(*AdjustStockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoodsService_CreateSKU_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateSKURequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoodsServiceServer).CreateSKU(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GoodsService_CreateSKU_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoodsServiceServer).CreateSKU(ctx, req.(*CreateSKURequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoodsService_UpdateSKU_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateSKURequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoodsServiceServer).UpdateSKU(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GoodsService_UpdateSKU_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoodsServiceServer).UpdateSKU(ctx, req.(*UpdateSKURequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoodsService_DeleteSKU_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteSKURequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoodsServiceServer).DeleteSKU(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GoodsService_DeleteSKU_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoodsServiceServer).DeleteSKU(ctx, req.(*DeleteSKURequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoodsService_CreateWarehouse_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateWarehouseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoodsServiceServer).CreateWarehouse(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GoodsService_CreateWarehouse_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoodsServiceServer).CreateWarehouse(ctx, req.(*CreateWarehouseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoodsService_ListWarehouses_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListWarehousesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoodsServiceServer).ListWarehouses(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GoodsService_ListWarehouses_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoodsServiceServer).ListWarehouses(ctx, req.(*ListWarehousesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoodsService_UpdateWarehouse_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateWarehouseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoodsServiceServer).UpdateWarehouse(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GoodsService_UpdateWarehouse_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoodsServiceServer).UpdateWarehouse(ctx, req.(*UpdateWarehouseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoodsService_DeleteWarehouse_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteWarehouseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoodsServiceServer).DeleteWarehouse(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GoodsService_DeleteWarehouse_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoodsServiceServer).DeleteWarehouse(ctx, req.(*DeleteWarehouseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoodsService_ReserveStock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReserveStockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoodsServiceServer).ReserveStock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GoodsService_ReserveStock_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoodsServiceServer).ReserveStock(ctx, req.(*ReserveStockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoodsService_CommitReservation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CommitReservationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoodsServiceServer).CommitReservation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GoodsService_CommitReservation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoodsServiceServer).CommitReservation(ctx, req.(*CommitReservationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoodsService_ReleaseReservation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReleaseReservationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoodsServiceServer).ReleaseReservation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GoodsService_ReleaseReservation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoodsServiceServer).ReleaseReservation(ctx, req.(*ReleaseReservationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// GoodsService_ServiceDesc is the grpc.ServiceDesc for GoodsService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var GoodsService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "goods.v1.GoodsService",
+	HandlerType: (*GoodsServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateGoodCard",
+			Handler:    _GoodsService_CreateGoodCard_Handler,
+		},
+		{
+			MethodName: "GetGoodCard",
+			Handler:    _GoodsService_GetGoodCard_Handler,
+		},
+		{
+			MethodName: "ListGoods",
+			Handler:    _GoodsService_ListGoods_Handler,
+		},
+		{
+			MethodName: "UpdateGoodCard",
+			Handler:    _GoodsService_UpdateGoodCard_Handler,
+		},
+		{
+			MethodName: "DeleteGoodCard",
+			Handler:    _GoodsService_DeleteGoodCard_Handler,
+		},
+		{
+			MethodName: "RestoreGoodCard",
+			Handler:    _GoodsService_RestoreGoodCard_Handler,
+		},
+		{
+			MethodName: "BatchGetGoodCards",
+			Handler:    _GoodsService_BatchGetGoodCards_Handler,
+		},
+		{
+			MethodName: "AddStock",
+			Handler:    _GoodsService_AddStock_Handler,
+		},
+		{
+			MethodName: "RemoveStock",
+			Handler:    _GoodsService_RemoveStock_Handler,
+		},
+		{
+			MethodName: "AdjustStock",
+			Handler:    _GoodsService_AdjustStock_Handler,
+		},
+		{
+			MethodName: "CreateSKU",
+			Handler:    _GoodsService_CreateSKU_Handler,
+		},
+		{
+			MethodName: "UpdateSKU",
+			Handler:    _GoodsService_UpdateSKU_Handler,
+		},
+		{
+			MethodName: "DeleteSKU",
+			Handler:    _GoodsService_DeleteSKU_Handler,
+		},
+		{
+			MethodName: "CreateWarehouse",
+			Handler:    _GoodsService_CreateWarehouse_Handler,
+		},
+		{
+			MethodName: "ListWarehouses",
+			Handler:    _GoodsService_ListWarehouses_Handler,
+		},
+		{
+			MethodName: "UpdateWarehouse",
+			Handler:    _GoodsService_UpdateWarehouse_Handler,
+		},
+		{
+			MethodName: "DeleteWarehouse",
+			Handler:    _GoodsService_DeleteWarehouse_Handler,
+		},
+		{
+			MethodName: "ReserveStock",
+			Handler:    _GoodsService_ReserveStock_Handler,
+		},
+		{
+			MethodName: "CommitReservation",
+			Handler:    _GoodsService_CommitReservation_Handler,
+		},
+		{
+			MethodName: "ReleaseReservation",
+			Handler:    _GoodsService_ReleaseReservation_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "goods/v1/goods.proto",
+}
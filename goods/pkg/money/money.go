@@ -0,0 +1,175 @@
+// Package money - денежные суммы без ошибок округления: целое число минимальных единиц валюты
+// (копеек, центов) и код валюты ISO 4217. Пакет используют сервис товаров и его клиенты (заказы, корзина).
+package money
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strings"
+)
+
+// Money - сумма в минимальных единицах валюты
+type Money struct {
+	Amount   int64  `json:"amount"`   // Сумма в минимальных единицах валюты: 12345 RUB - 123.45 рубля
+	Currency string `json:"currency"` // Код валюты ISO 4217
+}
+
+// Число знаков после запятой у поддерживаемых валют
+var exponents = map[string]int{
+	"RUB": 2, "USD": 2, "EUR": 2, "CNY": 2, "GBP": 2, "CHF": 2, "TRY": 2, "AED": 2, "INR": 2,
+	"KZT": 2, "BYN": 2, "UZS": 2, "AMD": 2, "KGS": 2, "AZN": 2, "GEL": 2, "TJS": 2, "MDL": 2,
+	"JPY": 0, "KRW": 0, "VND": 0,
+	"KWD": 3, "BHD": 3, "OMR": 3,
+}
+
+var (
+	ErrUnknownCurrency  = errors.New("unknown currency")
+	ErrInvalidAmount    = errors.New("invalid amount")
+	ErrCurrencyMismatch = errors.New("currency mismatch")
+)
+
+// Supported сообщает, поддерживается ли валюта code
+func Supported(code string) bool {
+	_, ok := exponents[code]
+	return ok
+}
+
+// Exponent возвращает число знаков после запятой у валюты code
+func Exponent(code string) (int, error) {
+	exp, ok := exponents[code]
+	if !ok {
+		return 0, fmt.Errorf("%w %q", ErrUnknownCurrency, code)
+	}
+	return exp, nil
+}
+
+// Parse разбирает десятичную запись суммы ("123.45") в валюте currency.
+// Знаков после запятой не может быть больше, чем у валюты.
+func Parse(s string, currency string) (Money, error) {
+	exp, err := Exponent(currency)
+	if err != nil {
+		return Money{}, err
+	}
+	s = strings.TrimSpace(s)
+	negative := strings.HasPrefix(s, "-")
+	units, fraction, _ := strings.Cut(strings.TrimPrefix(s, "-"), ".")
+	if units == "" || len(fraction) > exp || !digits(units) || !digits(fraction) {
+		return Money{}, fmt.Errorf("%w %q", ErrInvalidAmount, s)
+	}
+	fraction += strings.Repeat("0", exp-len(fraction))
+
+	var amount int64
+	for _, c := range units + fraction {
+		if amount > (math.MaxInt64-int64(c-'0'))/10 {
+			return Money{}, fmt.Errorf("%w %q", ErrInvalidAmount, s)
+		}
+		amount = amount*10 + int64(c-'0')
+	}
+	if negative {
+		amount = -amount
+	}
+	return Money{Amount: amount, Currency: currency}, nil
+}
+
+func digits(s string) bool {
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// Decimal возвращает десятичную запись суммы без кода валюты: "123.45"
+func (m Money) Decimal() string {
+	exp := exponents[m.Currency]
+	amount := m.Amount
+	sign := ""
+	if amount < 0 {
+		sign = "-"
+	}
+	abs := new(big.Int).Abs(big.NewInt(amount)).String()
+	if exp == 0 {
+		return sign + abs
+	}
+	if len(abs) <= exp {
+		abs = strings.Repeat("0", exp-len(abs)+1) + abs
+	}
+	return sign + abs[:len(abs)-exp] + "." + abs[len(abs)-exp:]
+}
+
+func (m Money) String() string {
+	return m.Decimal() + " " + m.Currency
+}
+
+// Add складывает суммы одной валюты
+func (m Money) Add(other Money) (Money, error) {
+	if m.Currency != other.Currency {
+		return Money{}, fmt.Errorf("%w: %s and %s", ErrCurrencyMismatch, m.Currency, other.Currency)
+	}
+	sum := m.Amount + other.Amount
+	if (other.Amount > 0 && sum < m.Amount) || (other.Amount < 0 && sum > m.Amount) {
+		return Money{}, fmt.Errorf("%w: overflow", ErrInvalidAmount)
+	}
+	return Money{Amount: sum, Currency: m.Currency}, nil
+}
+
+// Mul умножает сумму на количество, например цену позиции на число единиц товара
+func (m Money) Mul(quantity int64) (Money, error) {
+	product := new(big.Int).Mul(big.NewInt(m.Amount), big.NewInt(quantity))
+	if !product.IsInt64() {
+		return Money{}, fmt.Errorf("%w: overflow", ErrInvalidAmount)
+	}
+	return Money{Amount: product.Int64(), Currency: m.Currency}, nil
+}
+
+// Convert пересчитывает сумму в валюту currency по курсу rate - десятичной записи того,
+// сколько единиц currency стоит одна единица исходной валюты. Результат округляется
+// до минимальной единицы currency, половина - от нуля.
+func (m Money) Convert(currency string, rate string) (Money, error) {
+	from, err := Exponent(m.Currency)
+	if err != nil {
+		return Money{}, err
+	}
+	to, err := Exponent(currency)
+	if err != nil {
+		return Money{}, err
+	}
+	r, ok := new(big.Rat).SetString(rate)
+	if !ok || r.Sign() <= 0 {
+		return Money{}, fmt.Errorf("invalid exchange rate %q", rate)
+	}
+
+	value := new(big.Rat).Mul(new(big.Rat).SetInt64(m.Amount), r)
+	scale := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(abs(to-from))), nil)
+	if to > from {
+		value.Mul(value, new(big.Rat).SetInt(scale))
+	} else {
+		value.Quo(value, new(big.Rat).SetInt(scale))
+	}
+	amount := roundHalfAwayFromZero(value)
+	if !amount.IsInt64() {
+		return Money{}, fmt.Errorf("%w: overflow", ErrInvalidAmount)
+	}
+	return Money{Amount: amount.Int64(), Currency: currency}, nil
+}
+
+func roundHalfAwayFromZero(v *big.Rat) *big.Int {
+	num := new(big.Int).Abs(v.Num())
+	// (2|num| + den) / 2den - округление |v| с половиной вверх
+	q := new(big.Int).Add(new(big.Int).Mul(num, big.NewInt(2)), v.Denom())
+	q.Quo(q, new(big.Int).Mul(v.Denom(), big.NewInt(2)))
+	if v.Sign() < 0 {
+		q.Neg(q)
+	}
+	return q
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
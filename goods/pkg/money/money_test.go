@@ -0,0 +1,109 @@
+package money
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseAndDecimal(t *testing.T) {
+	cases := []struct {
+		in       string
+		currency string
+		amount   int64
+		decimal  string
+	}{
+		{"123.45", "RUB", 12345, "123.45"},
+		{"0.5", "USD", 50, "0.50"},
+		{"7", "EUR", 700, "7.00"},
+		{"0.07", "RUB", 7, "0.07"},
+		{"-1.2", "RUB", -120, "-1.20"},
+		{"1500", "JPY", 1500, "1500"},
+		{"1.234", "KWD", 1234, "1.234"},
+	}
+	for _, c := range cases {
+		m, err := Parse(c.in, c.currency)
+		if err != nil {
+			t.Fatalf("Parse(%q, %s): %v", c.in, c.currency, err)
+		}
+		if m.Amount != c.amount || m.Currency != c.currency {
+			t.Errorf("Parse(%q, %s) = %+v, want amount %d", c.in, c.currency, m, c.amount)
+		}
+		if got := m.Decimal(); got != c.decimal {
+			t.Errorf("Decimal(%+v) = %q, want %q", m, got, c.decimal)
+		}
+	}
+}
+
+func TestParseRejectsInvalidInput(t *testing.T) {
+	cases := map[string]string{
+		"too many decimals": "1.234",
+		"decimals for JPY":  "1.5",
+		"empty units":       ".5",
+		"not a number":      "abc",
+		"exponent":          "1e3",
+		"overflow":          "92233720368547758.08",
+		"double sign":       "--1",
+		"empty":             "",
+	}
+	for name, in := range cases {
+		currency := "RUB"
+		if name == "decimals for JPY" {
+			currency = "JPY"
+		}
+		if _, err := Parse(in, currency); !errors.Is(err, ErrInvalidAmount) {
+			t.Errorf("%s: Parse(%q) err = %v, want ErrInvalidAmount", name, in, err)
+		}
+	}
+	if _, err := Parse("1", "XXX"); !errors.Is(err, ErrUnknownCurrency) {
+		t.Errorf("unknown currency: err = %v", err)
+	}
+}
+
+func TestArithmetic(t *testing.T) {
+	a := Money{Amount: 1050, Currency: "RUB"}
+	sum, err := a.Add(Money{Amount: 250, Currency: "RUB"})
+	if err != nil || sum.Amount != 1300 {
+		t.Fatalf("Add = %+v, %v", sum, err)
+	}
+	if _, err := a.Add(Money{Amount: 1, Currency: "USD"}); !errors.Is(err, ErrCurrencyMismatch) {
+		t.Fatalf("Add with other currency: err = %v", err)
+	}
+	if _, err := (Money{Amount: 1 << 62, Currency: "RUB"}).Add(Money{Amount: 1 << 62, Currency: "RUB"}); !errors.Is(err, ErrInvalidAmount) {
+		t.Fatalf("Add overflow: err = %v", err)
+	}
+	total, err := a.Mul(3)
+	if err != nil || total.Amount != 3150 {
+		t.Fatalf("Mul = %+v, %v", total, err)
+	}
+	if _, err := (Money{Amount: 1 << 62, Currency: "RUB"}).Mul(4); !errors.Is(err, ErrInvalidAmount) {
+		t.Fatalf("Mul overflow: err = %v", err)
+	}
+}
+
+func TestConvert(t *testing.T) {
+	cases := []struct {
+		from Money
+		to   string
+		rate string
+		want int64
+	}{
+		{Money{Amount: 10000, Currency: "RUB"}, "USD", "0.011", 110},   // 100.00 RUB -> 1.10 USD
+		{Money{Amount: 12345, Currency: "USD"}, "JPY", "150.5", 18579}, // 123.45 USD -> 18579.225 JPY
+		{Money{Amount: 1000, Currency: "JPY"}, "KWD", "0.002", 2000},   // 1000 JPY -> 2.000 KWD
+		{Money{Amount: 1, Currency: "RUB"}, "USD", "0.5", 1},           // 0.005 -> 0.01: половина от нуля
+		{Money{Amount: -1, Currency: "RUB"}, "USD", "0.5", -1},
+		{Money{Amount: 300, Currency: "USD"}, "RUB", "1/3", 100}, // обратный курс дробью
+	}
+	for _, c := range cases {
+		got, err := c.from.Convert(c.to, c.rate)
+		if err != nil {
+			t.Fatalf("Convert(%v, %s, %s): %v", c.from, c.to, c.rate, err)
+		}
+		if got.Amount != c.want || got.Currency != c.to {
+			t.Errorf("Convert(%v, %s, %s) = %v, want %d", c.from, c.to, c.rate, got, c.want)
+		}
+	}
+	if _, err := (Money{Amount: 1, Currency: "RUB"}).Convert("USD", "0"); err == nil {
+		t.Error("zero rate accepted")
+	}
+}
@@ -239,9 +239,24 @@ const docTemplate = `{
         }
     },
     "definitions": {
+        "product_dto.Money": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "integer"
+                },
+                "currency": {
+                    "type": "string"
+                }
+            }
+        },
         "product_dto.ProductRequest": {
             "type": "object",
             "properties": {
+                "attributes": {
+                    "type": "object",
+                    "additionalProperties": true
+                },
                 "brand": {
                     "type": "string"
                 },
@@ -258,7 +273,7 @@ const docTemplate = `{
                     "type": "string"
                 },
                 "price": {
-                    "type": "number"
+                    "$ref": "#/definitions/product_dto.Money"
                 },
                 "stock": {
                     "type": "integer"
@@ -268,6 +283,23 @@ const docTemplate = `{
         "product_dto.SearchRequest": {
             "type": "object",
             "properties": {
+                "attributeFacets": {
+                    "description": "фасеты по атрибутам, в ответе - Attributes.\u003cимя\u003e",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "attributes": {
+                    "description": "имя атрибута -\u003e допустимые значения",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        }
+                    }
+                },
                 "brand": {
                     "type": "array",
                     "items": {
@@ -280,6 +312,10 @@ const docTemplate = `{
                         "type": "string"
                     }
                 },
+                "currency": {
+                    "description": "валюта, в которой заданы minPrice и maxPrice",
+                    "type": "string"
+                },
                 "highlightFields": {
                     "type": "array",
                     "items": {
@@ -287,10 +323,11 @@ const docTemplate = `{
                     }
                 },
                 "maxPrice": {
-                    "type": "number"
+                    "type": "integer"
                 },
                 "minPrice": {
-                    "type": "number"
+                    "description": "в минимальных единицах валюты (копейках)",
+                    "type": "integer"
                 },
                 "page": {
                     "type": "integer"
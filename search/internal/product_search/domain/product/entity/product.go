@@ -4,8 +4,12 @@ type Product struct {
 	ID          string
 	Name        string
 	Description string
-	Price       float64
+	Price       int64  // цена в минимальных единицах валюты (копейках)
+	Currency    string // код валюты цены ISO 4217
+	PriceMin    int64  // минимальная цена среди вариантов; у товара без вариантов равна Price
+	PriceMax    int64  // максимальная цена среди вариантов; у товара без вариантов равна Price
 	Stock       int
 	Category    string
 	Brand       string
+	Attributes  map[string]interface{} // значения атрибутов категории по имени атрибута
 }
@@ -1,14 +1,17 @@
 package entity
 
 type SearchParams struct {
-	Query           string   // текстовый запрос
-	Categories      []string // фильтрация по категориям
-	Brand           []string // фильтрация по брендам
-	MinPrice        float64  // минимальная цена
-	MaxPrice        float64  // максимальная цена
-	SortBy          string   // "relevance", "price", "popularity"
-	SortOrder       string   // "asc" или "desc"
-	Page            int      // номер страницы (с 1)
-	PageSize        int      // размер страницы
-	HighlightFields []string // поля для подсветки
+	Query           string              // текстовый запрос
+	Categories      []string            // фильтрация по категориям
+	Brand           []string            // фильтрация по брендам
+	Currency        string              // валюта цены; границы цены сравниваются только с ценами в ней
+	MinPrice        int64               // минимальная цена в минимальных единицах валюты
+	MaxPrice        int64               // максимальная цена в минимальных единицах валюты
+	SortBy          string              // "relevance", "price", "popularity"
+	SortOrder       string              // "asc" или "desc"
+	Page            int                 // номер страницы (с 1)
+	PageSize        int                 // размер страницы
+	HighlightFields []string            // поля для подсветки
+	Attributes      map[string][]string // фильтрация по атрибутам: имя атрибута -> допустимые значения
+	AttributeFacets []string            // атрибуты, по которым считаются фасеты
 }
@@ -1,6 +1,12 @@
 package config
 
-import "log"
+import (
+	"log"
+	"os"
+)
+
+// defaultProductTopic совпадает со значением KAFKA_PRODUCT_TOPIC по умолчанию в goods
+const defaultProductTopic = "products"
 
 type KafkaConfig struct {
 	Common   KafkaCommonConfig
@@ -18,7 +24,7 @@ func LoadKafkaConfig() KafkaConfig {
 		},
 		Consumer: KafkaConsumerConfig{
 			GroupID:        getEnv("KAFKA_CONSUMER_GROUP_ID"),
-			UserOrderTopic: getEnv("KAFKA_CONSUMER_USER_ORDER_TOPIC"),
+			ProductTopic:   productTopic(),
 			StartOffset:    parseInt64("KAFKA_CONSUMER_START_OFFSET"),
 			CommitInterval: parseDuration("KAFKA_CONSUMER_COMMIT_INTERVAL"),
 			UseTLS:         parseBool("KAFKA_USE_TLS"),
@@ -32,3 +38,18 @@ func LoadKafkaConfig() KafkaConfig {
 
 	return cfg
 }
+
+// productTopic - топик событий товаров. Имя переменной и значение по умолчанию совпадают с сервисом goods;
+// прежняя KAFKA_CONSUMER_USER_ORDER_TOPIC учитывается, если новая не задана.
+func productTopic() string {
+	if topic := os.Getenv("KAFKA_PRODUCT_TOPIC"); topic != "" {
+		log.Printf("[config:kafka] KAFKA_PRODUCT_TOPIC=%s", topic)
+		return topic
+	}
+	if topic := os.Getenv("KAFKA_CONSUMER_USER_ORDER_TOPIC"); topic != "" {
+		log.Printf("[config:kafka] KAFKA_CONSUMER_USER_ORDER_TOPIC=%s (deprecated, use KAFKA_PRODUCT_TOPIC)", topic)
+		return topic
+	}
+	log.Printf("[config:kafka] KAFKA_PRODUCT_TOPIC is not set, using %q", defaultProductTopic)
+	return defaultProductTopic
+}
@@ -12,7 +12,7 @@ type KafkaCommonConfig struct {
 
 type KafkaConsumerConfig struct {
 	GroupID        string
-	UserOrderTopic string
+	ProductTopic   string
 	StartOffset    int64
 	CommitInterval time.Duration
 	UseTLS         bool
@@ -0,0 +1,295 @@
+package repository
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"strconv"
+	"strings"
+)
+
+// mappingVersion - версия схемы индекса. Документы лежат в индексе <index>_v<версия>, а чтение и запись
+// идут через алиас <index>. Схему существующего индекса Elasticsearch не меняет, поэтому при её
+// изменении версия увеличивается: при старте создаётся новый индекс, в него переиндексируются
+// документы прежнего и алиас атомарно переключается на новый индекс.
+const mappingVersion = 4
+
+// legacyIndexVersion - версия индекса, созданного до версионирования: обычный индекс с именем алиаса
+const legacyIndexVersion = 1
+
+// reindexScripts приводят документы версии-ключа к следующей версии схемы
+var reindexScripts = map[int]string{
+	// Цена хранилась в рублях дробным числом, теперь - целым числом копеек вместе с валютой
+	1: "if (ctx._source.Price != null) { ctx._source.Price = Math.round(ctx._source.Price * 100) } " +
+		"if (ctx._source.Currency == null) { ctx._source.Currency = 'RUB' }",
+	// Появился диапазон цен вариантов; до следующего события товара он совпадает с ценой карточки
+	3: "if (ctx._source.PriceMin == null) { ctx._source.PriceMin = ctx._source.Price } " +
+		"if (ctx._source.PriceMax == null) { ctx._source.PriceMax = ctx._source.Price }",
+}
+
+func indexMapping() map[string]interface{} {
+	return map[string]interface{}{
+		"mappings": map[string]interface{}{
+			"properties": map[string]interface{}{
+				"Name": map[string]interface{}{
+					"type": "text",
+					"fields": map[string]interface{}{
+						"keyword": map[string]interface{}{
+							"type": "keyword",
+						},
+					},
+				},
+				"Description": map[string]interface{}{
+					"type": "text",
+				},
+				"Category": map[string]interface{}{
+					"type": "keyword",
+				},
+				"Brand": map[string]interface{}{
+					"type": "keyword",
+				},
+				"Price": map[string]interface{}{
+					"type": "long",
+				},
+				"PriceMin": map[string]interface{}{
+					"type": "long",
+				},
+				"PriceMax": map[string]interface{}{
+					"type": "long",
+				},
+				"Currency": map[string]interface{}{
+					"type": "keyword",
+				},
+				"Popularity": map[string]interface{}{
+					"type": "integer",
+				},
+				// Набор атрибутов зависит от категории; flattened индексирует значения как keyword
+				// без отдельного поля на каждый атрибут
+				"Attributes": map[string]interface{}{
+					"type": "flattened",
+				},
+			},
+		},
+	}
+}
+
+func (r *ProductRepository) versionedIndex(version int) string {
+	return fmt.Sprintf("%s_v%d", r.index, version)
+}
+
+// IndicesExistsAndCreateIfMissing готовит индекс текущей версии схемы за алиасом r.index.
+// Документы индекса прежней версии (или индекса, созданного до версионирования) переиндексируются
+// в новый индекс до переключения алиаса. Вызывается при старте до запуска консьюмеров Kafka:
+// сообщения, записанные в прежний индекс во время переиндексации, потерялись бы.
+// Прежний версионированный индекс остаётся для отката и удаляется вручную.
+func (r *ProductRepository) IndicesExistsAndCreateIfMissing() error {
+	target := r.versionedIndex(mappingVersion)
+
+	current, err := r.aliasIndices()
+	if err != nil {
+		return err
+	}
+	if len(current) == 1 && current[0] == target {
+		log.Printf("[ProductRepository] Index '%s' (alias '%s') is up to date", target, r.index)
+		return nil
+	}
+
+	exists, err := r.indexExists(target)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		if err := r.createIndex(target); err != nil {
+			return err
+		}
+	}
+
+	// Источник переиндексации: индекс под алиасом или индекс, созданный до версионирования
+	source, sourceVersion := "", 0
+	legacy := false
+	switch {
+	case len(current) > 1:
+		return fmt.Errorf("alias '%s' points to several indices: %v", r.index, current)
+	case len(current) == 1:
+		source = current[0]
+		sourceVersion, err = strconv.Atoi(strings.TrimPrefix(source, r.index+"_v"))
+		if err != nil || sourceVersion >= mappingVersion {
+			return fmt.Errorf("alias '%s' points to unexpected index '%s'", r.index, source)
+		}
+	default:
+		legacy, err = r.indexExists(r.index)
+		if err != nil {
+			return err
+		}
+		if legacy {
+			source, sourceVersion = r.index, legacyIndexVersion
+		}
+	}
+
+	if source != "" {
+		if err := r.reindex(source, sourceVersion, target); err != nil {
+			return err
+		}
+	}
+	return r.switchAlias(source, legacy, target)
+}
+
+// aliasIndices возвращает индексы, на которые указывает алиас r.index
+func (r *ProductRepository) aliasIndices() ([]string, error) {
+	res, err := r.es.Connection.Indices.GetAlias(r.es.Connection.Indices.GetAlias.WithName(r.index))
+	if err != nil {
+		return nil, fmt.Errorf("get alias: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == 404 {
+		return nil, nil
+	}
+	if res.IsError() {
+		return nil, fmt.Errorf("get alias: %s", res.String())
+	}
+
+	var aliases map[string]json.RawMessage
+	if err := json.NewDecoder(res.Body).Decode(&aliases); err != nil {
+		return nil, fmt.Errorf("decode alias: %w", err)
+	}
+	indices := make([]string, 0, len(aliases))
+	for index := range aliases {
+		indices = append(indices, index)
+	}
+	return indices, nil
+}
+
+func (r *ProductRepository) indexExists(index string) (bool, error) {
+	res, err := r.es.Connection.Indices.Exists([]string{index})
+	if err != nil {
+		return false, fmt.Errorf("check index existence: %w", err)
+	}
+	defer res.Body.Close()
+
+	switch res.StatusCode {
+	case 200:
+		return true, nil
+	case 404:
+		return false, nil
+	default:
+		return false, fmt.Errorf("unexpected status when checking index existence: %d", res.StatusCode)
+	}
+}
+
+func (r *ProductRepository) createIndex(index string) error {
+	log.Printf("[ProductRepository] Creating index '%s'", index)
+
+	body, err := json.Marshal(indexMapping())
+	if err != nil {
+		return fmt.Errorf("marshal mapping: %w", err)
+	}
+
+	res, err := r.es.Connection.Indices.Create(
+		index,
+		r.es.Connection.Indices.Create.WithContext(context.Background()),
+		r.es.Connection.Indices.Create.WithBody(bytes.NewReader(body)),
+	)
+	if err != nil {
+		return fmt.Errorf("create index: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return fmt.Errorf("failed to create index: %s", responseText(res.Body))
+	}
+
+	log.Printf("[ProductRepository] Index '%s' created successfully", index)
+	return nil
+}
+
+// reindex копирует документы source версии sourceVersion в target, приводя их к текущей схеме
+func (r *ProductRepository) reindex(source string, sourceVersion int, target string) error {
+	log.Printf("[ProductRepository] Reindexing '%s' (v%d) into '%s'", source, sourceVersion, target)
+
+	var scripts []string
+	for v := sourceVersion; v < mappingVersion; v++ {
+		if script, ok := reindexScripts[v]; ok {
+			scripts = append(scripts, script)
+		}
+	}
+	request := map[string]interface{}{
+		"source": map[string]interface{}{"index": source},
+		"dest":   map[string]interface{}{"index": target},
+	}
+	if len(scripts) > 0 {
+		request["script"] = map[string]interface{}{"lang": "painless", "source": strings.Join(scripts, " ")}
+	}
+	body, err := json.Marshal(request)
+	if err != nil {
+		return fmt.Errorf("marshal reindex: %w", err)
+	}
+
+	res, err := r.es.Connection.Reindex(
+		bytes.NewReader(body),
+		r.es.Connection.Reindex.WithContext(context.Background()),
+		r.es.Connection.Reindex.WithWaitForCompletion(true),
+		r.es.Connection.Reindex.WithRefresh(true),
+	)
+	if err != nil {
+		return fmt.Errorf("reindex: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return fmt.Errorf("reindex: %s", responseText(res.Body))
+	}
+	var result struct {
+		Total    int64             `json:"total"`
+		Failures []json.RawMessage `json:"failures"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&result); err != nil {
+		return fmt.Errorf("decode reindex: %w", err)
+	}
+	if len(result.Failures) > 0 {
+		return fmt.Errorf("reindex '%s' into '%s': %d failures, first: %s", source, target, len(result.Failures), result.Failures[0])
+	}
+
+	log.Printf("[ProductRepository] Reindexed %d documents into '%s'", result.Total, target)
+	return nil
+}
+
+// switchAlias атомарно направляет алиас r.index на target. Индекс, созданный до версионирования,
+// удаляется в том же запросе: алиас не может называться так же, как существующий индекс.
+func (r *ProductRepository) switchAlias(source string, legacy bool, target string) error {
+	var actions []map[string]interface{}
+	switch {
+	case legacy:
+		actions = append(actions, map[string]interface{}{"remove_index": map[string]interface{}{"index": source}})
+	case source != "":
+		actions = append(actions, map[string]interface{}{"remove": map[string]interface{}{"index": source, "alias": r.index}})
+	}
+	actions = append(actions, map[string]interface{}{"add": map[string]interface{}{"index": target, "alias": r.index}})
+
+	body, err := json.Marshal(map[string]interface{}{"actions": actions})
+	if err != nil {
+		return fmt.Errorf("marshal aliases: %w", err)
+	}
+
+	res, err := r.es.Connection.Indices.UpdateAliases(bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("update aliases: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return fmt.Errorf("update aliases: %s", responseText(res.Body))
+	}
+
+	log.Printf("[ProductRepository] Alias '%s' now points to '%s'", r.index, target)
+	return nil
+}
+
+func responseText(body io.Reader) string {
+	buf := new(bytes.Buffer)
+	buf.ReadFrom(body)
+	return strings.TrimSpace(buf.String())
+}
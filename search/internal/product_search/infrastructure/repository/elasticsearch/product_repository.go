@@ -4,9 +4,9 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
-	"strings"
 	"time"
 
 	entityF "gitlab.mai.ru/4-bogatyra/backend/search/internal/product_search/domain/facet/entity"
@@ -20,6 +20,9 @@ import (
 	"gitlab.mai.ru/4-bogatyra/backend/search/internal/product_search/infrastructure/config/elasticsearch"
 )
 
+// ErrProductNotFound - удаляемого товара нет в индексе
+var ErrProductNotFound = errors.New("product not found")
+
 type ProductRepository struct {
 	es    *elasticsearch.Client
 	index string
@@ -112,6 +115,10 @@ func (r *ProductRepository) Delete(id string) error {
 	}
 	defer res.Body.Close()
 
+	if res.StatusCode == 404 {
+		log.Printf("[ProductRepository] Product ID=%s not found in index", id)
+		return fmt.Errorf("delete %s: %w", id, ErrProductNotFound)
+	}
 	if res.IsError() {
 		log.Printf("[ProductRepository][ERROR] Delete returned error for ID=%s: %s", id, res.String())
 		return fmt.Errorf("delete error: %s", res.String())
@@ -300,17 +307,29 @@ func (r *ProductRepository) Search(params *entitySP.SearchParams) (*entitySR.Sea
 			"terms": map[string]interface{}{"Brand": params.Brand},
 		})
 	}
-	if params.MinPrice > 0 || params.MaxPrice > 0 {
-		rangeQ := map[string]interface{}{}
-		if params.MinPrice > 0 {
-			rangeQ["gte"] = params.MinPrice
-		}
-		if params.MaxPrice > 0 {
-			rangeQ["lte"] = params.MaxPrice
-		}
-		log.Printf("[ProductRepository] Applying price range filter: %+v", rangeQ)
+	if params.Currency != "" {
+		log.Printf("[ProductRepository] Filtering by currency: %s", params.Currency)
+		filters = append(filters, map[string]interface{}{
+			"term": map[string]interface{}{"Currency": params.Currency},
+		})
+	}
+	// Товар подходит, если диапазон цен его вариантов пересекается с запрошенным
+	if params.MinPrice > 0 {
+		log.Printf("[ProductRepository] Applying min price filter: %d", params.MinPrice)
+		filters = append(filters, map[string]interface{}{
+			"range": map[string]interface{}{"PriceMax": map[string]interface{}{"gte": params.MinPrice}},
+		})
+	}
+	if params.MaxPrice > 0 {
+		log.Printf("[ProductRepository] Applying max price filter: %d", params.MaxPrice)
 		filters = append(filters, map[string]interface{}{
-			"range": map[string]interface{}{"Price": rangeQ},
+			"range": map[string]interface{}{"PriceMin": map[string]interface{}{"lte": params.MaxPrice}},
+		})
+	}
+	for name, values := range params.Attributes {
+		log.Printf("[ProductRepository] Filtering by attribute %s: %+v", name, values)
+		filters = append(filters, map[string]interface{}{
+			"terms": map[string]interface{}{"Attributes." + name: values},
 		})
 	}
 	if len(filters) > 0 {
@@ -360,8 +379,14 @@ func (r *ProductRepository) Search(params *entitySP.SearchParams) (*entitySR.Sea
 			"terms": map[string]interface{}{"field": field, "size": 10},
 		}
 	}
+	for _, name := range params.AttributeFacets {
+		field := "Attributes." + name
+		aggs[field] = map[string]interface{}{
+			"terms": map[string]interface{}{"field": field, "size": 10},
+		}
+	}
 	query["aggs"] = aggs
-	log.Printf("[ProductRepository] Aggregations configured for fields: category, brand")
+	log.Printf("[ProductRepository] Aggregations configured for fields: category, brand, attributes %+v", params.AttributeFacets)
 
 	body, _ := json.Marshal(query)
 	log.Printf("[ProductRepository] Final ES query JSON: %s", string(body))
@@ -487,79 +512,3 @@ func (r *ProductRepository) Health() (*entityCH.ClusterHealth, error) {
 	log.Printf("[ProductRepository] Cluster health status: %s", clusterHealth.Status)
 	return clusterHealth, nil
 }
-
-func (r *ProductRepository) IndicesExistsAndCreateIfMissing() error {
-	ctx := context.Background()
-
-	res, err := r.es.Connection.Indices.Exists([]string{r.index})
-	if err != nil {
-		return fmt.Errorf("check index existence: %w", err)
-	}
-	defer res.Body.Close()
-
-	if res.StatusCode == 200 {
-		log.Printf("[ProductRepository] Index '%s' already exists", r.index)
-		return nil
-	}
-
-	if res.StatusCode != 404 {
-		return fmt.Errorf("unexpected status when checking index existence: %d", res.StatusCode)
-	}
-
-	log.Printf("[ProductRepository] Index '%s' not found. Creating...", r.index)
-
-	mapping := map[string]interface{}{
-		"mappings": map[string]interface{}{
-			"properties": map[string]interface{}{
-				"Name": map[string]interface{}{
-					"type": "text",
-					"fields": map[string]interface{}{
-						"keyword": map[string]interface{}{
-							"type": "keyword",
-						},
-					},
-				},
-				"Description": map[string]interface{}{
-					"type": "text",
-				},
-				"Category": map[string]interface{}{
-					"type": "keyword",
-				},
-				"Brand": map[string]interface{}{
-					"type": "keyword",
-				},
-				"Price": map[string]interface{}{
-					"type": "float",
-				},
-				"Popularity": map[string]interface{}{
-					"type": "integer",
-				},
-			},
-		},
-	}
-
-	body, err := json.Marshal(mapping)
-	if err != nil {
-		return fmt.Errorf("marshal mapping: %w", err)
-	}
-
-	// Создание индекса
-	createRes, err := r.es.Connection.Indices.Create(
-		r.index,
-		r.es.Connection.Indices.Create.WithContext(ctx),
-		r.es.Connection.Indices.Create.WithBody(bytes.NewReader(body)),
-	)
-	if err != nil {
-		return fmt.Errorf("create index: %w", err)
-	}
-	defer createRes.Body.Close()
-
-	if createRes.IsError() {
-		buf := new(bytes.Buffer)
-		buf.ReadFrom(createRes.Body)
-		return fmt.Errorf("failed to create index: %s", strings.TrimSpace(buf.String()))
-	}
-
-	log.Printf("[ProductRepository] Index '%s' created successfully", r.index)
-	return nil
-}
@@ -11,6 +11,12 @@ import (
 	"log"
 )
 
+// Message - прочитанное из Kafka событие товара: новое состояние или удаление из индекса
+type Message struct {
+	Product *entity.Product
+	Deleted bool // в Product заполнен только ID
+}
+
 type Consumer struct {
 	Reader *kafka.Reader
 }
@@ -18,11 +24,11 @@ type Consumer struct {
 func NewConsumer(consumerConnection *connection.Consumer) *Consumer {
 	cfg := consumerConnection.Config
 
-	log.Printf("[kafka:product] initializing consumer: topic=%s, groupID=%s", cfg.UserOrderTopic, cfg.GroupID)
+	log.Printf("[kafka:product] initializing consumer: topic=%s, groupID=%s", cfg.ProductTopic, cfg.GroupID)
 
 	reader := kafka.NewReader(kafka.ReaderConfig{
 		Brokers:        consumerConnection.Brokers,
-		Topic:          cfg.UserOrderTopic,
+		Topic:          cfg.ProductTopic,
 		GroupID:        cfg.GroupID,
 		Dialer:         consumerConnection.Connection,
 		MinBytes:       cfg.MinBytes,
@@ -36,7 +42,7 @@ func NewConsumer(consumerConnection *connection.Consumer) *Consumer {
 	return &Consumer{Reader: reader}
 }
 
-func (c *Consumer) ReadMessage(ctx context.Context) (*entity.Product, error) {
+func (c *Consumer) ReadMessage(ctx context.Context) (*Message, error) {
 	log.Println("[kafka:product] reading message from Kafka")
 
 	msg, err := c.Reader.ReadMessage(ctx)
@@ -56,18 +62,31 @@ func (c *Consumer) ReadMessage(ctx context.Context) (*entity.Product, error) {
 	}
 	log.Printf("[kafka:product] DTO parsed: %+v", dto)
 
+	if dto.ID == "" {
+		log.Println("[kafka:product][ERROR] message without product ID")
+		return nil, fmt.Errorf("message without product ID")
+	}
+	if dto.Deleted {
+		log.Printf("[kafka:product] tombstone received: ID=%s", dto.ID)
+		return &Message{Product: &entity.Product{ID: dto.ID}, Deleted: true}, nil
+	}
+
 	product := &entity.Product{
 		ID:          dto.ID,
 		Name:        dto.Name,
 		Description: dto.Description,
-		Price:       dto.Price,
+		Price:       dto.Price.Amount,
+		Currency:    dto.Price.Currency,
+		PriceMin:    dto.PriceMin.Amount,
+		PriceMax:    dto.PriceMax.Amount,
 		Stock:       dto.Stock,
 		Category:    dto.Category,
 		Brand:       dto.Brand,
+		Attributes:  dto.Attributes,
 	}
 
 	log.Printf("[kafka:product] Product entity created: %+v", product)
-	return product, nil
+	return &Message{Product: product}, nil
 }
 
 func (c *Consumer) Close() error {
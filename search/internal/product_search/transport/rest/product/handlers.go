@@ -1,6 +1,7 @@
 package product
 
 import (
+	"fmt"
 	entityP "gitlab.mai.ru/4-bogatyra/backend/search/internal/product_search/domain/product/entity"
 	"log"
 	"net/http"
@@ -11,6 +12,9 @@ import (
 	"gitlab.mai.ru/4-bogatyra/backend/search/internal/product_search/use_cases"
 )
 
+// maxAttributeFacets ограничивает число агрегаций по атрибутам в одном запросе
+const maxAttributeFacets = 10
+
 type Handler struct {
 	ProductService *use_cases.ProductService
 }
@@ -41,6 +45,27 @@ func (h *Handler) SearchProducts(c *gin.Context) {
 		return
 	}
 	log.Printf("[ProductHandler] Payload: %+v", req)
+	// Цены в разных валютах несравнимы
+	if (req.MinPrice > 0 || req.MaxPrice > 0) && req.Currency == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "currency is required with minPrice or maxPrice"})
+		return
+	}
+	if len(req.AttributeFacets) > maxAttributeFacets {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("at most %d attributeFacets are allowed", maxAttributeFacets)})
+		return
+	}
+	for name, values := range req.Attributes {
+		if name == "" || len(values) == 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "attribute filter needs a name and at least one value"})
+			return
+		}
+	}
+	for _, name := range req.AttributeFacets {
+		if name == "" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "attributeFacets must not contain empty names"})
+			return
+		}
+	}
 
 	result, err := h.ProductService.SearchProducts(&req)
 	if err != nil {
@@ -101,10 +126,14 @@ func (h *Handler) CreateProduct(c *gin.Context) {
 		ID:          req.ID,
 		Name:        req.Name,
 		Description: req.Description,
-		Price:       req.Price,
+		Price:       req.Price.Amount,
+		Currency:    req.Price.Currency,
+		PriceMin:    req.Price.Amount,
+		PriceMax:    req.Price.Amount,
 		Stock:       req.Stock,
 		Category:    req.Category,
 		Brand:       req.Brand,
+		Attributes:  req.Attributes,
 	}
 
 	if err := h.ProductService.IndexProduct(p); err != nil {
@@ -144,10 +173,14 @@ func (h *Handler) BulkCreateProducts(c *gin.Context) {
 			ID:          req.ID,
 			Name:        req.Name,
 			Description: req.Description,
-			Price:       req.Price,
+			Price:       req.Price.Amount,
+			Currency:    req.Price.Currency,
+			PriceMin:    req.Price.Amount,
+			PriceMax:    req.Price.Amount,
 			Stock:       req.Stock,
 			Category:    req.Category,
 			Brand:       req.Brand,
+			Attributes:  req.Attributes,
 		})
 	}
 
@@ -1,11 +1,18 @@
 package product_dto
 
 type ProductRequest struct {
-	ID          string  `json:"id"`
-	Name        string  `json:"name"`
-	Description string  `json:"description"`
-	Price       float64 `json:"price"`
-	Stock       int     `json:"stock"`
-	Category    string  `json:"category"`
-	Brand       string  `json:"brand"`
+	ID          string                 `json:"id"`
+	Name        string                 `json:"name"`
+	Description string                 `json:"description"`
+	Price       Money                  `json:"price"`
+	Stock       int                    `json:"stock"`
+	Category    string                 `json:"category"`
+	Brand       string                 `json:"brand"`
+	Attributes  map[string]interface{} `json:"attributes"`
+}
+
+// Money - сумма в минимальных единицах валюты (копейках) и код валюты ISO 4217
+type Money struct {
+	Amount   int64  `json:"amount"`
+	Currency string `json:"currency"`
 }
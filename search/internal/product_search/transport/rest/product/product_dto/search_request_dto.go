@@ -4,11 +4,15 @@ type SearchRequest struct {
 	Query           string   `json:"query"`
 	Categories      []string `json:"categories"`
 	Brand           []string `json:"brand"`
-	MinPrice        float64  `json:"minPrice"`
-	MaxPrice        float64  `json:"maxPrice"`
+	Currency        string   `json:"currency"` // валюта, в которой заданы minPrice и maxPrice
+	MinPrice        int64    `json:"minPrice"` // в минимальных единицах валюты (копейках)
+	MaxPrice        int64    `json:"maxPrice"`
 	SortBy          string   `json:"sortBy"`
 	SortOrder       string   `json:"sortOrder"`
 	Page            int      `json:"page"`
 	PageSize        int      `json:"pageSize"`
 	HighlightFields []string `json:"highlightFields"`
+	// Значения атрибутов сравниваются как строки: "42", "true"
+	Attributes      map[string][]string `json:"attributes"`      // имя атрибута -> допустимые значения
+	AttributeFacets []string            `json:"attributeFacets"` // фасеты по атрибутам, в ответе - Attributes.<имя>
 }
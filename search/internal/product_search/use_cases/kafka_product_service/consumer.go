@@ -2,8 +2,11 @@ package kafka_product_service
 
 import (
 	"context"
+	"errors"
 	"log"
 	"sync"
+
+	repository "gitlab.mai.ru/4-bogatyra/backend/search/internal/product_search/infrastructure/repository/elasticsearch"
 )
 
 func (kps *Service) StartConsumerWorkers(ctx context.Context, numWorkers int) {
@@ -67,11 +70,23 @@ func (kps *Service) consumeLoop(ctx context.Context, workerID int) {
 func (kps *Service) CreateProductFromKafka(ctx context.Context) error {
 	log.Println("[kafka_product_service] CreateProductFromKafka invoked")
 
-	product, err := kps.Consumer.ReadMessage(ctx)
+	msg, err := kps.Consumer.ReadMessage(ctx)
 	if err != nil {
 		log.Printf("[kafka_product_service][consumer] Error reading message from Kafka: %v", err)
 		return err
 	}
+	product := msg.Product
+
+	if msg.Deleted {
+		// Доставка at-least-once: повторное удаление уже удалённого товара не ошибка
+		err = kps.ProductService.Repo.Delete(product.ID)
+		if err != nil && !errors.Is(err, repository.ErrProductNotFound) {
+			log.Printf("[kafka_product_service][consumer][ERROR] Error deleting product: %v", err)
+			return err
+		}
+		log.Printf("[kafka_product_service][consumer] Product removed from index: ID=%s", product.ID)
+		return nil
+	}
 
 	log.Printf("[kafka_product_service][consumer] Received Product: %+v", product)
 
@@ -84,6 +84,7 @@ func (s *ProductService) SearchProducts(req *product_dto.SearchRequest) (*entity
 		Query:           req.Query,
 		Categories:      req.Categories,
 		Brand:           req.Brand,
+		Currency:        req.Currency,
 		MinPrice:        req.MinPrice,
 		MaxPrice:        req.MaxPrice,
 		SortBy:          req.SortBy,
@@ -91,6 +92,8 @@ func (s *ProductService) SearchProducts(req *product_dto.SearchRequest) (*entity
 		Page:            req.Page,
 		PageSize:        req.PageSize,
 		HighlightFields: req.HighlightFields,
+		Attributes:      req.Attributes,
+		AttributeFacets: req.AttributeFacets,
 	}
 
 	if params.PageSize == 0 {
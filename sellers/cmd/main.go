@@ -1,15 +1,22 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	config "market/internal/cfg"
 	"market/internal/services"
 	"market/internal/transport"
+	"os"
 )
 
 func main() {
 	cfg := config.LoadConfig()
+	// Схема меняется отдельным запуском: main migrate up|down|status
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		os.Exit(runMigrate(cfg, os.Args[2:]))
+	}
 	fmt.Printf("%v", cfg)
 	s := services.NewSrv(cfg)
+	go s.RunIdempotencyPurge(context.Background(), cfg.IdempotencyPurgeInterval)
 	transport.HandleCreate(cfg, s)
 }
@@ -3,6 +3,8 @@ package config
 import (
 	"log"
 	"os"
+	"strconv"
+	"time"
 
 	"github.com/joho/godotenv"
 )
@@ -14,6 +16,21 @@ type Config struct {
 	DBHost     string
 	DBPort     string
 	SslMode    string
+
+	RedisAddr     string // Пусто - кэш профилей продавцов отключён
+	RedisPassword string
+	RedisDB       int
+	RedisTimeout  time.Duration // Таймаут одной команды Redis; при недоступности Redis чтение идёт в базу
+	CacheTTL      time.Duration // Сколько живёт профиль продавца в кэше
+
+	IdempotencyTTL           time.Duration // Сколько хранится ответ на запрос с Idempotency-Key
+	IdempotencyPurgeInterval time.Duration // Период удаления истёкших ключей идемпотентности
+
+	JWTSecret       string        // Ключ подписи токенов продавцов (HS256); без него сервис не запускается
+	AccessTokenTTL  time.Duration // Сколько действует токен доступа
+	RefreshTokenTTL time.Duration // Сколько действует токен обновления
+
+	AdminJWTSecret string // Ключ проверки токенов администраторов (HS256); пусто - доступ администраторов отключён
 }
 
 func LoadConfig() Config {
@@ -30,5 +47,46 @@ func LoadConfig() Config {
 		DBHost:     os.Getenv("DB_HOST"),
 		DBPort:     os.Getenv("DB_PORT"),
 		SslMode:    os.Getenv("DB_SSLMODE"),
+
+		RedisAddr:     os.Getenv("REDIS_ADDR"),
+		RedisPassword: os.Getenv("REDIS_PASSWORD"),
+		RedisDB:       getInt("REDIS_DB", 0),
+		RedisTimeout:  getDuration("REDIS_TIMEOUT", 200*time.Millisecond),
+		CacheTTL:      getDuration("CACHE_TTL", 5*time.Minute),
+
+		IdempotencyTTL:           getDuration("IDEMPOTENCY_TTL", 24*time.Hour),
+		IdempotencyPurgeInterval: getDuration("IDEMPOTENCY_PURGE_INTERVAL", time.Hour),
+
+		JWTSecret:       os.Getenv("JWT_SECRET"),
+		AccessTokenTTL:  getDuration("ACCESS_TOKEN_TTL", 15*time.Minute),
+		RefreshTokenTTL: getDuration("REFRESH_TOKEN_TTL", 30*24*time.Hour),
+
+		AdminJWTSecret: os.Getenv("ADMIN_JWT_SECRET"),
+	}
+}
+
+// getInt читает целое число из переменной окружения, возвращая def, если она не задана
+func getInt(key string, def int) int {
+	raw := os.Getenv(key)
+	if raw == "" {
+		return def
+	}
+	v, err := strconv.Atoi(raw)
+	if err != nil {
+		log.Fatalf("Invalid int for %s: %v", key, err)
+	}
+	return v
+}
+
+// getDuration читает длительность из переменной окружения, возвращая def, если она не задана
+func getDuration(key string, def time.Duration) time.Duration {
+	raw := os.Getenv(key)
+	if raw == "" {
+		return def
+	}
+	v, err := time.ParseDuration(raw)
+	if err != nil {
+		log.Fatalf("Invalid duration for %s: %v", key, err)
 	}
+	return v
 }
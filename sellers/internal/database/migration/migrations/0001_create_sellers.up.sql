@@ -0,0 +1,8 @@
+-- IF NOT EXISTS: базы, созданные прежним одноразовым init.sql, принимают версионирование с этой миграции
+CREATE TABLE IF NOT EXISTS sellers (
+	uuid UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+	name VARCHAR(255) NOT NULL,
+	passport INT UNIQUE NOT NULL,
+	telephone_number VARCHAR(16) UNIQUE NOT NULL,
+	description TEXT
+);
@@ -0,0 +1 @@
+DROP TABLE seller_idempotency_keys;
@@ -0,0 +1 @@
+DROP INDEX sellers_name_idx;
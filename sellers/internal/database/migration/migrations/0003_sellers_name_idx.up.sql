@@ -0,0 +1,2 @@
+-- Сортировка и пагинация списка продавцов по названию
+CREATE INDEX sellers_name_idx ON sellers (name, uuid);
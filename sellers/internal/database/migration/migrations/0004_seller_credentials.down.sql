@@ -0,0 +1,2 @@
+DROP TABLE seller_refresh_tokens;
+ALTER TABLE sellers DROP COLUMN password_hash;
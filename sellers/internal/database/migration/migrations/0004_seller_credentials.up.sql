@@ -0,0 +1,10 @@
+-- NULL у продавцов, созданных до появления входа: войти они не могут, пока пароль не задан
+ALTER TABLE sellers ADD COLUMN password_hash TEXT;
+
+CREATE TABLE seller_refresh_tokens (
+	id UUID PRIMARY KEY,                                                 -- jti токена обновления
+	seller_id UUID NOT NULL REFERENCES sellers (uuid) ON DELETE CASCADE,
+	expires_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX seller_refresh_tokens_seller_idx ON seller_refresh_tokens (seller_id);
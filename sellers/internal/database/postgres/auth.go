@@ -0,0 +1,50 @@
+package postgresdb
+
+import (
+	"database/sql"
+	myErrors "market/internal/errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ReadCredentials возвращает продавца с номером телефона telephoneNumber и хэш его пароля.
+// Продавец без пароля не может войти, поэтому для него, как и для неизвестного номера, возвращается ErrInvalidCredentials.
+func (db *Postgres) ReadCredentials(telephoneNumber string) (uuid.UUID, string, error) {
+	var id uuid.UUID
+	var hash sql.NullString
+	err := db.Connection.QueryRow("SELECT uuid, password_hash FROM sellers WHERE telephone_number = $1", telephoneNumber).Scan(&id, &hash)
+	if err == sql.ErrNoRows || (err == nil && !hash.Valid) {
+		return uuid.Nil, "", myErrors.ErrInvalidCredentials
+	}
+	if err != nil {
+		return uuid.Nil, "", myErrors.ErrAuthInternal
+	}
+	return id, hash.String, nil
+}
+
+// SaveRefreshToken запоминает выданный токен обновления; истёкшие токены продавца при этом удаляются
+func (db *Postgres) SaveRefreshToken(tokenID uuid.UUID, sellerID uuid.UUID, expiresAt time.Time) error {
+	if _, err := db.Connection.Exec("DELETE FROM seller_refresh_tokens WHERE seller_id = $1 AND expires_at <= now()", sellerID); err != nil {
+		return myErrors.ErrAuthInternal
+	}
+	_, err := db.Connection.Exec("INSERT INTO seller_refresh_tokens (id, seller_id, expires_at) VALUES ($1, $2, $3)", tokenID, sellerID, expiresAt)
+	if err != nil {
+		return myErrors.ErrAuthInternal
+	}
+	return nil
+}
+
+// ConsumeRefreshToken удаляет действующий токен обновления и возвращает его продавца.
+// Каждый токен обновления используется один раз.
+func (db *Postgres) ConsumeRefreshToken(tokenID uuid.UUID) (uuid.UUID, error) {
+	var sellerID uuid.UUID
+	err := db.Connection.QueryRow("DELETE FROM seller_refresh_tokens WHERE id = $1 AND expires_at > now() RETURNING seller_id", tokenID).Scan(&sellerID)
+	if err == sql.ErrNoRows {
+		return uuid.Nil, myErrors.ErrInvalidToken
+	}
+	if err != nil {
+		return uuid.Nil, myErrors.ErrAuthInternal
+	}
+	return sellerID, nil
+}
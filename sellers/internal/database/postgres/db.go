@@ -4,6 +4,7 @@ import (
 	"database/sql"
 	"fmt"
 	config "market/internal/cfg"
+	"market/internal/database/migration"
 	myErrors "market/internal/errors"
 	myLog "market/internal/logger"
 	"market/internal/models"
@@ -19,8 +20,7 @@ type Postgres struct {
 }
 
 func NewPostgres(cfg config.Config) *Postgres {
-	connStr := fmt.Sprintf("user=%s password=%s dbname=%s host=%s port=%s sslmode=%s", cfg.DBUser, cfg.DBPassword, cfg.DBName, cfg.DBHost, cfg.DBPort, cfg.SslMode)
-	db, err := sql.Open("postgres", connStr)
+	db, err := Connect(cfg)
 	if err != nil {
 		myLog.Log.Fatalf("Failed to connect to PostgreSQL: %v", err)
 		return nil //, myErrors.ErrCreatePostgresConnection
@@ -35,24 +35,50 @@ func NewPostgres(cfg config.Config) *Postgres {
 	}
 	time.Sleep(time.Minute)
 
+	// Со схемой старее кода запросы падали бы уже во время работы
+	if err := migration.Check(db); err != nil {
+		myLog.Log.Fatalf("Refusing to serve: %v", err)
+		return nil
+	}
+
 	return &Postgres{
 		Connection: db,
 	}
 }
 
-func (db *Postgres) CreateSeller(seller models.Seller) (uuid.UUID, error) {
+// Connect открывает пул соединений с базой без проверки схемы (для команды migrate)
+func Connect(cfg config.Config) (*sql.DB, error) {
+	connStr := fmt.Sprintf("user=%s password=%s dbname=%s host=%s port=%s sslmode=%s", cfg.DBUser, cfg.DBPassword, cfg.DBName, cfg.DBHost, cfg.DBPort, cfg.SslMode)
+	return sql.Open("postgres", connStr)
+}
+
+func (db *Postgres) CreateSeller(seller models.Seller, passwordHash string) (uuid.UUID, error) {
 	query := `
-		INSERT INTO sellers (name, passport, telephone_number, description)
-		VALUES ($1, $2, $3, $4)
+		INSERT INTO sellers (name, passport, telephone_number, description, password_hash)
+		VALUES ($1, $2, $3, $4, $5)
 		RETURNING uuid`
 	var id uuid.UUID
-	err := db.Connection.QueryRow(query, seller.Name, seller.Passport, seller.Telephone_Number, seller.Description).Scan(&id)
+	err := db.Connection.QueryRow(query, seller.Name, *seller.Passport, seller.Telephone_Number, seller.Description, passwordHash).Scan(&id)
 	if err != nil {
 		return id, err
 	}
 	return id, nil
 }
 
+func (db *Postgres) ReadSeller(sellerID uuid.UUID) (models.Seller, error) {
+	seller := models.Seller{UUID: sellerID}
+	err := db.Connection.QueryRow(`
+		SELECT name, passport, telephone_number, COALESCE(description, '')
+		FROM sellers WHERE uuid = $1`, sellerID).Scan(&seller.Name, &seller.Passport, &seller.Telephone_Number, &seller.Description)
+	if err == sql.ErrNoRows {
+		return seller, myErrors.ErrReadSellerNotFound
+	}
+	if err != nil {
+		return seller, myErrors.ErrReadSellerInternal
+	}
+	return seller, nil
+}
+
 func (db *Postgres) DeleteSeller(sellerID uuid.UUID) error {
 	var exists bool
 	err := db.Connection.QueryRow("SELECT EXISTS(SELECT 1 FROM sellers WHERE uuid = $1)", sellerID).Scan(&exists)
@@ -67,18 +93,9 @@ func (db *Postgres) DeleteSeller(sellerID uuid.UUID) error {
 	return err_
 }
 
-func (db *Postgres) UpdateSeller(id uuid.UUID, seller models.Seller) error {
-	// Проверяем, существует ли продавец с таким ID
-	var exists bool
-	err := db.Connection.QueryRow("SELECT EXISTS(SELECT 1 FROM sellers WHERE uuid = $1)", id).Scan(&exists)
-
-	if err != nil {
-		return myErrors.ErrUpdateSellerInternal // Если произошла ошибка при выполнении запроса
-	}
-	if !exists {
-		return myErrors.ErrUpdateSellerNotFound
-	}
-
+// UpdateSeller меняет непустые поля профиля и, если passwordHash не пуст, пароль продавца.
+// Всё выполняется в одной транзакции; смена пароля отзывает токены обновления продавца.
+func (db *Postgres) UpdateSeller(id uuid.UUID, seller models.Seller, passwordHash string) error {
 	query := "UPDATE sellers SET"
 	var params []interface{}
 	var setClauses []string
@@ -99,9 +116,14 @@ func (db *Postgres) UpdateSeller(id uuid.UUID, seller models.Seller) error {
 		params = append(params, seller.Telephone_Number)
 		paramIndex++
 	}
-	if seller.Passport != 0 {
+	if seller.Passport != nil {
 		setClauses = append(setClauses, fmt.Sprintf(" passport = $%d", paramIndex))
-		params = append(params, seller.Passport)
+		params = append(params, *seller.Passport)
+		paramIndex++
+	}
+	if passwordHash != "" {
+		setClauses = append(setClauses, fmt.Sprintf(" password_hash = $%d", paramIndex))
+		params = append(params, passwordHash)
 		paramIndex++
 	}
 
@@ -115,7 +137,29 @@ func (db *Postgres) UpdateSeller(id uuid.UUID, seller models.Seller) error {
 	query += fmt.Sprintf(" WHERE uuid = $%d", paramIndex)
 	params = append(params, id)
 
-	// Выполняем запрос
-	_, err_ := db.Connection.Exec(query, params...)
-	return err_
+	tx, err := db.Connection.Begin()
+	if err != nil {
+		return myErrors.ErrUpdateSellerInternal
+	}
+	defer tx.Rollback()
+
+	res, err := tx.Exec(query, params...)
+	if err != nil {
+		return err
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return myErrors.ErrUpdateSellerInternal
+	} else if n == 0 {
+		return myErrors.ErrUpdateSellerNotFound
+	}
+	if passwordHash != "" {
+		if _, err := tx.Exec("DELETE FROM seller_refresh_tokens WHERE seller_id = $1", id); err != nil {
+			return myErrors.ErrUpdateSellerInternal
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return myErrors.ErrUpdateSellerInternal
+	}
+	return nil
 }
@@ -0,0 +1,88 @@
+package postgresdb
+
+import (
+	"database/sql"
+	myErrors "market/internal/errors"
+	"market/internal/models"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ClaimIdempotencyKey занимает ключ за запросом с отпечатком fingerprint на ttl. Истёкший ключ
+// и ключ, запрос которого не завершился за lease (например, процесс упал), занимаются заново.
+// Если ключ занят, возвращается сохранённый ответ первого запроса и false.
+func (db *Postgres) ClaimIdempotencyKey(scope uuid.UUID, key string, fingerprint string, ttl time.Duration, lease time.Duration) (models.IdempotentResponse, bool, error) {
+	// Ключ могут удалить между вставкой и чтением (истёк, или первый запрос завершился ошибкой) - тогда пробуем снова
+	for attempt := 0; attempt < 2; attempt++ {
+		var claimed bool
+		err := db.Connection.QueryRow(`
+			INSERT INTO seller_idempotency_keys (scope, key, fingerprint, expires_at)
+			VALUES ($1, $2, $3, now() + $4 * interval '1 millisecond')
+			ON CONFLICT (scope, key) DO UPDATE
+			SET fingerprint = EXCLUDED.fingerprint, status_code = NULL, content_type = '', body = NULL,
+			    created_at = now(), expires_at = EXCLUDED.expires_at
+			WHERE seller_idempotency_keys.expires_at <= now()
+			   OR (seller_idempotency_keys.status_code IS NULL AND seller_idempotency_keys.created_at <= now() - $5 * interval '1 millisecond')
+			RETURNING true`, scope, key, fingerprint, ttl.Milliseconds(), lease.Milliseconds()).Scan(&claimed)
+		if err == nil {
+			return models.IdempotentResponse{}, true, nil
+		}
+		if err != sql.ErrNoRows {
+			return models.IdempotentResponse{}, false, myErrors.ErrIdempotencyInternal
+		}
+
+		var stored models.IdempotentResponse
+		var statusCode sql.NullInt64
+		err = db.Connection.QueryRow(`
+			SELECT fingerprint, status_code, content_type, body FROM seller_idempotency_keys
+			WHERE scope = $1 AND key = $2`, scope, key).Scan(&stored.Fingerprint, &statusCode, &stored.ContentType, &stored.Body)
+		if err == sql.ErrNoRows {
+			continue
+		}
+		if err != nil {
+			return models.IdempotentResponse{}, false, myErrors.ErrIdempotencyInternal
+		}
+		stored.StatusCode = int(statusCode.Int64)
+		return stored, false, nil
+	}
+	return models.IdempotentResponse{}, false, myErrors.ErrIdempotencyInProgress
+}
+
+// SaveIdempotentResponse запоминает ответ на запрос, занявший ключ
+func (db *Postgres) SaveIdempotentResponse(scope uuid.UUID, key string, response models.IdempotentResponse) error {
+	_, err := db.Connection.Exec(`
+		UPDATE seller_idempotency_keys SET status_code = $3, content_type = $4, body = $5
+		WHERE scope = $1 AND key = $2 AND status_code IS NULL`,
+		scope, key, response.StatusCode, response.ContentType, response.Body)
+	if err != nil {
+		return myErrors.ErrIdempotencyInternal
+	}
+	return nil
+}
+
+// ReleaseIdempotencyKey освобождает ключ, ответ на который не запоминается
+func (db *Postgres) ReleaseIdempotencyKey(scope uuid.UUID, key string) error {
+	_, err := db.Connection.Exec("DELETE FROM seller_idempotency_keys WHERE scope = $1 AND key = $2 AND status_code IS NULL", scope, key)
+	if err != nil {
+		return myErrors.ErrIdempotencyInternal
+	}
+	return nil
+}
+
+// PurgeIdempotencyKeys удаляет до limit истёкших ключей и возвращает их количество
+func (db *Postgres) PurgeIdempotencyKeys(limit int) (int, error) {
+	res, err := db.Connection.Exec(`
+		DELETE FROM seller_idempotency_keys
+		WHERE (scope, key) IN (
+			SELECT scope, key FROM seller_idempotency_keys WHERE expires_at <= now() LIMIT $1
+		)`, limit)
+	if err != nil {
+		return 0, myErrors.ErrIdempotencyInternal
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return 0, myErrors.ErrIdempotencyInternal
+	}
+	return int(n), nil
+}
@@ -2,14 +2,29 @@ package postgresdb
 
 import (
 	"market/internal/models"
+	"time"
 
 	"github.com/google/uuid"
 )
 
 type InterfacePostgresDB interface {
-	CreateSeller(seller models.Seller) (uuid.UUID, error)
+	CreateSeller(seller models.Seller, passwordHash string) (uuid.UUID, error)
+
+	ReadSeller(sellerID uuid.UUID) (models.Seller, error)
+
+	ListSellers(filter models.SellerFilter) (models.SellerPage, error)
 
 	DeleteSeller(sellerID uuid.UUID) error
 
-	UpdateSeller(uuid uuid.UUID, seller models.Seller) error
+	// Пустой passwordHash оставляет прежний пароль
+	UpdateSeller(uuid uuid.UUID, seller models.Seller, passwordHash string) error
+
+	ReadCredentials(telephoneNumber string) (uuid.UUID, string, error)
+	SaveRefreshToken(tokenID uuid.UUID, sellerID uuid.UUID, expiresAt time.Time) error
+	ConsumeRefreshToken(tokenID uuid.UUID) (uuid.UUID, error)
+
+	ClaimIdempotencyKey(scope uuid.UUID, key string, fingerprint string, ttl time.Duration, lease time.Duration) (models.IdempotentResponse, bool, error)
+	SaveIdempotentResponse(scope uuid.UUID, key string, response models.IdempotentResponse) error
+	ReleaseIdempotencyKey(scope uuid.UUID, key string) error
+	PurgeIdempotencyKeys(limit int) (int, error)
 }
@@ -0,0 +1,156 @@
+package postgresdb
+
+import (
+	"database/sql"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	myErrors "market/internal/errors"
+	"market/internal/models"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// sellerSortColumns сопоставляет порядок сортировки с колонкой и направлением
+var sellerSortColumns = map[string]struct {
+	column string
+	desc   bool
+}{
+	models.SellerSortDefault:  {column: "uuid"},
+	models.SellerSortNameAsc:  {column: "name"},
+	models.SellerSortNameDesc: {column: "name", desc: true},
+}
+
+// listCursor - позиция последней отданной строки. Пагинация по ключу (keyset),
+// поэтому регистрация новых продавцов не сдвигает уже выданные страницы.
+type listCursor struct {
+	Sort  string    `json:"s"`
+	Value string    `json:"v"`
+	ID    uuid.UUID `json:"id"`
+}
+
+func encodeCursor(c listCursor) string {
+	raw, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+func decodeCursor(s string) (listCursor, error) {
+	var c listCursor
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return c, myErrors.ErrInvalidCursor
+	}
+	if err := json.Unmarshal(raw, &c); err != nil || c.ID == uuid.Nil {
+		return c, myErrors.ErrInvalidCursor
+	}
+	return c, nil
+}
+
+// likePattern экранирует спецсимволы LIKE, чтобы подстрока искалась буквально
+func likePattern(s string) string {
+	return "%" + strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s) + "%"
+}
+
+func (db *Postgres) ListSellers(filter models.SellerFilter) (models.SellerPage, error) {
+	order, ok := sellerSortColumns[filter.Sort]
+	if !ok {
+		return models.SellerPage{}, myErrors.ErrInvalidSellerSort
+	}
+
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = models.DefaultPageLimit
+	}
+	if limit > models.MaxPageLimit {
+		limit = models.MaxPageLimit
+	}
+
+	var params []interface{}
+	var whereClauses []string
+	paramIndex := 1 // Индекс параметра для использования в запросе
+
+	addClause := func(clause string, value interface{}) {
+		whereClauses = append(whereClauses, fmt.Sprintf(clause, paramIndex))
+		params = append(params, value)
+		paramIndex++
+	}
+
+	if filter.Name != "" {
+		addClause("name ILIKE $%d", likePattern(filter.Name))
+	}
+	if filter.Phone != "" {
+		addClause("telephone_number LIKE $%d", likePattern(filter.Phone))
+	}
+	if filter.Passport != nil {
+		addClause("passport = $%d", *filter.Passport)
+	}
+	if filter.HasDescription != nil {
+		addClause("(COALESCE(description, '') <> '') = $%d", *filter.HasDescription)
+	}
+
+	if filter.Cursor != "" {
+		cursor, err := decodeCursor(filter.Cursor)
+		if err != nil {
+			return models.SellerPage{}, err
+		}
+		if cursor.Sort != filter.Sort {
+			return models.SellerPage{}, myErrors.ErrInvalidCursor
+		}
+		op := ">"
+		if order.desc {
+			op = "<"
+		}
+		// Сравнение кортежей (ключ, uuid) даёт однозначный порядок даже при одинаковых названиях
+		cast := "text"
+		if order.column == "uuid" {
+			cast = "uuid"
+		}
+		whereClauses = append(whereClauses, fmt.Sprintf("(%s, uuid) %s ($%d::%s, $%d)", order.column, op, paramIndex, cast, paramIndex+1))
+		params = append(params, cursor.Value, cursor.ID)
+		paramIndex += 2
+	}
+
+	query := `
+		SELECT uuid, name, passport, telephone_number, COALESCE(description, ''), ` + order.column + `::text
+		FROM sellers`
+	if len(whereClauses) > 0 {
+		query += " WHERE " + strings.Join(whereClauses, " AND ")
+	}
+	direction := "ASC"
+	if order.desc {
+		direction = "DESC"
+	}
+	query += fmt.Sprintf(" ORDER BY %s %s, uuid %s LIMIT $%d", order.column, direction, direction, paramIndex)
+	// Запрашиваем на одну строку больше, чтобы понять, есть ли следующая страница
+	params = append(params, limit+1)
+
+	rows, err := db.Connection.Query(query, params...)
+	if err != nil {
+		return models.SellerPage{}, myErrors.ErrListSellersInternal
+	}
+	defer rows.Close()
+
+	page := models.SellerPage{Items: make([]models.Seller, 0, limit)}
+	var lastKey string
+	for rows.Next() {
+		var seller models.Seller
+		var sortKey sql.NullString
+		err := rows.Scan(&seller.UUID, &seller.Name, &seller.Passport, &seller.Telephone_Number, &seller.Description, &sortKey)
+		if err != nil {
+			return models.SellerPage{}, myErrors.ErrListSellersInternal
+		}
+		if len(page.Items) == limit {
+			// Лишняя строка существует - значит, есть следующая страница
+			last := page.Items[len(page.Items)-1]
+			page.NextCursor = encodeCursor(listCursor{Sort: filter.Sort, Value: lastKey, ID: last.UUID})
+			break
+		}
+		page.Items = append(page.Items, seller)
+		lastKey = sortKey.String
+	}
+	if err := rows.Err(); err != nil {
+		return models.SellerPage{}, myErrors.ErrListSellersInternal
+	}
+	return page, nil
+}
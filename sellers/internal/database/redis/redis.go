@@ -1 +1,197 @@
-package rediscashe
\ No newline at end of file
+package rediscashe
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	config "market/internal/cfg"
+	myLog "market/internal/logger"
+	"math/rand"
+	"sync/atomic"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"golang.org/x/sync/singleflight"
+)
+
+const (
+	// После ошибки Redis кэш не читается столько времени, чтобы недоступный Redis не замедлял каждый запрос
+	cooldown = 5 * time.Second
+	// Сколько загрузка из базы держит блокировку ключа от других экземпляров сервиса
+	lockTTL = 2 * time.Second
+	// Сколько экземпляр без блокировки ждёт, пока значение загрузит её владелец
+	lockWait = 300 * time.Millisecond
+	lockPoll = 20 * time.Millisecond
+)
+
+// setIfCurrent записывает значение, только если версия ключа не изменилась с начала загрузки.
+// Иначе загрузка могла прочитать базу до изменения, инвалидация которого уже прошла, и записала бы устаревшее значение.
+var setIfCurrent = redis.NewScript(`
+if (redis.call('GET', KEYS[2]) or '') ~= ARGV[1] then
+	return 0
+end
+redis.call('SET', KEYS[1], ARGV[2], 'PX', ARGV[3])
+return 1`)
+
+// Cache - кэш чтения поверх Redis. Ошибки Redis не прерывают запрос: значение загружается из базы.
+// Значения хранятся в JSON, у каждого значения есть ключ версии, который увеличивается при инвалидации.
+type Cache struct {
+	client *redis.Client
+	ttl    time.Duration
+	prefix string
+
+	group     singleflight.Group
+	downUntil atomic.Int64 // Unix-время в наносекундах, до которого кэш не читается
+}
+
+// NewClient создаёт клиент Redis с коротким таймаутом: недоступный Redis не должен задерживать запросы
+func NewClient(cfg config.Config) *redis.Client {
+	return redis.NewClient(&redis.Options{
+		Addr:         cfg.RedisAddr,
+		Password:     cfg.RedisPassword,
+		DB:           cfg.RedisDB,
+		DialTimeout:  cfg.RedisTimeout,
+		ReadTimeout:  cfg.RedisTimeout,
+		WriteTimeout: cfg.RedisTimeout,
+		MaxRetries:   -1,
+	})
+}
+
+// NewCache создаёт кэш с ключами вида prefix:...; значения живут ttl с разбросом до 10%,
+// чтобы ключи, загруженные одновременно, не истекали одновременно
+func NewCache(client *redis.Client, prefix string, ttl time.Duration) *Cache {
+	return &Cache{client: client, ttl: ttl, prefix: prefix}
+}
+
+func (c *Cache) key(kind string, id string) string {
+	return c.prefix + ":" + kind + ":" + id
+}
+
+func (c *Cache) versionKey(id string) string {
+	return c.prefix + ":version:" + id
+}
+
+func (c *Cache) available() bool {
+	return time.Now().UnixNano() >= c.downUntil.Load()
+}
+
+func (c *Cache) fail(op string, err error) {
+	c.downUntil.Store(time.Now().Add(cooldown).UnixNano())
+	myLog.Log.Warnf("Redis %s failed, reading from database: %v", op, err)
+}
+
+func (c *Cache) expiration() time.Duration {
+	return c.ttl + time.Duration(rand.Int63n(int64(c.ttl)/10+1))
+}
+
+// load возвращает значение kind:id из кэша или загружает его через fetch.
+// Одновременные промахи по ключу внутри процесса объединяются в одну загрузку, а между экземплярами
+// загружает тот, кто занял блокировку ключа: остальные ждут значение до lockWait.
+// Версия id общая для всех kind: инвалидация id сбрасывает все его значения.
+func load[T any](c *Cache, kind string, id string, fetch func() (T, error)) (T, error) {
+	if !c.available() {
+		return fetch()
+	}
+
+	ctx := context.Background()
+	key := c.key(kind, id)
+	cached, err := c.client.Get(ctx, key).Bytes()
+	if err == nil {
+		var value T
+		if json.Unmarshal(cached, &value) == nil {
+			return value, nil
+		}
+	} else if !errors.Is(err, redis.Nil) {
+		c.fail("GET", err)
+		return fetch()
+	}
+
+	raw, err, _ := c.group.Do(key, func() (interface{}, error) {
+		return c.fill(ctx, key, id, func() (interface{}, error) { return fetch() })
+	})
+	var value T
+	if err != nil {
+		return value, err
+	}
+	// Каждый вызов получает свою копию: значения с общими срезами нельзя отдавать нескольким вызывающим
+	if err := json.Unmarshal(raw.([]byte), &value); err != nil {
+		return fetch()
+	}
+	return value, nil
+}
+
+// fill загружает значение из базы и записывает его в кэш, если за время загрузки оно не было инвалидировано
+func (c *Cache) fill(ctx context.Context, key string, id string, fetch func() (interface{}, error)) ([]byte, error) {
+	version, err := c.client.Get(ctx, c.versionKey(id)).Result()
+	if err != nil && !errors.Is(err, redis.Nil) {
+		c.fail("GET", err)
+		return marshalFetched(fetch)
+	}
+
+	lockKey := key + ":lock"
+	locked, err := c.client.SetNX(ctx, lockKey, 1, lockTTL).Result()
+	if err != nil {
+		c.fail("SETNX", err)
+		return marshalFetched(fetch)
+	}
+	if locked {
+		defer c.client.Del(ctx, lockKey)
+	} else if cached, ok := c.await(ctx, key); ok {
+		return cached, nil
+	}
+
+	raw, err := marshalFetched(fetch)
+	if err != nil {
+		return nil, err
+	}
+	err = setIfCurrent.Run(ctx, c.client, []string{key, c.versionKey(id)}, version, raw, c.expiration().Milliseconds()).Err()
+	if err != nil {
+		c.fail("SET", err)
+	}
+	return raw, nil
+}
+
+// await ждёт, пока значение загрузит экземпляр, занявший блокировку
+func (c *Cache) await(ctx context.Context, key string) ([]byte, bool) {
+	deadline := time.Now().Add(lockWait)
+	for time.Now().Before(deadline) {
+		time.Sleep(lockPoll)
+		cached, err := c.client.Get(ctx, key).Bytes()
+		if err == nil {
+			return cached, true
+		}
+		if !errors.Is(err, redis.Nil) {
+			return nil, false
+		}
+	}
+	return nil, false
+}
+
+func marshalFetched(fetch func() (interface{}, error)) ([]byte, error) {
+	value, err := fetch()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(value)
+}
+
+// Invalidate удаляет значения ids всех видов kinds и увеличивает их версии,
+// чтобы незавершённые загрузки не записали значения, прочитанные до изменения
+func (c *Cache) Invalidate(kinds []string, ids ...string) {
+	if len(ids) == 0 {
+		return
+	}
+	ctx := context.Background()
+	pipe := c.client.TxPipeline()
+	for _, id := range ids {
+		for _, kind := range kinds {
+			pipe.Del(ctx, c.key(kind, id))
+		}
+		pipe.Incr(ctx, c.versionKey(id))
+		// Версия нужна, только пока живут значения и загрузки, начатые до инвалидации
+		pipe.Expire(ctx, c.versionKey(id), c.ttl+lockTTL)
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		myLog.Log.Errorf("Redis invalidation of %v failed, cached values may be stale for up to %v: %v", ids, c.ttl, err)
+	}
+}
@@ -0,0 +1,41 @@
+package rediscashe
+
+import (
+	postgresdb "market/internal/database/postgres"
+	"market/internal/models"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+const kindSeller = "seller"
+
+var sellerKinds = []string{kindSeller}
+
+// CachedDB кэширует профили продавцов поверх базы; изменение и удаление продавца сбрасывают его профиль.
+// Остальные методы передаются базе без изменений.
+type CachedDB struct {
+	postgresdb.InterfacePostgresDB
+	cache *Cache
+}
+
+func NewCachedDB(db postgresdb.InterfacePostgresDB, client *redis.Client, ttl time.Duration) *CachedDB {
+	return &CachedDB{InterfacePostgresDB: db, cache: NewCache(client, "sellers", ttl)}
+}
+
+func (db *CachedDB) ReadSeller(sellerID uuid.UUID) (models.Seller, error) {
+	return load(db.cache, kindSeller, sellerID.String(), func() (models.Seller, error) {
+		return db.InterfacePostgresDB.ReadSeller(sellerID)
+	})
+}
+
+func (db *CachedDB) UpdateSeller(id uuid.UUID, seller models.Seller, passwordHash string) error {
+	defer db.cache.Invalidate(sellerKinds, id.String())
+	return db.InterfacePostgresDB.UpdateSeller(id, seller, passwordHash)
+}
+
+func (db *CachedDB) DeleteSeller(sellerID uuid.UUID) error {
+	defer db.cache.Invalidate(sellerKinds, sellerID.String())
+	return db.InterfacePostgresDB.DeleteSeller(sellerID)
+}
@@ -0,0 +1,72 @@
+package rediscashe
+
+import (
+	postgresdb "market/internal/database/postgres"
+	myErrors "market/internal/errors"
+	"market/internal/models"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+// fakeSellerDB хранит одного продавца и считает чтения из базы
+type fakeSellerDB struct {
+	postgresdb.InterfacePostgresDB
+	seller *models.Seller
+	reads  int
+}
+
+func (f *fakeSellerDB) ReadSeller(sellerID uuid.UUID) (models.Seller, error) {
+	f.reads++
+	if f.seller == nil {
+		return models.Seller{}, myErrors.ErrReadSellerNotFound
+	}
+	return *f.seller, nil
+}
+
+func (f *fakeSellerDB) UpdateSeller(id uuid.UUID, seller models.Seller, passwordHash string) error {
+	f.seller.Name = seller.Name
+	return nil
+}
+
+func (f *fakeSellerDB) DeleteSeller(sellerID uuid.UUID) error {
+	f.seller = nil
+	return nil
+}
+
+func TestSellerCache(t *testing.T) {
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr(), MaxRetries: -1, DialTimeout: 100 * time.Millisecond})
+	defer client.Close()
+	fake := &fakeSellerDB{seller: &models.Seller{Name: "Shop"}}
+	db := NewCachedDB(fake, client, time.Minute)
+	id := uuid.New()
+
+	db.ReadSeller(id)
+	if seller, err := db.ReadSeller(id); err != nil || seller.Name != "Shop" || fake.reads != 1 {
+		t.Fatalf("second read: %+v, %v, database reads = %d, want cached", seller, err, fake.reads)
+	}
+
+	if err := db.UpdateSeller(id, models.Seller{Name: "Better shop"}, ""); err != nil {
+		t.Fatal(err)
+	}
+	if seller, _ := db.ReadSeller(id); seller.Name != "Better shop" {
+		t.Fatalf("read after update = %q, want fresh profile", seller.Name)
+	}
+
+	if err := db.DeleteSeller(id); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.ReadSeller(id); err != myErrors.ErrReadSellerNotFound {
+		t.Fatalf("read after delete: err = %v, want not found", err)
+	}
+
+	mr.Close()
+	fake.seller = &models.Seller{Name: "Offline"}
+	if seller, err := db.ReadSeller(id); err != nil || seller.Name != "Offline" {
+		t.Fatalf("read without Redis: %+v, %v, want database value", seller, err)
+	}
+}
@@ -35,6 +35,10 @@ var ErrParseJSON = NewError(fasthttp.StatusBadRequest, "error decoding json")
 // postrgess
 var ErrCreateSellerInternal = NewError(fasthttp.StatusInternalServerError, "error create seller")
 var ErrCreateSellerFound = NewError(fasthttp.StatusFound, "error create seller: seller not new")
+var ErrPassportRequired = NewError(fasthttp.StatusBadRequest, "error create seller: passport is required")
+
+var ErrReadSellerInternal = NewError(fasthttp.StatusInternalServerError, "error read seller")
+var ErrReadSellerNotFound = NewError(fasthttp.StatusNotFound, "error read seller: seller not found")
 
 var ErrUpdateSellerInternal = NewError(fasthttp.StatusInternalServerError, "error update seller")
 var ErrUpdateSellerNotFound = NewError(fasthttp.StatusInternalServerError, "error update seller: seller not found")
@@ -42,3 +46,27 @@ var ErrUpdateSellerNotFields = NewError(fasthttp.StatusInternalServerError, "err
 
 var ErrDeleteSellerInternal = NewError(fasthttp.StatusInternalServerError, "error delete seller")
 var ErrDeleteSellerNotFound = NewError(fasthttp.StatusNotFound, "error delete seller: seller not found")
+
+// Ошибки ключей идемпотентности
+var (
+	ErrIdempotencyKeyInvalid = NewError(fasthttp.StatusBadRequest, "error: Idempotency-Key must be 1 to 255 characters")
+	ErrIdempotencyKeyReused  = NewError(fasthttp.StatusUnprocessableEntity, "error: Idempotency-Key was already used with a different request")
+	ErrIdempotencyInProgress = NewError(fasthttp.StatusConflict, "error: request with this Idempotency-Key is still in progress")
+	ErrIdempotencyInternal   = NewError(fasthttp.StatusInternalServerError, "error: internal error while processing Idempotency-Key")
+)
+
+// Ошибки чтения и поиска продавцов
+var (
+	ErrListSellersInternal = NewError(fasthttp.StatusInternalServerError, "error list sellers")
+	ErrInvalidSellerSort   = NewError(fasthttp.StatusBadRequest, "error list sellers: invalid sort")
+	ErrInvalidCursor       = NewError(fasthttp.StatusBadRequest, "error list sellers: invalid cursor")
+	ErrAdminOnly           = NewError(fasthttp.StatusForbidden, "error: administrator rights required")
+)
+
+// Ошибки аутентификации продавцов
+var (
+	ErrPasswordInvalid    = NewError(fasthttp.StatusBadRequest, "error: password must be 8 to 72 bytes long")
+	ErrInvalidCredentials = NewError(fasthttp.StatusUnauthorized, "error: invalid telephone number or password")
+	ErrInvalidToken       = NewError(fasthttp.StatusUnauthorized, "error: invalid or expired token")
+	ErrAuthInternal       = NewError(fasthttp.StatusInternalServerError, "error: internal error while authenticating seller")
+)
@@ -26,40 +26,40 @@ func initLogger() *MyLogger {
 
 func (l *MyLogger) Infof(mes string, v ...interface{}) {
 	if len(v) == 0 {
-		l.Lg.Info().Msgf(mes)
+		l.Lg.Info().Msg(mes)
 		return
 	}
-	l.Lg.Info().Msgf(mes, v)
+	l.Lg.Info().Msgf(mes, v...)
 }
 
 func (l *MyLogger) Debugf(mes string, v ...interface{}) {
 	if len(v) == 0 {
-		l.Lg.Debug().Msgf(mes)
+		l.Lg.Debug().Msg(mes)
 		return
 	}
-	l.Lg.Debug().Msgf(mes, v)
+	l.Lg.Debug().Msgf(mes, v...)
 }
 
 func (l *MyLogger) Errorf(mes string, v ...interface{}) {
 	if len(v) == 0 {
-		l.Lg.Error().Msgf(mes)
+		l.Lg.Error().Msg(mes)
 		return
 	}
-	l.Lg.Error().Msgf(mes, v)
+	l.Lg.Error().Msgf(mes, v...)
 }
 
 func (l *MyLogger) Warnf(mes string, v ...interface{}) {
 	if len(v) == 0 {
-		l.Lg.Warn().Msgf(mes)
+		l.Lg.Warn().Msg(mes)
 		return
 	}
-	l.Lg.Warn().Msgf(mes, v)
+	l.Lg.Warn().Msgf(mes, v...)
 }
 
 func (l *MyLogger) Fatalf(mes string, v ...interface{}) {
 	if len(v) == 0 {
-		l.Lg.Fatal().Msgf(mes)
+		l.Lg.Fatal().Msg(mes)
 		return
 	}
-	l.Lg.Fatal().Msgf(mes, v)
+	l.Lg.Fatal().Msgf(mes, v...)
 }
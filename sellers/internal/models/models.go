@@ -1,9 +1,76 @@
 package models
 
+import "github.com/google/uuid"
+
 // Seller представляет таблицу sellers
 type Seller struct {
-	Name             string `json:"name"`
-	Passport         int    `json:"passport"`
-	Telephone_Number string `json:"telephone_number"`
-	Description      string `json:"description"`
+	UUID             uuid.UUID `json:"uuid"` // Заполняется при чтении, в запросах на изменение игнорируется
+	Name             string    `json:"name"`
+	Passport         *int      `json:"passport,omitempty"` // Не отдаётся посторонним, см. Caller.CanSeePrivate; обязателен при регистрации
+	Telephone_Number string    `json:"telephone_number"`
+	Description      string    `json:"description"`
+}
+
+// SellerAccount - тело регистрации и изменения продавца: профиль и пароль.
+// При изменении пустой пароль оставляет прежний.
+type SellerAccount struct {
+	Seller
+	Password string `json:"password"`
+}
+
+// Tokens - пара токенов, выдаваемая при входе и обновлении
+type Tokens struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	TokenType    string `json:"token_type"` // Всегда "Bearer"
+	ExpiresIn    int    `json:"expires_in"` // Через сколько секунд истекает токен доступа
+}
+
+// Caller - кто выполняет запрос к сервису. Нулевое значение - анонимный покупатель.
+type Caller struct {
+	SellerID uuid.UUID // Продавец, от имени которого выполняется запрос; uuid.Nil, если не продавец
+	Admin    bool      // Запрос от администратора
+}
+
+// CanSeePrivate сообщает, видит ли вызывающий паспорт и телефон продавца sellerID целиком
+func (c Caller) CanSeePrivate(sellerID uuid.UUID) bool {
+	return c.Admin || (c.SellerID != uuid.Nil && c.SellerID == sellerID)
+}
+
+// Порядок сортировки списка продавцов
+const (
+	SellerSortDefault  = ""          // По uuid
+	SellerSortNameAsc  = "name_asc"  // По названию по возрастанию
+	SellerSortNameDesc = "name_desc" // По названию по убыванию
+)
+
+// SellerFilter - параметры поиска продавцов
+type SellerFilter struct {
+	Name           string // Подстрока названия без учёта регистра
+	Phone          string // Подстрока номера телефона
+	Passport       *int   // Точный номер паспорта
+	HasDescription *bool  // Только продавцы с описанием (true) или без него (false)
+	Sort           string // Порядок сортировки, одна из констант SellerSort*
+	Limit          int    // Размер страницы
+	Cursor         string // Курсор, полученный с предыдущей страницы
+}
+
+// Ограничения размера страницы списка продавцов
+const (
+	DefaultPageLimit = 20
+	MaxPageLimit     = 100
+)
+
+// SellerPage - страница списка продавцов
+type SellerPage struct {
+	Items      []Seller `json:"items"`
+	NextCursor string   `json:"nextCursor,omitempty"` // Пустой, если страниц больше нет
+}
+
+// IdempotentResponse - ответ на первый запрос с ключом Idempotency-Key, который повторяется для повторов
+type IdempotentResponse struct {
+	Fingerprint string // Отпечаток первого запроса
+	StatusCode  int    // 0, пока первый запрос выполняется
+	ContentType string
+	Body        []byte
 }
@@ -0,0 +1,157 @@
+package services
+
+import (
+	myErrors "market/internal/errors"
+	"market/internal/models"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Тип токена в claim typ: токен обновления нельзя предъявить вместо токена доступа и наоборот
+const (
+	tokenAccess  = "access"
+	tokenRefresh = "refresh"
+	// Токен администратора выпускает сервис администрирования и подписывает ключом ADMIN_JWT_SECRET
+	tokenAdmin = "admin"
+)
+
+// Ограничения длины пароля; bcrypt учитывает не больше 72 байт
+const (
+	minPasswordLength = 8
+	maxPasswordLength = 72
+)
+
+// dummyHash сравнивается с паролем неизвестного продавца, чтобы время ответа не выдавало,
+// зарегистрирован ли номер телефона
+var dummyHash, _ = bcrypt.GenerateFromPassword([]byte("dummy password"), bcrypt.DefaultCost)
+
+type tokenClaims struct {
+	Type string `json:"typ"`
+	jwt.RegisteredClaims
+}
+
+func (srv *Srv) Login(telephoneNumber string, password string) (models.Tokens, error) {
+	id, hash, err := srv.db.ReadCredentials(telephoneNumber)
+	if err == myErrors.ErrInvalidCredentials {
+		bcrypt.CompareHashAndPassword(dummyHash, []byte(password))
+		return models.Tokens{}, err
+	}
+	if err != nil {
+		return models.Tokens{}, err
+	}
+	if bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)) != nil {
+		return models.Tokens{}, myErrors.ErrInvalidCredentials
+	}
+	return srv.issueTokens(id)
+}
+
+func (srv *Srv) Refresh(refreshToken string) (models.Tokens, error) {
+	claims, err := srv.parseToken(refreshToken, tokenRefresh, srv.jwtSecret)
+	if err != nil {
+		return models.Tokens{}, err
+	}
+	tokenID, err := uuid.Parse(claims.ID)
+	if err != nil {
+		return models.Tokens{}, myErrors.ErrInvalidToken
+	}
+	sellerID, err := srv.db.ConsumeRefreshToken(tokenID)
+	if err != nil {
+		return models.Tokens{}, err
+	}
+	return srv.issueTokens(sellerID)
+}
+
+func (srv *Srv) Authenticate(accessToken string) (uuid.UUID, error) {
+	claims, err := srv.parseToken(accessToken, tokenAccess, srv.jwtSecret)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	return subjectID(claims)
+}
+
+func (srv *Srv) AuthenticateAdmin(adminToken string) (uuid.UUID, error) {
+	if len(srv.adminJWTSecret) == 0 {
+		return uuid.Nil, myErrors.ErrInvalidToken
+	}
+	claims, err := srv.parseToken(adminToken, tokenAdmin, srv.adminJWTSecret)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	return subjectID(claims)
+}
+
+// subjectID возвращает ID владельца токена из claim sub
+func subjectID(claims tokenClaims) (uuid.UUID, error) {
+	id, err := uuid.Parse(claims.Subject)
+	if err != nil || id == uuid.Nil {
+		return uuid.Nil, myErrors.ErrInvalidToken
+	}
+	return id, nil
+}
+
+// issueTokens подписывает пару токенов продавца и запоминает токен обновления
+func (srv *Srv) issueTokens(sellerID uuid.UUID) (models.Tokens, error) {
+	now := time.Now()
+	access, err := srv.signToken(tokenAccess, sellerID, uuid.New(), now, srv.accessTokenTTL)
+	if err != nil {
+		return models.Tokens{}, err
+	}
+	refreshID := uuid.New()
+	refresh, err := srv.signToken(tokenRefresh, sellerID, refreshID, now, srv.refreshTokenTTL)
+	if err != nil {
+		return models.Tokens{}, err
+	}
+	if err := srv.db.SaveRefreshToken(refreshID, sellerID, now.Add(srv.refreshTokenTTL)); err != nil {
+		return models.Tokens{}, err
+	}
+	return models.Tokens{
+		AccessToken:  access,
+		RefreshToken: refresh,
+		TokenType:    "Bearer",
+		ExpiresIn:    int(srv.accessTokenTTL.Seconds()),
+	}, nil
+}
+
+func (srv *Srv) signToken(typ string, sellerID uuid.UUID, tokenID uuid.UUID, now time.Time, ttl time.Duration) (string, error) {
+	claims := tokenClaims{
+		Type: typ,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   sellerID.String(),
+			ID:        tokenID.String(),
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(ttl)),
+		},
+	}
+	signed, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(srv.jwtSecret)
+	if err != nil {
+		return "", myErrors.ErrAuthInternal
+	}
+	return signed, nil
+}
+
+// parseToken проверяет подпись ключом key, срок и тип токена
+func (srv *Srv) parseToken(raw string, typ string, key []byte) (tokenClaims, error) {
+	var claims tokenClaims
+	_, err := jwt.ParseWithClaims(raw, &claims, func(*jwt.Token) (interface{}, error) {
+		return key, nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}), jwt.WithExpirationRequired())
+	if err != nil || claims.Type != typ {
+		return tokenClaims{}, myErrors.ErrInvalidToken
+	}
+	return claims, nil
+}
+
+// hashPassword проверяет длину пароля и возвращает его bcrypt-хэш
+func hashPassword(password string) (string, error) {
+	if len(password) < minPasswordLength || len(password) > maxPasswordLength {
+		return "", myErrors.ErrPasswordInvalid
+	}
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", myErrors.ErrAuthInternal
+	}
+	return string(hash), nil
+}
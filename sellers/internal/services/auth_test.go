@@ -0,0 +1,212 @@
+package services
+
+import (
+	postgresdb "market/internal/database/postgres"
+	myErrors "market/internal/errors"
+	"market/internal/models"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+)
+
+// fakeAuthDB хранит продавцов по телефону и выданные токены обновления
+type fakeAuthDB struct {
+	postgresdb.InterfacePostgresDB
+	ids     map[string]uuid.UUID
+	hashes  map[uuid.UUID]string
+	refresh map[uuid.UUID]uuid.UUID
+	updates int
+}
+
+func newFakeAuthDB() *fakeAuthDB {
+	return &fakeAuthDB{ids: map[string]uuid.UUID{}, hashes: map[uuid.UUID]string{}, refresh: map[uuid.UUID]uuid.UUID{}}
+}
+
+func (f *fakeAuthDB) CreateSeller(seller models.Seller, passwordHash string) (uuid.UUID, error) {
+	id := uuid.New()
+	f.ids[seller.Telephone_Number] = id
+	f.hashes[id] = passwordHash
+	return id, nil
+}
+
+func (f *fakeAuthDB) UpdateSeller(id uuid.UUID, seller models.Seller, passwordHash string) error {
+	f.updates++
+	if passwordHash != "" {
+		f.hashes[id] = passwordHash
+	}
+	return nil
+}
+
+func (f *fakeAuthDB) ReadCredentials(telephoneNumber string) (uuid.UUID, string, error) {
+	id, ok := f.ids[telephoneNumber]
+	if !ok {
+		return uuid.Nil, "", myErrors.ErrInvalidCredentials
+	}
+	return id, f.hashes[id], nil
+}
+
+func (f *fakeAuthDB) SaveRefreshToken(tokenID uuid.UUID, sellerID uuid.UUID, expiresAt time.Time) error {
+	f.refresh[tokenID] = sellerID
+	return nil
+}
+
+func (f *fakeAuthDB) ConsumeRefreshToken(tokenID uuid.UUID) (uuid.UUID, error) {
+	sellerID, ok := f.refresh[tokenID]
+	if !ok {
+		return uuid.Nil, myErrors.ErrInvalidToken
+	}
+	delete(f.refresh, tokenID)
+	return sellerID, nil
+}
+
+func newAuthSrv(db postgresdb.InterfacePostgresDB) *Srv {
+	return &Srv{db: db, jwtSecret: []byte("secret"), accessTokenTTL: time.Minute, refreshTokenTTL: time.Hour}
+}
+
+func TestLoginAndRefresh(t *testing.T) {
+	srv := newAuthSrv(newFakeAuthDB())
+	passport := 123456
+	account := models.SellerAccount{Seller: models.Seller{Passport: &passport, Telephone_Number: "+79991234567"}, Password: "correct horse"}
+
+	if _, err := srv.Create(models.SellerAccount{Seller: models.Seller{Telephone_Number: "+79990000000"}, Password: account.Password}); err != myErrors.ErrPassportRequired {
+		t.Fatalf("no passport: err = %v, want ErrPassportRequired", err)
+	}
+
+	if _, err := srv.Create(models.SellerAccount{Seller: account.Seller, Password: "short"}); err != myErrors.ErrPasswordInvalid {
+		t.Fatalf("short password: err = %v, want ErrPasswordInvalid", err)
+	}
+	id, err := srv.Create(account)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := srv.Login(account.Telephone_Number, "wrong password"); err != myErrors.ErrInvalidCredentials {
+		t.Fatalf("wrong password: err = %v, want ErrInvalidCredentials", err)
+	}
+	if _, err := srv.Login("+70000000000", account.Password); err != myErrors.ErrInvalidCredentials {
+		t.Fatalf("unknown phone: err = %v, want ErrInvalidCredentials", err)
+	}
+	tokens, err := srv.Login(account.Telephone_Number, account.Password)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, err := srv.Authenticate(tokens.AccessToken); err != nil || got != id {
+		t.Fatalf("Authenticate(access) = %v, %v, want %v", got, err, id)
+	}
+	if _, err := srv.Authenticate(tokens.RefreshToken); err != myErrors.ErrInvalidToken {
+		t.Fatalf("Authenticate(refresh): err = %v, want ErrInvalidToken", err)
+	}
+
+	refreshed, err := srv.Refresh(tokens.RefreshToken)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, err := srv.Authenticate(refreshed.AccessToken); err != nil || got != id {
+		t.Fatalf("Authenticate(refreshed access) = %v, %v, want %v", got, err, id)
+	}
+	if _, err := srv.Refresh(tokens.RefreshToken); err != myErrors.ErrInvalidToken {
+		t.Fatalf("reused refresh token: err = %v, want ErrInvalidToken", err)
+	}
+}
+
+func TestAuthenticateRejectsForeignAndExpiredTokens(t *testing.T) {
+	srv := newAuthSrv(newFakeAuthDB())
+	id := uuid.New()
+
+	expired, err := srv.signToken(tokenAccess, id, uuid.New(), time.Now().Add(-time.Hour), time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := srv.Authenticate(expired); err != myErrors.ErrInvalidToken {
+		t.Fatalf("expired token: err = %v, want ErrInvalidToken", err)
+	}
+
+	other := newAuthSrv(newFakeAuthDB())
+	other.jwtSecret = []byte("another secret")
+	foreign, err := other.signToken(tokenAccess, id, uuid.New(), time.Now(), time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := srv.Authenticate(foreign); err != myErrors.ErrInvalidToken {
+		t.Fatalf("token signed with another key: err = %v, want ErrInvalidToken", err)
+	}
+}
+
+func TestAuthenticateAdmin(t *testing.T) {
+	srv := newAuthSrv(newFakeAuthDB())
+	adminID := uuid.New()
+	signAdmin := func(typ string, key string) string {
+		claims := tokenClaims{
+			Type: typ,
+			RegisteredClaims: jwt.RegisteredClaims{
+				Subject:   adminID.String(),
+				ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Minute)),
+			},
+		}
+		signed, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(key))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return signed
+	}
+
+	if _, err := srv.AuthenticateAdmin(signAdmin(tokenAdmin, "admin secret")); err != myErrors.ErrInvalidToken {
+		t.Fatalf("admin secret not configured: err = %v, want ErrInvalidToken", err)
+	}
+
+	srv.adminJWTSecret = []byte("admin secret")
+	if got, err := srv.AuthenticateAdmin(signAdmin(tokenAdmin, "admin secret")); err != nil || got != adminID {
+		t.Fatalf("AuthenticateAdmin = %v, %v, want %v", got, err, adminID)
+	}
+	if _, err := srv.AuthenticateAdmin(signAdmin(tokenAdmin, "secret")); err != myErrors.ErrInvalidToken {
+		t.Fatalf("admin token signed with the seller key: err = %v, want ErrInvalidToken", err)
+	}
+	if _, err := srv.AuthenticateAdmin(signAdmin(tokenAccess, "admin secret")); err != myErrors.ErrInvalidToken {
+		t.Fatalf("seller token type: err = %v, want ErrInvalidToken", err)
+	}
+
+	access, err := srv.signToken(tokenAccess, uuid.New(), uuid.New(), time.Now(), time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := srv.AuthenticateAdmin(access); err != myErrors.ErrInvalidToken {
+		t.Fatalf("seller access token: err = %v, want ErrInvalidToken", err)
+	}
+}
+
+func TestUpdateAndSetPassword(t *testing.T) {
+	db := newFakeAuthDB()
+	srv := newAuthSrv(db)
+	passport := 123456
+	account := models.SellerAccount{Seller: models.Seller{Passport: &passport, Telephone_Number: "+79991234567"}, Password: "correct horse"}
+	id, err := srv.Create(account)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Профиль и пароль меняются одним обращением к базе
+	if err := srv.Update(id, models.SellerAccount{Seller: models.Seller{Name: "Shop"}, Password: "new password"}); err != nil {
+		t.Fatal(err)
+	}
+	if db.updates != 1 {
+		t.Fatalf("database updates = %d, want 1", db.updates)
+	}
+	if _, err := srv.Login(account.Telephone_Number, "new password"); err != nil {
+		t.Fatalf("login with the new password: %v", err)
+	}
+
+	if err := srv.SetPassword(models.Caller{SellerID: id}, id, "admin password"); err != myErrors.ErrAdminOnly {
+		t.Fatalf("seller: err = %v, want ErrAdminOnly", err)
+	}
+	if err := srv.SetPassword(models.Caller{Admin: true}, id, "short"); err != myErrors.ErrPasswordInvalid {
+		t.Fatalf("short password: err = %v, want ErrPasswordInvalid", err)
+	}
+	if err := srv.SetPassword(models.Caller{Admin: true}, id, "admin password"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := srv.Login(account.Telephone_Number, "admin password"); err != nil {
+		t.Fatalf("login with the password set by admin: %v", err)
+	}
+}
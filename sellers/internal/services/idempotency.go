@@ -0,0 +1,76 @@
+package services
+
+import (
+	"context"
+	myErrors "market/internal/errors"
+	myLog "market/internal/logger"
+	"market/internal/models"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// idempotencyLease - через сколько незавершённый запрос считается потерянным и ключ можно занять снова
+	idempotencyLease = 10 * time.Minute
+	// idempotencyPurgeBatchSize - сколько истёкших ключей удаляется за один запрос
+	idempotencyPurgeBatchSize = 1000
+	maxIdempotencyKeyLength   = 255
+)
+
+func (srv *Srv) BeginIdempotent(scope uuid.UUID, key string, fingerprint string) (*models.IdempotentResponse, error) {
+	if key == "" || len(key) > maxIdempotencyKeyLength {
+		return nil, myErrors.ErrIdempotencyKeyInvalid
+	}
+
+	stored, claimed, err := srv.db.ClaimIdempotencyKey(scope, key, fingerprint, srv.idempotencyTTL, idempotencyLease)
+	if err != nil {
+		return nil, err
+	}
+	if claimed {
+		return nil, nil
+	}
+	if stored.Fingerprint != fingerprint {
+		return nil, myErrors.ErrIdempotencyKeyReused
+	}
+	if stored.StatusCode == 0 {
+		return nil, myErrors.ErrIdempotencyInProgress
+	}
+	return &stored, nil
+}
+
+func (srv *Srv) FinishIdempotent(scope uuid.UUID, key string, response models.IdempotentResponse) error {
+	// Ошибка сервера обычно означает откат изменений, поэтому повтор должен выполниться заново
+	if response.StatusCode >= 500 {
+		return srv.db.ReleaseIdempotencyKey(scope, key)
+	}
+	return srv.db.SaveIdempotentResponse(scope, key, response)
+}
+
+// RunIdempotencyPurge периодически удаляет истёкшие ключи идемпотентности, пока не отменён ctx
+func (srv *Srv) RunIdempotencyPurge(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			srv.purgeIdempotencyKeys()
+		}
+	}
+}
+
+func (srv *Srv) purgeIdempotencyKeys() {
+	for {
+		n, err := srv.db.PurgeIdempotencyKeys(idempotencyPurgeBatchSize)
+		if err != nil {
+			myLog.Log.Errorf("Failed to purge idempotency keys: %v", err)
+			return
+		}
+		if n < idempotencyPurgeBatchSize {
+			return
+		}
+	}
+}
@@ -7,7 +7,29 @@ import (
 )
 
 type InterfaceService interface {
-	Create(s models.Seller) (uuid.UUID, error)
-	Update(id uuid.UUID, new models.Seller) error
+	Create(account models.SellerAccount) (uuid.UUID, error)
+	// Паспорт и телефон скрываются, если caller не сам продавец и не администратор
+	Read(caller models.Caller, id uuid.UUID) (models.Seller, error)
+	// Поиск продавцов доступен только администраторам
+	List(caller models.Caller, filter models.SellerFilter) (models.SellerPage, error)
+	Update(id uuid.UUID, account models.SellerAccount) error
+	// Задаёт пароль продавцу по поручению администратора: так получают пароль продавцы,
+	// зарегистрированные до появления входа, и те, кто его забыл
+	SetPassword(caller models.Caller, id uuid.UUID, password string) error
 	Delete(id uuid.UUID) error
+
+	// Выдаёт пару токенов продавцу с номером телефона и паролем
+	Login(telephoneNumber string, password string) (models.Tokens, error)
+	// Обменивает токен обновления на новую пару; использованный токен обновления больше не действует
+	Refresh(refreshToken string) (models.Tokens, error)
+	// Возвращает SupplierID из токена доступа
+	Authenticate(accessToken string) (uuid.UUID, error)
+	// Проверяет токен администратора и возвращает ID администратора
+	AuthenticateAdmin(adminToken string) (uuid.UUID, error)
+
+	// Возвращает nil, если ключ занят за этим запросом и его нужно выполнить,
+	// иначе - сохранённый ответ на первый запрос с тем же ключом
+	BeginIdempotent(scope uuid.UUID, key string, fingerprint string) (*models.IdempotentResponse, error)
+	// Запоминает ответ на запрос, занявший ключ; ответ с ошибкой сервера освобождает ключ для повтора
+	FinishIdempotent(scope uuid.UUID, key string, response models.IdempotentResponse) error
 }
@@ -0,0 +1,41 @@
+package services
+
+import (
+	myErrors "market/internal/errors"
+	"market/internal/models"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// visiblePhoneDigits - сколько последних символов телефона видно постороннему
+const visiblePhoneDigits = 2
+
+func (srv *Srv) Read(caller models.Caller, id uuid.UUID) (models.Seller, error) {
+	seller, err := srv.db.ReadSeller(id)
+	if err != nil {
+		return seller, err
+	}
+	if !caller.CanSeePrivate(id) {
+		seller = maskSeller(seller)
+	}
+	return seller, nil
+}
+
+func (srv *Srv) List(caller models.Caller, filter models.SellerFilter) (models.SellerPage, error) {
+	if !caller.Admin {
+		return models.SellerPage{}, myErrors.ErrAdminOnly
+	}
+	return srv.db.ListSellers(filter)
+}
+
+// maskSeller убирает паспорт и оставляет от телефона только последние цифры
+func maskSeller(seller models.Seller) models.Seller {
+	seller.Passport = nil
+	phone := []rune(seller.Telephone_Number)
+	if len(phone) > visiblePhoneDigits {
+		hidden := len(phone) - visiblePhoneDigits
+		seller.Telephone_Number = strings.Repeat("*", hidden) + string(phone[hidden:])
+	}
+	return seller
+}
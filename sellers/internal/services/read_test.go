@@ -0,0 +1,74 @@
+package services
+
+import (
+	postgresdb "market/internal/database/postgres"
+	myErrors "market/internal/errors"
+	"market/internal/models"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+// fakeDB отдаёт одного продавца и запоминает фильтр поиска
+type fakeDB struct {
+	postgresdb.InterfacePostgresDB
+	seller models.Seller
+	listed bool
+}
+
+func (f *fakeDB) ReadSeller(sellerID uuid.UUID) (models.Seller, error) {
+	if sellerID != f.seller.UUID {
+		return models.Seller{}, myErrors.ErrReadSellerNotFound
+	}
+	return f.seller, nil
+}
+
+func (f *fakeDB) ListSellers(filter models.SellerFilter) (models.SellerPage, error) {
+	f.listed = true
+	return models.SellerPage{Items: []models.Seller{f.seller}}, nil
+}
+
+func TestReadMasksPrivateFields(t *testing.T) {
+	id := uuid.New()
+	passport := 123456
+	db := &fakeDB{seller: models.Seller{UUID: id, Name: "Shop", Passport: &passport, Telephone_Number: "+79991234567"}}
+	srv := &Srv{db: db}
+
+	tests := []struct {
+		name   string
+		caller models.Caller
+		masked bool
+	}{
+		{"anonymous", models.Caller{}, true},
+		{"other seller", models.Caller{SellerID: uuid.New()}, true},
+		{"seller themself", models.Caller{SellerID: id}, false},
+		{"admin", models.Caller{Admin: true}, false},
+	}
+	for _, tt := range tests {
+		seller, err := srv.Read(tt.caller, id)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		if tt.masked && (seller.Passport != nil || seller.Telephone_Number != "**********67") {
+			t.Errorf("%s: passport = %v, phone = %q, want masked", tt.name, seller.Passport, seller.Telephone_Number)
+		}
+		if !tt.masked && seller != db.seller {
+			t.Errorf("%s: got %+v, want %+v", tt.name, seller, db.seller)
+		}
+	}
+}
+
+func TestListRequiresAdmin(t *testing.T) {
+	db := &fakeDB{}
+	srv := &Srv{db: db}
+
+	if _, err := srv.List(models.Caller{SellerID: uuid.New()}, models.SellerFilter{}); err != myErrors.ErrAdminOnly {
+		t.Fatalf("seller: err = %v, want ErrAdminOnly", err)
+	}
+	if db.listed {
+		t.Fatal("database queried for a non-admin caller")
+	}
+	if _, err := srv.List(models.Caller{Admin: true}, models.SellerFilter{}); err != nil || !db.listed {
+		t.Fatalf("admin: err = %v, listed = %v", err, db.listed)
+	}
+}
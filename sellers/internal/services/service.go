@@ -3,29 +3,84 @@ package services
 import (
 	config "market/internal/cfg"
 	database "market/internal/database/postgres"
+	rediscashe "market/internal/database/redis"
+	myErrors "market/internal/errors"
+	myLog "market/internal/logger"
 	"market/internal/models"
+	"time"
 
 	"github.com/google/uuid"
 )
 
 type Srv struct {
 	db database.InterfacePostgresDB
+
+	idempotencyTTL time.Duration
+
+	jwtSecret       []byte
+	accessTokenTTL  time.Duration
+	refreshTokenTTL time.Duration
+
+	adminJWTSecret []byte // Пустой - токены администраторов не принимаются
 }
 
 func NewSrv(cfg config.Config) *Srv {
-	base := database.NewPostgres(cfg)
+	if cfg.JWTSecret == "" {
+		myLog.Log.Fatalf("JWT_SECRET is not set, seller tokens cannot be signed")
+		return nil
+	}
+	var base database.InterfacePostgresDB = database.NewPostgres(cfg)
+	if cfg.RedisAddr != "" {
+		base = rediscashe.NewCachedDB(base, rediscashe.NewClient(cfg), cfg.CacheTTL)
+	} else {
+		myLog.Log.Warnf("REDIS_ADDR is not set, seller reads are not cached")
+	}
+	if cfg.AdminJWTSecret == "" {
+		myLog.Log.Warnf("ADMIN_JWT_SECRET is not set, administrator requests are rejected")
+	}
 	return &Srv{
-		db: base,
+		db:             base,
+		idempotencyTTL: cfg.IdempotencyTTL,
+
+		jwtSecret:       []byte(cfg.JWTSecret),
+		accessTokenTTL:  cfg.AccessTokenTTL,
+		refreshTokenTTL: cfg.RefreshTokenTTL,
+
+		adminJWTSecret: []byte(cfg.AdminJWTSecret),
 	}
 }
-func (srv *Srv) Create(seller models.Seller) (uuid.UUID, error) {
-	uuid, err := srv.db.CreateSeller(seller)
-	return uuid, err
+func (srv *Srv) Create(account models.SellerAccount) (uuid.UUID, error) {
+	if account.Passport == nil {
+		return uuid.Nil, myErrors.ErrPassportRequired
+	}
+	hash, err := hashPassword(account.Password)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	id, err := srv.db.CreateSeller(account.Seller, hash)
+	return id, err
 }
 
-func (srv *Srv) Update(id uuid.UUID, newSellers models.Seller) error {
-	err := srv.db.UpdateSeller(id, newSellers)
-	return err
+func (srv *Srv) Update(id uuid.UUID, account models.SellerAccount) error {
+	var hash string
+	if account.Password != "" {
+		var err error
+		if hash, err = hashPassword(account.Password); err != nil {
+			return err
+		}
+	}
+	return srv.db.UpdateSeller(id, account.Seller, hash)
+}
+
+func (srv *Srv) SetPassword(caller models.Caller, id uuid.UUID, password string) error {
+	if !caller.Admin {
+		return myErrors.ErrAdminOnly
+	}
+	hash, err := hashPassword(password)
+	if err != nil {
+		return err
+	}
+	return srv.db.UpdateSeller(id, models.Seller{}, hash)
 }
 
 func (srv *Srv) Delete(id uuid.UUID) error {
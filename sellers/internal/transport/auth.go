@@ -0,0 +1,72 @@
+package transport
+
+import (
+	"bytes"
+	myErrors "market/internal/errors"
+	"market/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/valyala/fasthttp"
+)
+
+// Ключ, под которым withSeller сохраняет продавца в контексте запроса
+const sellerIDKey = "sellerID"
+
+var bearerPrefix = []byte("Bearer ")
+
+// bearerToken возвращает токен из заголовка Authorization: Bearer <token>; пустой, если заголовка нет
+func bearerToken(ctx *fasthttp.RequestCtx) (string, bool) {
+	header := ctx.Request.Header.Peek(fasthttp.HeaderAuthorization)
+	if len(header) == 0 {
+		return "", false
+	}
+	if !bytes.HasPrefix(header, bearerPrefix) {
+		return "", true
+	}
+	return string(bytes.TrimSpace(header[len(bearerPrefix):])), true
+}
+
+// withSeller пропускает запрос к обработчику только с действующим токеном доступа продавца.
+// SupplierID берётся из токена, поэтому продавец может действовать только от своего имени.
+func (hb *HandlersBuilder) withSeller(next fasthttp.RequestHandler) fasthttp.RequestHandler {
+	return func(ctx *fasthttp.RequestCtx) {
+		token, ok := bearerToken(ctx)
+		if !ok {
+			serviceErrorResponse(ctx, myErrors.ErrInvalidToken, "Unauthorized")
+			return
+		}
+		sellerID, err := hb.srv.Authenticate(token)
+		if err != nil {
+			serviceErrorResponse(ctx, err, "Unauthorized")
+			return
+		}
+		ctx.SetUserValue(sellerIDKey, sellerID)
+		next(ctx)
+	}
+}
+
+// sellerFromCtx возвращает продавца, установленного withSeller
+func sellerFromCtx(ctx *fasthttp.RequestCtx) uuid.UUID {
+	id, _ := ctx.UserValue(sellerIDKey).(uuid.UUID)
+	return id
+}
+
+// callerFromRequest определяет вызывающего по токену из Authorization: Bearer - токену доступа продавца
+// или токену администратора, подписанному ADMIN_JWT_SECRET. Без токена запрос анонимный.
+func (hb *HandlersBuilder) callerFromRequest(ctx *fasthttp.RequestCtx) (models.Caller, error) {
+	var caller models.Caller
+	token, ok := bearerToken(ctx)
+	if !ok {
+		return caller, nil
+	}
+	if _, err := hb.srv.AuthenticateAdmin(token); err == nil {
+		caller.Admin = true
+		return caller, nil
+	}
+	id, err := hb.srv.Authenticate(token)
+	if err != nil {
+		return caller, err
+	}
+	caller.SellerID = id
+	return caller, nil
+}
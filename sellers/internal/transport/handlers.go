@@ -2,13 +2,16 @@ package transport
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 
 	config "market/internal/cfg"
+	myErrors "market/internal/errors"
 	myLog "market/internal/logger"
 	"market/internal/models"
 	service "market/internal/services"
 	"net/http"
+	"strconv"
 
 	"github.com/fasthttp/router"
 	"github.com/google/uuid"
@@ -34,13 +37,23 @@ func HandleCreate(cfg config.Config, s service.InterfaceService) {
 		http.ListenAndServe(":8090", nil)
 	}()
 
-	hb.rout.POST("/sellers/create", hb.HandleSellersCreate()) //общее количество просмотров товаров
+	hb.rout.POST("/sellers/create", hb.HandleSellersCreate()) // регистрация продавца
 
-	hb.rout.PUT("/sellers/update/{uuid}", hb.HandleUpdateSeller()) // — обновление информации о продавце
+	hb.rout.POST("/sellers/login", hb.HandleLogin()) // вход по телефону и паролю
 
-	hb.rout.DELETE("/sellers/delete", hb.HandleDeleteSeller()) // удаление продавца
+	hb.rout.POST("/sellers/refresh", hb.HandleRefresh()) // обмен токена обновления на новую пару
 
-	fmt.Println(fasthttp.ListenAndServe(":8080", hb.rout.Handler))
+	hb.rout.GET("/sellers/{uuid}", hb.HandleReadSeller()) // профиль продавца
+
+	hb.rout.GET("/sellers", hb.HandleListSellers()) // поиск продавцов для администраторов
+
+	hb.rout.PUT("/sellers/update", hb.withSeller(hb.HandleUpdateSeller())) // — обновление информации о своём продавце
+
+	hb.rout.DELETE("/sellers/delete", hb.withSeller(hb.HandleDeleteSeller())) // удаление своего продавца
+
+	hb.rout.PUT("/sellers/{uuid}/password", hb.HandleSetSellerPassword()) // задание пароля продавцу администратором
+
+	fmt.Println(fasthttp.ListenAndServe(":8080", hb.idempotent(hb.rout.Handler)))
 }
 
 // Вспомогательная функция для отправки JSON ответа
@@ -54,6 +67,16 @@ func jsonResponse(ctx *fasthttp.RequestCtx, response interface{}) {
 	ctx.SetBody(respBody)
 }
 
+// serviceErrorResponse отвечает кодом и причиной ошибки сервиса, прочие ошибки - 500 с message
+func serviceErrorResponse(ctx *fasthttp.RequestCtx, err error, message string) {
+	var myErr myErrors.Error
+	if errors.As(err, &myErr) {
+		httpErrorResponse(ctx, myErr.GetHttpCode(), myErr.GetCause())
+		return
+	}
+	httpErrorResponse(ctx, fasthttp.StatusInternalServerError, message)
+}
+
 // Вспомогательная функция для отправки ошибки
 func httpErrorResponse(ctx *fasthttp.RequestCtx, statusCode int, message string) {
 	ctx.SetStatusCode(statusCode)
@@ -66,19 +89,18 @@ func (hb *HandlersBuilder) HandleSellersCreate() func(ctx *fasthttp.RequestCtx)
 	return metrics(func(ctx *fasthttp.RequestCtx) {
 		myLog.Log.Debugf("Start func HandleSellersCreate")
 		if ctx.IsPost() {
-			// Создаем экземпляр структуры Seller
-			var seller models.Seller
+			// Профиль продавца и пароль для входа
+			var account models.SellerAccount
 
 			// Считываем и декодируем JSON из тела запроса
-			if err := json.Unmarshal(ctx.PostBody(), &seller); err != nil {
+			if err := json.Unmarshal(ctx.PostBody(), &account); err != nil {
 				httpErrorResponse(ctx, fasthttp.StatusBadRequest, "Invalid request body")
-				fmt.Println(err.Error())
 				return
 			}
 
-			id, err := hb.srv.Create(seller)
+			id, err := hb.srv.Create(account)
 			if err != nil {
-				httpErrorResponse(ctx, fasthttp.StatusInternalServerError, "Failed to create seller")
+				serviceErrorResponse(ctx, err, "Failed to create seller")
 				return
 			}
 
@@ -93,38 +115,172 @@ func (hb *HandlersBuilder) HandleSellersCreate() func(ctx *fasthttp.RequestCtx)
 	}, "HandleSellersCreate")
 }
 
+func (hb *HandlersBuilder) HandleLogin() func(ctx *fasthttp.RequestCtx) {
+	return metrics(func(ctx *fasthttp.RequestCtx) {
+		myLog.Log.Debugf("Start func HandleLogin")
+		if !ctx.IsPost() {
+			httpErrorResponse(ctx, fasthttp.StatusMethodNotAllowed, "Method not allowed")
+			return
+		}
+
+		var request struct {
+			TelephoneNumber string `json:"telephone_number"`
+			Password        string `json:"password"`
+		}
+		if err := json.Unmarshal(ctx.PostBody(), &request); err != nil {
+			httpErrorResponse(ctx, fasthttp.StatusBadRequest, "Invalid request body")
+			return
+		}
+
+		tokens, err := hb.srv.Login(request.TelephoneNumber, request.Password)
+		if err != nil {
+			serviceErrorResponse(ctx, err, "Failed to log in")
+			return
+		}
+
+		ctx.SetStatusCode(fasthttp.StatusOK)
+		jsonResponse(ctx, tokens)
+	}, "HandleLogin")
+}
+
+func (hb *HandlersBuilder) HandleRefresh() func(ctx *fasthttp.RequestCtx) {
+	return metrics(func(ctx *fasthttp.RequestCtx) {
+		myLog.Log.Debugf("Start func HandleRefresh")
+		if !ctx.IsPost() {
+			httpErrorResponse(ctx, fasthttp.StatusMethodNotAllowed, "Method not allowed")
+			return
+		}
+
+		var request struct {
+			RefreshToken string `json:"refresh_token"`
+		}
+		if err := json.Unmarshal(ctx.PostBody(), &request); err != nil {
+			httpErrorResponse(ctx, fasthttp.StatusBadRequest, "Invalid request body")
+			return
+		}
+
+		tokens, err := hb.srv.Refresh(request.RefreshToken)
+		if err != nil {
+			serviceErrorResponse(ctx, err, "Failed to refresh tokens")
+			return
+		}
+
+		ctx.SetStatusCode(fasthttp.StatusOK)
+		jsonResponse(ctx, tokens)
+	}, "HandleRefresh")
+}
+
+func (hb *HandlersBuilder) HandleReadSeller() func(ctx *fasthttp.RequestCtx) {
+	return metrics(func(ctx *fasthttp.RequestCtx) {
+		myLog.Log.Debugf("Start func HandleReadSeller")
+		if !ctx.IsGet() {
+			httpErrorResponse(ctx, fasthttp.StatusMethodNotAllowed, "Method not allowed")
+			return
+		}
+
+		caller, err := hb.callerFromRequest(ctx)
+		if err != nil {
+			serviceErrorResponse(ctx, err, "Unauthorized")
+			return
+		}
+		sellerID, err := uuid.Parse(fmt.Sprintf("%v", ctx.UserValue("uuid")))
+		if err != nil {
+			httpErrorResponse(ctx, fasthttp.StatusBadRequest, "Invalid seller ID")
+			return
+		}
+
+		seller, err := hb.srv.Read(caller, sellerID)
+		if err != nil {
+			serviceErrorResponse(ctx, err, "Failed to read seller")
+			return
+		}
+
+		ctx.SetStatusCode(fasthttp.StatusOK)
+		jsonResponse(ctx, seller)
+	}, "HandleReadSeller")
+}
+
+func (hb *HandlersBuilder) HandleListSellers() func(ctx *fasthttp.RequestCtx) {
+	return metrics(func(ctx *fasthttp.RequestCtx) {
+		myLog.Log.Debugf("Start func HandleListSellers")
+		if !ctx.IsGet() {
+			httpErrorResponse(ctx, fasthttp.StatusMethodNotAllowed, "Method not allowed")
+			return
+		}
+
+		caller, err := hb.callerFromRequest(ctx)
+		if err != nil {
+			serviceErrorResponse(ctx, err, "Unauthorized")
+			return
+		}
+		filter, err := parseSellerFilter(ctx.QueryArgs())
+		if err != nil {
+			httpErrorResponse(ctx, fasthttp.StatusBadRequest, err.Error())
+			return
+		}
+
+		page, err := hb.srv.List(caller, filter)
+		if err != nil {
+			serviceErrorResponse(ctx, err, "Failed to list sellers")
+			return
+		}
+
+		ctx.SetStatusCode(fasthttp.StatusOK)
+		jsonResponse(ctx, page)
+	}, "HandleListSellers")
+}
+
+// parseSellerFilter разбирает query-параметры поиска продавцов
+func parseSellerFilter(args *fasthttp.Args) (models.SellerFilter, error) {
+	filter := models.SellerFilter{
+		Name:   string(args.Peek("name")),
+		Phone:  string(args.Peek("phone")),
+		Sort:   string(args.Peek("sort")),
+		Cursor: string(args.Peek("cursor")),
+	}
+
+	if v := args.Peek("passport"); len(v) > 0 {
+		passport, err := strconv.Atoi(string(v))
+		if err != nil {
+			return filter, fmt.Errorf("Invalid passport")
+		}
+		filter.Passport = &passport
+	}
+	if v := args.Peek("has_description"); len(v) > 0 {
+		has, err := strconv.ParseBool(string(v))
+		if err != nil {
+			return filter, fmt.Errorf("Invalid has_description")
+		}
+		filter.HasDescription = &has
+	}
+	if v := args.Peek("limit"); len(v) > 0 {
+		limit, err := strconv.Atoi(string(v))
+		if err != nil || limit <= 0 {
+			return filter, fmt.Errorf("Invalid limit")
+		}
+		filter.Limit = limit
+	}
+	return filter, nil
+}
+
 func (hb *HandlersBuilder) HandleUpdateSeller() func(ctx *fasthttp.RequestCtx) {
 	return metrics(func(ctx *fasthttp.RequestCtx) {
 		myLog.Log.Debugf("Start func HandleUpdateSeller")
 
-		// Проверяем, что метод запроса - PUT или PATCH (в зависимости от вашей структуры)
+		// Проверяем, что метод запроса - PUT
 		if ctx.IsPut() {
-			var seller models.Seller
+			var account models.SellerAccount
 
 			// Считываем и декодируем JSON из тела запроса
-			if err := json.Unmarshal(ctx.PostBody(), &seller); err != nil {
+			if err := json.Unmarshal(ctx.PostBody(), &account); err != nil {
 				httpErrorResponse(ctx, fasthttp.StatusBadRequest, "Invalid request body")
 				return
 			}
 
-			// Получаем ID из параметров URL
-			id, ok := ctx.UserValue("uuid").(string) // Предполагается, что ID передаётся как параметр
-			if !ok {
-				myLog.Log.Errorf("Error unknown type id")
-			}
-			fmt.Println(id)
-			sellerID, err := uuid.Parse(fmt.Sprintf("%v", id)) // Преобразование ID в UUID
-			fmt.Println(sellerID)
+			// Продавец изменяет только себя: ID берётся из токена, а не из запроса
+			err := hb.srv.Update(sellerFromCtx(ctx), account)
 			if err != nil {
-				httpErrorResponse(ctx, fasthttp.StatusBadRequest, "Invalid seller ID")
-				fmt.Println(err.Error())
-				return
-			}
-
-			// Вызываем метод обновления
-			err = hb.srv.Update(sellerID, seller)
-			if err != nil {
-				httpErrorResponse(ctx, fasthttp.StatusInternalServerError, "Failed to update seller")
+				serviceErrorResponse(ctx, err, "Failed to update seller")
 				return
 			}
 
@@ -144,26 +300,10 @@ func (hb *HandlersBuilder) HandleDeleteSeller() func(ctx *fasthttp.RequestCtx) {
 
 		// Проверяем, что метод запроса - DELETE
 		if ctx.IsDelete() {
-			// Получаем ID продавца из параметров URL
-			id := ctx.UserValue("uuid").(string) // ID передается как параметр
-			fmt.Println(id)
-			sellerID, err := uuid.Parse(fmt.Sprintf("%v", id)) // Преобразование ID в UUID
-			fmt.Println(sellerID)
-			if err != nil {
-				httpErrorResponse(ctx, fasthttp.StatusBadRequest, "Invalid seller ID")
-				return
-			}
-
-			// Вызываем метод удаления
-			err = hb.srv.Delete(sellerID)
+			// Продавец удаляет только себя: ID берётся из токена
+			err := hb.srv.Delete(sellerFromCtx(ctx))
 			if err != nil {
-				// Если продавец не найден, возвращаем 404 Not Found
-				if err.Error() == "not found" {
-					httpErrorResponse(ctx, fasthttp.StatusNotFound, fmt.Sprintf("Seller with ID %s not found", sellerID))
-					return
-				}
-				// В случае других ошибок возвращаем 500 Internal Server Error
-				httpErrorResponse(ctx, fasthttp.StatusInternalServerError, "Failed to delete seller")
+				serviceErrorResponse(ctx, err, "Failed to delete seller")
 				return
 			}
 
@@ -176,3 +316,38 @@ func (hb *HandlersBuilder) HandleDeleteSeller() func(ctx *fasthttp.RequestCtx) {
 		httpErrorResponse(ctx, fasthttp.StatusMethodNotAllowed, "Method not allowed")
 	}, "HandleDeleteSeller")
 }
+
+func (hb *HandlersBuilder) HandleSetSellerPassword() func(ctx *fasthttp.RequestCtx) {
+	return metrics(func(ctx *fasthttp.RequestCtx) {
+		myLog.Log.Debugf("Start func HandleSetSellerPassword")
+		if !ctx.IsPut() {
+			httpErrorResponse(ctx, fasthttp.StatusMethodNotAllowed, "Method not allowed")
+			return
+		}
+
+		caller, err := hb.callerFromRequest(ctx)
+		if err != nil {
+			serviceErrorResponse(ctx, err, "Unauthorized")
+			return
+		}
+		sellerID, err := uuid.Parse(fmt.Sprintf("%v", ctx.UserValue("uuid")))
+		if err != nil {
+			httpErrorResponse(ctx, fasthttp.StatusBadRequest, "Invalid seller ID")
+			return
+		}
+		var request struct {
+			Password string `json:"password"`
+		}
+		if err := json.Unmarshal(ctx.PostBody(), &request); err != nil {
+			httpErrorResponse(ctx, fasthttp.StatusBadRequest, "Invalid request body")
+			return
+		}
+
+		if err := hb.srv.SetPassword(caller, sellerID, request.Password); err != nil {
+			serviceErrorResponse(ctx, err, "Failed to set seller password")
+			return
+		}
+
+		ctx.SetStatusCode(fasthttp.StatusNoContent)
+	}, "HandleSetSellerPassword")
+}
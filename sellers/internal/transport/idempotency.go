@@ -0,0 +1,83 @@
+package transport
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	myLog "market/internal/logger"
+	"market/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/valyala/fasthttp"
+)
+
+// tokenRoutes - маршруты, которые выдают токены. Их ответы не сохраняются: повтор выдал бы те же токены
+// в обход одноразового обмена токена обновления и пережил бы смену пароля.
+var tokenRoutes = map[string]bool{
+	"POST /sellers/login":   true,
+	"POST /sellers/refresh": true,
+}
+
+// idempotent повторяет ответ на первый изменяющий запрос с тем же заголовком Idempotency-Key
+// вместо повторного выполнения. Повтор ключа с другим методом, путём или телом получает 422.
+// Запросы без заголовка и запросы к tokenRoutes выполняются как обычно.
+func (hb *HandlersBuilder) idempotent(next fasthttp.RequestHandler) fasthttp.RequestHandler {
+	return func(ctx *fasthttp.RequestCtx) {
+		key := string(ctx.Request.Header.Peek("Idempotency-Key"))
+		if key == "" || !isMutating(ctx) || tokenRoutes[string(ctx.Method())+" "+string(ctx.Path())] {
+			next(ctx)
+			return
+		}
+
+		// Ключи продавца или администратора не пересекаются с чужими ключами; регистрация
+		// и прочие запросы без действующего токена делят общую область
+		scope := uuid.Nil
+		if token, ok := bearerToken(ctx); ok {
+			if adminID, err := hb.srv.AuthenticateAdmin(token); err == nil {
+				scope = adminID
+			} else if sellerID, err := hb.srv.Authenticate(token); err == nil {
+				scope = sellerID
+			}
+		}
+		fingerprint := requestFingerprint(ctx)
+
+		stored, err := hb.srv.BeginIdempotent(scope, key, fingerprint)
+		if err != nil {
+			serviceErrorResponse(ctx, err, "Failed to process Idempotency-Key")
+			return
+		}
+		if stored != nil {
+			ctx.Response.Header.Set("Idempotent-Replayed", "true")
+			ctx.SetStatusCode(stored.StatusCode)
+			ctx.SetContentType(stored.ContentType)
+			ctx.SetBody(stored.Body)
+			return
+		}
+
+		next(ctx)
+
+		response := models.IdempotentResponse{
+			StatusCode:  ctx.Response.StatusCode(),
+			ContentType: string(ctx.Response.Header.ContentType()),
+			Body:        ctx.Response.Body(),
+		}
+		if err := hb.srv.FinishIdempotent(scope, key, response); err != nil {
+			// Ответ уже сформирован; повтор с этим ключом получит 409, пока ключ не освободится по таймауту
+			myLog.Log.Errorf("Failed to store idempotent response: %v", err)
+		}
+	}
+}
+
+func isMutating(ctx *fasthttp.RequestCtx) bool {
+	return ctx.IsPost() || ctx.IsPut() || ctx.IsPatch() || ctx.IsDelete()
+}
+
+// requestFingerprint - отпечаток метода, пути с параметрами и тела запроса
+func requestFingerprint(ctx *fasthttp.RequestCtx) string {
+	h := sha256.New()
+	h.Write(ctx.Method())
+	h.Write([]byte{' '})
+	h.Write(ctx.RequestURI())
+	h.Write([]byte{'\n'})
+	h.Write(ctx.PostBody())
+	return hex.EncodeToString(h.Sum(nil))
+}